@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/scheduler"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	var (
+		actorID         = flag.String("actor", "", "User ID of the admin running the import")
+		usersFile       = flag.String("users", "", "CSV file of users")
+		coursesFile     = flag.String("courses", "", "CSV file of courses")
+		enrollmentsFile = flag.String("enrollments", "", "CSV file of enrollments")
+		commit          = flag.Bool("commit", false, "Write the import; without it the files are only checked")
+		createAccounts  = flag.Bool("create-accounts", false, "Create sign-in accounts for imported users")
+		sendInvitations = flag.Bool("send-invitations", false, "Email imported users a link to set their password")
+	)
+	flag.Parse()
+
+	if *actorID == "" {
+		log.Fatal("-actor is required")
+	}
+
+	_ = godotenv.Load()
+
+	request := scheduler.ImportRequest{
+		DryRun:          boolPtr(!*commit),
+		CreateAccounts:  createAccounts,
+		SendInvitations: sendInvitations,
+	}
+	request.UsersCsv = readFile(*usersFile)
+	request.CoursesCsv = readFile(*coursesFile)
+	request.EnrollmentsCsv = readFile(*enrollmentsFile)
+	if request.UsersCsv == nil && request.CoursesCsv == nil && request.EnrollmentsCsv == nil {
+		log.Fatal("at least one of -users, -courses or -enrollments is required")
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	var orgID string
+	err = pool.QueryRow(ctx, "select org_id from users where user_id = $1 and role = 'admin'", *actorID).Scan(&orgID)
+	if err != nil {
+		log.Fatalf("Failed to find admin %s: %v", *actorID, err)
+	}
+
+	var accounts scheduler.AccountProvisioner
+	if *createAccounts {
+		firebaseService, err := auth.NewFirebaseService()
+		if err != nil {
+			log.Fatalf("Failed to initialize Firebase service: %v", err)
+		}
+		accounts = firebaseService
+	}
+
+	report, err := scheduler.ImportCSV(ctx, pool, accounts, orgID, *actorID, request, time.Now())
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// readFile returns the contents of path, or nil when no path is given.
+func readFile(path string) *string {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	contents := string(data)
+	return &contents
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
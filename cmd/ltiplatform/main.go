@@ -0,0 +1,76 @@
+// Command ltiplatform runs a simulated LTI 1.3 platform that launches a local
+// API as one user from one course, for trying launches without an LMS.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"scheduler-api/internal/lti"
+)
+
+func main() {
+	var (
+		addr         = flag.String("addr", "localhost:9000", "Address to serve the platform on")
+		toolURL      = flag.String("tool", "http://localhost:8000", "Base URL of the API to launch")
+		issuer       = flag.String("issuer", "", "Issuer of the platform (default the platform's URL)")
+		clientID     = flag.String("client-id", "booksmart-local", "Client ID of the tool")
+		deploymentID = flag.String("deployment", "1", "Deployment ID of the tool")
+		subject      = flag.String("sub", "simulated-learner", "Platform user ID of the launching user")
+		givenName    = flag.String("given-name", "Ada", "Given name of the user")
+		familyName   = flag.String("family-name", "Lovelace", "Family name of the user")
+		email        = flag.String("email", "ada@example.com", "Email of the user; empty withholds it")
+		roles        = flag.String("roles", lti.RoleLearner, "Comma-separated context roles of the user")
+		contextID    = flag.String("context", "simulated-course", "ID of the course the launch comes from")
+		contextTitle = flag.String("context-title", "Calculus", "Title of the course")
+		contextLabel = flag.String("context-label", "MATH 101", "Label of the course")
+	)
+	flag.Parse()
+
+	baseURL := "http://" + *addr
+	if *issuer == "" {
+		*issuer = baseURL
+	}
+
+	simulator, err := lti.NewSimulator(*issuer, *clientID, *deploymentID)
+	if err != nil {
+		log.Fatalf("Failed to create platform: %v", err)
+	}
+	simulator.ToolLoginURL = strings.TrimSuffix(*toolURL, "/") + "/lti/login"
+	simulator.TargetLinkURI = strings.TrimSuffix(*toolURL, "/") + "/lti/launch"
+	simulator.User = lti.SimulatedUser{
+		Subject:    *subject,
+		GivenName:  *givenName,
+		FamilyName: *familyName,
+		Email:      *email,
+		Roles:      strings.Split(*roles, ","),
+	}
+	simulator.Context = lti.Context{ID: *contextID, Title: *contextTitle, Label: *contextLabel}
+
+	// The signing key is new on every run, so the registration only
+	// needs adding once per issuer and client ID; keys are fetched again
+	// when they change.
+	platform := simulator.Platform(baseURL)
+	registration := map[string]any{
+		"name":           "Simulated platform",
+		"issuer":         platform.Issuer,
+		"client_id":      platform.ClientID,
+		"deployment_ids": platform.DeploymentIDs,
+		"auth_login_url": platform.AuthLoginURL,
+		"jwks_url":       platform.JWKSURL,
+	}
+
+	log.Printf("Register the platform with POST /v1/lti/platforms/:")
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(registration)
+	log.Printf("Then open %s/launch to launch as %s", baseURL, *subject)
+
+	if err := http.ListenAndServe(*addr, simulator.Handler()); err != nil {
+		log.Fatalf("Failed to serve platform: %v", err)
+	}
+}
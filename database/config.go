@@ -8,7 +8,9 @@ import (
 	"os"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"scheduler-api/internal/tracing"
+
+	"github.com/lib/pq"
 )
 
 // Config holds database configuration
@@ -87,10 +89,11 @@ func (c *Config) ConnectionString() string {
 
 // Connect establishes a connection to the database
 func Connect(config *Config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", config.ConnectionString())
+	connector, err := pq.NewConnector(config.ConnectionString())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db := sql.OpenDB(tracing.WrapConnector(connector))
 
 	// Configure connection pool
 	db.SetMaxOpenConns(config.MaxOpenConns)
@@ -136,6 +139,29 @@ func Migrate(db *sql.DB, migrationsDir string) error {
 		{"002", "002_create_indexes.sql"},
 		{"003", "003_sample_data.sql"},
 		{"004", "004_add_firebase_auth.sql"},
+		{"005", "005_class_lifecycle.sql"},
+		{"006", "006_class_participant_history.sql"},
+		{"007", "007_notifications.sql"},
+		{"008", "008_webhooks.sql"},
+		{"009", "009_jobs.sql"},
+		{"010", "010_waitlists.sql"},
+		{"011", "011_tutor_workload.sql"},
+		{"012", "012_resources.sql"},
+		{"013", "013_self_booking.sql"},
+		{"014", "014_time_off.sql"},
+		{"015", "015_blackouts.sql"},
+		{"016", "016_enrollment_lifecycle.sql"},
+		{"017", "017_invoicing.sql"},
+		{"018", "018_payroll.sql"},
+		{"019", "019_analytics.sql"},
+		{"020", "020_oneroster.sql"},
+		{"021", "021_scim.sql"},
+		{"022", "022_lti.sql"},
+		{"023", "023_live_events.sql"},
+		{"024", "024_row_versions.sql"},
+		{"025", "025_idempotency_keys.sql"},
+		{"026", "026_org_quotas.sql"},
+		{"027", "027_availability_hours_rollup.sql"},
 	}
 
 	for _, migration := range migrations {
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+
+	"scheduler-api/internal/metrics"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RegisterPoolMetrics exposes the connection stats of the pgx pool and the
+// database/sql pool, labelled "pgx" and "sql", read on every scrape.
+func RegisterPoolMetrics(pgxPool *pgxpool.Pool, sqlDB *sql.DB) {
+	metrics.NewGaugeFunc("db_pool_connections", "Open database connections, by pool and state.", []string{"pool", "state"}, func(emit metrics.Emit) {
+		pgxStat := pgxPool.Stat()
+		emit(float64(pgxStat.AcquiredConns()), "pgx", "in_use")
+		emit(float64(pgxStat.IdleConns()), "pgx", "idle")
+
+		sqlStats := sqlDB.Stats()
+		emit(float64(sqlStats.InUse), "sql", "in_use")
+		emit(float64(sqlStats.Idle), "sql", "idle")
+	})
+
+	metrics.NewGaugeFunc("db_pool_max_connections", "Connections a database pool may open.", []string{"pool"}, func(emit metrics.Emit) {
+		emit(float64(pgxPool.Stat().MaxConns()), "pgx")
+		emit(float64(sqlDB.Stats().MaxOpenConnections), "sql")
+	})
+
+	metrics.NewCounterFunc("db_pool_waits_total", "Connection requests that had to wait for a connection, by pool.", []string{"pool"}, func(emit metrics.Emit) {
+		emit(float64(pgxPool.Stat().EmptyAcquireCount()), "pgx")
+		emit(float64(sqlDB.Stats().WaitCount), "sql")
+	})
+
+	metrics.NewCounterFunc("db_pool_wait_seconds_total", "Time spent waiting for connections, by pool.", []string{"pool"}, func(emit metrics.Emit) {
+		emit(pgxPool.Stat().AcquireDuration().Seconds(), "pgx")
+		emit(sqlDB.Stats().WaitDuration.Seconds(), "sql")
+	})
+}
@@ -5,16 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
+
+	"scheduler-api/internal/metrics"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 )
 
 // FirebaseService handles Firebase authentication operations
 type FirebaseService struct {
 	client *auth.Client
-	ctx    context.Context
 }
 
 // FirebaseConfig holds Firebase configuration
@@ -65,7 +71,6 @@ func newFirebaseFromFile(ctx context.Context, filePath string) (*FirebaseService
 
 	return &FirebaseService{
 		client: client,
-		ctx:    ctx,
 	}, nil
 }
 
@@ -84,7 +89,6 @@ func newFirebaseFromJSON(ctx context.Context, serviceAccountJSON string) (*Fireb
 
 	return &FirebaseService{
 		client: client,
-		ctx:    ctx,
 	}, nil
 }
 
@@ -116,18 +120,49 @@ func newFirebaseFromEnvVars(ctx context.Context) (*FirebaseService, error) {
 	return newFirebaseFromJSON(ctx, string(credentialsJSON))
 }
 
+var firebaseVerifyDuration = metrics.NewHistogram("auth_firebase_verify_duration_seconds",
+	"Time taken to verify Firebase ID tokens, by result.", metrics.DefaultBuckets, "result")
+
+var tracer = otel.Tracer("scheduler-api/internal/auth")
+
+// startSpan starts a client span of a call to Firebase, named after the
+// method making it
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "firebase "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.RPCSystemKey.String("firebase"), semconv.RPCMethodKey.String(method)))
+}
+
+// endSpan ends a span of a call to Firebase, recording err
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 // VerifyIDToken verifies a Firebase ID token and returns the token claims
-func (fs *FirebaseService) VerifyIDToken(idToken string) (*auth.Token, error) {
-	token, err := fs.client.VerifyIDToken(fs.ctx, idToken)
+func (fs *FirebaseService) VerifyIDToken(ctx context.Context, idToken string) (_ *auth.Token, err error) {
+	ctx, span := startSpan(ctx, "VerifyIDToken")
+	defer func() { endSpan(span, err) }()
+
+	start := time.Now()
+	token, err := fs.client.VerifyIDToken(ctx, idToken)
 	if err != nil {
+		firebaseVerifyDuration.Observe(time.Since(start).Seconds(), "error")
 		return nil, fmt.Errorf("failed to verify ID token: %w", err)
 	}
+	firebaseVerifyDuration.Observe(time.Since(start).Seconds(), "ok")
 	return token, nil
 }
 
 // GetUser gets user information by Firebase UID
-func (fs *FirebaseService) GetUser(uid string) (*auth.UserRecord, error) {
-	user, err := fs.client.GetUser(fs.ctx, uid)
+func (fs *FirebaseService) GetUser(ctx context.Context, uid string) (_ *auth.UserRecord, err error) {
+	ctx, span := startSpan(ctx, "GetUser")
+	defer func() { endSpan(span, err) }()
+
+	user, err := fs.client.GetUser(ctx, uid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user %s: %w", uid, err)
 	}
@@ -135,8 +170,11 @@ func (fs *FirebaseService) GetUser(uid string) (*auth.UserRecord, error) {
 }
 
 // GetUserByEmail gets user information by email
-func (fs *FirebaseService) GetUserByEmail(email string) (*auth.UserRecord, error) {
-	user, err := fs.client.GetUserByEmail(fs.ctx, email)
+func (fs *FirebaseService) GetUserByEmail(ctx context.Context, email string) (_ *auth.UserRecord, err error) {
+	ctx, span := startSpan(ctx, "GetUserByEmail")
+	defer func() { endSpan(span, err) }()
+
+	user, err := fs.client.GetUserByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by email %s: %w", email, err)
 	}
@@ -144,32 +182,100 @@ func (fs *FirebaseService) GetUserByEmail(email string) (*auth.UserRecord, error
 }
 
 // CreateUser creates a new Firebase user
-func (fs *FirebaseService) CreateUser(email, password string) (*auth.UserRecord, error) {
+func (fs *FirebaseService) CreateUser(ctx context.Context, email, password string) (_ *auth.UserRecord, err error) {
+	ctx, span := startSpan(ctx, "CreateUser")
+	defer func() { endSpan(span, err) }()
+
 	params := &auth.UserToCreate{}
 	params.Email(email).Password(password).EmailVerified(false)
 
-	user, err := fs.client.CreateUser(fs.ctx, params)
+	user, err := fs.client.CreateUser(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	return user, nil
 }
 
+// CreateInvitedUser creates a Firebase user without a password, for
+// someone who will set their own through an invitation link. An existing
+// user with the email is returned instead, with created false.
+func (fs *FirebaseService) CreateInvitedUser(ctx context.Context, email, displayName string) (_ string, _ bool, err error) {
+	ctx, span := startSpan(ctx, "CreateInvitedUser")
+	defer func() { endSpan(span, err) }()
+
+	params := &auth.UserToCreate{}
+	params.Email(email).DisplayName(displayName).EmailVerified(false)
+
+	user, err := fs.client.CreateUser(ctx, params)
+	if err == nil {
+		return user.UID, true, nil
+	}
+	if !auth.IsEmailAlreadyExists(err) {
+		return "", false, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	existing, err := fs.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", false, err
+	}
+	return existing.UID, false, nil
+}
+
+// PasswordResetLink generates a link that lets the user with the email set
+// a new password
+func (fs *FirebaseService) PasswordResetLink(ctx context.Context, email string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "PasswordResetLink")
+	defer func() { endSpan(span, err) }()
+
+	link, err := fs.client.PasswordResetLink(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password reset link for %s: %w", email, err)
+	}
+	return link, nil
+}
+
 // UpdateUser updates an existing Firebase user
-func (fs *FirebaseService) UpdateUser(uid string, email string) (*auth.UserRecord, error) {
+func (fs *FirebaseService) UpdateUser(ctx context.Context, uid string, email string) (_ *auth.UserRecord, err error) {
+	ctx, span := startSpan(ctx, "UpdateUser")
+	defer func() { endSpan(span, err) }()
+
 	params := &auth.UserToUpdate{}
 	params.Email(email)
 
-	user, err := fs.client.UpdateUser(fs.ctx, uid, params)
+	user, err := fs.client.UpdateUser(ctx, uid, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user %s: %w", uid, err)
 	}
 	return user, nil
 }
 
+// UpdateAccount updates the email and display name of a Firebase user and
+// enables or disables sign-in. Disabling a user also revokes its refresh
+// tokens, so it is signed out everywhere.
+func (fs *FirebaseService) UpdateAccount(ctx context.Context, uid, email, displayName string, disabled bool) (err error) {
+	ctx, span := startSpan(ctx, "UpdateAccount")
+	defer func() { endSpan(span, err) }()
+
+	params := &auth.UserToUpdate{}
+	params.Email(email).DisplayName(displayName).Disabled(disabled)
+
+	if _, err := fs.client.UpdateUser(ctx, uid, params); err != nil {
+		return fmt.Errorf("failed to update user %s: %w", uid, err)
+	}
+	if disabled {
+		if err := fs.client.RevokeRefreshTokens(ctx, uid); err != nil {
+			return fmt.Errorf("failed to revoke tokens of user %s: %w", uid, err)
+		}
+	}
+	return nil
+}
+
 // DeleteUser deletes a Firebase user
-func (fs *FirebaseService) DeleteUser(uid string) error {
-	err := fs.client.DeleteUser(fs.ctx, uid)
+func (fs *FirebaseService) DeleteUser(ctx context.Context, uid string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteUser")
+	defer func() { endSpan(span, err) }()
+
+	err = fs.client.DeleteUser(ctx, uid)
 	if err != nil {
 		return fmt.Errorf("failed to delete user %s: %w", uid, err)
 	}
@@ -177,10 +283,26 @@ func (fs *FirebaseService) DeleteUser(uid string) error {
 }
 
 // SetCustomClaims sets custom claims for a user (for role-based access)
-func (fs *FirebaseService) SetCustomClaims(uid string, claims map[string]interface{}) error {
-	err := fs.client.SetCustomUserClaims(fs.ctx, uid, claims)
+func (fs *FirebaseService) SetCustomClaims(ctx context.Context, uid string, claims map[string]interface{}) (err error) {
+	ctx, span := startSpan(ctx, "SetCustomClaims")
+	defer func() { endSpan(span, err) }()
+
+	err = fs.client.SetCustomUserClaims(ctx, uid, claims)
 	if err != nil {
 		return fmt.Errorf("failed to set custom claims for user %s: %w", uid, err)
 	}
 	return nil
 }
+
+// CustomToken mints a token the client exchanges for a Firebase session as
+// uid, carrying claims into the session's ID tokens
+func (fs *FirebaseService) CustomToken(ctx context.Context, uid string, claims map[string]interface{}) (_ string, err error) {
+	ctx, span := startSpan(ctx, "CustomToken")
+	defer func() { endSpan(span, err) }()
+
+	token, err := fs.client.CustomTokenWithClaims(ctx, uid, claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to create custom token for user %s: %w", uid, err)
+	}
+	return token, nil
+}
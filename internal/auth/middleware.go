@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"firebase.google.com/go/v4/auth"
+	"scheduler-api/internal/logging"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -46,7 +49,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token, err := m.extractToken(c)
 		if err != nil {
-			m.logger.Warn("Authentication failed: invalid token", zap.Error(err))
+			m.log(c).Warn("Authentication failed: invalid token", zap.Error(err))
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "unauthorized",
 				"message": "Valid authentication token required",
@@ -56,9 +59,9 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		// Verify the token with Firebase
-		claims, err := m.firebaseService.VerifyIDToken(token)
+		claims, err := m.firebaseService.VerifyIDToken(c.Request.Context(), token)
 		if err != nil {
-			m.logger.Warn("Authentication failed: token verification failed", 
+			m.log(c).Warn("Authentication failed: token verification failed", 
 				zap.Error(err), 
 				zap.String("token_prefix", token[:min(10, len(token))]))
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -70,9 +73,9 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		// Look up the user in our database
-		user, err := m.getUserByFirebaseUID(claims.UID)
+		user, err := m.getUserByFirebaseUID(c.Request.Context(), claims.UID)
 		if err != nil {
-			m.logger.Error("Failed to get user from database", 
+			m.log(c).Error("Failed to get user from database", 
 				zap.Error(err), 
 				zap.String("firebase_uid", claims.UID))
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -87,7 +90,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			// User exists in Firebase but not in our database
 			// Only allow access to create user endpoint for new users
 			if c.Request.Method == "POST" && strings.Contains(c.Request.URL.Path, "/v1/user/") {
-				m.logger.Info("New Firebase user accessing create user endpoint", 
+				m.log(c).Info("New Firebase user accessing create user endpoint", 
 					zap.String("firebase_uid", claims.UID),
 					zap.String("email", getEmailFromClaims(claims)),
 					zap.String("path", c.Request.URL.Path))
@@ -102,7 +105,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			}
 			
 			// Block access to all other endpoints for new users
-			m.logger.Info("New Firebase user blocked from accessing endpoint - user record required", 
+			m.log(c).Info("New Firebase user blocked from accessing endpoint - user record required", 
 				zap.String("firebase_uid", claims.UID),
 				zap.String("email", getEmailFromClaims(claims)),
 				zap.String("path", c.Request.URL.Path),
@@ -119,8 +122,8 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		// Update last login time
-		if err := m.updateLastLogin(user.UserID); err != nil {
-			m.logger.Warn("Failed to update last login time", 
+		if err := m.updateLastLogin(c.Request.Context(), user.UserID); err != nil {
+			m.log(c).Warn("Failed to update last login time", 
 				zap.Error(err), 
 				zap.String("user_id", user.UserID))
 		}
@@ -128,9 +131,11 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		// Add user to context
 		c.Set("currentUser", user)
 		c.Set("firebaseToken", claims)
-		
-		m.logger.Info("User authenticated successfully", 
+		logging.With(c.Request.Context(),
 			zap.String("user_id", user.UserID),
+			zap.String("org_id", user.OrgID))
+		
+		m.log(c).Debug("User authenticated successfully", 
 			zap.String("email", user.Email),
 			zap.String("role", user.Role))
 
@@ -138,6 +143,11 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	}
 }
 
+// log returns the logger of the request, which carries its request ID
+func (m *AuthMiddleware) log(c *gin.Context) *zap.Logger {
+	return logging.FromContext(c.Request.Context(), m.logger)
+}
+
 // RequireRole middleware that requires specific role(s)
 func (m *AuthMiddleware) RequireRole(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -161,7 +171,7 @@ func (m *AuthMiddleware) RequireRole(allowedRoles ...string) gin.HandlerFunc {
 			}
 		}
 
-		m.logger.Warn("Access denied: insufficient role", 
+		m.log(c).Warn("Access denied: insufficient role", 
 			zap.String("user_id", currentUser.UserID),
 			zap.String("user_role", currentUser.Role),
 			zap.Strings("required_roles", allowedRoles))
@@ -190,7 +200,7 @@ func (m *AuthMiddleware) RequireOrganization(orgID string) gin.HandlerFunc {
 		currentUser := user.(*User)
 		
 		if currentUser.OrgID != orgID {
-			m.logger.Warn("Access denied: organization mismatch", 
+			m.log(c).Warn("Access denied: organization mismatch", 
 				zap.String("user_id", currentUser.UserID),
 				zap.String("user_org", currentUser.OrgID),
 				zap.String("required_org", orgID))
@@ -229,7 +239,7 @@ func (m *AuthMiddleware) extractToken(c *gin.Context) (string, error) {
 }
 
 // getUserByFirebaseUID retrieves user from database using Firebase UID
-func (m *AuthMiddleware) getUserByFirebaseUID(firebaseUID string) (*User, error) {
+func (m *AuthMiddleware) getUserByFirebaseUID(ctx context.Context, firebaseUID string) (*User, error) {
 	query := `
 		SELECT user_id, firebase_uid, org_id, role, first_name, last_name, 
 		       email, COALESCE(last_login_at, created_at) as last_login_at, 
@@ -239,7 +249,7 @@ func (m *AuthMiddleware) getUserByFirebaseUID(firebaseUID string) (*User, error)
 	`
 
 	var user User
-	err := m.db.QueryRow(query, firebaseUID).Scan(
+	err := m.db.QueryRowContext(ctx, query, firebaseUID).Scan(
 		&user.UserID,
 		&user.FirebaseUID,
 		&user.OrgID,
@@ -262,9 +272,9 @@ func (m *AuthMiddleware) getUserByFirebaseUID(firebaseUID string) (*User, error)
 }
 
 // updateLastLogin updates the user's last login timestamp
-func (m *AuthMiddleware) updateLastLogin(userID string) error {
+func (m *AuthMiddleware) updateLastLogin(ctx context.Context, userID string) error {
 	query := `UPDATE users SET last_login_at = NOW() WHERE user_id = $1`
-	_, err := m.db.Exec(query, userID)
+	_, err := m.db.ExecContext(ctx, query, userID)
 	return err
 }
 
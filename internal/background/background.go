@@ -0,0 +1,70 @@
+// Package background holds what the outbox workers, the job runner and
+// their maintenance jobs share: settings read from the environment, retry
+// backoff and chunked deletes of old rows.
+package background
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Executor is satisfied by *pgxpool.Pool and pgx.Tx. Pass the transaction
+// that makes a change so rows written alongside it, such as outbox entries,
+// are only kept if the change commits.
+type Executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// DurationFromEnv reads a duration such as "30s" from the environment,
+// falling back to defaultValue when it is unset or invalid.
+func DurationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// IntFromEnv reads an integer from the environment, falling back to
+// defaultValue when it is unset or invalid.
+func IntFromEnv(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Backoff returns how long to wait before retrying after the given number of
+// attempts: base doubled per attempt, capped at max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
+
+// PurgeChunkSize is how many rows each statement run by Purge deletes at
+// most.
+const PurgeChunkSize = 5000
+
+// Purge runs query, which deletes at most $2 rows older than the cutoff in
+// $1, until a run deletes less than a full chunk. Deleting in chunks keeps
+// each transaction short.
+func Purge(ctx context.Context, db Executor, query string, cutoff time.Time) error {
+	for {
+		tag, err := db.Exec(ctx, query, cutoff, PurgeChunkSize)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() < PurgeChunkSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 5*time.Minute
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{20, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts, base, max); got != tt.expected {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.expected)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("BACKGROUND_TEST_DURATION", "90s")
+	t.Setenv("BACKGROUND_TEST_INT", "12")
+	t.Setenv("BACKGROUND_TEST_INVALID", "soon")
+
+	if got := DurationFromEnv("BACKGROUND_TEST_DURATION", time.Second); got != 90*time.Second {
+		t.Errorf("DurationFromEnv() = %v, want 1m30s", got)
+	}
+	if got := DurationFromEnv("BACKGROUND_TEST_INVALID", time.Second); got != time.Second {
+		t.Errorf("DurationFromEnv() of an invalid value = %v, want the default", got)
+	}
+	if got := IntFromEnv("BACKGROUND_TEST_INT", 1); got != 12 {
+		t.Errorf("IntFromEnv() = %d, want 12", got)
+	}
+	if got := IntFromEnv("BACKGROUND_TEST_UNSET", 1); got != 1 {
+		t.Errorf("IntFromEnv() of an unset variable = %d, want the default", got)
+	}
+}
+
+// chunkedTable deletes up to the chunk size from a table of rows rows.
+type chunkedTable struct {
+	rows int
+	runs int
+}
+
+func (t *chunkedTable) Exec(_ context.Context, _ string, arguments ...any) (pgconn.CommandTag, error) {
+	t.runs++
+	deleted := min(t.rows, arguments[1].(int))
+	t.rows -= deleted
+	return pgconn.NewCommandTag(fmt.Sprintf("DELETE %d", deleted)), nil
+}
+
+func TestPurge(t *testing.T) {
+	table := &chunkedTable{rows: 2*PurgeChunkSize + 1}
+	if err := Purge(context.Background(), table, "delete", time.Now()); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if table.rows != 0 || table.runs != 3 {
+		t.Errorf("Purge() left %d rows after %d runs, want 0 after 3", table.rows, table.runs)
+	}
+}
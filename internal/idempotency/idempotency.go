@@ -0,0 +1,270 @@
+// Package idempotency makes POST requests safe to retry. A request sent with
+// an Idempotency-Key header is handled once per user and key: retries get
+// the response of the first request, and reusing the key for a different
+// request is rejected. Keys are kept until a maintenance job purges them.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/logging"
+	"scheduler-api/internal/tracing"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// Header carries the key a client picks for a request and its retries.
+	Header = "Idempotency-Key"
+	// ReplayedHeader marks responses replayed from an earlier request.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// storedHeaders are the response headers replayed with a stored response.
+var storedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("idempotency", queryFiles)
+}
+
+var (
+	//go:embed queries/claim_key.sql
+	claimKeySQL string
+
+	//go:embed queries/get_key.sql
+	getKeySQL string
+
+	//go:embed queries/complete_key.sql
+	completeKeySQL string
+
+	//go:embed queries/release_key.sql
+	releaseKeySQL string
+
+	//go:embed queries/purge_keys.sql
+	purgeKeysSQL string
+)
+
+// Config controls how keys are held.
+type Config struct {
+	// LockTimeout is how long a request holds its key. A retry after that
+	// takes the key over, for requests whose instance stopped before they
+	// got a response.
+	LockTimeout time.Duration
+}
+
+// DefaultConfig returns the defaults, which outlast the slowest imports.
+func DefaultConfig() Config {
+	return Config{
+		LockTimeout: 5 * time.Minute,
+	}
+}
+
+// LoadConfigFromEnv overrides the defaults with IDEMPOTENCY_* environment
+// variables.
+func LoadConfigFromEnv() Config {
+	config := DefaultConfig()
+	config.LockTimeout = background.DurationFromEnv("IDEMPOTENCY_LOCK_TIMEOUT", config.LockTimeout)
+	return config
+}
+
+// Middleware honors Idempotency-Key on POST requests of the authenticated
+// user, so it goes after the authentication middleware. Responses below 500
+// are stored and replayed; server errors release the key so the request can
+// be retried.
+func Middleware(logger *zap.Logger, pgxPool *pgxpool.Pool, config Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		if !validKey(key) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_idempotency_key",
+				"message": "Idempotency-Key must be 1 to 255 printable ASCII characters",
+			})
+			return
+		}
+
+		currentUser, err := auth.GetCurrentUser(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var (
+			ctx         = c.Request.Context()
+			now         = time.Now()
+			fingerprint = requestFingerprint(c.Request, body)
+		)
+
+		var claimed string
+		err = pgxPool.QueryRow(ctx, claimKeySQL, currentUser.UserID, key, fingerprint, now, now.Add(-config.LockTimeout)).Scan(&claimed)
+		if errors.Is(err, pgx.ErrNoRows) {
+			replay(c, pgxPool, currentUser.UserID, key, fingerprint)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// The response is stored even when the client has gone, since that
+		// is when it retries.
+		storeCtx := context.WithoutCancel(ctx)
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			if _, err := pgxPool.Exec(storeCtx, releaseKeySQL, currentUser.UserID, key); err != nil {
+				logging.FromContext(ctx, logger).Error("Failed to release idempotency key", zap.String("user_id", currentUser.UserID), zap.Error(err))
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		headers := map[string]string{}
+		for _, name := range storedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if _, err := pgxPool.Exec(storeCtx, completeKeySQL, currentUser.UserID, key, status, headers, recorder.body.Bytes(), time.Now()); err != nil {
+			logging.FromContext(ctx, logger).Error("Failed to store idempotent response", zap.String("user_id", currentUser.UserID), zap.Error(err))
+			return
+		}
+		stored = true
+	}
+}
+
+type storedKey struct {
+	Fingerprint     string
+	ResponseStatus  *int
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	CompletedAt     *time.Time
+}
+
+// replay answers a request whose key is taken with the stored response, or
+// an error when the key was used for another request or is still held.
+func replay(c *gin.Context, pgxPool *pgxpool.Pool, userID, key, fingerprint string) {
+	rows, err := pgxPool.Query(c.Request.Context(), getKeySQL, userID, key)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	stored, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[storedKey])
+
+	switch {
+	case err != nil && !errors.Is(err, pgx.ErrNoRows):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+
+	case err == nil && stored.Fingerprint != fingerprint:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "idempotency_key_reused",
+			"message": "The Idempotency-Key was already used for a different request",
+		})
+
+	// Released by a failed request in between, or still being handled.
+	case err != nil || stored.CompletedAt == nil:
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"error":   "idempotency_key_in_progress",
+			"message": "A request with the Idempotency-Key is being handled; retry it later",
+		})
+
+	default:
+		for name, value := range stored.ResponseHeaders {
+			c.Header(name, value)
+		}
+		c.Header(ReplayedHeader, "true")
+		c.Writer.WriteHeader(*stored.ResponseStatus)
+		_, _ = c.Writer.Write(stored.ResponseBody)
+		c.Abort()
+	}
+}
+
+// Purge deletes keys created before cutoff, in chunks to keep transactions
+// short. Retries after that are handled as new requests.
+func Purge(ctx context.Context, db background.Executor, cutoff time.Time) error {
+	return background.Purge(ctx, db, purgeKeysSQL, cutoff)
+}
+
+// responseRecorder keeps a copy of the response body written through it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+func validKey(key string) bool {
+	if len(key) == 0 || len(key) > maxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestFingerprint identifies a request by its method, path, query and
+// body. JSON bodies are compared by value, so a retry that encodes the same
+// payload differently still matches.
+func requestFingerprint(r *http.Request, body []byte) string {
+	var payload any
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") && json.Unmarshal(body, &payload) == nil {
+		body, _ = json.Marshal(payload)
+	}
+
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,127 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"", false},
+		{"a", true},
+		{"9f1c2b7e-5d4a-4c0e-8f3a-2b1d6e7c8a90", true},
+		{"retry key/1", true},
+		{strings.Repeat("k", maxKeyLength), true},
+		{strings.Repeat("k", maxKeyLength+1), false},
+		{"tab\tkey", false},
+		{"clé", false},
+	}
+
+	for _, tt := range tests {
+		if got := validKey(tt.key); got != tt.want {
+			t.Errorf("validKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	request := func(method, target, contentType string) *http.Request {
+		r := httptest.NewRequest(method, target, nil)
+		r.Header.Set("Content-Type", contentType)
+		return r
+	}
+
+	base := requestFingerprint(request("POST", "/v1/class/", "application/json"), []byte(`{"course_id":"c-1","start":"10:00"}`))
+
+	same := []struct {
+		name string
+		r    *http.Request
+		body string
+	}{
+		{"reordered fields", request("POST", "/v1/class/", "application/json"), `{"start":"10:00","course_id":"c-1"}`},
+		{"whitespace", request("POST", "/v1/class/", "application/json; charset=utf-8"), "{ \"course_id\": \"c-1\",\n \"start\": \"10:00\" }"},
+	}
+	for _, tt := range same {
+		if got := requestFingerprint(tt.r, []byte(tt.body)); got != base {
+			t.Errorf("%s: fingerprint differs from the original request", tt.name)
+		}
+	}
+
+	different := []struct {
+		name string
+		r    *http.Request
+		body string
+	}{
+		{"payload", request("POST", "/v1/class/", "application/json"), `{"course_id":"c-2","start":"10:00"}`},
+		{"path", request("POST", "/v1/course/", "application/json"), `{"course_id":"c-1","start":"10:00"}`},
+		{"query", request("POST", "/v1/class/?notify=false", "application/json"), `{"course_id":"c-1","start":"10:00"}`},
+		{"not JSON", request("POST", "/v1/class/", "text/plain"), `{"start":"10:00","course_id":"c-1"}`},
+	}
+	for _, tt := range different {
+		if got := requestFingerprint(tt.r, []byte(tt.body)); got == base {
+			t.Errorf("%s: fingerprint matches the original request", tt.name)
+		}
+	}
+}
+
+func TestResponseRecorder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	c.JSON(http.StatusCreated, gin.H{"id": "class-1"})
+
+	if recorder.Status() != http.StatusCreated {
+		t.Errorf("status = %d, want %d", recorder.Status(), http.StatusCreated)
+	}
+	if recorder.body.String() != w.Body.String() {
+		t.Errorf("recorded body = %q, want %q", recorder.body.String(), w.Body.String())
+	}
+	if recorder.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", recorder.Header().Get("Content-Type"))
+	}
+}
+
+func TestMiddlewarePassesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Requests the middleware leaves alone never reach the database.
+	r := gin.New()
+	r.Use(Middleware(nil, nil, DefaultConfig()))
+	r.Any("/v1/class/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		want   int
+	}{
+		{"no key", http.MethodPost, "", http.StatusNoContent},
+		{"not a POST", http.MethodPut, "key-1", http.StatusNoContent},
+		{"no user", http.MethodPost, "key-1", http.StatusNoContent},
+		{"invalid key", http.MethodPost, strings.Repeat("k", maxKeyLength+1), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/v1/class/", strings.NewReader(`{}`))
+		if tt.key != "" {
+			req.Header.Set(Header, tt.key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+insert into idempotency_keys (user_id, idempotency_key, fingerprint, created_at)
+values ($1, $2, $3, $4)
+on conflict (user_id, idempotency_key) do update set
+	created_at = excluded.created_at
+where
+	idempotency_keys.completed_at is NULL
+	and idempotency_keys.fingerprint = excluded.fingerprint
+	and idempotency_keys.created_at < $5
+returning idempotency_key;
@@ -0,0 +1,7 @@
+update idempotency_keys
+set
+	response_status = $3,
+	response_headers = $4,
+	response_body = $5,
+	completed_at = $6
+where user_id = $1 and idempotency_key = $2;
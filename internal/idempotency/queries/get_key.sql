@@ -0,0 +1,8 @@
+select
+	fingerprint,
+	response_status,
+	response_headers,
+	response_body,
+	completed_at
+from idempotency_keys
+where user_id = $1 and idempotency_key = $2;
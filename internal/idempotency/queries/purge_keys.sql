@@ -0,0 +1,7 @@
+delete from idempotency_keys
+where (user_id, idempotency_key) in (
+	select user_id, idempotency_key
+	from idempotency_keys
+	where created_at < $1
+	limit $2
+);
@@ -0,0 +1,2 @@
+delete from idempotency_keys
+where user_id = $1 and idempotency_key = $2 and completed_at is NULL;
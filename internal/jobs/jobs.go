@@ -0,0 +1,109 @@
+// Package jobs runs background work from a Postgres-backed queue.
+//
+// Every API replica runs a Runner. Jobs are claimed with
+// select ... for update skip locked, so a job runs on one replica at a time,
+// and a job whose lease expires (e.g. because its replica died) is claimed
+// again. Execution is therefore at least once and handlers must be
+// idempotent. Cron schedules are stored in the database too, so each
+// scheduled run is enqueued exactly once however many replicas are running.
+package jobs
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"time"
+
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/tracing"
+)
+
+// Job statuses.
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// DefaultMaxAttempts is how often a job is tried before it is marked failed.
+const DefaultMaxAttempts = 5
+
+// Job is a claimed job being passed to its handler.
+type Job struct {
+	ID      string
+	Kind    string
+	Payload []byte
+	// Attempt is 1 on the first run and grows with every retry.
+	Attempt     int
+	MaxAttempts int
+}
+
+// Handler runs one kind of job. A returned error retries the job with
+// backoff until it runs out of attempts.
+type Handler func(ctx context.Context, job Job) error
+
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("jobs", queryFiles)
+}
+
+var (
+	//go:embed queries/enqueue_job.sql
+	enqueueJobSQL string
+
+	//go:embed queries/retry_job.sql
+	retryJobSQL string
+
+	//go:embed queries/cancel_job.sql
+	cancelJobSQL string
+)
+
+// Enqueue queues a job to run at runAt. payload is stored as JSON. The job
+// belongs to no organization, as the work it does spans all of them.
+func Enqueue(ctx context.Context, db background.Executor, kind string, payload any, runAt time.Time) error {
+	return enqueue(ctx, db, nil, kind, payload, runAt, nil)
+}
+
+// EnqueueForOrg queues a job like Enqueue on behalf of an organization, whose
+// admins can then see, retry and cancel it.
+func EnqueueForOrg(ctx context.Context, db background.Executor, orgID, kind string, payload any, runAt time.Time) error {
+	return enqueue(ctx, db, &orgID, kind, payload, runAt, nil)
+}
+
+func enqueue(ctx context.Context, db background.Executor, orgID *string, kind string, payload any, runAt time.Time, dedupeKey *string) error {
+	if payload == nil {
+		payload = map[string]any{}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, enqueueJobSQL, orgID, kind, data, DefaultMaxAttempts, runAt, dedupeKey, time.Now())
+	return err
+}
+
+// Retry queues a failed or cancelled job to run again from its first
+// attempt. It reports false if the job is not failed or cancelled.
+func Retry(ctx context.Context, db background.Executor, jobID string, now time.Time) (bool, error) {
+	tag, err := db.Exec(ctx, retryJobSQL, jobID, now)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Cancel stops a queued job from running. Jobs that are already running
+// cannot be cancelled; it reports false if the job is not queued.
+func Cancel(ctx context.Context, db background.Executor, jobID string, now time.Time) (bool, error) {
+	tag, err := db.Exec(ctx, cancelJobSQL, jobID, now)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,6 @@
+update job_schedules
+set
+	next_run_at = $2,
+	last_run_at = $3,
+	updated_at = $3
+where name = $1;
@@ -0,0 +1,6 @@
+update jobs
+set
+	status = 'cancelled',
+	finished_at = $2,
+	updated_at = $2
+where job_id = $1 and status = 'queued';
@@ -0,0 +1,5 @@
+select name, kind, next_run_at
+from job_schedules
+where next_run_at <= $1
+order by next_run_at
+for update skip locked;
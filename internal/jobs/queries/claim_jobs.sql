@@ -0,0 +1,27 @@
+with claimed as (
+	select job_id
+	from jobs
+	where
+		(status = 'queued' and run_at <= $1)
+		or (status = 'running' and locked_until < $1)
+	order by run_at
+	limit $2
+	for update skip locked
+)
+
+update jobs as j
+set
+	status = 'running',
+	attempts = j.attempts + 1,
+	locked_until = $3,
+	locked_by = $4,
+	started_at = $1,
+	updated_at = $1
+from claimed
+where j.job_id = claimed.job_id
+returning
+	j.job_id,
+	j.kind,
+	j.payload,
+	j.attempts,
+	j.max_attempts;
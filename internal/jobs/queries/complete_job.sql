@@ -0,0 +1,8 @@
+update jobs
+set
+	status = 'succeeded',
+	locked_until = null,
+	last_error = null,
+	finished_at = $3,
+	updated_at = $3
+where job_id = $1 and status = 'running' and locked_by = $2;
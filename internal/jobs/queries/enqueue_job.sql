@@ -0,0 +1,3 @@
+insert into jobs (org_id, kind, payload, status, max_attempts, run_at, dedupe_key, created_at, updated_at)
+values ($1, $2, $3, 'queued', $4, $5, $6, $7, $7)
+on conflict (dedupe_key) do nothing;
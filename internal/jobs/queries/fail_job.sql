@@ -0,0 +1,9 @@
+update jobs
+set
+	status = $3,
+	run_at = $4,
+	locked_until = null,
+	last_error = $5,
+	finished_at = case when $3 = 'failed' then $6 else finished_at end,
+	updated_at = $6
+where job_id = $1 and status = 'running' and locked_by = $2;
@@ -0,0 +1,9 @@
+update jobs
+set
+	status = 'queued',
+	attempts = 0,
+	run_at = $2,
+	last_error = null,
+	finished_at = null,
+	updated_at = $2
+where job_id = $1 and status in ('failed', 'cancelled');
@@ -0,0 +1,11 @@
+insert into job_schedules (name, kind, cron, next_run_at, updated_at)
+values ($1, $2, $3, $4, $5)
+on conflict (name) do update
+set
+	kind = excluded.kind,
+	cron = excluded.cron,
+	next_run_at = case
+		when job_schedules.cron = excluded.cron then job_schedules.next_run_at
+		else excluded.next_run_at
+	end,
+	updated_at = excluded.updated_at;
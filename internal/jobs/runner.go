@@ -0,0 +1,296 @@
+package jobs
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"scheduler-api/internal/background"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed queries/claim_jobs.sql
+	claimJobsSQL string
+
+	//go:embed queries/complete_job.sql
+	completeJobSQL string
+
+	//go:embed queries/fail_job.sql
+	failJobSQL string
+
+	//go:embed queries/upsert_schedule.sql
+	upsertScheduleSQL string
+
+	//go:embed queries/claim_due_schedules.sql
+	claimDueSchedulesSQL string
+
+	//go:embed queries/advance_schedule.sql
+	advanceScheduleSQL string
+)
+
+// Config controls how the runner polls and retries jobs.
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease is how long a job may run before another replica may claim it
+	// again. It should comfortably exceed the slowest job.
+	Lease       time.Duration
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultConfig returns the runner defaults.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 5 * time.Second,
+		BatchSize:    10,
+		Lease:        10 * time.Minute,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+// LoadConfigFromEnv overrides the defaults with JOB_* environment variables.
+// Durations use time.ParseDuration syntax, e.g. "10m".
+func LoadConfigFromEnv() Config {
+	config := DefaultConfig()
+	config.PollInterval = background.DurationFromEnv("JOB_POLL_INTERVAL", config.PollInterval)
+	config.BatchSize = background.IntFromEnv("JOB_BATCH_SIZE", config.BatchSize)
+	config.Lease = background.DurationFromEnv("JOB_LEASE", config.Lease)
+	config.BaseBackoff = background.DurationFromEnv("JOB_BASE_BACKOFF", config.BaseBackoff)
+	config.MaxBackoff = background.DurationFromEnv("JOB_MAX_BACKOFF", config.MaxBackoff)
+	return config
+}
+
+type cronEntry struct {
+	name     string
+	kind     string
+	spec     string
+	schedule Schedule
+}
+
+// Runner claims and runs queued jobs and enqueues jobs for cron schedules.
+type Runner struct {
+	logger   *zap.Logger
+	pgxPool  *pgxpool.Pool
+	config   Config
+	workerID string
+	handlers map[string]Handler
+	crons    []cronEntry
+	now      func() time.Time
+}
+
+// NewRunner creates a runner. Register handlers and schedules before calling
+// Run.
+func NewRunner(logger *zap.Logger, pgxPool *pgxpool.Pool, config Config) *Runner {
+	hostname, _ := os.Hostname()
+
+	return &Runner{
+		logger:   logger,
+		pgxPool:  pgxPool,
+		config:   config,
+		workerID: hostname + "/" + uuid.New().String(),
+		handlers: map[string]Handler{},
+		now:      time.Now,
+	}
+}
+
+// Register sets the handler for a kind of job.
+func (r *Runner) Register(kind string, handler Handler) {
+	r.handlers[kind] = handler
+}
+
+// Cron enqueues a job of the given kind on a cron schedule, evaluated in
+// UTC. name identifies the schedule across restarts and replicas.
+func (r *Runner) Cron(name, spec, kind string) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("schedule %s: %q never runs", name, spec)
+	}
+
+	r.crons = append(r.crons, cronEntry{name: name, kind: kind, spec: spec, schedule: schedule})
+	return nil
+}
+
+// Run saves the registered schedules and processes jobs until ctx is
+// cancelled.
+func (r *Runner) Run(ctx context.Context) {
+	if err := r.saveSchedules(ctx); err != nil {
+		r.logger.Error("Failed to save job schedules", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("Job runner run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce enqueues due scheduled jobs and runs one batch of queued jobs.
+func (r *Runner) RunOnce(ctx context.Context) error {
+	if err := r.enqueueDueSchedules(ctx); err != nil {
+		return err
+	}
+
+	now := r.now()
+	rows, err := r.pgxPool.Query(ctx, claimJobsSQL, now, r.config.BatchSize, now.Add(r.config.Lease), r.workerID)
+	if err != nil {
+		return fmt.Errorf("failed to claim jobs: %w", err)
+	}
+
+	claimed, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Job, error) {
+		var job Job
+		err := row.Scan(&job.ID, &job.Kind, &job.Payload, &job.Attempt, &job.MaxAttempts)
+		return job, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to claim jobs: %w", err)
+	}
+
+	for _, job := range claimed {
+		r.run(ctx, job)
+	}
+
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context, job Job) {
+	logger := r.logger.With(zap.String("job_id", job.ID), zap.String("kind", job.Kind), zap.Int("attempt", job.Attempt))
+
+	err := r.execute(ctx, job)
+	now := r.now()
+
+	if err == nil {
+		if _, err := r.pgxPool.Exec(ctx, completeJobSQL, job.ID, r.workerID, now); err != nil {
+			logger.Error("Failed to mark job succeeded", zap.Error(err))
+		}
+		return
+	}
+
+	status, runAt := StatusQueued, now.Add(background.Backoff(job.Attempt, r.config.BaseBackoff, r.config.MaxBackoff))
+	if job.Attempt >= job.MaxAttempts {
+		status, runAt = StatusFailed, now
+	}
+
+	logger.Warn("Job failed", zap.String("status", status), zap.Error(err))
+
+	if _, err := r.pgxPool.Exec(ctx, failJobSQL, job.ID, r.workerID, status, runAt, err.Error(), now); err != nil {
+		logger.Error("Failed to record job failure", zap.Error(err))
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job Job) (err error) {
+	handler, ok := r.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %s", job.Kind)
+	}
+
+	// A job that keeps crashing its replica would otherwise never finish;
+	// this way it runs out of attempts instead.
+	if job.Attempt > job.MaxAttempts {
+		return fmt.Errorf("job exceeded %d attempts", job.MaxAttempts)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+func (r *Runner) saveSchedules(ctx context.Context) error {
+	now := r.now().UTC()
+
+	for _, entry := range r.crons {
+		next := entry.schedule.Next(now)
+		if _, err := r.pgxPool.Exec(ctx, upsertScheduleSQL, entry.name, entry.kind, entry.spec, next, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueueDueSchedules enqueues a job for each schedule whose next run has
+// come and moves the schedule on. Runs missed while no replica was up are
+// collapsed into one.
+func (r *Runner) enqueueDueSchedules(ctx context.Context) error {
+	if len(r.crons) == 0 {
+		return nil
+	}
+
+	schedules := map[string]Schedule{}
+	for _, entry := range r.crons {
+		schedules[entry.name] = entry.schedule
+	}
+
+	now := r.now().UTC()
+
+	tx, err := r.pgxPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, claimDueSchedulesSQL, now)
+	if err != nil {
+		return fmt.Errorf("failed to load due schedules: %w", err)
+	}
+
+	type dueSchedule struct {
+		name, kind string
+		nextRunAt  time.Time
+	}
+	due, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dueSchedule, error) {
+		var d dueSchedule
+		err := row.Scan(&d.name, &d.kind, &d.nextRunAt)
+		return d, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load due schedules: %w", err)
+	}
+
+	for _, d := range due {
+		schedule, ok := schedules[d.name]
+		if !ok {
+			// Registered by a replica running a different version; it
+			// will advance the schedule itself.
+			continue
+		}
+
+		dedupeKey := "schedule:" + d.name + ":" + strconv.FormatInt(d.nextRunAt.Unix(), 10)
+		if err := enqueue(ctx, tx, nil, d.kind, nil, now, &dedupeKey); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, advanceScheduleSQL, d.name, schedule.Next(now), now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,307 @@
+package live
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"scheduler-api/internal/background"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed queries/get_event.sql
+	getEventSQL string
+
+	//go:embed queries/list_events.sql
+	listEventsSQL string
+
+	//go:embed queries/list_events_after.sql
+	listEventsAfterSQL string
+
+	//go:embed queries/purge_events.sql
+	purgeEventsSQL string
+
+	//go:embed queries/resume_after.sql
+	resumeAfterSQL string
+)
+
+const (
+	// bufferSize is how many events a subscription holds for a stream that
+	// has not written them yet. Subscriptions that fall further behind are
+	// closed, and their clients resume from the last event they got.
+	bufferSize = 64
+
+	// reconnectDelay is the wait before listening again after the
+	// connection is lost.
+	reconnectDelay = 5 * time.Second
+
+	// catchUpLimit bounds the events fanned out after reconnecting.
+	catchUpLimit = 1000
+
+	// resumeOverlap is how long before the last event seen events are read
+	// again when resuming, for those whose transactions committed after it.
+	// The changes behind events are short transactions.
+	resumeOverlap = time.Minute
+)
+
+// Broker listens for published events and fans them out to the
+// subscriptions of this instance.
+type Broker struct {
+	logger  *zap.Logger
+	pgxPool *pgxpool.Pool
+
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	// lastID is the highest event ID fanned out, which fanning out resumes
+	// from after the connection is lost.
+	lastID int64
+}
+
+// NewBroker creates a broker. Run starts it.
+func NewBroker(logger *zap.Logger, pgxPool *pgxpool.Pool) *Broker {
+	return &Broker{
+		logger:        logger,
+		pgxPool:       pgxPool,
+		subscriptions: map[*Subscription]struct{}{},
+	}
+}
+
+// Subscription receives the events a subscriber sees.
+type Subscription struct {
+	Subscriber
+
+	broker *Broker
+	events chan Event
+	closed bool
+}
+
+// Events delivers the subscription's events. It is closed when the
+// subscription is, including when it falls too far behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
+
+// Subscribe starts receiving the events subscriber sees.
+func (b *Broker) Subscribe(subscriber Subscriber) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &Subscription{Subscriber: subscriber, broker: b, events: make(chan Event, bufferSize)}
+	b.subscriptions[s] = struct{}{}
+	return s
+}
+
+// remove closes a subscription. The caller holds b.mu.
+func (b *Broker) remove(s *Subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.events)
+	delete(b.subscriptions, s)
+}
+
+// Replay lists the events subscriber sees that were published after the
+// event afterID, oldest first and at most limit of them.
+func (b *Broker) Replay(ctx context.Context, subscriber Subscriber, afterID int64, limit int) ([]Event, error) {
+	rows, err := b.pgxPool.Query(ctx, listEventsSQL, subscriber.OrgID, afterID, subscriber.Admin, subscriber.UserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[Event])
+}
+
+// ResumeAfter returns the ID to replay events after for a client whose
+// last event is lastID. It is before lastID by the events published within
+// resumeOverlap of it, which the client may not have got yet.
+func (b *Broker) ResumeAfter(ctx context.Context, lastID int64) (int64, error) {
+	return resumeAfter(ctx, b.pgxPool, lastID)
+}
+
+// rowQuerier is a pool or connection events are read with.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func resumeAfter(ctx context.Context, db rowQuerier, lastID int64) (int64, error) {
+	var afterID int64
+	return afterID, db.QueryRow(ctx, resumeAfterSQL, lastID, resumeOverlap.Seconds()).Scan(&afterID)
+}
+
+// Purge deletes events published before cutoff, in chunks to keep
+// transactions short.
+func Purge(ctx context.Context, db background.Executor, cutoff time.Time) error {
+	return background.Purge(ctx, db, purgeEventsSQL, cutoff)
+}
+
+// Run listens for events until ctx is cancelled, listening again whenever
+// the connection is lost.
+func (b *Broker) Run(ctx context.Context) {
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.logger.Error("Live event listener failed", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) listen(ctx context.Context) error {
+	pooled, err := b.pgxPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection keeps listening until it is closed, so it does not go
+	// back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "listen "+Channel); err != nil {
+		return err
+	}
+
+	if err := b.catchUp(ctx, conn); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		orgID, id, err := parseNotification(notification.Payload)
+		if err != nil {
+			b.logger.Warn("Ignoring malformed live event notification", zap.String("payload", notification.Payload))
+			continue
+		}
+		if !b.hasSubscribers(orgID) {
+			b.advance(id)
+			continue
+		}
+
+		rows, err := conn.Query(ctx, getEventSQL, id)
+		if err != nil {
+			return err
+		}
+		event, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[Event])
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Purged before it could be read.
+			b.advance(id)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		b.fanOut(event)
+	}
+}
+
+// catchUp fans out the events published while the broker was not
+// listening, and again those published shortly before the last one fanned
+// out, which may have committed since. Nothing is missed on the first
+// connection, before which no stream was open.
+func (b *Broker) catchUp(ctx context.Context, conn *pgx.Conn) error {
+	b.mu.Lock()
+	lastID := b.lastID
+	b.mu.Unlock()
+
+	if lastID == 0 {
+		return nil
+	}
+
+	afterID, err := resumeAfter(ctx, conn, lastID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.Query(ctx, listEventsAfterSQL, afterID, catchUpLimit)
+	if err != nil {
+		return err
+	}
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Event])
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		b.fanOut(event)
+	}
+	return nil
+}
+
+func (b *Broker) hasSubscribers(orgID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subscriptions {
+		if s.OrgID == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Broker) advance(id int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID = max(b.lastID, id)
+}
+
+// fanOut sends an event to the subscriptions that see it, closing those
+// too far behind to take it.
+func (b *Broker) fanOut(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID = max(b.lastID, event.ID)
+	for s := range b.subscriptions {
+		if !s.Sees(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			b.remove(s)
+		}
+	}
+}
+
+// parseNotification reads the organization and event ID a notification
+// announces.
+func parseNotification(payload string) (string, int64, error) {
+	orgID, idText, ok := strings.Cut(payload, " ")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid payload %q", payload)
+	}
+
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid payload %q", payload)
+	}
+	return orgID, id, nil
+}
@@ -0,0 +1,116 @@
+// Package live streams schedule changes to connected clients as they happen.
+//
+// Changes are published as events into the live_events table, in the
+// transaction that makes them. A trigger announces each event on the
+// live_events notification channel when that transaction commits, and the
+// Broker of every API instance listening on it fans the event out to the
+// streams of its subscribers. Event IDs only increase, so a client that
+// reconnects resumes from the last event it saw. IDs are taken when events
+// are published rather than when they commit, so an event can commit after
+// one with a later ID. Resuming therefore re-reads the events published
+// shortly before the last one seen, and clients may get an event twice.
+package live
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/tracing"
+)
+
+// Events streamed to clients.
+const (
+	EventClassCreated             = "class.created"
+	EventClassRescheduled         = "class.rescheduled"
+	EventClassCancelled           = "class.cancelled"
+	EventClassRestored            = "class.restored"
+	EventClassParticipantsUpdated = "class.participants_updated"
+	EventAvailabilityChanged      = "availability.changed"
+	EventAttendanceRecorded       = "attendance.recorded"
+)
+
+// Channel is the notification channel events are announced on.
+const Channel = "live_events"
+
+// Event is a published change.
+type Event struct {
+	ID    int64  `json:"id"`
+	OrgID string `json:"org_id"`
+	Type  string `json:"type"`
+	// UserIDs are the users the event concerns. Events without them
+	// concern the whole organization.
+	UserIDs   []string        `json:"-"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Subscriber is the user a stream is for.
+type Subscriber struct {
+	OrgID  string
+	UserID string
+	Admin  bool
+}
+
+// Sees reports whether an event is streamed to the subscriber. Admins see
+// every event of their organization, and other users the events of the
+// whole organization and those that concern them.
+func (s Subscriber) Sees(e Event) bool {
+	if e.OrgID != s.OrgID {
+		return false
+	}
+	return s.Admin || e.UserIDs == nil || slices.Contains(e.UserIDs, s.UserID)
+}
+
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("live", queryFiles)
+}
+
+var (
+	//go:embed queries/publish_event.sql
+	publishEventSQL string
+
+	//go:embed queries/publish_class_event.sql
+	publishClassEventSQL string
+)
+
+// Publish publishes an event of the organization concerning userIDs, or the
+// whole organization when userIDs is nil. data becomes the "data" field of
+// the event.
+func Publish(ctx context.Context, db background.Executor, orgID, event string, userIDs []string, data any, now time.Time) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, publishEventSQL, orgID, event, userIDs, payload, now)
+	return err
+}
+
+// PublishClassEvent publishes a class event concerning the class
+// participants and formerUserIDs, the participants just removed from it,
+// with the class as it is at publish time as its data.
+func PublishClassEvent(ctx context.Context, db background.Executor, classID, event string, formerUserIDs []string, now time.Time) error {
+	_, err := db.Exec(ctx, publishClassEventSQL, classID, event, formerUserIDs, now)
+	return err
+}
+
+// WriteEvent writes an event in the text/event-stream format, with its ID
+// as the ID clients resume from and the event as JSON data.
+func WriteEvent(w io.Writer, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
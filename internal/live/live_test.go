@@ -0,0 +1,117 @@
+package live
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestSubscriberSees(t *testing.T) {
+	var (
+		orgWide  = Event{OrgID: "org-1"}
+		forAda   = Event{OrgID: "org-1", UserIDs: []string{"ada"}}
+		forNoOne = Event{OrgID: "org-1", UserIDs: []string{}}
+		otherOrg = Event{OrgID: "org-2"}
+	)
+
+	tests := []struct {
+		subscriber Subscriber
+		event      Event
+		want       bool
+	}{
+		{Subscriber{OrgID: "org-1", UserID: "ada"}, orgWide, true},
+		{Subscriber{OrgID: "org-1", UserID: "ada"}, forAda, true},
+		{Subscriber{OrgID: "org-1", UserID: "alan"}, forAda, false},
+		{Subscriber{OrgID: "org-1", UserID: "alan"}, forNoOne, false},
+		{Subscriber{OrgID: "org-1", UserID: "grace", Admin: true}, forAda, true},
+		{Subscriber{OrgID: "org-1", UserID: "grace", Admin: true}, forNoOne, true},
+		{Subscriber{OrgID: "org-1", UserID: "grace", Admin: true}, otherOrg, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.subscriber.Sees(tt.event); got != tt.want {
+			t.Errorf("%+v sees %+v = %v, want %v", tt.subscriber, tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestWriteEvent(t *testing.T) {
+	event := Event{
+		ID:        42,
+		OrgID:     "org-1",
+		Type:      EventClassCancelled,
+		UserIDs:   []string{"ada"},
+		Data:      json.RawMessage(`{"class_id":"c-1"}`),
+		CreatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEvent(&buf, event); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id: 42\nevent: class.cancelled\n" +
+		`data: {"id":42,"org_id":"org-1","type":"class.cancelled","data":{"class_id":"c-1"},"created_at":"2026-03-01T10:00:00Z"}` + "\n\n"
+	if buf.String() != want {
+		t.Errorf("WriteEvent() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	orgID, id, err := parseNotification("org-1 42")
+	if err != nil || orgID != "org-1" || id != 42 {
+		t.Errorf("parseNotification() = %q, %d, %v", orgID, id, err)
+	}
+
+	for _, payload := range []string{"", "org-1", "org-1 x"} {
+		if _, _, err := parseNotification(payload); err == nil {
+			t.Errorf("expected an error for %q", payload)
+		}
+	}
+}
+
+func TestBrokerFanOut(t *testing.T) {
+	broker := NewBroker(zap.NewNop(), nil)
+
+	ada := broker.Subscribe(Subscriber{OrgID: "org-1", UserID: "ada"})
+	alan := broker.Subscribe(Subscriber{OrgID: "org-1", UserID: "alan"})
+	defer alan.Close()
+
+	broker.fanOut(Event{ID: 1, OrgID: "org-1", UserIDs: []string{"ada"}})
+	broker.fanOut(Event{ID: 2, OrgID: "org-1"})
+	broker.fanOut(Event{ID: 3, OrgID: "org-2"})
+
+	if got := (<-ada.Events()).ID; got != 1 {
+		t.Errorf("ada got event %d, want 1", got)
+	}
+	if got := (<-ada.Events()).ID; got != 2 {
+		t.Errorf("ada got event %d, want 2", got)
+	}
+	if got := (<-alan.Events()).ID; got != 2 {
+		t.Errorf("alan got event %d, want 2", got)
+	}
+	if !broker.hasSubscribers("org-1") || broker.hasSubscribers("org-2") || broker.lastID != 3 {
+		t.Errorf("subscribers of org-1 = %v, org-2 = %v, last ID = %d", broker.hasSubscribers("org-1"), broker.hasSubscribers("org-2"), broker.lastID)
+	}
+
+	// A subscription that stops reading is closed once its buffer is full.
+	for id := int64(4); id < 4+bufferSize+1; id++ {
+		broker.fanOut(Event{ID: id, OrgID: "org-1", UserIDs: []string{"ada"}})
+	}
+	received := 0
+	for range ada.Events() {
+		received++
+	}
+	if received != bufferSize {
+		t.Errorf("ada received %d events before being closed, want %d", received, bufferSize)
+	}
+
+	ada.Close()
+	alan.Close()
+	if broker.hasSubscribers("org-1") {
+		t.Error("closed subscriptions are still subscribed")
+	}
+}
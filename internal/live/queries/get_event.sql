@@ -0,0 +1,9 @@
+select
+	event_id,
+	org_id::text,
+	event,
+	user_ids::text[],
+	data,
+	created_at
+from live_events
+where event_id = $1;
@@ -0,0 +1,14 @@
+select
+	event_id,
+	org_id::text,
+	event,
+	user_ids::text[],
+	data,
+	created_at
+from live_events
+where
+	org_id = $1
+	and event_id > $2
+	and ($3 or user_ids is NULL or $4::uuid = any(user_ids))
+order by event_id
+limit $5;
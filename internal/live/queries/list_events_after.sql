@@ -0,0 +1,11 @@
+select
+	event_id,
+	org_id::text,
+	event,
+	user_ids::text[],
+	data,
+	created_at
+from live_events
+where event_id > $1
+order by event_id
+limit $2;
@@ -0,0 +1,23 @@
+insert into live_events (org_id, event, user_ids, data, created_at)
+select
+	c.org_id,
+	$2::text,
+	array(
+		select cp.user_id
+		from class_participants as cp
+		where cp.class_id = c.class_id
+		union
+		select former.user_id
+		from unnest($3::uuid[]) as former(user_id)
+		order by 1
+	),
+	jsonb_build_object(
+		'class_id', c.class_id,
+		'course_id', c.course_id,
+		'start_time', c.start_time,
+		'duration', c.duration,
+		'status', c.status
+	),
+	$4::timestamptz
+from classes as c
+where c.class_id = $1;
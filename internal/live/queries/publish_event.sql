@@ -0,0 +1,2 @@
+insert into live_events (org_id, event, user_ids, data, created_at)
+values ($1, $2, $3, $4, $5);
@@ -0,0 +1,7 @@
+delete from live_events
+where event_id in (
+	select event_id
+	from live_events
+	where created_at < $1
+	limit $2
+);
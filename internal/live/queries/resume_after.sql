@@ -0,0 +1,13 @@
+-- The ID to replay events after for a client whose last event is $1: that
+-- of the last event published more than $2 seconds before it, since the
+-- events published around it can commit after it. Events that are gone
+-- are resumed after as they are.
+select coalesce(min(e.event_id) - 1, $1)
+from live_events as e
+where
+	e.event_id <= $1
+	and e.created_at >= (
+		select l.created_at - make_interval(secs => $2)
+		from live_events as l
+		where l.event_id = $1
+	);
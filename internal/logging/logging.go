@@ -0,0 +1,123 @@
+// Package logging writes a structured access log line for every request and
+// gives the code serving it a logger that carries the request's ID, so the
+// lines it logs can be told apart from those of other requests.
+package logging
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader carries the ID of a request, both ways.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+type contextKey struct{}
+
+// requestLogger is the logger of a request, which gains fields as the
+// request is served.
+type requestLogger struct {
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+func (l *requestLogger) get() *zap.Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.logger
+}
+
+// Middleware gives each request an ID, the one a client or proxy sent in
+// X-Request-ID when it is usable, and sends it back in the response. Code
+// serving the request logs through FromContext, and once it is served the
+// middleware logs the access line. Register it first, so every other
+// middleware runs inside it.
+func Middleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		l := &requestLogger{logger: logger.With(zap.String("request_id", requestID))}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, l))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", max(c.Writer.Size(), 0)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		if status >= http.StatusInternalServerError {
+			l.get().Error("Request failed", fields...)
+		} else {
+			l.get().Info("Request served", fields...)
+		}
+	}
+}
+
+// Recovery turns panics into 500 responses, logging them with the request's
+// logger. Register it after Middleware, so the access line has the 500.
+func Recovery(logger *zap.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		FromContext(c.Request.Context(), logger).Error("Panic serving request", zap.Any("panic", err), zap.Stack("stack"))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// FromContext returns the logger of the request ctx belongs to, or fallback
+// for contexts of no request, such as those of background workers.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*requestLogger); ok {
+		return l.get()
+	}
+	return fallback
+}
+
+// With adds fields to the logger of the request ctx belongs to, for the
+// lines logged after, including the access line.
+func With(ctx context.Context, fields ...zap.Field) {
+	if l, ok := ctx.Value(contextKey{}).(*requestLogger); ok {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.logger = l.logger.With(fields...)
+	}
+}
+
+// validRequestID accepts IDs of letters, digits and "-_.:" up to 128
+// characters, which cannot forge log fields or response headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newLogger returns a logger that writes JSON lines to the buffer.
+func newLogger() (*zap.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.DebugLevel)
+	return zap.New(core), &buf
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		entry := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, buf := newLogger()
+
+	r := gin.New()
+	r.Use(Middleware(logger))
+	r.GET("/v1/course/:course_id/", func(c *gin.Context) {
+		With(c.Request.Context(), zap.String("user_id", "ada"))
+		FromContext(c.Request.Context(), zap.NewNop()).Info("Loading course")
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/course/c-1/", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "req-42" {
+		t.Errorf("%s = %q, want the request's", RequestIDHeader, got)
+	}
+
+	entries := lines(t, buf)
+	if len(entries) != 2 {
+		t.Fatalf("%d log lines, want the handler's and the access line", len(entries))
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "req-42" || entry["user_id"] != "ada" {
+			t.Errorf("line %v lacks the request ID or user", entry)
+		}
+	}
+	if access := entries[1]; access["route"] != "/v1/course/:course_id/" || access["status"] != float64(http.StatusNoContent) {
+		t.Errorf("access line = %v", access)
+	}
+}
+
+func TestMiddlewareReplacesInvalidRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, _ := newLogger()
+
+	r := gin.New()
+	r.Use(Middleware(logger))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "forged\"id")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got == "" || got == "forged\"id" {
+		t.Errorf("%s = %q, want a fresh ID", RequestIDHeader, got)
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, buf := newLogger()
+
+	r := gin.New()
+	r.Use(Middleware(logger), Recovery(logger))
+	r.GET("/", func(c *gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	entries := lines(t, buf)
+	if len(entries) != 2 || entries[0]["panic"] != "boom" || entries[1]["level"] != "error" {
+		t.Errorf("log lines = %v, want the panic and a failed access line", entries)
+	}
+}
+
+func TestFromContextFallback(t *testing.T) {
+	fallback := zap.NewNop()
+	if got := FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context(), fallback); got != fallback {
+		t.Error("FromContext() outside a request did not return the fallback")
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"0b6e9f2c-6d8a-4e55-9c62-2f3b3f1c9a10", true},
+		{"trace:abc_1.2", true},
+		{"", false},
+		{"has space", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		if got := validRequestID(tt.id); got != tt.want {
+			t.Errorf("validRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package lti implements the tool side of an LTI 1.3 resource link launch:
+// the OIDC third-party login a platform such as Canvas or Moodle starts it
+// with, and the validation of the id_token the platform then posts back.
+//
+// Simulator plays the platform, for tests and for trying launches locally.
+package lti
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Version is the LTI version of the launches tools accept.
+const Version = "1.3.0"
+
+// MessageTypeResourceLink is the message type of a resource link launch.
+const MessageTypeResourceLink = "LtiResourceLinkRequest"
+
+// Context membership roles, as HasRole matches them.
+const (
+	RoleLearner           = "Learner"
+	RoleInstructor        = "Instructor"
+	RoleTeachingAssistant = "TeachingAssistant"
+	RoleContentDeveloper  = "ContentDeveloper"
+	RoleMentor            = "Mentor"
+	RoleAdministrator     = "Administrator"
+)
+
+const (
+	claimPrefix    = "https://purl.imsglobal.org/spec/lti/claim/"
+	membershipRole = "http://purl.imsglobal.org/vocab/lis/v2/membership"
+)
+
+// Claim names of the LTI claims of an id_token.
+const (
+	ClaimMessageType   = claimPrefix + "message_type"
+	ClaimVersion       = claimPrefix + "version"
+	ClaimDeploymentID  = claimPrefix + "deployment_id"
+	ClaimTargetLinkURI = claimPrefix + "target_link_uri"
+	ClaimResourceLink  = claimPrefix + "resource_link"
+	ClaimRoles         = claimPrefix + "roles"
+	ClaimContext       = claimPrefix + "context"
+)
+
+// ErrInvalidLaunch is returned for login requests and id_tokens that are
+// not valid launches.
+var ErrInvalidLaunch = errors.New("invalid LTI launch")
+
+// Platform is a platform registration: how the tool knows the platform and
+// how the platform knows the tool.
+type Platform struct {
+	Issuer string
+	// ClientID is the client ID the platform issued the tool.
+	ClientID      string
+	DeploymentIDs []string
+	// AuthLoginURL is the platform's OIDC authorization endpoint.
+	AuthLoginURL string
+	// JWKSURL serves the keys the platform signs id_tokens with.
+	JWKSURL string
+}
+
+// LoginRequest is the third-party initiated login a platform starts a launch
+// with.
+type LoginRequest struct {
+	Issuer        string
+	LoginHint     string
+	TargetLinkURI string
+	// MessageHint is opaque to the tool and passed back to the platform.
+	MessageHint string
+	// ClientID and DeploymentID are optional, but platforms with more than
+	// one registration of a tool send ClientID.
+	ClientID     string
+	DeploymentID string
+}
+
+// ParseLoginRequest reads a login request from the query or form values a
+// platform sends it as.
+func ParseLoginRequest(values url.Values) (LoginRequest, error) {
+	login := LoginRequest{
+		Issuer:        values.Get("iss"),
+		LoginHint:     values.Get("login_hint"),
+		TargetLinkURI: values.Get("target_link_uri"),
+		MessageHint:   values.Get("lti_message_hint"),
+		ClientID:      values.Get("client_id"),
+		DeploymentID:  values.Get("lti_deployment_id"),
+	}
+
+	for name, value := range map[string]string{"iss": login.Issuer, "login_hint": login.LoginHint, "target_link_uri": login.TargetLinkURI} {
+		if value == "" {
+			return LoginRequest{}, fmt.Errorf("%w: the login request has no %s", ErrInvalidLaunch, name)
+		}
+	}
+	return login, nil
+}
+
+// AuthURL returns the platform authorization URL that a login redirects the
+// browser to. The platform posts the id_token of the launch and state back
+// to redirectURI, and the id_token carries nonce.
+func AuthURL(platform Platform, login LoginRequest, redirectURI, state, nonce string) (string, error) {
+	u, err := url.Parse(platform.AuthLoginURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization URL: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("scope", "openid")
+	query.Set("response_type", "id_token")
+	query.Set("response_mode", "form_post")
+	query.Set("prompt", "none")
+	query.Set("client_id", platform.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("login_hint", login.LoginHint)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	if login.MessageHint != "" {
+		query.Set("lti_message_hint", login.MessageHint)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Context is the course, or other group of users, a launch comes from.
+type Context struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label,omitempty"`
+	Title string   `json:"title,omitempty"`
+	Type  []string `json:"type,omitempty"`
+}
+
+// ResourceLink is the link in the platform that was launched.
+type ResourceLink struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// Launch is a validated resource link launch.
+type Launch struct {
+	// Subject identifies the user within the platform.
+	Subject       string
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Email         string
+	DeploymentID  string
+	TargetLinkURI string
+	ResourceLink  ResourceLink
+	Roles         []string
+	// Context is nil for launches from outside a context.
+	Context *Context
+}
+
+// HasRole reports whether the user has the context membership role, such as
+// RoleInstructor, given by its full URI, by a sub-role URI such as
+// .../membership/Instructor#TeachingAssistant for RoleTeachingAssistant, or
+// by the short name that LTI 1.3 deprecates but still allows.
+func (l Launch) HasRole(role string) bool {
+	return slices.ContainsFunc(l.Roles, func(r string) bool {
+		return r == role || (strings.HasPrefix(r, membershipRole) && strings.HasSuffix(r, "#"+role))
+	})
+}
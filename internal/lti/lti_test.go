@@ -0,0 +1,198 @@
+package lti
+
+import (
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newSimulatedPlatform(t *testing.T) (*Simulator, Platform) {
+	t.Helper()
+
+	simulator, err := NewSimulator("https://lms.example.com", "client-1", "deployment-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(simulator.Handler())
+	t.Cleanup(server.Close)
+
+	return simulator, simulator.Platform(server.URL)
+}
+
+func newVerifier(t *testing.T) *Verifier {
+	t.Helper()
+
+	verifier := NewVerifier(http.DefaultClient)
+	t.Cleanup(verifier.Close)
+	return verifier
+}
+
+func TestParseLoginRequestAndAuthURL(t *testing.T) {
+	values := url.Values{
+		"iss":              {"https://lms.example.com"},
+		"login_hint":       {"42"},
+		"target_link_uri":  {"https://tool.example.com/lti/launch"},
+		"lti_message_hint": {"hint"},
+		"client_id":        {"client-1"},
+	}
+	login, err := ParseLoginRequest(values)
+	if err != nil {
+		t.Fatalf("ParseLoginRequest() error = %v", err)
+	}
+
+	platform := Platform{ClientID: "client-1", AuthLoginURL: "https://lms.example.com/auth?tenant=1"}
+	authURL, err := AuthURL(platform, login, "https://tool.example.com/lti/launch", "state-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("AuthURL() error = %v", err)
+	}
+
+	u, _ := url.Parse(authURL)
+	want := map[string]string{
+		"tenant": "1", "scope": "openid", "response_type": "id_token", "response_mode": "form_post",
+		"prompt": "none", "client_id": "client-1", "redirect_uri": "https://tool.example.com/lti/launch",
+		"login_hint": "42", "lti_message_hint": "hint", "state": "state-1", "nonce": "nonce-1",
+	}
+	for name, value := range want {
+		if got := u.Query().Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+
+	for _, name := range []string{"iss", "login_hint", "target_link_uri"} {
+		incomplete := url.Values{}
+		for key, value := range values {
+			if key != name {
+				incomplete[key] = value
+			}
+		}
+		if _, err := ParseLoginRequest(incomplete); !errors.Is(err, ErrInvalidLaunch) {
+			t.Errorf("ParseLoginRequest() without %s error = %v, want ErrInvalidLaunch", name, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	simulator, platform := newSimulatedPlatform(t)
+	verifier := newVerifier(t)
+	now := time.Now()
+
+	idToken, err := simulator.IDToken("nonce-1", now, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	launch, err := verifier.Verify(platform, idToken, "nonce-1", now)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if launch.Subject != "simulated-learner" || launch.Email != "ada@example.com" || launch.Context == nil || launch.Context.Title != "Calculus" || launch.ResourceLink.ID != "simulated-link" {
+		t.Errorf("Verify() = %+v", launch)
+	}
+	if !launch.HasRole(RoleLearner) || launch.HasRole(RoleInstructor) {
+		t.Errorf("roles %v", launch.Roles)
+	}
+
+	other, _ := NewSimulator(simulator.Issuer, simulator.ClientID, simulator.DeploymentID)
+	forged, err := other.IDToken("nonce-1", now, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]any
+		nonce     string
+		now       time.Time
+	}{
+		{name: "another nonce", nonce: "nonce-2", now: now},
+		{name: "expired", nonce: "nonce-1", now: now.Add(10 * time.Minute)},
+		{name: "issued in the future", nonce: "nonce-1", now: now.Add(-5 * time.Minute)},
+		{name: "another issuer", overrides: map[string]any{"iss": "https://other.example.com"}, nonce: "nonce-1", now: now},
+		{name: "another audience", overrides: map[string]any{"aud": "client-2", "azp": nil}, nonce: "nonce-1", now: now},
+		{name: "several audiences", overrides: map[string]any{"aud": []string{"client-1", "client-2"}, "azp": nil}, nonce: "nonce-1", now: now},
+		{name: "unknown deployment", overrides: map[string]any{ClaimDeploymentID: "deployment-2"}, nonce: "nonce-1", now: now},
+		{name: "LTI 1.1", overrides: map[string]any{ClaimVersion: "1.1"}, nonce: "nonce-1", now: now},
+		{name: "deep linking", overrides: map[string]any{ClaimMessageType: "LtiDeepLinkingRequest"}, nonce: "nonce-1", now: now},
+		{name: "anonymous", overrides: map[string]any{"sub": nil}, nonce: "nonce-1", now: now},
+		{name: "no resource link", overrides: map[string]any{ClaimResourceLink: nil}, nonce: "nonce-1", now: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idToken, err := simulator.IDToken("nonce-1", now, tt.overrides)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := verifier.Verify(platform, idToken, tt.nonce, tt.now); !errors.Is(err, ErrInvalidLaunch) {
+				t.Errorf("Verify() error = %v, want ErrInvalidLaunch", err)
+			}
+		})
+	}
+
+	hs256, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": platform.Issuer}).SignedString([]byte("secret"))
+	for name, idToken := range map[string]string{"another key": forged, "HS256": hs256, "garbage": "not.a.token"} {
+		if _, err := verifier.Verify(platform, idToken, "nonce-1", now); !errors.Is(err, ErrInvalidLaunch) {
+			t.Errorf("Verify() of %s error = %v, want ErrInvalidLaunch", name, err)
+		}
+	}
+
+	unreachable := platform
+	unreachable.JWKSURL = "http://127.0.0.1:1/jwks"
+	if _, err := verifier.Verify(unreachable, idToken, "nonce-1", now); err == nil || errors.Is(err, ErrInvalidLaunch) {
+		t.Errorf("Verify() with unreachable keys error = %v", err)
+	}
+}
+
+func TestSimulatorLaunch(t *testing.T) {
+	simulator, platform := newSimulatedPlatform(t)
+	simulator.ToolLoginURL = "https://tool.example.com/lti/login"
+	simulator.TargetLinkURI = "https://tool.example.com/lti/launch"
+	simulator.User.Roles = []string{"http://purl.imsglobal.org/vocab/lis/v2/membership/Instructor#TeachingAssistant"}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	response, err := client.Get(strings.TrimSuffix(platform.AuthLoginURL, "/auth") + "/launch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	location, _ := url.Parse(response.Header.Get("Location"))
+	login, err := ParseLoginRequest(location.Query())
+	if err != nil {
+		t.Fatalf("the launch redirected to %s: %v", location, err)
+	}
+
+	authURL, _ := AuthURL(platform, login, simulator.TargetLinkURI, "state-1", "nonce-1")
+	response, err = client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("authorization status = %d: %s", response.StatusCode, body)
+	}
+
+	fields := map[string]string{}
+	for _, match := range regexp.MustCompile(`name="(\w+)" value="([^"]*)"`).FindAllStringSubmatch(string(body), -1) {
+		fields[match[1]] = html.UnescapeString(match[2])
+	}
+	if fields["state"] != "state-1" {
+		t.Errorf("state = %q", fields["state"])
+	}
+
+	launch, err := newVerifier(t).Verify(platform, fields["id_token"], "nonce-1", time.Now())
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !launch.HasRole(RoleTeachingAssistant) || launch.HasRole(RoleInstructor) || launch.TargetLinkURI != simulator.TargetLinkURI {
+		t.Errorf("launch = %+v", launch)
+	}
+}
@@ -0,0 +1,206 @@
+package lti
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SimulatedUser is the user a Simulator launches the tool as.
+type SimulatedUser struct {
+	Subject    string
+	GivenName  string
+	FamilyName string
+	Email      string
+	// Roles are full role URIs or short names such as RoleLearner.
+	Roles []string
+}
+
+// Simulator is an LTI 1.3 platform that launches a tool as one user from
+// one context. Its handler serves the platform's key set at /jwks and its
+// authorization endpoint at /auth, and starts a launch at /launch.
+type Simulator struct {
+	Issuer       string
+	ClientID     string
+	DeploymentID string
+	// ToolLoginURL is the tool's login initiation URL.
+	ToolLoginURL  string
+	TargetLinkURI string
+	User          SimulatedUser
+	Context       Context
+	ResourceLink  ResourceLink
+
+	key   *rsa.PrivateKey
+	keyID string
+}
+
+// NewSimulator returns a simulator with a fresh signing key.
+func NewSimulator(issuer, clientID, deploymentID string) (*Simulator, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		DeploymentID: deploymentID,
+		User: SimulatedUser{
+			Subject:    "simulated-learner",
+			GivenName:  "Ada",
+			FamilyName: "Lovelace",
+			Email:      "ada@example.com",
+			Roles:      []string{membershipRole + "#" + RoleLearner},
+		},
+		Context:      Context{ID: "simulated-course", Label: "MATH 101", Title: "Calculus"},
+		ResourceLink: ResourceLink{ID: "simulated-link", Title: "Schedule"},
+		key:          key,
+		keyID:        fmt.Sprintf("sim-%d", time.Now().UnixNano()),
+	}, nil
+}
+
+// Platform returns the registration of the simulator served at baseURL.
+func (s *Simulator) Platform(baseURL string) Platform {
+	return Platform{
+		Issuer:        s.Issuer,
+		ClientID:      s.ClientID,
+		DeploymentIDs: []string{s.DeploymentID},
+		AuthLoginURL:  baseURL + "/auth",
+		JWKSURL:       baseURL + "/jwks",
+	}
+}
+
+// IDToken signs the id_token of a launch for a login that used nonce.
+// Claims in overrides replace the defaults, and nil values remove them.
+func (s *Simulator) IDToken(nonce string, now time.Time, overrides map[string]any) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":              s.Issuer,
+		"sub":              s.User.Subject,
+		"aud":              s.ClientID,
+		"azp":              s.ClientID,
+		"iat":              now.Unix(),
+		"exp":              now.Add(5 * time.Minute).Unix(),
+		"nonce":            nonce,
+		"given_name":       s.User.GivenName,
+		"family_name":      s.User.FamilyName,
+		"name":             s.User.GivenName + " " + s.User.FamilyName,
+		"email":            s.User.Email,
+		ClaimMessageType:   MessageTypeResourceLink,
+		ClaimVersion:       Version,
+		ClaimDeploymentID:  s.DeploymentID,
+		ClaimTargetLinkURI: s.TargetLinkURI,
+		ClaimResourceLink:  s.ResourceLink,
+		ClaimRoles:         s.User.Roles,
+		ClaimContext:       s.Context,
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+	return token.SignedString(s.key)
+}
+
+// Handler serves the simulated platform.
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks", s.serveJWKS)
+	mux.HandleFunc("/auth", s.serveAuth)
+	mux.HandleFunc("/launch", s.serveLaunch)
+	return mux
+}
+
+func (s *Simulator) serveJWKS(w http.ResponseWriter, r *http.Request) {
+	encode := base64.RawURLEncoding.EncodeToString
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": s.keyID,
+			"n":   encode(s.key.N.Bytes()),
+			"e":   encode(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+// serveLaunch starts a launch the way a user clicking the tool's link does,
+// by sending the browser to the tool's login initiation URL.
+func (s *Simulator) serveLaunch(w http.ResponseWriter, r *http.Request) {
+	login, err := url.Parse(s.ToolLoginURL)
+	if err != nil || s.ToolLoginURL == "" {
+		http.Error(w, "the simulator has no tool login URL", http.StatusInternalServerError)
+		return
+	}
+
+	query := login.Query()
+	query.Set("iss", s.Issuer)
+	query.Set("login_hint", s.User.Subject)
+	query.Set("target_link_uri", s.TargetLinkURI)
+	query.Set("lti_message_hint", s.ResourceLink.ID)
+	query.Set("client_id", s.ClientID)
+	query.Set("lti_deployment_id", s.DeploymentID)
+	login.RawQuery = query.Encode()
+
+	http.Redirect(w, r, login.String(), http.StatusFound)
+}
+
+var formPost = template.Must(template.New("form_post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="id_token" value="{{.IDToken}}">
+<input type="hidden" name="state" value="{{.State}}">
+<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// serveAuth is the authorization endpoint, which answers the tool's
+// authentication request by posting the launch back to it.
+func (s *Simulator) serveAuth(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	switch {
+	case query.Get("client_id") != s.ClientID:
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	case query.Get("response_type") != "id_token" || query.Get("response_mode") != "form_post" || query.Get("scope") != "openid":
+		http.Error(w, "unsupported authentication request", http.StatusBadRequest)
+		return
+	case query.Get("login_hint") != s.User.Subject:
+		http.Error(w, "unknown login_hint", http.StatusBadRequest)
+		return
+	case query.Get("redirect_uri") == "" || query.Get("nonce") == "":
+		http.Error(w, "redirect_uri and nonce are required", http.StatusBadRequest)
+		return
+	}
+
+	idToken, err := s.IDToken(query.Get("nonce"), time.Now(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	formPost.Execute(w, map[string]string{
+		"Action":  query.Get("redirect_uri"),
+		"IDToken": idToken,
+		"State":   query.Get("state"),
+	})
+}
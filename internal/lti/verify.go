@@ -0,0 +1,158 @@
+package lti
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// leeway is the clock skew tolerated between the platform and the tool.
+const leeway = time.Minute
+
+// Verifier validates launch id_tokens, caching the key set of each platform.
+// Key sets refresh hourly, and when a token is signed with a key they do not
+// hold yet, as happens after the platform rotates its keys.
+type Verifier struct {
+	client *http.Client
+
+	mu   sync.Mutex
+	jwks map[string]*keyfunc.JWKS
+}
+
+// NewVerifier returns a verifier that fetches key sets with client.
+func NewVerifier(client *http.Client) *Verifier {
+	return &Verifier{client: client, jwks: map[string]*keyfunc.JWKS{}}
+}
+
+// Close stops refreshing the cached key sets.
+func (v *Verifier) Close() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for url, jwks := range v.jwks {
+		jwks.EndBackground()
+		delete(v.jwks, url)
+	}
+}
+
+func (v *Verifier) keySet(url string) (*keyfunc.JWKS, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if jwks, ok := v.jwks[url]; ok {
+		return jwks, nil
+	}
+
+	jwks, err := keyfunc.Get(url, keyfunc.Options{
+		Client:            v.client,
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  time.Minute,
+		RefreshTimeout:    10 * time.Second,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the platform keys: %w", err)
+	}
+	v.jwks[url] = jwks
+	return jwks, nil
+}
+
+// claims are the id_token claims of a resource link launch.
+type claims struct {
+	Issuer          string           `json:"iss"`
+	Subject         string           `json:"sub"`
+	Audience        jwt.ClaimStrings `json:"aud"`
+	AuthorizedParty string           `json:"azp"`
+	ExpiresAt       *jwt.NumericDate `json:"exp"`
+	IssuedAt        *jwt.NumericDate `json:"iat"`
+	Nonce           string           `json:"nonce"`
+	Name            string           `json:"name"`
+	GivenName       string           `json:"given_name"`
+	FamilyName      string           `json:"family_name"`
+	Email           string           `json:"email"`
+
+	MessageType   string        `json:"https://purl.imsglobal.org/spec/lti/claim/message_type"`
+	Version       string        `json:"https://purl.imsglobal.org/spec/lti/claim/version"`
+	DeploymentID  string        `json:"https://purl.imsglobal.org/spec/lti/claim/deployment_id"`
+	TargetLinkURI string        `json:"https://purl.imsglobal.org/spec/lti/claim/target_link_uri"`
+	ResourceLink  *ResourceLink `json:"https://purl.imsglobal.org/spec/lti/claim/resource_link"`
+	Roles         []string      `json:"https://purl.imsglobal.org/spec/lti/claim/roles"`
+	Context       *Context      `json:"https://purl.imsglobal.org/spec/lti/claim/context"`
+}
+
+// Valid does nothing: Verify checks the claims against its own clock.
+func (c *claims) Valid() error {
+	return nil
+}
+
+// Verify validates the id_token the platform posted for a launch whose
+// login used nonce. Tokens must be signed with RS256 by a key of the
+// platform, be issued by it for the tool, be current at now, and carry an
+// LTI 1.3 resource link launch from one of its deployments. Tokens that are
+// not valid launches give errors wrapping ErrInvalidLaunch.
+func (v *Verifier) Verify(platform Platform, idToken, nonce string, now time.Time) (Launch, error) {
+	jwks, err := v.keySet(platform.JWKSURL)
+	if err != nil {
+		return Launch{}, err
+	}
+
+	c := &claims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if _, err := parser.ParseWithClaims(idToken, c, jwks.Keyfunc); err != nil {
+		return Launch{}, fmt.Errorf("%w: %v", ErrInvalidLaunch, err)
+	}
+
+	if err := c.validate(platform, nonce, now); err != nil {
+		return Launch{}, fmt.Errorf("%w: %v", ErrInvalidLaunch, err)
+	}
+
+	return Launch{
+		Subject:       c.Subject,
+		Name:          c.Name,
+		GivenName:     c.GivenName,
+		FamilyName:    c.FamilyName,
+		Email:         c.Email,
+		DeploymentID:  c.DeploymentID,
+		TargetLinkURI: c.TargetLinkURI,
+		ResourceLink:  *c.ResourceLink,
+		Roles:         c.Roles,
+		Context:       c.Context,
+	}, nil
+}
+
+func (c *claims) validate(platform Platform, nonce string, now time.Time) error {
+	switch {
+	case c.Issuer != platform.Issuer:
+		return fmt.Errorf("the token was issued by %q", c.Issuer)
+	case !slices.Contains(c.Audience, platform.ClientID):
+		return fmt.Errorf("the token is not for client %s", platform.ClientID)
+	case len(c.Audience) > 1 && c.AuthorizedParty != platform.ClientID:
+		return fmt.Errorf("the token is not authorized for client %s", platform.ClientID)
+	case c.AuthorizedParty != "" && c.AuthorizedParty != platform.ClientID:
+		return fmt.Errorf("the token is not authorized for client %s", platform.ClientID)
+	case c.ExpiresAt == nil || !now.Before(c.ExpiresAt.Add(leeway)):
+		return fmt.Errorf("the token has expired")
+	case c.IssuedAt == nil || now.Add(leeway).Before(c.IssuedAt.Time):
+		return fmt.Errorf("the token is not valid yet")
+	case nonce == "" || c.Nonce != nonce:
+		return fmt.Errorf("the token nonce does not match the login")
+	case c.Version != Version:
+		return fmt.Errorf("unsupported LTI version %q", c.Version)
+	case c.MessageType != MessageTypeResourceLink:
+		return fmt.Errorf("unsupported message type %q", c.MessageType)
+	case !slices.Contains(platform.DeploymentIDs, c.DeploymentID):
+		return fmt.Errorf("unknown deployment %q", c.DeploymentID)
+	case c.Subject == "":
+		return fmt.Errorf("anonymous launches are not supported")
+	case c.ResourceLink == nil || c.ResourceLink.ID == "":
+		return fmt.Errorf("the launch has no resource link")
+	case c.Context != nil && c.Context.ID == "":
+		return fmt.Errorf("the launch context has no id")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequestDuration = NewHistogram("http_request_duration_seconds",
+		"Time taken to serve HTTP requests, by route and status.", DefaultBuckets, "method", "route", "status")
+
+	httpRequestsInFlight atomic.Int64
+)
+
+func init() {
+	NewGaugeFunc("http_requests_in_flight", "HTTP requests being served.", nil, func(emit Emit) {
+		emit(float64(httpRequestsInFlight.Load()))
+	})
+}
+
+// Middleware records how long each request takes by its route, rather than
+// its path, so routes with IDs in them are one series. Requests that match
+// no route are recorded as "unmatched".
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		httpRequestsInFlight.Add(1)
+		defer httpRequestsInFlight.Add(-1)
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestDuration.Observe(time.Since(start).Seconds(), c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+	}
+}
+
+// Handler serves the metrics of the Default registry to scrapes that send
+// token as a bearer token. Without a token, every scrape is refused, so
+// metrics are not served to anyone by mistake.
+func Handler(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+token)) != 1 {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Status(http.StatusOK)
+		_ = Default.Write(c.Writer)
+	}
+}
@@ -0,0 +1,327 @@
+// Package metrics exposes counters, histograms and gauges in the Prometheus
+// text format. Metrics register with the Default registry when they are
+// created, usually as package variables of the code they measure, and
+// Handler serves them for scraping.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are histogram buckets in seconds, from 5ms to 10s.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metrics in the order they were registered.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+	names   map[string]bool
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{names: map[string]bool{}}
+}
+
+// Default is the registry metrics are created in.
+var Default = NewRegistry()
+
+type metric interface {
+	name() string
+	write(w io.Writer) error
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[m.name()] {
+		panic("metrics: " + m.name() + " is already registered")
+	}
+	r.names[m.name()] = true
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes every metric of the registry in the text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := slices.Clone(r.metrics)
+	r.mu.Unlock()
+
+	for _, m := range metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// desc is what every metric has: a name, help text and label names.
+type desc struct {
+	metricName string
+	help       string
+	kind       string
+	labels     []string
+}
+
+func (d desc) name() string {
+	return d.metricName
+}
+
+func (d desc) writeHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.metricName, escapeHelp(d.help), d.metricName, d.kind)
+	return err
+}
+
+// key joins label values into a map key.
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", d.metricName, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// values splits a map key back into label values.
+func (d desc) values(key string) []string {
+	if len(d.labels) == 0 {
+		return nil
+	}
+	return strings.Split(key, "\xff")
+}
+
+// Counter is a count that only goes up, per combination of label values.
+type Counter struct {
+	desc
+
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+// NewCounter creates a counter in the Default registry.
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{desc: desc{name, help, "counter", labels}, counts: map[string]float64{}}
+	Default.register(c)
+	return c
+}
+
+// Inc adds one to the count of the label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v, which must not be negative, to the count of the label values.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[key] += v
+}
+
+func (c *Counter) write(w io.Writer) error {
+	c.mu.Lock()
+	counts := maps.Clone(c.counts)
+	c.mu.Unlock()
+
+	if err := c.writeHeader(w); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(counts) {
+		if err := writeSample(w, c.metricName, c.labels, c.values(key), "", "", counts[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Histogram counts observations into buckets, per combination of label
+// values.
+type Histogram struct {
+	desc
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram creates a histogram in the Default registry. buckets are the
+// upper bounds of the buckets, in increasing order.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		desc:    desc{name, help, "histogram", labels},
+		buckets: buckets,
+		series:  map[string]*histogramSeries{},
+	}
+	Default.register(h)
+	return h
+}
+
+// Observe records v for the label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	h.mu.Lock()
+	series := make(map[string]histogramSeries, len(h.series))
+	for key, s := range h.series {
+		series[key] = histogramSeries{slices.Clone(s.counts), s.count, s.sum}
+	}
+	h.mu.Unlock()
+
+	if err := h.writeHeader(w); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(series) {
+		var (
+			s      = series[key]
+			values = h.values(key)
+		)
+		for i, bound := range h.buckets {
+			if err := writeSample(w, h.metricName+"_bucket", h.labels, values, "le", formatFloat(bound), float64(s.counts[i])); err != nil {
+				return err
+			}
+		}
+		if err := writeSample(w, h.metricName+"_bucket", h.labels, values, "le", "+Inf", float64(s.count)); err != nil {
+			return err
+		}
+		if err := writeSample(w, h.metricName+"_sum", h.labels, values, "", "", s.sum); err != nil {
+			return err
+		}
+		if err := writeSample(w, h.metricName+"_count", h.labels, values, "", "", float64(s.count)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Emit reports one value of a metric collected on scrape.
+type Emit func(v float64, labelValues ...string)
+
+// collected is a metric whose values are read when it is scraped.
+type collected struct {
+	desc
+	collect func(Emit)
+}
+
+// NewGaugeFunc creates a gauge in the Default registry whose values
+// collect emits on every scrape.
+func NewGaugeFunc(name, help string, labels []string, collect func(Emit)) {
+	Default.register(&collected{desc{name, help, "gauge", labels}, collect})
+}
+
+// NewCounterFunc creates a counter in the Default registry whose values
+// collect emits on every scrape, for counts kept elsewhere.
+func NewCounterFunc(name, help string, labels []string, collect func(Emit)) {
+	Default.register(&collected{desc{name, help, "counter", labels}, collect})
+}
+
+func (c *collected) write(w io.Writer) error {
+	type sample struct {
+		values []string
+		v      float64
+	}
+	var samples []sample
+	c.collect(func(v float64, labelValues ...string) {
+		c.key(labelValues)
+		samples = append(samples, sample{labelValues, v})
+	})
+
+	if err := c.writeHeader(w); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		if err := writeSample(w, c.metricName, c.labels, s.values, "", "", s.v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSample writes a sample line, with an extra label such as a
+// histogram's "le" when extraName is set.
+func writeSample(w io.Writer, name string, labels, values []string, extraName, extraValue string, v float64) error {
+	var b strings.Builder
+	b.WriteString(name)
+
+	if len(labels) > 0 || extraName != "" {
+		b.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(label + `="` + escapeLabel(values[i]) + `"`)
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		b.WriteByte('}')
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(formatFloat(v))
+	b.WriteByte('\n')
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
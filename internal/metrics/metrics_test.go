@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// withRegistry swaps Default for an empty registry for the test.
+func withRegistry(t *testing.T) *Registry {
+	t.Helper()
+
+	saved := Default
+	Default = NewRegistry()
+	t.Cleanup(func() { Default = saved })
+	return Default
+}
+
+func write(t *testing.T, r *Registry) string {
+	t.Helper()
+
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return b.String()
+}
+
+func TestCounter(t *testing.T) {
+	r := withRegistry(t)
+
+	c := NewCounter("classes_created_total", "Classes created.", "source")
+	c.Inc("booking")
+	c.Add(2, "api")
+	c.Inc("api")
+
+	want := `# HELP classes_created_total Classes created.
+# TYPE classes_created_total counter
+classes_created_total{source="api"} 3
+classes_created_total{source="booking"} 1
+`
+	if got := write(t, r); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCounterWithEmptyLabelValue(t *testing.T) {
+	r := withRegistry(t)
+
+	c := NewCounter("events_total", "Events.", "kind")
+	c.Inc("")
+
+	if got := write(t, r); !strings.Contains(got, `events_total{kind=""} 1`) {
+		t.Errorf("Write() =\n%s\nwant a sample with an empty kind", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	r := withRegistry(t)
+
+	h := NewHistogram("verify_seconds", "Verification time.", []float64{0.1, 1}, "result")
+	h.Observe(0.05, "ok")
+	h.Observe(0.5, "ok")
+	h.Observe(2, "ok")
+
+	want := `# HELP verify_seconds Verification time.
+# TYPE verify_seconds histogram
+verify_seconds_bucket{result="ok",le="0.1"} 1
+verify_seconds_bucket{result="ok",le="1"} 2
+verify_seconds_bucket{result="ok",le="+Inf"} 3
+verify_seconds_sum{result="ok"} 2.55
+verify_seconds_count{result="ok"} 3
+`
+	if got := write(t, r); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	r := withRegistry(t)
+
+	NewGaugeFunc("pool_connections", "Open connections.", []string{"pool"}, func(emit Emit) {
+		emit(3, "pgx")
+		emit(1, `s"q\l`)
+	})
+
+	want := `# HELP pool_connections Open connections.
+# TYPE pool_connections gauge
+pool_connections{pool="pgx"} 3
+pool_connections{pool="s\"q\\l"} 1
+`
+	if got := write(t, r); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWrongLabelCount(t *testing.T) {
+	withRegistry(t)
+
+	c := NewCounter("events_total", "Events.", "kind")
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc() with a missing label value did not panic")
+		}
+	}()
+	c.Inc()
+}
+
+func TestRegisterTwice(t *testing.T) {
+	withRegistry(t)
+
+	NewCounter("events_total", "Events.")
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	NewCounter("events_total", "Events.")
+}
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	withRegistry(t)
+	NewCounter("events_total", "Events.").Inc()
+
+	r := gin.New()
+	r.GET("/metrics", Handler("secret"))
+
+	tests := []struct {
+		authorization string
+		want          int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("Authorization %q: status = %d, want %d", tt.authorization, w.Code, tt.want)
+		}
+		if w.Code == http.StatusOK && !strings.Contains(w.Body.String(), "events_total 1\n") {
+			t.Errorf("body = %q, want the events_total sample", w.Body.String())
+		}
+	}
+
+	// Without a token, metrics are not served at all.
+	r = gin.New()
+	r.GET("/metrics", Handler(""))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status without a token = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/v1/course/:course_id/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/course/c-1/", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/nowhere/", nil))
+
+	for _, values := range [][]string{
+		{http.MethodGet, "/v1/course/:course_id/", "204"},
+		{http.MethodGet, "unmatched", "404"},
+	} {
+		httpRequestDuration.mu.Lock()
+		s, ok := httpRequestDuration.series[httpRequestDuration.key(values)]
+		httpRequestDuration.mu.Unlock()
+		if !ok || s.count != 1 {
+			t.Errorf("no request recorded for %v", values)
+		}
+	}
+}
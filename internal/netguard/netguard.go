@@ -0,0 +1,80 @@
+// Package netguard keeps requests to URLs that organizations configure, such
+// as webhook receivers, roster sources and LTI key sets, away from loopback,
+// private and link-local addresses. Without it, an admin could make the
+// server call services inside the deployment.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned for URLs and connections on addresses that
+// are not public.
+var ErrPrivateAddress = errors.New("the URL must be on a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which netip does not
+// count as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddress reports whether requests may be sent to addr.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// CheckURL rejects URLs whose host is localhost or a non-public IP address.
+// Host names are checked again by clients from NewClient when they connect,
+// after they are resolved.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddress(addr) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// NewClient returns a client with the given timeout. Unless allowPrivate is
+// set, for local development, it refuses to connect to non-public
+// addresses. The check runs on the resolved address of every connection,
+// redirects included, so a host name cannot be used to get around it.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	// Requests are not sent through a proxy, which would be the address
+	// checked instead of the server's.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
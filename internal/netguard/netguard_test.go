@@ -0,0 +1,87 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("IsPublicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://localhost:8080/hook",
+		"http://api.localhost/hook",
+		"http://127.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"https://10.0.0.5/hook",
+	} {
+		if err := CheckURL(rawURL); !errors.Is(err, ErrPrivateAddress) {
+			t.Errorf("CheckURL(%q) error = %v, want ErrPrivateAddress", rawURL, err)
+		}
+	}
+
+	for _, rawURL := range []string{"https://hooks.example.com/bookSmart", "https://93.184.216.34/hook"} {
+		if err := CheckURL(rawURL); err != nil {
+			t.Errorf("CheckURL(%q) error = %v", rawURL, err)
+		}
+	}
+}
+
+func TestClientRefusesPrivateAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	post := func(client *http.Client) error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, receiver.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	if err := post(NewClient(time.Second, false)); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("post to %s error = %v, want ErrPrivateAddress", receiver.URL, err)
+	}
+
+	if err := post(NewClient(time.Second, true)); err != nil {
+		t.Errorf("post to %s error = %v with private networks allowed", receiver.URL, err)
+	}
+}
@@ -0,0 +1,174 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SMTPChannel sends messages as plain text email through an SMTP server.
+type SMTPChannel struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send delivers the message to its recipient's email address.
+func (ch *SMTPChannel) Send(ctx context.Context, message Message) error {
+	var auth smtp.Auth
+	if ch.Username != "" {
+		auth = smtp.PlainAuth("", ch.Username, ch.Password, ch.Host)
+	}
+
+	if err := smtp.SendMail(ch.Host+":"+ch.Port, auth, ch.From, []string{message.To}, emailMessage(ch.From, message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// headerBreaks replaces line breaks, so values such as course names cannot
+// end a header and start another.
+var headerBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// emailMessage formats message as a plain text email. The subject is
+// encoded as an RFC 2047 word when it is not plain ASCII.
+func emailMessage(from string, message Message) []byte {
+	return []byte(strings.Join([]string{
+		"From: " + headerBreaks.Replace(from),
+		"To: " + headerBreaks.Replace(message.To),
+		"Subject: " + mime.QEncoding.Encode("UTF-8", headerBreaks.Replace(message.Subject)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		message.Body,
+	}, "\r\n"))
+}
+
+// SMSGateway sends a text message to a phone number. Implement it to plug in
+// an SMS provider.
+type SMSGateway interface {
+	SendSMS(ctx context.Context, to, body string) error
+}
+
+// SMSChannel sends the message body as a text message through a gateway.
+type SMSChannel struct {
+	Gateway SMSGateway
+}
+
+// Send delivers the message body to its recipient's phone number.
+func (ch *SMSChannel) Send(ctx context.Context, message Message) error {
+	return ch.Gateway.SendSMS(ctx, message.To, message.Body)
+}
+
+// HTTPSMSGateway posts text messages as JSON to a provider endpoint, using a
+// bearer token for authentication.
+type HTTPSMSGateway struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// SendSMS posts {"to": ..., "body": ...} to the gateway URL.
+func (g *HTTPSMSGateway) SendSMS(ctx context.Context, to, body string) error {
+	payload, err := json.Marshal(map[string]string{"to": to, "body": body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+
+	client := g.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call SMS gateway: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("SMS gateway returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogChannel writes messages to the application log instead of sending
+// them. Useful for local development.
+type LogChannel struct {
+	Logger *zap.Logger
+	Name   string
+}
+
+// Send logs the message.
+func (ch *LogChannel) Send(ctx context.Context, message Message) error {
+	ch.Logger.Info("Notification delivered to log",
+		zap.String("channel", ch.Name),
+		zap.String("notification_id", message.NotificationID),
+		zap.String("event", message.Event),
+		zap.String("to", message.To),
+		zap.String("subject", message.Subject),
+		zap.String("body", message.Body))
+	return nil
+}
+
+// FileChannel appends each message as a JSON line to a file, so tests and
+// local setups can inspect what would have been sent.
+type FileChannel struct {
+	Path string
+	Name string
+
+	mu sync.Mutex
+}
+
+// Send appends the message to the file.
+func (ch *FileChannel) Send(ctx context.Context, message Message) error {
+	line, err := json.Marshal(map[string]string{
+		"channel":         ch.Name,
+		"notification_id": message.NotificationID,
+		"event":           message.Event,
+		"to":              message.To,
+		"subject":         message.Subject,
+		"body":            message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	file, err := os.OpenFile(ch.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = io.WriteString(file, string(line)+"\n")
+	return err
+}
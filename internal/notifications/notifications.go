@@ -0,0 +1,132 @@
+// Package notifications delivers messages about scheduling changes to users.
+//
+// Scheduling code writes notifications into an outbox table inside the same
+// transaction as the change itself. A Worker later delivers them through the
+// channel each user opted into, retrying with backoff when a channel fails.
+package notifications
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"time"
+
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/tracing"
+)
+
+// Events that produce notifications.
+const (
+	EventClassCreated             = "class.created"
+	EventClassRescheduled         = "class.rescheduled"
+	EventClassCancelled           = "class.cancelled"
+	EventClassRestored            = "class.restored"
+	EventClassParticipantsUpdated = "class.participants_updated"
+	EventClassParticipantRemoved  = "class.participant_removed"
+	EventClassSubstituteAssigned  = "class.substitute_assigned"
+	EventClassReminder            = "class.reminder"
+	EventWaitlistOffered          = "waitlist.offered"
+	EventUserInvited              = "user.invited"
+)
+
+// Channels a notification can be delivered through.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+// Delivery statuses of a notification.
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+)
+
+// Message is a rendered notification ready to be sent to one recipient.
+type Message struct {
+	NotificationID string
+	Event          string
+	To             string
+	Subject        string
+	Body           string
+}
+
+// Channel sends rendered messages, e.g. by email or SMS.
+type Channel interface {
+	Send(ctx context.Context, message Message) error
+}
+
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("notifications", queryFiles)
+}
+
+//go:embed queries/enqueue_class_event.sql
+var enqueueClassEventSQL string
+
+// EnqueueClassEvent queues a notification about a class for each of its
+// participants, on every channel they have enabled. Class details are
+// captured at enqueue time; extra is merged into the payload.
+func EnqueueClassEvent(ctx context.Context, db background.Executor, classID, event string, extra map[string]any, now time.Time) error {
+	if extra == nil {
+		extra = map[string]any{}
+	}
+
+	payload, err := json.Marshal(extra)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, enqueueClassEventSQL, classID, event, payload, now)
+	return err
+}
+
+//go:embed queries/enqueue_class_removals.sql
+var enqueueClassRemovalsSQL string
+
+// EnqueueClassRemovals queues a notification for each user removed from a
+// class, on every channel they have enabled. Call it after the removal, when
+// EnqueueClassEvent no longer reaches them.
+func EnqueueClassRemovals(ctx context.Context, db background.Executor, classID string, userIDs []string, now time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec(ctx, enqueueClassRemovalsSQL, classID, userIDs, now)
+	return err
+}
+
+//go:embed queries/enqueue_class_reminders.sql
+var enqueueClassRemindersSQL string
+
+// EnqueueClassReminders queues a reminder for every participant of each
+// scheduled class starting within leadTime of now. Each reminder is queued
+// once per class start time, so calling it repeatedly is safe, and a
+// rescheduled class gets a fresh reminder.
+func EnqueueClassReminders(ctx context.Context, db background.Executor, now time.Time, leadTime time.Duration) error {
+	_, err := db.Exec(ctx, enqueueClassRemindersSQL, now, now.Add(leadTime))
+	return err
+}
+
+//go:embed queries/enqueue_waitlist_offer.sql
+var enqueueWaitlistOfferSQL string
+
+// EnqueueWaitlistOffer queues a notification telling a waitlisted user that
+// a seat has been offered to them, on every channel they have enabled.
+func EnqueueWaitlistOffer(ctx context.Context, db background.Executor, waitlistID string, now time.Time) error {
+	_, err := db.Exec(ctx, enqueueWaitlistOfferSQL, waitlistID, now)
+	return err
+}
+
+//go:embed queries/enqueue_user_invitation.sql
+var enqueueUserInvitationSQL string
+
+// EnqueueUserInvitation queues an email inviting a user to sign in through
+// link. Invitations are always sent by email, whatever the user's
+// preferences.
+func EnqueueUserInvitation(ctx context.Context, db background.Executor, userID, link string, now time.Time) error {
+	_, err := db.Exec(ctx, enqueueUserInvitationSQL, userID, link, now)
+	return err
+}
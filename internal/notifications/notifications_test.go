@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplatesRenderEveryEvent(t *testing.T) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+
+	courseName := "Algebra"
+	reason := "tutor_unavailable"
+	previous := time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC)
+	data := TemplateData{
+		FirstName: "Ada",
+		Class: ClassDetails{
+			ClassID:           "class-1",
+			CourseName:        &courseName,
+			StartTime:         time.Date(2024, 3, 5, 16, 30, 0, 0, time.UTC),
+			Duration:          60,
+			CancelReason:      &reason,
+			PreviousStartTime: &previous,
+		},
+		Waitlist: WaitlistDetails{
+			WaitlistID:     "waitlist-1",
+			OfferExpiresAt: time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC),
+		},
+		Invitation: InvitationDetails{
+			InviteLink:       "https://example.com/invite",
+			OrganizationName: "Northside Tutoring",
+		},
+	}
+
+	events := []string{
+		EventClassCreated,
+		EventClassRescheduled,
+		EventClassCancelled,
+		EventClassRestored,
+		EventClassParticipantsUpdated,
+		EventClassParticipantRemoved,
+		EventClassSubstituteAssigned,
+		EventClassReminder,
+		EventWaitlistOffered,
+		EventUserInvited,
+	}
+	for _, event := range events {
+		subject, body, err := templates.Render(event, data)
+		if err != nil {
+			t.Errorf("Render(%s) error = %v", event, err)
+			continue
+		}
+		if subject == "" || body == "" {
+			t.Errorf("Render(%s) returned empty subject or body", event)
+		}
+	}
+
+	_, body, _ := templates.Render(EventClassCancelled, data)
+	want := "Hi Ada,\n\nYour Algebra class on Tue Mar 5, 2024 at 16:30 UTC has been cancelled (tutor unavailable)."
+	if body != want {
+		t.Errorf("cancelled body = %q, want %q", body, want)
+	}
+
+	_, body, _ = templates.Render(EventWaitlistOffered, TemplateData{
+		FirstName: "Ada",
+		Class:     ClassDetails{CourseName: &courseName},
+		Waitlist:  data.Waitlist,
+	})
+	want = "Hi Ada,\n\nA seat has opened up in Algebra. Accept it by Sun Mar 3, 2024 at 12:00 UTC, after which it will be offered to the next person on the waitlist."
+	if body != want {
+		t.Errorf("waitlist offered body = %q, want %q", body, want)
+	}
+
+	substitute := "Grace Hopper"
+	_, body, _ = templates.Render(EventClassSubstituteAssigned, TemplateData{
+		FirstName: "Ada",
+		Class: ClassDetails{
+			CourseName:     &courseName,
+			StartTime:      data.Class.StartTime,
+			Duration:       60,
+			Role:           "student",
+			SubstituteName: &substitute,
+		},
+	})
+	want = "Hi Ada,\n\nYour Algebra class on Tue Mar 5, 2024 at 16:30 UTC will be taught by Grace Hopper."
+	if body != want {
+		t.Errorf("substitute assigned body = %q, want %q", body, want)
+	}
+
+	_, body, _ = templates.Render(EventUserInvited, data)
+	want = "Hi Ada,\n\nYou have been invited to Northside Tutoring. Set your password to sign in: https://example.com/invite"
+	if body != want {
+		t.Errorf("user invited body = %q, want %q", body, want)
+	}
+
+	if _, _, err := templates.Render("class.unknown", data); err == nil {
+		t.Error("Render() of unknown event should fail")
+	}
+}
+
+func TestEmailMessageKeepsSubjectOnOneLine(t *testing.T) {
+	msg := string(emailMessage("scheduler@example.com", Message{
+		To:      "ada@example.com",
+		Subject: "Class updated: Algebra\r\nBcc: victim@example.com",
+		Body:    "Hi Ada",
+	}))
+
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Fatalf("subject started a new header: %q", msg)
+	}
+	if !strings.Contains(msg, "\r\nSubject: Class updated: Algebra Bcc: victim@example.com\r\n") {
+		t.Errorf("message = %q, want the subject on one line", msg)
+	}
+
+	msg = string(emailMessage("scheduler@example.com", Message{To: "ada@example.com", Subject: "Class updated: Français"}))
+	if !strings.Contains(msg, "\r\nSubject: =?UTF-8?q?Class_updated:_Fran=C3=A7ais?=\r\n") {
+		t.Errorf("message = %q, want an encoded subject", msg)
+	}
+}
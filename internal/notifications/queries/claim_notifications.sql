@@ -0,0 +1,29 @@
+with claimed as (
+	select notification_id
+	from notifications
+	where status = 'pending' and next_attempt_at <= $1
+	order by next_attempt_at
+	limit $2
+	for update skip locked
+)
+
+update notifications as n
+set
+	attempts = n.attempts + 1,
+	next_attempt_at = $3,
+	updated_at = $1
+from claimed, users as u
+where
+	n.notification_id = claimed.notification_id
+	and n.user_id = u.user_id
+returning
+	n.notification_id,
+	n.user_id,
+	n.channel,
+	n.event,
+	n.payload,
+	n.attempts,
+	u.first_name,
+	u.last_name,
+	u.email,
+	u.phone_number;
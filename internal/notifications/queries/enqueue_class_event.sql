@@ -0,0 +1,27 @@
+insert into notifications (org_id, user_id, channel, event, payload, status, next_attempt_at, created_at, updated_at)
+select
+	c.org_id,
+	cp.user_id,
+	ch.channel,
+	$2,
+	jsonb_build_object(
+		'class_id', c.class_id,
+		'course_name', co.course_name,
+		'start_time', c.start_time,
+		'duration', c.duration,
+		'status', c.status,
+		'cancel_reason', c.cancel_reason,
+		'role', cp.role
+	) || $3::jsonb,
+	'pending',
+	$4,
+	$4,
+	$4
+from classes as c
+inner join class_participants as cp on c.class_id = cp.class_id
+left join courses as co on c.course_id = co.course_id
+cross join (values ('email'), ('sms')) as ch (channel)
+left join notification_preferences as np on cp.user_id = np.user_id and ch.channel = np.channel
+where
+	c.class_id = $1
+	and coalesce(np.enabled, ch.channel = 'email');
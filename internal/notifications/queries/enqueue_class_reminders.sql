@@ -0,0 +1,32 @@
+insert into notifications (
+	org_id, user_id, channel, event, payload, dedupe_key, status, next_attempt_at, created_at, updated_at
+)
+select
+	c.org_id,
+	cp.user_id,
+	ch.channel,
+	'class.reminder',
+	jsonb_build_object(
+		'class_id', c.class_id,
+		'course_name', co.course_name,
+		'start_time', c.start_time,
+		'duration', c.duration,
+		'status', c.status,
+		'role', cp.role
+	),
+	'class.reminder:' || c.class_id || ':' || cp.user_id || ':' || ch.channel || ':' || extract(epoch from c.start_time),
+	'pending',
+	$1,
+	$1,
+	$1
+from classes as c
+inner join class_participants as cp on c.class_id = cp.class_id
+left join courses as co on c.course_id = co.course_id
+cross join (values ('email'), ('sms')) as ch (channel)
+left join notification_preferences as np on cp.user_id = np.user_id and ch.channel = np.channel
+where
+	c.status = 'scheduled'
+	and c.start_time > $1
+	and c.start_time <= $2
+	and coalesce(np.enabled, ch.channel = 'email')
+on conflict (dedupe_key) do nothing;
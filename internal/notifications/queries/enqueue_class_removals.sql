@@ -0,0 +1,26 @@
+insert into notifications (org_id, user_id, channel, event, payload, status, next_attempt_at, created_at, updated_at)
+select
+	c.org_id,
+	u.user_id,
+	ch.channel,
+	'class.participant_removed',
+	jsonb_build_object(
+		'class_id', c.class_id,
+		'course_name', co.course_name,
+		'start_time', c.start_time,
+		'duration', c.duration,
+		'status', c.status
+	),
+	'pending',
+	$3,
+	$3,
+	$3
+from classes as c
+inner join users as u on c.org_id = u.org_id
+left join courses as co on c.course_id = co.course_id
+cross join (values ('email'), ('sms')) as ch (channel)
+left join notification_preferences as np on u.user_id = np.user_id and ch.channel = np.channel
+where
+	c.class_id = $1
+	and u.user_id = any($2)
+	and coalesce(np.enabled, ch.channel = 'email');
@@ -0,0 +1,17 @@
+insert into notifications (org_id, user_id, channel, event, payload, status, next_attempt_at, created_at, updated_at)
+select
+	u.org_id,
+	u.user_id,
+	'email',
+	'user.invited',
+	jsonb_build_object(
+		'invite_link', $2::text,
+		'organization_name', o.name
+	),
+	'pending',
+	$3,
+	$3,
+	$3
+from users as u
+inner join organizations as o on u.org_id = o.organization_id
+where u.user_id = $1;
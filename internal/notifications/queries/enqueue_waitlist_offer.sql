@@ -0,0 +1,28 @@
+insert into notifications (org_id, user_id, channel, event, payload, status, next_attempt_at, created_at, updated_at)
+select
+	w.org_id,
+	w.user_id,
+	ch.channel,
+	'waitlist.offered',
+	jsonb_build_object(
+		'waitlist_id', w.waitlist_id,
+		'offer_expires_at', w.offer_expires_at,
+		'class_id', c.class_id,
+		'course_name', co.course_name,
+		'start_time', c.start_time,
+		'duration', c.duration,
+		'status', c.status,
+		'role', 'student'
+	),
+	'pending',
+	$2,
+	$2,
+	$2
+from waitlist_entries as w
+left join classes as c on w.class_id = c.class_id
+left join courses as co on coalesce(w.course_id, c.course_id) = co.course_id
+cross join (values ('email'), ('sms')) as ch (channel)
+left join notification_preferences as np on w.user_id = np.user_id and ch.channel = np.channel
+where
+	w.waitlist_id = $1
+	and coalesce(np.enabled, ch.channel = 'email');
@@ -0,0 +1,7 @@
+update notifications
+set
+	status = $2,
+	next_attempt_at = $3,
+	last_error = $4,
+	updated_at = $5
+where notification_id = $1;
@@ -0,0 +1,7 @@
+update notifications
+set
+	status = 'sent',
+	sent_at = $2,
+	last_error = null,
+	updated_at = $2
+where notification_id = $1;
@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// ClassDetails is the class snapshot stored in a notification payload.
+type ClassDetails struct {
+	ClassID           string     `json:"class_id"`
+	CourseName        *string    `json:"course_name"`
+	StartTime         time.Time  `json:"start_time"`
+	Duration          int        `json:"duration"`
+	Status            string     `json:"status"`
+	CancelReason      *string    `json:"cancel_reason"`
+	Role              string     `json:"role"`
+	PreviousStartTime *time.Time `json:"previous_start_time,omitempty"`
+	PreviousDuration  *int       `json:"previous_duration,omitempty"`
+	SubstituteName    *string    `json:"substitute_name,omitempty"`
+}
+
+// WaitlistDetails is the waitlist offer stored in a notification payload.
+// Offers for a class also carry the class details.
+type WaitlistDetails struct {
+	WaitlistID     string    `json:"waitlist_id"`
+	OfferExpiresAt time.Time `json:"offer_expires_at"`
+}
+
+// InvitationDetails is the invitation stored in a notification payload.
+type InvitationDetails struct {
+	InviteLink       string `json:"invite_link"`
+	OrganizationName string `json:"organization_name"`
+}
+
+// TemplateData is what message templates are rendered with.
+type TemplateData struct {
+	FirstName  string
+	LastName   string
+	Class      ClassDetails
+	Waitlist   WaitlistDetails
+	Invitation InvitationDetails
+}
+
+var templateFuncs = template.FuncMap{
+	"formatTime": func(t time.Time) string {
+		return t.UTC().Format("Mon Jan 2, 2006 at 15:04 MST")
+	},
+	"courseName": func(class ClassDetails) string {
+		if class.CourseName == nil || *class.CourseName == "" {
+			return "tutoring"
+		}
+		return *class.CourseName
+	},
+	"reason": func(code string) string {
+		return strings.ReplaceAll(code, "_", " ")
+	},
+}
+
+// Templates renders messages for each event from the embedded templates.
+type Templates struct {
+	byEvent map[string]*template.Template
+}
+
+// LoadTemplates parses the embedded message templates. Each event has a
+// templates/<event>.tmpl file defining a "subject" and a "body" template.
+func LoadTemplates() (*Templates, error) {
+	files, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	byEvent := map[string]*template.Template{}
+	for _, file := range files {
+		event := strings.TrimSuffix(file.Name(), ".tmpl")
+		tmpl, err := template.New(event).Funcs(templateFuncs).ParseFS(templateFiles, "templates/"+file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", file.Name(), err)
+		}
+		byEvent[event] = tmpl
+	}
+
+	return &Templates{byEvent: byEvent}, nil
+}
+
+// Render returns the subject and body of the message for an event.
+func (t *Templates) Render(event string, data TemplateData) (string, string, error) {
+	tmpl, ok := t.byEvent[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %s", event)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject for %s: %w", event, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render body for %s: %w", event, err)
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimSpace(body.String()), nil
+}
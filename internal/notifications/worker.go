@@ -0,0 +1,270 @@
+package notifications
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"scheduler-api/internal/background"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed queries/claim_notifications.sql
+	claimNotificationsSQL string
+
+	//go:embed queries/mark_notification_sent.sql
+	markNotificationSentSQL string
+
+	//go:embed queries/mark_notification_failed.sql
+	markNotificationFailedSQL string
+)
+
+// Config controls how the worker polls and retries.
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease is how long a claimed notification is hidden from other workers
+	// while it is being delivered.
+	Lease time.Duration
+}
+
+// DefaultConfig returns the worker defaults.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 15 * time.Second,
+		BatchSize:    50,
+		MaxAttempts:  5,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   time.Hour,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// LoadConfigFromEnv overrides the defaults with NOTIFICATION_* environment
+// variables. Durations use time.ParseDuration syntax, e.g. "24h".
+func LoadConfigFromEnv() Config {
+	config := DefaultConfig()
+	config.PollInterval = background.DurationFromEnv("NOTIFICATION_POLL_INTERVAL", config.PollInterval)
+	config.BatchSize = background.IntFromEnv("NOTIFICATION_BATCH_SIZE", config.BatchSize)
+	config.MaxAttempts = background.IntFromEnv("NOTIFICATION_MAX_ATTEMPTS", config.MaxAttempts)
+	config.BaseBackoff = background.DurationFromEnv("NOTIFICATION_BASE_BACKOFF", config.BaseBackoff)
+	config.MaxBackoff = background.DurationFromEnv("NOTIFICATION_MAX_BACKOFF", config.MaxBackoff)
+	return config
+}
+
+// ChannelsFromEnv builds the delivery channels from environment variables.
+// Email uses SMTP when SMTP_HOST is set and SMS uses an HTTP gateway when
+// SMS_GATEWAY_URL is set. Otherwise messages go to NOTIFICATION_SINK_FILE if
+// set, or to the log.
+func ChannelsFromEnv(logger *zap.Logger) map[string]Channel {
+	channels := map[string]Channel{}
+
+	for _, name := range []string{ChannelEmail, ChannelSMS} {
+		if path := os.Getenv("NOTIFICATION_SINK_FILE"); path != "" {
+			channels[name] = &FileChannel{Path: path, Name: name}
+		} else {
+			channels[name] = &LogChannel{Logger: logger, Name: name}
+		}
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		channels[ChannelEmail] = &SMTPChannel{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}
+	}
+
+	if url := os.Getenv("SMS_GATEWAY_URL"); url != "" {
+		channels[ChannelSMS] = &SMSChannel{
+			Gateway: &HTTPSMSGateway{URL: url, Token: os.Getenv("SMS_GATEWAY_TOKEN")},
+		}
+	}
+
+	return channels
+}
+
+// Worker delivers pending notifications from the outbox.
+type Worker struct {
+	logger    *zap.Logger
+	pgxPool   *pgxpool.Pool
+	channels  map[string]Channel
+	templates *Templates
+	config    Config
+	now       func() time.Time
+}
+
+// NewWorker creates a worker that delivers through the given channels, keyed
+// by channel name.
+func NewWorker(logger *zap.Logger, pgxPool *pgxpool.Pool, channels map[string]Channel, config Config) (*Worker, error) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Worker{
+		logger:    logger,
+		pgxPool:   pgxPool,
+		channels:  channels,
+		templates: templates,
+		config:    config,
+		now:       time.Now,
+	}, nil
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("Notification worker run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce delivers one batch of pending notifications.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	pending, err := w.claim(ctx, w.now())
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range pending {
+		w.deliver(ctx, notification)
+	}
+
+	return nil
+}
+
+type claimedNotification struct {
+	NotificationID string
+	UserID         string
+	Channel        string
+	Event          string
+	Payload        []byte
+	Attempts       int
+	FirstName      string
+	LastName       string
+	Email          *string
+	PhoneNumber    *string
+}
+
+func (w *Worker) claim(ctx context.Context, now time.Time) ([]claimedNotification, error) {
+	rows, err := w.pgxPool.Query(ctx, claimNotificationsSQL, now, w.config.BatchSize, now.Add(w.config.Lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var claimed []claimedNotification
+	for rows.Next() {
+		var n claimedNotification
+		if err := rows.Scan(&n.NotificationID, &n.UserID, &n.Channel, &n.Event, &n.Payload, &n.Attempts,
+			&n.FirstName, &n.LastName, &n.Email, &n.PhoneNumber); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		claimed = append(claimed, n)
+	}
+
+	return claimed, rows.Err()
+}
+
+func (w *Worker) deliver(ctx context.Context, n claimedNotification) {
+	err := w.send(ctx, n)
+	now := w.now()
+
+	if err == nil {
+		if _, err := w.pgxPool.Exec(ctx, markNotificationSentSQL, n.NotificationID, now); err != nil {
+			w.logger.Error("Failed to mark notification sent", zap.String("notification_id", n.NotificationID), zap.Error(err))
+		}
+		return
+	}
+
+	status, nextAttempt := StatusPending, now.Add(background.Backoff(n.Attempts, w.config.BaseBackoff, w.config.MaxBackoff))
+	if n.Attempts >= w.config.MaxAttempts {
+		status, nextAttempt = StatusFailed, now
+	}
+
+	w.logger.Warn("Notification delivery failed",
+		zap.String("notification_id", n.NotificationID),
+		zap.String("channel", n.Channel),
+		zap.Int("attempts", n.Attempts),
+		zap.String("status", status),
+		zap.Error(err))
+
+	if _, err := w.pgxPool.Exec(ctx, markNotificationFailedSQL, n.NotificationID, status, nextAttempt, err.Error(), now); err != nil {
+		w.logger.Error("Failed to record notification failure", zap.String("notification_id", n.NotificationID), zap.Error(err))
+	}
+}
+
+func (w *Worker) send(ctx context.Context, n claimedNotification) error {
+	channel, ok := w.channels[n.Channel]
+	if !ok {
+		return fmt.Errorf("channel %s is not configured", n.Channel)
+	}
+
+	to := n.Email
+	if n.Channel == ChannelSMS {
+		to = n.PhoneNumber
+	}
+	if to == nil || *to == "" {
+		return fmt.Errorf("user has no address for channel %s", n.Channel)
+	}
+
+	var (
+		class      ClassDetails
+		waitlist   WaitlistDetails
+		invitation InvitationDetails
+	)
+	if err := json.Unmarshal(n.Payload, &class); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+	if err := json.Unmarshal(n.Payload, &waitlist); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+	if err := json.Unmarshal(n.Payload, &invitation); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+
+	subject, body, err := w.templates.Render(n.Event, TemplateData{
+		FirstName:  n.FirstName,
+		LastName:   n.LastName,
+		Class:      class,
+		Waitlist:   waitlist,
+		Invitation: invitation,
+	})
+	if err != nil {
+		return err
+	}
+
+	return channel.Send(ctx, Message{
+		NotificationID: n.NotificationID,
+		Event:          n.Event,
+		To:             *to,
+		Subject:        subject,
+		Body:           body,
+	})
+}
@@ -0,0 +1,176 @@
+package oneroster
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxFileSize bounds each file read from a bundle.
+const maxFileSize = 200 << 20
+
+// Manifest values of a file.
+const (
+	manifestBulk   = "bulk"
+	manifestDelta  = "delta"
+	manifestAbsent = "absent"
+)
+
+// ReadBundle reads a zipped OneRoster CSV bundle. Bundles without a
+// manifest are read as bulk files; files the manifest marks absent are not
+// read, and files it marks delta are not complete.
+func ReadBundle(data []byte) (Roster, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Roster{}, fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		// Some publishers zip a folder rather than its files.
+		name := file.Name
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		files[strings.ToLower(name)] = file
+	}
+
+	modes := map[string]string{}
+	if file, ok := files["manifest.csv"]; ok {
+		rows, err := readBundleFile(file)
+		if err != nil {
+			return Roster{}, err
+		}
+		for _, row := range rows {
+			if name, ok := strings.CutPrefix(row["propertyname"], "file."); ok {
+				modes[name] = strings.ToLower(row["value"])
+			}
+		}
+	}
+
+	roster := Roster{Complete: map[string]bool{}}
+	read := func(collection string, parse func(map[string]string)) error {
+		file, ok := files[collection+".csv"]
+		mode := modes[collection]
+		if !ok || mode == manifestAbsent {
+			return nil
+		}
+
+		rows, err := readBundleFile(file)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			parse(row)
+		}
+
+		roster.Complete[collection] = mode != manifestDelta
+		return nil
+	}
+
+	err = errors.Join(
+		read(CollectionOrgs, func(row map[string]string) {
+			roster.Orgs = append(roster.Orgs, Org{
+				SourcedID:       row["sourcedid"],
+				Status:          row["status"],
+				Name:            row["name"],
+				Type:            row["type"],
+				ParentSourcedID: row["parentsourcedid"],
+			})
+		}),
+		read(CollectionUsers, func(row map[string]string) {
+			roster.Users = append(roster.Users, User{
+				SourcedID:     row["sourcedid"],
+				Status:        row["status"],
+				EnabledUser:   !strings.EqualFold(row["enableduser"], "false"),
+				OrgSourcedIDs: splitList(row["orgsourcedids"]),
+				Role:          strings.ToLower(row["role"]),
+				Username:      row["username"],
+				GivenName:     row["givenname"],
+				FamilyName:    row["familyname"],
+				Email:         row["email"],
+				Phone:         row["phone"],
+			})
+		}),
+		read(CollectionClasses, func(row map[string]string) {
+			roster.Classes = append(roster.Classes, Class{
+				SourcedID:       row["sourcedid"],
+				Status:          row["status"],
+				Title:           row["title"],
+				ClassCode:       row["classcode"],
+				CourseSourcedID: row["coursesourcedid"],
+				SchoolSourcedID: row["schoolsourcedid"],
+			})
+		}),
+		read(CollectionEnrollments, func(row map[string]string) {
+			roster.Enrollments = append(roster.Enrollments, Enrollment{
+				SourcedID:       row["sourcedid"],
+				Status:          row["status"],
+				ClassSourcedID:  row["classsourcedid"],
+				SchoolSourcedID: row["schoolsourcedid"],
+				UserSourcedID:   row["usersourcedid"],
+				Role:            strings.ToLower(row["role"]),
+				Primary:         strings.EqualFold(row["primary"], "true"),
+			})
+		}),
+	)
+	if err != nil {
+		return Roster{}, err
+	}
+
+	return roster, nil
+}
+
+// readBundleFile reads the rows of a CSV file of a bundle, keyed by
+// lowercased column name.
+func readBundleFile(file *zip.File) ([]map[string]string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	r := csv.NewReader(io.LimitReader(f, maxFileSize))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, value := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// splitList splits a comma separated list of sourced IDs.
+func splitList(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
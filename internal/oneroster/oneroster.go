@@ -0,0 +1,97 @@
+// Package oneroster reads IMS OneRoster 1.1 rosters published by schools,
+// either as a zipped CSV bundle or from a OneRoster REST API.
+//
+// Both are read into the same Roster, holding the orgs, users, classes and
+// enrollments the scheduler syncs. Fields the scheduler has no use for are
+// dropped.
+package oneroster
+
+import "strings"
+
+// Record statuses. OneRoster 1.0 also used inactive for records that were
+// removed.
+const (
+	StatusActive      = "active"
+	StatusToBeDeleted = "tobedeleted"
+	StatusInactive    = "inactive"
+)
+
+// Collections of a roster.
+const (
+	CollectionOrgs        = "orgs"
+	CollectionUsers       = "users"
+	CollectionClasses     = "classes"
+	CollectionEnrollments = "enrollments"
+)
+
+// Org is a school, district or other organization of the roster.
+type Org struct {
+	SourcedID       string
+	Status          string
+	Name            string
+	Type            string
+	ParentSourcedID string
+}
+
+// User is a student, teacher or other person of the roster.
+type User struct {
+	SourcedID     string
+	Status        string
+	EnabledUser   bool
+	OrgSourcedIDs []string
+	Role          string
+	Username      string
+	GivenName     string
+	FamilyName    string
+	Email         string
+	Phone         string
+}
+
+// Class is a section of a course taught at a school.
+type Class struct {
+	SourcedID       string
+	Status          string
+	Title           string
+	ClassCode       string
+	CourseSourcedID string
+	SchoolSourcedID string
+}
+
+// Enrollment places a user in a class.
+type Enrollment struct {
+	SourcedID       string
+	Status          string
+	ClassSourcedID  string
+	SchoolSourcedID string
+	UserSourcedID   string
+	Role            string
+	Primary         bool
+}
+
+// Roster is everything read from a bundle or an API.
+type Roster struct {
+	Orgs        []Org
+	Users       []User
+	Classes     []Class
+	Enrollments []Enrollment
+
+	// Complete holds the collections that were read in full. Records
+	// missing from a complete collection have been removed; a collection
+	// that is not complete, such as a delta file, only lists changes.
+	Complete map[string]bool
+}
+
+// Active reports whether a record with status is still part of the roster.
+func Active(status string) bool {
+	switch strings.ToLower(status) {
+	case StatusToBeDeleted, StatusInactive:
+		return false
+	default:
+		return true
+	}
+}
+
+// Active reports whether the user is part of the roster and may sign in.
+func (u User) Active() bool {
+	return Active(u.Status) && u.EnabledUser
+}
@@ -0,0 +1,169 @@
+package oneroster
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func bundle(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadBundle(t *testing.T) {
+	data := bundle(t, map[string]string{
+		"roster/manifest.csv": "propertyName,value\nmanifest.version,1.0\nfile.orgs,bulk\nfile.users,bulk\nfile.classes,delta\nfile.enrollments,absent\n",
+		"roster/orgs.csv":     "sourcedId,status,dateLastModified,name,type,identifier,parentSourcedId\norg-1,active,,Northside High,school,,district-1\n",
+		"roster/users.csv": "\ufeffsourcedId,status,dateLastModified,enabledUser,orgSourcedIds,role,username,userIds,givenName,familyName,middleName,identifier,email,sms,phone\n" +
+			"u-1,active,,true,\"org-1,org-2\",Student,ada,,Ada,Lovelace,,,ada@example.com,,\n" +
+			"u-2,tobedeleted,,false,org-1,teacher,alan,,Alan,Turing,,,alan@example.com,,555-0100\n",
+		"roster/classes.csv":     "sourcedId,status,dateLastModified,title,grades,courseSourcedId,classCode,classType,location,schoolSourcedId\nc-1,active,,Algebra 1,,course-1,ALG1,scheduled,,org-1\n",
+		"roster/enrollments.csv": "sourcedId,status,dateLastModified,classSourcedId,schoolSourcedId,userSourcedId,role,primary\ne-1,active,,c-1,org-1,u-1,student,false\n",
+	})
+
+	roster, err := ReadBundle(data)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+
+	if len(roster.Orgs) != 1 || roster.Orgs[0].ParentSourcedID != "district-1" {
+		t.Errorf("unexpected orgs %+v", roster.Orgs)
+	}
+
+	if len(roster.Users) != 2 {
+		t.Fatalf("expected 2 users, got %+v", roster.Users)
+	}
+	ada, alan := roster.Users[0], roster.Users[1]
+	if ada.Role != "student" || !slices.Equal(ada.OrgSourcedIDs, []string{"org-1", "org-2"}) || !ada.Active() {
+		t.Errorf("unexpected user %+v", ada)
+	}
+	if alan.Active() || alan.Phone != "555-0100" {
+		t.Errorf("expected an inactive user with a phone number, got %+v", alan)
+	}
+
+	if len(roster.Classes) != 1 || roster.Classes[0].SchoolSourcedID != "org-1" {
+		t.Errorf("unexpected classes %+v", roster.Classes)
+	}
+	if roster.Enrollments != nil {
+		t.Errorf("expected the absent enrollments file not to be read, got %+v", roster.Enrollments)
+	}
+
+	complete := map[string]bool{CollectionOrgs: true, CollectionUsers: true, CollectionClasses: false}
+	for collection, want := range complete {
+		if roster.Complete[collection] != want {
+			t.Errorf("Complete[%s] = %v, want %v", collection, roster.Complete[collection], want)
+		}
+	}
+	if _, ok := roster.Complete[CollectionEnrollments]; ok {
+		t.Error("expected enrollments not to be listed as read")
+	}
+}
+
+func TestReadBundleWithoutManifest(t *testing.T) {
+	roster, err := ReadBundle(bundle(t, map[string]string{"users.csv": "sourcedId,givenName\nu-1,Ada\n"}))
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+
+	if !roster.Complete[CollectionUsers] || roster.Complete[CollectionClasses] {
+		t.Errorf("expected only users to be complete, got %v", roster.Complete)
+	}
+	if !roster.Users[0].Active() {
+		t.Error("expected a user without a status to be active")
+	}
+
+	if _, err := ReadBundle([]byte("not a zip")); err == nil {
+		t.Error("expected an error for a file that is not a zip")
+	}
+}
+
+func TestClientFetch(t *testing.T) {
+	records := map[string][]map[string]any{
+		CollectionOrgs: {{"sourcedId": "org-1", "status": "active", "name": "Northside High", "type": "school"}},
+		CollectionUsers: {
+			{"sourcedId": "u-1", "status": "active", "enabledUser": "true", "role": "student", "givenName": "Ada", "familyName": "Lovelace",
+				"orgs": []map[string]string{{"sourcedId": "org-1"}}},
+			{"sourcedId": "u-2", "status": "active", "enabledUser": false, "role": "teacher", "givenName": "Alan", "familyName": "Turing"},
+			{"sourcedId": "u-3", "status": "active", "role": "student", "givenName": "Grace", "familyName": "Hopper"},
+		},
+		CollectionClasses: {{"sourcedId": "c-1", "status": "active", "title": "Algebra 1",
+			"course": map[string]string{"sourcedId": "course-1"}, "school": map[string]string{"sourcedId": "org-1"}}},
+		CollectionEnrollments: {{"sourcedId": "e-1", "status": "active", "role": "student", "primary": "false",
+			"class": map[string]string{"sourcedId": "c-1"}, "user": map[string]string{"sourcedId": "u-1"}}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "token-1", "token_type": "bearer"})
+	})
+	mux.HandleFunc("GET /ims/oneroster/v1p1/{collection}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		collection := r.PathValue("collection")
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		all := records[collection]
+		page := all[min(offset, len(all)):min(offset+limit, len(all))]
+		_ = json.NewEncoder(w).Encode(map[string]any{collection: page})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL+"/ims/oneroster/v1p1/", server.URL+"/token", "client", "secret")
+	client.PageSize = 2
+
+	roster, err := client.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if len(roster.Users) != 3 {
+		t.Fatalf("expected every page of users, got %+v", roster.Users)
+	}
+	if !roster.Users[0].Active() || roster.Users[1].Active() || !roster.Users[2].Active() {
+		t.Errorf("unexpected enabled users %+v", roster.Users)
+	}
+	if !slices.Equal(roster.Users[0].OrgSourcedIDs, []string{"org-1"}) {
+		t.Errorf("unexpected orgs of user %+v", roster.Users[0])
+	}
+	if roster.Classes[0].SchoolSourcedID != "org-1" || roster.Enrollments[0].ClassSourcedID != "c-1" || roster.Enrollments[0].Primary {
+		t.Errorf("unexpected classes %+v or enrollments %+v", roster.Classes, roster.Enrollments)
+	}
+	if !roster.Complete[CollectionEnrollments] {
+		t.Error("expected collections read from the API to be complete")
+	}
+
+	client.ClientSecret = "wrong"
+	if _, err := client.Fetch(context.Background()); err == nil {
+		t.Error("expected an error for rejected credentials")
+	}
+}
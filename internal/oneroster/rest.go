@@ -0,0 +1,288 @@
+package oneroster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultPageSize is how many records are asked for per request.
+const defaultPageSize = 500
+
+// Client reads rosters from a OneRoster REST API, such as
+// https://example.com/ims/oneroster/v1p1.
+type Client struct {
+	BaseURL string
+	// TokenURL, ClientID and ClientSecret, when TokenURL is set, get an
+	// OAuth 2 bearer token with the client credentials grant.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	PageSize     int
+	HTTPClient   *http.Client
+}
+
+// NewClient creates a client that sends its requests with httpClient.
+// Sources are configured by organizations, so the server passes a client
+// that cannot reach its internal network.
+func NewClient(httpClient *http.Client, baseURL, tokenURL, clientID, clientSecret string) *Client {
+	return &Client{
+		BaseURL:      strings.TrimSuffix(baseURL, "/"),
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		PageSize:     defaultPageSize,
+		HTTPClient:   httpClient,
+	}
+}
+
+// FetchBundle downloads and reads a zipped CSV bundle.
+func FetchBundle(ctx context.Context, client *http.Client, bundleURL string) (Roster, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, nil)
+	if err != nil {
+		return Roster{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Roster{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Roster{}, fmt.Errorf("bundle download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize))
+	if err != nil {
+		return Roster{}, err
+	}
+
+	return ReadBundle(data)
+}
+
+// ref is a reference to another record.
+type ref struct {
+	SourcedID string `json:"sourcedId"`
+}
+
+// flexBool is a boolean that OneRoster 1.1 sends as a string.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*b = false
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	*b = flexBool(parsed)
+	return err
+}
+
+// Fetch reads every org, user, class and enrollment of the API.
+func (c *Client) Fetch(ctx context.Context) (Roster, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return Roster{}, err
+	}
+
+	roster := Roster{Complete: map[string]bool{}}
+
+	var orgs []struct {
+		SourcedID string `json:"sourcedId"`
+		Status    string `json:"status"`
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Parent    *ref   `json:"parent"`
+	}
+	if err := c.list(ctx, token, CollectionOrgs, &orgs); err != nil {
+		return Roster{}, err
+	}
+	for _, org := range orgs {
+		o := Org{SourcedID: org.SourcedID, Status: org.Status, Name: org.Name, Type: org.Type}
+		if org.Parent != nil {
+			o.ParentSourcedID = org.Parent.SourcedID
+		}
+		roster.Orgs = append(roster.Orgs, o)
+	}
+
+	var users []struct {
+		SourcedID   string    `json:"sourcedId"`
+		Status      string    `json:"status"`
+		EnabledUser *flexBool `json:"enabledUser"`
+		Orgs        []ref     `json:"orgs"`
+		Role        string    `json:"role"`
+		Username    string    `json:"username"`
+		GivenName   string    `json:"givenName"`
+		FamilyName  string    `json:"familyName"`
+		Email       string    `json:"email"`
+		Phone       string    `json:"phone"`
+	}
+	if err := c.list(ctx, token, CollectionUsers, &users); err != nil {
+		return Roster{}, err
+	}
+	for _, user := range users {
+		u := User{
+			SourcedID:   user.SourcedID,
+			Status:      user.Status,
+			EnabledUser: user.EnabledUser == nil || bool(*user.EnabledUser),
+			Role:        strings.ToLower(user.Role),
+			Username:    user.Username,
+			GivenName:   user.GivenName,
+			FamilyName:  user.FamilyName,
+			Email:       user.Email,
+			Phone:       user.Phone,
+		}
+		for _, org := range user.Orgs {
+			u.OrgSourcedIDs = append(u.OrgSourcedIDs, org.SourcedID)
+		}
+		roster.Users = append(roster.Users, u)
+	}
+
+	var classes []struct {
+		SourcedID string `json:"sourcedId"`
+		Status    string `json:"status"`
+		Title     string `json:"title"`
+		ClassCode string `json:"classCode"`
+		Course    ref    `json:"course"`
+		School    ref    `json:"school"`
+	}
+	if err := c.list(ctx, token, CollectionClasses, &classes); err != nil {
+		return Roster{}, err
+	}
+	for _, class := range classes {
+		roster.Classes = append(roster.Classes, Class{
+			SourcedID:       class.SourcedID,
+			Status:          class.Status,
+			Title:           class.Title,
+			ClassCode:       class.ClassCode,
+			CourseSourcedID: class.Course.SourcedID,
+			SchoolSourcedID: class.School.SourcedID,
+		})
+	}
+
+	var enrollments []struct {
+		SourcedID string   `json:"sourcedId"`
+		Status    string   `json:"status"`
+		Class     ref      `json:"class"`
+		School    ref      `json:"school"`
+		User      ref      `json:"user"`
+		Role      string   `json:"role"`
+		Primary   flexBool `json:"primary"`
+	}
+	if err := c.list(ctx, token, CollectionEnrollments, &enrollments); err != nil {
+		return Roster{}, err
+	}
+	for _, enrollment := range enrollments {
+		roster.Enrollments = append(roster.Enrollments, Enrollment{
+			SourcedID:       enrollment.SourcedID,
+			Status:          enrollment.Status,
+			ClassSourcedID:  enrollment.Class.SourcedID,
+			SchoolSourcedID: enrollment.School.SourcedID,
+			UserSourcedID:   enrollment.User.SourcedID,
+			Role:            strings.ToLower(enrollment.Role),
+			Primary:         bool(enrollment.Primary),
+		})
+	}
+
+	for _, collection := range []string{CollectionOrgs, CollectionUsers, CollectionClasses, CollectionEnrollments} {
+		roster.Complete[collection] = true
+	}
+
+	return roster, nil
+}
+
+// list reads every page of a collection into records, a pointer to a
+// slice.
+func (c *Client) list(ctx context.Context, token, collection string, records any) error {
+	var all []json.RawMessage
+	for offset := 0; ; offset += c.PageSize {
+		query := url.Values{
+			"limit":  {strconv.Itoa(c.PageSize)},
+			"offset": {strconv.Itoa(offset)},
+		}
+
+		page := map[string][]json.RawMessage{}
+		if err := c.get(ctx, token, c.BaseURL+"/"+collection+"?"+query.Encode(), &page); err != nil {
+			return fmt.Errorf("failed to list %s: %w", collection, err)
+		}
+
+		all = append(all, page[collection]...)
+		if len(page[collection]) < c.PageSize {
+			break
+		}
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, records)
+}
+
+func (c *Client) get(ctx context.Context, token, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxFileSize)).Decode(v)
+}
+
+// token gets a bearer token, or none when the client has no token URL.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.TokenURL == "" {
+		return "", nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("token response has no access_token")
+	}
+
+	return body.AccessToken, nil
+}
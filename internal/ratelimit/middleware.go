@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"net/http"
+	"scheduler-api/internal/auth"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// decisionKey holds the decision reported so far on the gin context, so the
+// middleware that runs later only reports a limit that is closer.
+const decisionKey = "ratelimit.decision"
+
+// ByIP limits requests by client address. Register it before
+// authentication, so floods are turned away before their tokens are
+// verified.
+func (l *Limiter) ByIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d, ok := l.take(check{bucketKey{"ip", c.ClientIP(), ""}, l.config.IP})
+		if ok && !report(c, d) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// ByUser limits authenticated requests by user and by organization, with
+// the limits of the route's class. Register it after authentication;
+// requests without a current user are only limited by address.
+func (l *Limiter) ByUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser, err := auth.GetCurrentUser(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		class := l.class(c)
+		d, ok := l.take(
+			check{bucketKey{"user", currentUser.UserID, class}, l.config.User[class]},
+			check{bucketKey{"org", currentUser.OrgID, class}, l.config.Org[class]},
+		)
+		if ok && !report(c, d) {
+			return
+		}
+		c.Next()
+	}
+}
+
+func (l *Limiter) class(c *gin.Context) Class {
+	switch {
+	case l.batchRoutes[c.Request.Method+" "+c.FullPath()]:
+		return Batch
+	case c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead:
+		return Read
+	default:
+		return Write
+	}
+}
+
+// report sets the RateLimit headers of a decision, unless a limit closer to
+// being reached was already reported. It writes a 429 response and returns
+// false when the request is over the limit.
+func report(c *gin.Context, d decision) bool {
+	if value, ok := c.Get(decisionKey); ok {
+		if previous := value.(decision); d.Allowed && previous.Remaining <= d.Remaining {
+			d = previous
+		}
+	}
+	c.Set(decisionKey, d)
+
+	c.Header("RateLimit-Limit", strconv.Itoa(d.Limit.Requests))
+	c.Header("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(int(d.Reset.Seconds())))
+	c.Header("RateLimit-Policy", strconv.Itoa(d.Limit.Requests)+";w="+strconv.Itoa(int(d.Limit.Period.Seconds())))
+
+	if !d.Allowed {
+		c.Header("Retry-After", strconv.Itoa(int(d.RetryAfter.Seconds())))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":   "rate_limited",
+			"message": "Too many requests; retry after " + strconv.Itoa(int(d.RetryAfter.Seconds())) + " seconds",
+		})
+		return false
+	}
+
+	return true
+}
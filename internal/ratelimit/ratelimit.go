@@ -0,0 +1,270 @@
+// Package ratelimit bounds how often clients can call the API. Requests
+// take a token from buckets keyed by client address, user and organization,
+// which refill at a steady rate up to their limit, so clients may burst up
+// to a limit and then keep to its rate. Authenticated limits depend on the
+// route class: reads, writes and expensive batch routes.
+//
+// Buckets live in memory, so each API instance enforces the limits on the
+// requests it serves.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Class is the kind of route a request is for.
+type Class string
+
+const (
+	Read  Class = "read"
+	Write Class = "write"
+	Batch Class = "batch"
+)
+
+// Limit allows Requests requests per Period, all at once at most. The zero
+// Limit allows every request.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit reads a limit written as "requests/period", e.g. "300/1m".
+// "off" disables the limit.
+func ParseLimit(value string) (Limit, error) {
+	if value == "off" {
+		return Limit{}, nil
+	}
+
+	requestsText, periodText, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q", value)
+	}
+	requests, err := strconv.Atoi(requestsText)
+	if err != nil || requests < 1 {
+		return Limit{}, fmt.Errorf("invalid limit %q", value)
+	}
+	period, err := time.ParseDuration(periodText)
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q", value)
+	}
+
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+func (l Limit) enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// rate is how many tokens the limit's buckets gain per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Config sets the limits.
+type Config struct {
+	// IP limits every request by client address, before authentication.
+	IP Limit
+	// User and Org limit authenticated requests by route class.
+	User map[Class]Limit
+	Org  map[Class]Limit
+	// BatchRoutes are the routes classed as batch, as "METHOD /path/".
+	// Other GET and HEAD routes are reads, and the rest writes.
+	BatchRoutes []string
+}
+
+// DefaultConfig returns the defaults, which leave room for interactive use
+// and bulk clients but not for loops hammering the API.
+func DefaultConfig() Config {
+	return Config{
+		IP: Limit{1200, time.Minute},
+		User: map[Class]Limit{
+			Read:  {300, time.Minute},
+			Write: {60, time.Minute},
+			Batch: {10, time.Minute},
+		},
+		Org: map[Class]Limit{
+			Read:  {3000, time.Minute},
+			Write: {600, time.Minute},
+			Batch: {60, time.Minute},
+		},
+	}
+}
+
+// LoadConfigFromEnv overrides the defaults with RATE_LIMIT_* environment
+// variables. Limits use ParseLimit syntax, e.g. "300/1m" or "off".
+func LoadConfigFromEnv() Config {
+	config := DefaultConfig()
+	config.IP = limitFromEnv("RATE_LIMIT_IP", config.IP)
+	for _, class := range []Class{Read, Write, Batch} {
+		suffix := strings.ToUpper(string(class))
+		config.User[class] = limitFromEnv("RATE_LIMIT_USER_"+suffix, config.User[class])
+		config.Org[class] = limitFromEnv("RATE_LIMIT_ORG_"+suffix, config.Org[class])
+	}
+	return config
+}
+
+// Limiter keeps the token buckets.
+type Limiter struct {
+	config      Config
+	batchRoutes map[string]bool
+	now         func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+// New creates a limiter. Run removes its idle buckets.
+func New(config Config) *Limiter {
+	batchRoutes := map[string]bool{}
+	for _, route := range config.BatchRoutes {
+		batchRoutes[route] = true
+	}
+
+	return &Limiter{
+		config:      config,
+		batchRoutes: batchRoutes,
+		now:         time.Now,
+		buckets:     map[bucketKey]*bucket{},
+	}
+}
+
+type bucketKey struct {
+	scope string
+	id    string
+	class Class
+}
+
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds the tokens gained since the bucket was last updated.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed*b.limit.rate())
+		b.updated = now
+	}
+}
+
+// full reports whether the bucket has refilled, so it can be dropped and
+// recreated when needed.
+func (b *bucket) full(now time.Time) bool {
+	return now.Sub(b.updated) >= b.limit.Period
+}
+
+// check is a request to take a token from a bucket.
+type check struct {
+	key   bucketKey
+	limit Limit
+}
+
+// decision is the outcome of taking tokens, as reported to the client: the
+// limit closest to being reached, or the one that was.
+type decision struct {
+	Allowed    bool
+	Limit      Limit
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// take takes a token from each bucket checked, or from none of them when
+// one is empty. It returns false when no limit applies.
+func (l *Limiter) take(checks ...check) (decision, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	buckets := make([]*bucket, 0, len(checks))
+	allowed := true
+	for _, c := range checks {
+		if !c.limit.enabled() {
+			continue
+		}
+
+		b, ok := l.buckets[c.key]
+		if !ok || b.limit != c.limit {
+			b = &bucket{limit: c.limit, tokens: float64(c.limit.Requests), updated: now}
+			l.buckets[c.key] = b
+		}
+		b.refill(now)
+		buckets = append(buckets, b)
+		allowed = allowed && b.tokens >= 1
+	}
+	if len(buckets) == 0 {
+		return decision{}, false
+	}
+
+	var reported decision
+	for i, b := range buckets {
+		if allowed {
+			b.tokens--
+		}
+
+		d := decision{
+			Allowed:   allowed,
+			Limit:     b.limit,
+			Remaining: int(b.tokens),
+			Reset:     seconds((float64(b.limit.Requests) - b.tokens) / b.limit.rate()),
+		}
+		if !allowed && b.tokens < 1 {
+			d.RetryAfter = seconds((1 - b.tokens) / b.limit.rate())
+		}
+
+		if i == 0 || d.RetryAfter > reported.RetryAfter ||
+			(d.RetryAfter == reported.RetryAfter && d.Remaining < reported.Remaining) {
+			reported = d
+		}
+	}
+
+	return reported, true
+}
+
+// Run removes idle buckets until ctx is cancelled.
+func (l *Limiter) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.prune()
+		}
+	}
+}
+
+func (l *Limiter) prune() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// seconds rounds a number of seconds up to a whole duration in seconds.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s)) * time.Second
+}
+
+func limitFromEnv(key string, defaultValue Limit) Limit {
+	if value, err := ParseLimit(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
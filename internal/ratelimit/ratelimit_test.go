@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Limit
+		wantErr bool
+	}{
+		{"300/1m", Limit{300, time.Minute}, false},
+		{"5/10s", Limit{5, 10 * time.Second}, false},
+		{"off", Limit{}, false},
+		{"300", Limit{}, true},
+		{"0/1m", Limit{}, true},
+		{"ten/1m", Limit{}, true},
+		{"10/0s", Limit{}, true},
+		{"10/minute", Limit{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLimit(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTake(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	l := New(DefaultConfig())
+	l.now = func() time.Time { return now }
+
+	limit := Limit{3, 3 * time.Second}
+	key := bucketKey{"user", "ada", Write}
+
+	for i := 2; i >= 0; i-- {
+		d, _ := l.take(check{key, limit})
+		if !d.Allowed || d.Remaining != i {
+			t.Fatalf("request %d: %+v, want allowed with %d remaining", 3-i, d, i)
+		}
+	}
+
+	d, _ := l.take(check{key, limit})
+	if d.Allowed || d.RetryAfter != time.Second || d.Reset != 3*time.Second {
+		t.Fatalf("request over the limit: %+v, want denied, retry after 1s and reset in 3s", d)
+	}
+
+	// One token comes back per second.
+	now = now.Add(time.Second)
+	if d, _ := l.take(check{key, limit}); !d.Allowed || d.Remaining != 0 {
+		t.Fatalf("request after a second: %+v, want allowed with 0 remaining", d)
+	}
+}
+
+func TestTakeAllOrNothing(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	l := New(DefaultConfig())
+	l.now = func() time.Time { return now }
+
+	var (
+		user = check{bucketKey{"user", "ada", Read}, Limit{10, time.Minute}}
+		org  = check{bucketKey{"org", "org-1", Read}, Limit{2, time.Minute}}
+	)
+
+	l.take(user, org)
+	if d, _ := l.take(user, org); !d.Allowed || d.Limit != org.limit || d.Remaining != 0 {
+		t.Fatalf("second request: %+v, want allowed and the org limit reported", d)
+	}
+	if d, _ := l.take(user, org); d.Allowed || d.Limit != org.limit {
+		t.Fatalf("third request: %+v, want denied by the org limit", d)
+	}
+
+	// The denied request took no token from the user's bucket.
+	if got := l.buckets[user.key].tokens; got != 8 {
+		t.Errorf("user tokens = %v, want 8", got)
+	}
+}
+
+func TestTakeWithoutLimits(t *testing.T) {
+	l := New(DefaultConfig())
+	if _, ok := l.take(check{bucketKey{"ip", "192.0.2.1", ""}, Limit{}}); ok {
+		t.Error("take() with a disabled limit applied it")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	l := New(DefaultConfig())
+	l.now = func() time.Time { return now }
+
+	l.take(check{bucketKey{"ip", "192.0.2.1", ""}, Limit{5, time.Minute}})
+	l.take(check{bucketKey{"ip", "192.0.2.2", ""}, Limit{5, time.Hour}})
+
+	now = now.Add(2 * time.Minute)
+	l.prune()
+
+	if len(l.buckets) != 1 {
+		t.Errorf("%d buckets after pruning, want 1", len(l.buckets))
+	}
+}
+
+func TestByIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	config := DefaultConfig()
+	config.IP = Limit{2, time.Minute}
+	l := New(config)
+
+	r := gin.New()
+	r.Use(l.ByIP())
+	r.GET("/v1/course/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	var w *httptest.ResponseRecorder
+	for range 3 {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/course/", nil))
+	}
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "60",
+		"RateLimit-Policy":    "2;w=60",
+		"Retry-After":         "30",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestClass(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	config := DefaultConfig()
+	config.BatchRoutes = []string{"POST /v1/availability/"}
+	l := New(config)
+
+	var got Class
+	r := gin.New()
+	handler := func(c *gin.Context) { got = l.class(c) }
+	r.GET("/v1/course/:course_id/", handler)
+	r.POST("/v1/course/", handler)
+	r.POST("/v1/availability/", handler)
+
+	tests := []struct {
+		method, path string
+		want         Class
+	}{
+		{http.MethodGet, "/v1/course/c-1/", Read},
+		{http.MethodPost, "/v1/course/", Write},
+		{http.MethodPost, "/v1/availability/", Batch},
+	}
+
+	for _, tt := range tests {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+		if got != tt.want {
+			t.Errorf("%s %s is %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"fmt"
+	"net/mail"
+	"slices"
 	"time"
 )
 
@@ -128,3 +130,117 @@ func groupConsecutiveMergedChunks(chunks []TimeInterval) []TimeInterval {
 
 	return grouped
 }
+
+// classEndTime returns when a class starting at start and lasting duration
+// minutes ends.
+func classEndTime(start time.Time, duration int) time.Time {
+	return start.Add(time.Duration(duration) * time.Minute)
+}
+
+// parseClockTime parses an "HH:MM" time of day on the 15-minute grid into
+// minutes after midnight. "24:00" is accepted as the end of the day.
+func parseClockTime(value string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil || len(value) != 5 {
+		return 0, fmt.Errorf("time %q must be formatted as HH:MM", value)
+	}
+
+	total := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || total > 24*60 {
+		return 0, fmt.Errorf("time %q is not a valid time of day", value)
+	}
+	if minute%15 != 0 {
+		return 0, fmt.Errorf("time %q must be on 00, 15, 30, or 45 minutes", value)
+	}
+
+	return total, nil
+}
+
+// formatClockTime formats minutes after midnight as "HH:MM".
+func formatClockTime(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// classSlot is a class counted towards a tutor's workload.
+type classSlot struct {
+	StartTime time.Time
+	Duration  int
+}
+
+func (s classSlot) endTime() time.Time {
+	return classEndTime(s.StartTime, s.Duration)
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence of
+// each.
+func uniqueIDs(ids []string) []string {
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// intervalsCover reports whether the intervals together cover the time from
+// start to end without a gap.
+func intervalsCover(intervals []TimeInterval, start, end time.Time) bool {
+	for _, interval := range groupConsecutiveChunks(intervals) {
+		if !interval[0].After(start) && !interval[1].Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subtractIntervals returns the parts of intervals not covered by any of
+// cuts.
+func subtractIntervals(intervals, cuts []TimeInterval) []TimeInterval {
+	cuts = groupConsecutiveChunks(cuts)
+
+	remaining := []TimeInterval{}
+	for _, interval := range intervals {
+		start, end := interval[0], interval[1]
+		for _, cut := range cuts {
+			if !cut[1].After(start) || !cut[0].Before(end) {
+				continue
+			}
+			if cut[0].After(start) {
+				remaining = append(remaining, TimeInterval{start, cut[0]})
+			}
+			start = cut[1]
+			if !start.Before(end) {
+				break
+			}
+		}
+		if start.Before(end) {
+			remaining = append(remaining, TimeInterval{start, end})
+		}
+	}
+
+	return remaining
+}
+
+// intervalsSpan returns the earliest start and latest end of non-empty
+// intervals.
+func intervalsSpan(intervals []TimeInterval) (time.Time, time.Time) {
+	start, end := intervals[0][0], intervals[0][1]
+	for _, interval := range intervals[1:] {
+		if interval[0].Before(start) {
+			start = interval[0]
+		}
+		if interval[1].After(end) {
+			end = interval[1]
+		}
+	}
+
+	return start, end
+}
+
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
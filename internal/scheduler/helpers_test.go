@@ -350,3 +350,104 @@ func TestGroupConsecutiveChunks(t *testing.T) {
 		})
 	}
 }
+
+func TestClassEndTime(t *testing.T) {
+	start := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+	expected := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	if end := classEndTime(start, 90); !end.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, end)
+	}
+}
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{value: "00:00", expected: 0},
+		{value: "09:45", expected: 585},
+		{value: "24:00", expected: 1440},
+		{value: "09:10", wantErr: true},
+		{value: "24:15", wantErr: true},
+		{value: "9:00", wantErr: true},
+		{value: "noon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := parseClockTime(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %d", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+			if formatted := formatClockTime(result); formatted != tt.value {
+				t.Errorf("expected %q, got %q", tt.value, formatted)
+			}
+		})
+	}
+}
+
+func TestIntervalsCover(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 3, 4, hour, minute, 0, 0, time.UTC)
+	}
+
+	// 09:00-10:00 in chunks, then a gap until 10:30.
+	chunks := []TimeInterval{
+		{at(9, 30), at(9, 45)},
+		{at(9, 0), at(9, 15)},
+		{at(9, 15), at(9, 30)},
+		{at(9, 45), at(10, 0)},
+		{at(10, 30), at(11, 0)},
+	}
+
+	if !intervalsCover(chunks, at(9, 0), at(10, 0)) {
+		t.Error("expected unordered consecutive chunks to cover 09:00-10:00")
+	}
+	if !intervalsCover(chunks, at(9, 15), at(9, 45)) {
+		t.Error("expected a range inside the chunks to be covered")
+	}
+	if intervalsCover(chunks, at(9, 30), at(10, 45)) {
+		t.Error("expected a range across the gap not to be covered")
+	}
+	if intervalsCover(nil, at(9, 0), at(9, 15)) {
+		t.Error("expected no chunks to cover nothing")
+	}
+}
+
+func TestSubtractIntervals(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, 12, 23, hour, 0, 0, 0, time.UTC)
+	}
+
+	intervals := []TimeInterval{{at(8), at(12)}, {at(14), at(16)}}
+	cuts := []TimeInterval{{at(9), at(10)}, {at(11), at(15)}}
+
+	got := subtractIntervals(intervals, cuts)
+	want := []TimeInterval{{at(8), at(9)}, {at(10), at(11)}, {at(15), at(16)}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i][0].Equal(want[i][0]) || !got[i][1].Equal(want[i][1]) {
+			t.Errorf("interval %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := subtractIntervals(intervals, []TimeInterval{{at(0), at(23)}}); len(got) != 0 {
+		t.Errorf("expected a covering cut to remove everything, got %v", got)
+	}
+	if got := subtractIntervals(intervals, nil); len(got) != 2 {
+		t.Errorf("expected no cuts to keep both intervals, got %v", got)
+	}
+}
@@ -0,0 +1,500 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/idempotency"
+	"scheduler-api/internal/jobs"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/notifications"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Kinds of the scheduling maintenance jobs.
+const (
+	JobRollOverTrackers            = "trackers.rollover"
+	JobCloseTrackerPeriods         = "trackers.close_periods"
+	JobExpandRecurringAvailability = "availability.expand_recurring"
+	JobPurgeStaleAvailability      = "availability.purge_stale"
+	JobSendClassReminders          = "notifications.class_reminders"
+	JobProcessWaitlists            = "waitlists.process"
+	JobExpireSlotHolds             = "booking.expire_holds"
+	JobRefreshAnalytics            = "analytics.refresh"
+	JobSyncRosters                 = "roster.sync"
+	JobPurgeLiveEvents             = "live.purge_events"
+	JobPurgeIdempotencyKeys        = "idempotency.purge_keys"
+)
+
+// MaintenanceConfig controls the scheduling maintenance jobs.
+type MaintenanceConfig struct {
+	// ReminderLeadTime is how long before a class its reminder is sent.
+	ReminderLeadTime time.Duration
+	// AvailabilityHorizon is how far ahead recurring availability is expanded.
+	AvailabilityHorizon time.Duration
+	// AvailabilityRetention is how long unmatched availability is kept after
+	// it has passed.
+	AvailabilityRetention time.Duration
+	// WaitlistOfferTTL is how long a seat offered from a waitlist is held.
+	WaitlistOfferTTL time.Duration
+	// LiveEventRetention is how long live events are kept for clients that
+	// resume their stream.
+	LiveEventRetention time.Duration
+	// IdempotencyKeyRetention is how long the responses of idempotent
+	// requests are kept for retries.
+	IdempotencyKeyRetention time.Duration
+}
+
+// LoadMaintenanceConfigFromEnv reads the maintenance settings, falling back
+// to a 24 hour reminder lead time, a 14 day availability horizon, a 30 day
+// retention, 48 hour waitlist offers, and a day of live events and
+// idempotency keys.
+func LoadMaintenanceConfigFromEnv() MaintenanceConfig {
+	return MaintenanceConfig{
+		ReminderLeadTime:        background.DurationFromEnv("NOTIFICATION_REMINDER_LEAD_TIME", 24*time.Hour),
+		AvailabilityHorizon:     background.DurationFromEnv("AVAILABILITY_EXPANSION_HORIZON", 14*24*time.Hour),
+		AvailabilityRetention:   background.DurationFromEnv("AVAILABILITY_RETENTION", 30*24*time.Hour),
+		WaitlistOfferTTL:        background.DurationFromEnv("WAITLIST_OFFER_TTL", defaultWaitlistOfferTTL),
+		LiveEventRetention:      background.DurationFromEnv("LIVE_EVENT_RETENTION", 24*time.Hour),
+		IdempotencyKeyRetention: background.DurationFromEnv("IDEMPOTENCY_KEY_RETENTION", 24*time.Hour),
+	}
+}
+
+// RegisterMaintenanceJobs registers the scheduling maintenance handlers and
+// their cron schedules (in UTC) with the job runner. Every handler is
+// idempotent, since jobs may run more than once.
+func RegisterMaintenanceJobs(runner *jobs.Runner, logger *zap.Logger, pgxPool *pgxpool.Pool, config MaintenanceConfig) error {
+	m := &maintenance{logger: logger, pgxPool: pgxPool, config: config}
+
+	runner.Register(JobRollOverTrackers, m.rollOverTrackers)
+	runner.Register(JobCloseTrackerPeriods, m.closeTrackerPeriods)
+	runner.Register(JobExpandRecurringAvailability, m.expandRecurringAvailability)
+	runner.Register(JobPurgeStaleAvailability, m.purgeStaleAvailability)
+	runner.Register(JobSendClassReminders, m.sendClassReminders)
+	runner.Register(JobProcessWaitlists, m.processWaitlists)
+	runner.Register(JobExpireSlotHolds, m.expireSlotHolds)
+	runner.Register(JobRefreshAnalytics, m.refreshAnalytics)
+	runner.Register(JobSyncRosters, m.syncRosters)
+	runner.Register(JobPurgeLiveEvents, m.purgeLiveEvents)
+	runner.Register(JobPurgeIdempotencyKeys, m.purgeIdempotencyKeys)
+
+	schedules := []struct{ name, spec, kind string }{
+		{"roll-over-trackers", "5 0 * * *", JobRollOverTrackers},
+		{"close-tracker-periods", "10 * * * *", JobCloseTrackerPeriods},
+		{"expand-recurring-availability", "30 1 * * *", JobExpandRecurringAvailability},
+		{"purge-stale-availability", "0 3 * * *", JobPurgeStaleAvailability},
+		{"send-class-reminders", "*/5 * * * *", JobSendClassReminders},
+		{"process-waitlists", "*/5 * * * *", JobProcessWaitlists},
+		{"expire-slot-holds", "* * * * *", JobExpireSlotHolds},
+		{"refresh-analytics", "*/15 * * * *", JobRefreshAnalytics},
+		{"sync-rosters", "0 4 * * *", JobSyncRosters},
+		{"purge-live-events", "20 * * * *", JobPurgeLiveEvents},
+		{"purge-idempotency-keys", "40 * * * *", JobPurgeIdempotencyKeys},
+	}
+	for _, schedule := range schedules {
+		if err := runner.Cron(schedule.name, schedule.spec, schedule.kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type maintenance struct {
+	logger  *zap.Logger
+	pgxPool *pgxpool.Pool
+	config  MaintenanceConfig
+}
+
+//go:embed queries/maintenance/list_rollover_courses.sql
+var listRolloverCoursesSQL string
+
+//go:embed queries/maintenance/create_tracker_period.sql
+var createTrackerPeriodSQL string
+
+//go:embed queries/maintenance/complete_tracker_classes.sql
+var completeTrackerClassesSQL string
+
+//go:embed queries/maintenance/close_tracker_periods.sql
+var closeTrackerPeriodsSQL string
+
+//go:embed queries/maintenance/list_recurring_availability.sql
+var listRecurringAvailabilitySQL string
+
+//go:embed queries/maintenance/insert_recurring_availability_chunk.sql
+var insertRecurringAvailabilityChunkSQL string
+
+//go:embed queries/maintenance/purge_stale_availability.sql
+var purgeStaleAvailabilitySQL string
+
+type rolloverCourse struct {
+	CourseID  string
+	OrgID     string
+	StartAt   time.Time
+	EndAt     *time.Time
+	Interval  string
+	Frequency int
+}
+
+// rollOverTrackers makes sure every running course has a tracker for each
+// period expandCoursePeriods lists for it, so a blackout over the next
+// period does not leave a course without an open period ahead.
+func (m *maintenance) rollOverTrackers(ctx context.Context, job jobs.Job) error {
+	now := time.Now()
+
+	courses := []rolloverCourse{}
+	if err := pgxscan.Select(ctx, m.pgxPool, &courses, listRolloverCoursesSQL, now); err != nil {
+		return err
+	}
+
+	// Course periods are at most a month long, so this covers the current
+	// period and every one expandCoursePeriods may list after it.
+	from, to := now.AddDate(0, -1, 0), now.AddDate(0, maxSkippedCoursePeriods+2, 0)
+	blackouts := map[string][]TimeInterval{}
+	for _, course := range courses {
+		orgBlackouts, ok := blackouts[course.OrgID]
+		if !ok {
+			var err error
+			if orgBlackouts, err = listBlackoutIntervals(ctx, m.pgxPool, course.OrgID, from, to); err != nil {
+				return err
+			}
+			blackouts[course.OrgID] = orgBlackouts
+		}
+
+		periods, err := expandCoursePeriods(course.StartAt, course.EndAt, course.Interval, now, orgBlackouts)
+		if err != nil {
+			m.logger.Warn("Skipping tracker rollover", zap.String("course_id", course.CourseID), zap.Error(err))
+			continue
+		}
+
+		for _, period := range periods {
+			if _, err := m.pgxPool.Exec(ctx, createTrackerPeriodSQL, course.CourseID, period.start, period.end, course.Frequency, now, period.skipped); err != nil {
+				return fmt.Errorf("failed to create tracker for course %s: %w", course.CourseID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxSkippedCoursePeriods bounds how many blacked-out periods, such as the
+// weeks of a summer break, a course's recurrence is expanded across.
+const maxSkippedCoursePeriods = 26
+
+type coursePeriodPlan struct {
+	start, end time.Time
+	skipped    bool
+}
+
+// expandCoursePeriods lists the periods of a course to keep trackers for at
+// at: the current one, then the following ones up to and including the first
+// the blackouts do not cover completely. Covered periods are skipped, and
+// periods from the end of the course on are left out.
+func expandCoursePeriods(courseStart time.Time, courseEnd *time.Time, interval string, at time.Time, blackouts []TimeInterval) ([]coursePeriodPlan, error) {
+	start, end, err := coursePeriod(courseStart, interval, at)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := []coursePeriodPlan{}
+	for range maxSkippedCoursePeriods + 2 {
+		if courseEnd != nil && !start.Before(*courseEnd) {
+			break
+		}
+
+		skipped := intervalsCover(blackouts, start, end)
+		periods = append(periods, coursePeriodPlan{start, end, skipped})
+		if len(periods) > 1 && !skipped {
+			break
+		}
+
+		if start, end, err = coursePeriod(courseStart, interval, end); err != nil {
+			return nil, err
+		}
+	}
+
+	return periods, nil
+}
+
+// closeTrackerPeriods counts classes that have ended as completed and
+// settles the status of periods that are over.
+func (m *maintenance) closeTrackerPeriods(ctx context.Context, job jobs.Job) error {
+	now := time.Now()
+
+	tx, err := m.pgxPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	for _, query := range []string{completeTrackerClassesSQL, closeTrackerPeriodsSQL} {
+		changes := []trackerStatusChange{}
+		if err := pgxscan.Select(ctx, tx, &changes, query, now); err != nil {
+			return err
+		}
+		if err := enqueueTrackerStatusChanges(ctx, tx, changes, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+type recurringAvailabilityRule struct {
+	UserID      string
+	OrgID       string
+	Role        UserRole
+	Weekday     int
+	StartMinute int
+	EndMinute   int
+	Timezone    string
+}
+
+// expandRecurringAvailability turns recurring availability rules into
+// availability chunks up to the configured horizon, leaving out the blackout
+// periods of each organization. The payload may name a single user_id to
+// expand, e.g. after their rules changed.
+func (m *maintenance) expandRecurringAvailability(ctx context.Context, job jobs.Job) error {
+	var payload struct {
+		UserID *string `json:"user_id"`
+	}
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	var (
+		now = time.Now()
+		to  = now.Add(m.config.AvailabilityHorizon)
+	)
+
+	rules := []recurringAvailabilityRule{}
+	if err := pgxscan.Select(ctx, m.pgxPool, &rules, listRecurringAvailabilitySQL, payload.UserID); err != nil {
+		return err
+	}
+
+	blackouts := map[string][]TimeInterval{}
+	batch := &pgx.Batch{}
+	for _, rule := range rules {
+		loc, err := time.LoadLocation(rule.Timezone)
+		if err != nil {
+			m.logger.Warn("Skipping recurring availability with unknown timezone",
+				zap.String("user_id", rule.UserID), zap.String("timezone", rule.Timezone))
+			continue
+		}
+
+		orgBlackouts, ok := blackouts[rule.OrgID]
+		if !ok {
+			if orgBlackouts, err = listBlackoutIntervals(ctx, m.pgxPool, rule.OrgID, now, to); err != nil {
+				return err
+			}
+			// Widen blackouts to the 15-minute grid so what is left of the
+			// rule still splits into chunks.
+			for i, blackout := range orgBlackouts {
+				end := blackout[1].Truncate(15 * time.Minute)
+				if end.Before(blackout[1]) {
+					end = end.Add(15 * time.Minute)
+				}
+				orgBlackouts[i] = TimeInterval{blackout[0].Truncate(15 * time.Minute), end}
+			}
+			blackouts[rule.OrgID] = orgBlackouts
+		}
+
+		intervals := expandRecurringRule(time.Weekday(rule.Weekday), rule.StartMinute, rule.EndMinute, loc, now, to)
+		intervals = subtractIntervals(intervals, orgBlackouts)
+		chunks, err := convertIntervalsIntoChunks(intervals)
+		if err != nil {
+			return err
+		}
+
+		for _, chunk := range chunks {
+			batch.Queue(insertRecurringAvailabilityChunkSQL, rule.OrgID, rule.UserID, rule.Role, chunk[0], chunk[1], now)
+		}
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	// Chunks that already exist are skipped, so only inserted ones count.
+	batchResult := m.pgxPool.SendBatch(ctx, batch)
+	var written int64
+	for range batch.Len() {
+		tag, err := batchResult.Exec()
+		if err != nil {
+			_ = batchResult.Close()
+			return err
+		}
+		written += tag.RowsAffected()
+	}
+	if err := batchResult.Close(); err != nil {
+		return err
+	}
+	availabilityChunksWritten.Add(float64(written), sourceRecurring)
+
+	// Expansions for one user follow a change of their rules. The nightly
+	// expansion of everyone only extends the horizon.
+	if payload.UserID == nil {
+		return nil
+	}
+	return publishAvailabilityChanged(ctx, m.pgxPool, *payload.UserID, now)
+}
+
+// purgeStaleAvailability deletes unmatched availability that ended longer
+// ago than the retention period, in chunks to keep transactions short.
+func (m *maintenance) purgeStaleAvailability(ctx context.Context, job jobs.Job) error {
+	return background.Purge(ctx, m.pgxPool, purgeStaleAvailabilitySQL, time.Now().Add(-m.config.AvailabilityRetention))
+}
+
+// sendClassReminders queues reminders for classes starting soon.
+func (m *maintenance) sendClassReminders(ctx context.Context, job jobs.Job) error {
+	return notifications.EnqueueClassReminders(ctx, m.pgxPool, time.Now(), m.config.ReminderLeadTime)
+}
+
+// processWaitlists expires offers past their deadline and offers every open
+// seat, including seats freed by students who dropped a course, to the next
+// waiting entries.
+func (m *maintenance) processWaitlists(ctx context.Context, job jobs.Job) error {
+	now := time.Now()
+
+	if _, err := m.pgxPool.Exec(ctx, expireWaitlistOffersSQL, now); err != nil {
+		return err
+	}
+
+	targets := []waitlistTarget{}
+	if err := pgxscan.Select(ctx, m.pgxPool, &targets, queryListOpenWaitlistsSQL); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		err := pgx.BeginFunc(ctx, m.pgxPool, func(tx pgx.Tx) error {
+			return offerOpenSeats(ctx, tx, target, m.config.WaitlistOfferTTL, now)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expireSlotHolds ends holds past their deadline so their slots can be
+// booked again.
+func (m *maintenance) expireSlotHolds(ctx context.Context, job jobs.Job) error {
+	_, err := m.pgxPool.Exec(ctx, expireSlotHoldsSQL, time.Now())
+	return err
+}
+
+// refreshAnalytics brings the rollups behind the reports up to date.
+func (m *maintenance) refreshAnalytics(ctx context.Context, job jobs.Job) error {
+	return refreshAnalytics(ctx, m.pgxPool, time.Now())
+}
+
+// syncRosters syncs every active roster source, acting as the admin who
+// added it. Each sync is recorded as a run of its source, so one that fails
+// is logged and does not stop the others.
+func (m *maintenance) syncRosters(ctx context.Context, job jobs.Job) error {
+	sources := []rosterSource{}
+	if err := pgxscan.Select(ctx, m.pgxPool, &sources, queryListDueRosterSourcesSQL); err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		run, err := runRosterSync(ctx, m.pgxPool, source, *source.CreatedBy, false, m.config.WaitlistOfferTTL, time.Now())
+		if err != nil {
+			m.logger.Error("Roster sync failed", zap.String("source_id", source.SourceID), zap.String("run_id", run.RunId), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// purgeLiveEvents deletes live events older than the retention period.
+// Clients that resume from one of them get the events still kept.
+func (m *maintenance) purgeLiveEvents(ctx context.Context, job jobs.Job) error {
+	return live.Purge(ctx, m.pgxPool, time.Now().Add(-m.config.LiveEventRetention))
+}
+
+// purgeIdempotencyKeys deletes idempotency keys older than the retention
+// period. Requests retried with one of them are handled as new requests.
+func (m *maintenance) purgeIdempotencyKeys(ctx context.Context, job jobs.Job) error {
+	return idempotency.Purge(ctx, m.pgxPool, time.Now().Add(-m.config.IdempotencyKeyRetention))
+}
+
+// coursePeriod returns the tracking period of a course that contains at.
+// Periods start at the course start and repeat every interval; times before
+// the course starts fall into its first period. Intervals are accepted as
+// stored in the database ("week", "month") or as in the API ("weekly",
+// "monthly").
+func coursePeriod(courseStart time.Time, interval string, at time.Time) (time.Time, time.Time, error) {
+	var days int
+	switch interval {
+	case "week", "weekly":
+		days = 7
+	case "bi-weekly":
+		days = 14
+	case "month", "monthly":
+		months := (at.Year()-courseStart.Year())*12 + int(at.Month()-courseStart.Month())
+		if courseStart.AddDate(0, months, 0).After(at) {
+			months--
+		}
+		months = max(months, 0)
+		return courseStart.AddDate(0, months, 0), courseStart.AddDate(0, months+1, 0), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown course interval %q", interval)
+	}
+
+	n := 0
+	if at.After(courseStart) {
+		n = int(at.Sub(courseStart) / (time.Duration(days) * 24 * time.Hour))
+	}
+	start := courseStart.AddDate(0, 0, n*days)
+	if start.After(at) && n > 0 {
+		// Daylight saving changes can make a period a little shorter than
+		// the fixed duration used above.
+		n--
+		start = courseStart.AddDate(0, 0, n*days)
+	}
+	return start, courseStart.AddDate(0, 0, (n+1)*days), nil
+}
+
+// expandRecurringRule returns the intervals a weekly rule covers between
+// from and to, in the rule's location. Intervals are clipped to the window
+// and the 15-minute grid so they can be split into availability chunks.
+func expandRecurringRule(weekday time.Weekday, startMinute, endMinute int, loc *time.Location, from, to time.Time) []TimeInterval {
+	intervals := []TimeInterval{}
+
+	day := time.Date(from.In(loc).Year(), from.In(loc).Month(), from.In(loc).Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != weekday {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, startMinute, 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), 0, endMinute, 0, 0, loc)
+		if start.Before(from) {
+			start = from.Truncate(15 * time.Minute)
+			if start.Before(from) {
+				start = start.Add(15 * time.Minute)
+			}
+		}
+		if end.After(to) {
+			end = to.Truncate(15 * time.Minute)
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		intervals = append(intervals, TimeInterval{start.UTC(), end.UTC()})
+	}
+
+	return intervals
+}
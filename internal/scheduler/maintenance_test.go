@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandCoursePeriods(t *testing.T) {
+	week := func(n int) time.Time {
+		return time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n)
+	}
+	courseEnd := week(4)
+
+	tests := []struct {
+		name      string
+		courseEnd *time.Time
+		blackouts []TimeInterval
+		expected  []coursePeriodPlan
+	}{
+		{
+			name: "current and next period",
+			expected: []coursePeriodPlan{
+				{week(1), week(2), false},
+				{week(2), week(3), false},
+			},
+		},
+		{
+			name:      "partly blacked out periods are not skipped",
+			blackouts: []TimeInterval{{week(2), week(2).AddDate(0, 0, 3)}},
+			expected: []coursePeriodPlan{
+				{week(1), week(2), false},
+				{week(2), week(3), false},
+			},
+		},
+		{
+			name:      "blacked out periods are skipped up to an open one",
+			blackouts: []TimeInterval{{week(2), week(3)}, {week(3), week(4)}},
+			expected: []coursePeriodPlan{
+				{week(1), week(2), false},
+				{week(2), week(3), true},
+				{week(3), week(4), true},
+				{week(4), week(5), false},
+			},
+		},
+		{
+			name:      "blacked out current period",
+			blackouts: []TimeInterval{{week(1), week(2)}},
+			expected: []coursePeriodPlan{
+				{week(1), week(2), true},
+				{week(2), week(3), false},
+			},
+		},
+		{
+			name:      "course ends during a blackout",
+			courseEnd: &courseEnd,
+			blackouts: []TimeInterval{{week(2), week(6)}},
+			expected: []coursePeriodPlan{
+				{week(1), week(2), false},
+				{week(2), week(3), true},
+				{week(3), week(4), true},
+			},
+		},
+		{
+			name:      "expansion stops after a long blackout",
+			blackouts: []TimeInterval{{week(2), week(100)}},
+			expected: func() []coursePeriodPlan {
+				periods := []coursePeriodPlan{{week(1), week(2), false}}
+				for n := 2; n < maxSkippedCoursePeriods+3; n++ {
+					periods = append(periods, coursePeriodPlan{week(n), week(n + 1), true})
+				}
+				return periods
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := expandCoursePeriods(week(0), tt.courseEnd, "week", week(1).Add(time.Hour), tt.blackouts)
+			if err != nil {
+				t.Fatalf("expandCoursePeriods() error = %v", err)
+			}
+
+			if len(periods) != len(tt.expected) {
+				t.Fatalf("expandCoursePeriods() = %d periods, want %d", len(periods), len(tt.expected))
+			}
+			for i, period := range periods {
+				want := tt.expected[i]
+				if !period.start.Equal(want.start) || !period.end.Equal(want.end) || period.skipped != want.skipped {
+					t.Errorf("period %d = %v-%v skipped %v, want %v-%v skipped %v",
+						i, period.start, period.end, period.skipped, want.start, want.end, want.skipped)
+				}
+			}
+		})
+	}
+
+	if _, err := expandCoursePeriods(week(0), nil, "fortnight", week(1), nil); err == nil {
+		t.Error("expandCoursePeriods() accepted an unknown interval")
+	}
+}
+
+func TestCoursePeriod(t *testing.T) {
+	courseStart := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		interval      string
+		at            time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "first week",
+			interval:      "week",
+			at:            time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+			expectedStart: courseStart,
+			expectedEnd:   time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "third bi-weekly period",
+			interval:      "bi-weekly",
+			at:            time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2023, 2, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "monthly on period boundary",
+			interval:      "monthly",
+			at:            time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "before course start",
+			interval:      "week",
+			at:            time.Date(2022, 12, 25, 0, 0, 0, 0, time.UTC),
+			expectedStart: courseStart,
+			expectedEnd:   time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := coursePeriod(courseStart, tt.interval, tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !start.Equal(tt.expectedStart) || !end.Equal(tt.expectedEnd) {
+				t.Errorf("expected %v - %v, got %v - %v", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+
+	if _, _, err := coursePeriod(courseStart, "daily", courseStart); err == nil {
+		t.Error("expected error for unknown interval")
+	}
+}
+
+func TestExpandRecurringRule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Monday 2023-03-06 through Monday 2023-03-20, across the DST change.
+	from := time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 20, 15, 0, 0, 0, time.UTC)
+
+	intervals := expandRecurringRule(time.Monday, 9*60, 11*60, loc, from, to)
+	expected := []TimeInterval{
+		{time.Date(2023, 3, 6, 14, 0, 0, 0, time.UTC), time.Date(2023, 3, 6, 16, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 3, 13, 13, 0, 0, 0, time.UTC), time.Date(2023, 3, 13, 15, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 3, 20, 13, 0, 0, 0, time.UTC), time.Date(2023, 3, 20, 15, 0, 0, 0, time.UTC)},
+	}
+
+	if len(intervals) != len(expected) {
+		t.Fatalf("expected %d intervals, got %d: %v", len(expected), len(intervals), intervals)
+	}
+	for i := range expected {
+		if !intervals[i][0].Equal(expected[i][0]) || !intervals[i][1].Equal(expected[i][1]) {
+			t.Errorf("interval %d: expected %v, got %v", i, expected[i], intervals[i])
+		}
+	}
+}
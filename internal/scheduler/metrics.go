@@ -0,0 +1,18 @@
+package scheduler
+
+import "scheduler-api/internal/metrics"
+
+// Sources of created classes and written availability, as metric labels.
+const (
+	sourceAPI       = "api"
+	sourceBooking   = "booking"
+	sourceRecurring = "recurring"
+)
+
+var (
+	classesCreated = metrics.NewCounter("scheduler_classes_created_total",
+		"Classes created, by how they were created.", "source")
+
+	availabilityChunksWritten = metrics.NewCounter("scheduler_availability_chunks_written_total",
+		"Availability chunks written, by where they came from.", "source")
+)
@@ -13,6 +13,63 @@ const (
 	FirebaseAuthScopes = "FirebaseAuth.Scopes"
 )
 
+// Defines values for BlackoutPeriodSource.
+const (
+	Ics    BlackoutPeriodSource = "ics"
+	Manual BlackoutPeriodSource = "manual"
+)
+
+// Defines values for BlackoutPolicy.
+const (
+	Block BlackoutPolicy = "block"
+	Warn  BlackoutPolicy = "warn"
+)
+
+// Defines values for BookableSlotStatus.
+const (
+	Booked    BookableSlotStatus = "booked"
+	Held      BookableSlotStatus = "held"
+	Open      BookableSlotStatus = "open"
+	Withdrawn BookableSlotStatus = "withdrawn"
+)
+
+// Defines values for ClassAttendanceRole.
+const (
+	ClassAttendanceRoleStudent ClassAttendanceRole = "student"
+	ClassAttendanceRoleTeacher ClassAttendanceRole = "teacher"
+)
+
+// Defines values for ClassCancellationReason.
+const (
+	Holiday            ClassCancellationReason = "holiday"
+	Other              ClassCancellationReason = "other"
+	SchedulingConflict ClassCancellationReason = "scheduling_conflict"
+	StudentUnavailable ClassCancellationReason = "student_unavailable"
+	TutorUnavailable   ClassCancellationReason = "tutor_unavailable"
+	Weather            ClassCancellationReason = "weather"
+)
+
+// Defines values for ClassHistoryEntryAction.
+const (
+	ClassHistoryEntryActionCancelled           ClassHistoryEntryAction = "cancelled"
+	ClassHistoryEntryActionCreated             ClassHistoryEntryAction = "created"
+	ClassHistoryEntryActionParticipantsUpdated ClassHistoryEntryAction = "participants_updated"
+	ClassHistoryEntryActionRescheduled         ClassHistoryEntryAction = "rescheduled"
+	ClassHistoryEntryActionRestored            ClassHistoryEntryAction = "restored"
+)
+
+// Defines values for ClassParticipantRole.
+const (
+	ClassParticipantRoleStudent ClassParticipantRole = "student"
+	ClassParticipantRoleTeacher ClassParticipantRole = "teacher"
+)
+
+// Defines values for ClassStatus.
+const (
+	ClassStatusCancelled ClassStatus = "cancelled"
+	ClassStatusScheduled ClassStatus = "scheduled"
+)
+
 // Defines values for CourseInterval.
 const (
 	CourseIntervalBiWeekly CourseInterval = "bi-weekly"
@@ -27,12 +84,152 @@ const (
 	CourseUpdateIntervalWeekly   CourseUpdateInterval = "weekly"
 )
 
+// Defines values for EnrollmentHistoryEntryAction.
+const (
+	EnrollmentHistoryEntryActionCompleted   EnrollmentHistoryEntryAction = "completed"
+	EnrollmentHistoryEntryActionDropped     EnrollmentHistoryEntryAction = "dropped"
+	EnrollmentHistoryEntryActionEnrolled    EnrollmentHistoryEntryAction = "enrolled"
+	EnrollmentHistoryEntryActionReactivated EnrollmentHistoryEntryAction = "reactivated"
+)
+
+// Defines values for EnrollmentRole.
+const (
+	EnrollmentRoleStudent EnrollmentRole = "student"
+	EnrollmentRoleTeacher EnrollmentRole = "teacher"
+)
+
+// Defines values for EnrollmentStatus.
+const (
+	EnrollmentStatusActive    EnrollmentStatus = "active"
+	EnrollmentStatusCompleted EnrollmentStatus = "completed"
+	EnrollmentStatusDropped   EnrollmentStatus = "dropped"
+)
+
+// Defines values for ImportFile.
+const (
+	Courses     ImportFile = "courses"
+	Enrollments ImportFile = "enrollments"
+	Users       ImportFile = "users"
+)
+
+// Defines values for InvoiceKind.
+const (
+	InvoiceKindCreditNote InvoiceKind = "credit_note"
+	InvoiceKindInvoice    InvoiceKind = "invoice"
+)
+
+// Defines values for InvoiceStatus.
+const (
+	InvoiceStatusDraft  InvoiceStatus = "draft"
+	InvoiceStatusIssued InvoiceStatus = "issued"
+	InvoiceStatusPaid   InvoiceStatus = "paid"
+	InvoiceStatusVoid   InvoiceStatus = "void"
+)
+
+// Defines values for JobStatus.
+const (
+	JobStatusCancelled JobStatus = "cancelled"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+)
+
+// Defines values for NotificationChannel.
+const (
+	Email NotificationChannel = "email"
+	Sms   NotificationChannel = "sms"
+)
+
+// Defines values for NotificationStatus.
+const (
+	NotificationStatusFailed  NotificationStatus = "failed"
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+)
+
+// Defines values for RateCardClassType.
+const (
+	Group      RateCardClassType = "group"
+	Individual RateCardClassType = "individual"
+)
+
+// Defines values for ReportGrouping.
+const (
+	ReportGroupingCourse ReportGrouping = "course"
+	ReportGroupingMonth  ReportGrouping = "month"
+	ReportGroupingTutor  ReportGrouping = "tutor"
+	ReportGroupingWeek   ReportGrouping = "week"
+)
+
+// Defines values for RosterFormat.
+const (
+	Csv  RosterFormat = "csv"
+	Rest RosterFormat = "rest"
+)
+
+// Defines values for RosterRecordType.
+const (
+	RosterRecordTypeClass      RosterRecordType = "class"
+	RosterRecordTypeEnrollment RosterRecordType = "enrollment"
+	RosterRecordTypeOrg        RosterRecordType = "org"
+	RosterRecordTypeUser       RosterRecordType = "user"
+)
+
+// Defines values for RosterSyncRunStatus.
+const (
+	RosterSyncRunStatusFailed    RosterSyncRunStatus = "failed"
+	RosterSyncRunStatusSucceeded RosterSyncRunStatus = "succeeded"
+)
+
+// Defines values for SelfBookingAudience.
+const (
+	AllStudents      SelfBookingAudience = "all_students"
+	EnrolledStudents SelfBookingAudience = "enrolled_students"
+	Nobody           SelfBookingAudience = "nobody"
+)
+
+// Defines values for SlotHoldStatus.
+const (
+	SlotHoldStatusActive    SlotHoldStatus = "active"
+	SlotHoldStatusConfirmed SlotHoldStatus = "confirmed"
+	SlotHoldStatusExpired   SlotHoldStatus = "expired"
+	SlotHoldStatusReleased  SlotHoldStatus = "released"
+)
+
+// Defines values for TimeOffAffectedClassStatus.
+const (
+	NeedsSubstitute TimeOffAffectedClassStatus = "needs_substitute"
+	Reassigned      TimeOffAffectedClassStatus = "reassigned"
+)
+
+// Defines values for TimeOffStatus.
+const (
+	TimeOffStatusApproved  TimeOffStatus = "approved"
+	TimeOffStatusCancelled TimeOffStatus = "cancelled"
+	TimeOffStatusPending   TimeOffStatus = "pending"
+	TimeOffStatusRejected  TimeOffStatus = "rejected"
+)
+
+// Defines values for TimesheetEntryKind.
+const (
+	Adjustment       TimesheetEntryKind = "adjustment"
+	LateCancellation TimesheetEntryKind = "late_cancellation"
+	Taught           TimesheetEntryKind = "taught"
+)
+
+// Defines values for TimesheetStatus.
+const (
+	TimesheetStatusApproved TimesheetStatus = "approved"
+	TimesheetStatusDraft    TimesheetStatus = "draft"
+)
+
 // Defines values for TrackerStatus.
 const (
-	Fulfilled   TrackerStatus = "fulfilled"
-	Scheduled   TrackerStatus = "scheduled"
-	Skipped     TrackerStatus = "skipped"
-	Unscheduled TrackerStatus = "unscheduled"
+	TrackerStatusFulfilled   TrackerStatus = "fulfilled"
+	TrackerStatusScheduled   TrackerStatus = "scheduled"
+	TrackerStatusSkipped     TrackerStatus = "skipped"
+	TrackerStatusUnscheduled TrackerStatus = "unscheduled"
 )
 
 // Defines values for UserRole.
@@ -49,6 +246,72 @@ const (
 	UserUpdateRoleTutor   UserUpdateRole = "tutor"
 )
 
+// Defines values for WaitlistStatus.
+const (
+	WaitlistStatusAccepted WaitlistStatus = "accepted"
+	WaitlistStatusDeclined WaitlistStatus = "declined"
+	WaitlistStatusExpired  WaitlistStatus = "expired"
+	WaitlistStatusOffered  WaitlistStatus = "offered"
+	WaitlistStatusRemoved  WaitlistStatus = "removed"
+	WaitlistStatusWaiting  WaitlistStatus = "waiting"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// AtRiskStudent defines model for AtRiskStudent.
+type AtRiskStudent struct {
+	// AttendedClasses Classes of the period the student attended
+	AttendedClasses int    `json:"attended_classes"`
+	CourseId        string `json:"course_id"`
+	CourseName      string `json:"course_name"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+
+	// MissedClasses Past classes of the period the student was absent from or has no attendance for
+	MissedClasses int       `json:"missed_classes"`
+	PeriodEnd     time.Time `json:"period_end"`
+	PeriodStart   time.Time `json:"period_start"`
+
+	// RemainingClasses Scheduled classes of the period the student has yet to take
+	RemainingClasses int `json:"remaining_classes"`
+	RequiredClasses  int `json:"required_classes"`
+
+	// Shortfall Required classes the student cannot reach even attending every remaining class
+	Shortfall  int    `json:"shortfall"`
+	StudentId  string `json:"student_id"`
+	TrackingId string `json:"tracking_id"`
+}
+
+// AttendanceReportRow defines model for AttendanceReportRow.
+type AttendanceReportRow struct {
+	Absent int `json:"absent"`
+
+	// AttendanceRate Share of recorded students who attended, omitted without recorded attendance
+	AttendanceRate   *float64 `json:"attendance_rate,omitempty"`
+	Attended         int      `json:"attended"`
+	CancelledClasses int      `json:"cancelled_classes"`
+
+	// Classes Scheduled classes in the group
+	Classes int `json:"classes"`
+
+	// Key Course or tutor ID, or the first day of the week or month. Omitted for classes without a course or teacher
+	Key *string `json:"key,omitempty"`
+
+	// Label Course name, tutor name, or the first day of the week or month
+	Label string `json:"label"`
+
+	// Students Students taking part in the scheduled classes, counted once per class
+	Students int `json:"students"`
+
+	// Unrecorded Students without recorded attendance, including classes yet to happen
+	Unrecorded int `json:"unrecorded"`
+}
+
 // Availability defines model for Availability.
 type Availability struct {
 	AvailableTimeIntervals []TimeInterval `json:"available_time_intervals"`
@@ -61,6 +324,30 @@ type AvailabilityChanges struct {
 	Remove *[]TimeInterval `json:"remove,omitempty"`
 }
 
+// AvailabilityDemandHour defines model for AvailabilityDemandHour.
+type AvailabilityDemandHour struct {
+	// Hour Hour of the day, 0 to 23
+	Hour int `json:"hour"`
+
+	// StudentMinutes Minutes of student availability (demand)
+	StudentMinutes int `json:"student_minutes"`
+
+	// Students Students offering availability in the hour
+	Students int `json:"students"`
+
+	// SupplyRatio Tutor minutes per student minute, omitted without student availability
+	SupplyRatio *float64 `json:"supply_ratio,omitempty"`
+
+	// TutorMinutes Minutes of tutor availability (supply)
+	TutorMinutes int `json:"tutor_minutes"`
+
+	// Tutors Tutors offering availability in the hour
+	Tutors int `json:"tutors"`
+
+	// Weekday Day of the week, 0 = Sunday
+	Weekday int `json:"weekday"`
+}
+
 // AvailabilityUpdate defines model for AvailabilityUpdate.
 type AvailabilityUpdate struct {
 	Changes AvailabilityChanges `json:"changes"`
@@ -72,15 +359,189 @@ type BatchAvailabilityRequest struct {
 	UserIds []string `json:"user_ids"`
 }
 
+// BlackoutImport defines model for BlackoutImport.
+type BlackoutImport struct {
+	// Calendar Contents of an iCalendar (.ics) file
+	Calendar string `json:"calendar"`
+
+	// Timezone Timezone of all-day events and times without a TZID
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// BlackoutImportResult defines model for BlackoutImportResult.
+type BlackoutImportResult struct {
+	Blackouts []BlackoutPeriod `json:"blackouts"`
+
+	// Imported Events added or updated
+	Imported int `json:"imported"`
+
+	// Skipped Recurring or incomplete events that were not imported
+	Skipped int `json:"skipped"`
+}
+
+// BlackoutPeriod defines model for BlackoutPeriod.
+type BlackoutPeriod struct {
+	BlackoutId *string               `json:"blackout_id,omitempty"`
+	CreatedAt  *time.Time            `json:"created_at,omitempty"`
+	EndTime    time.Time             `json:"end_time"`
+	Name       string                `json:"name"`
+	Source     *BlackoutPeriodSource `json:"source,omitempty"`
+	StartTime  time.Time             `json:"start_time"`
+}
+
+// BlackoutPeriodSource defines model for BlackoutPeriod.Source.
+type BlackoutPeriodSource string
+
+// BlackoutPolicy What happens when a class or availability falls inside a blackout
+// period: warn lets it through with a Warning header, block rejects it.
+type BlackoutPolicy string
+
+// BlackoutPolicySettings defines model for BlackoutPolicySettings.
+type BlackoutPolicySettings struct {
+	Policy BlackoutPolicy `json:"policy"`
+}
+
+// BookableSlot defines model for BookableSlot.
+type BookableSlot struct {
+	// ClassId Class the slot was booked into
+	ClassId *string `json:"class_id,omitempty"`
+
+	// CourseId Course the slot is for. Slots without a course are general office hours.
+	CourseId *string `json:"course_id,omitempty"`
+
+	// Duration Length of the slot in minutes
+	Duration  int                 `json:"duration"`
+	SlotId    *string             `json:"slot_id,omitempty"`
+	StartTime time.Time           `json:"start_time"`
+	Status    *BookableSlotStatus `json:"status,omitempty"`
+	TutorId   *string             `json:"tutor_id,omitempty"`
+}
+
+// BookableSlotBatch defines model for BookableSlotBatch.
+type BookableSlotBatch struct {
+	Slots []BookableSlot `json:"slots"`
+}
+
+// BookableSlotStatus defines model for BookableSlotStatus.
+type BookableSlotStatus string
+
 // Class defines model for Class.
 type Class struct {
-	CourseId *string `json:"course_id,omitempty"`
+	CancelReason *ClassCancellationReason `json:"cancel_reason,omitempty"`
+	ClassId      *string                  `json:"class_id,omitempty"`
+	CourseId     *string                  `json:"course_id,omitempty"`
+	CourseName   *string                  `json:"course_name,omitempty"`
 
 	// Duration Duration in minutes
-	Duration  int       `json:"duration"`
+	Duration int `json:"duration"`
+
+	// MaxStudents Maximum number of students. Students added past the limit join the waitlist. Unlimited when omitted.
+	MaxStudents  *int                `json:"max_students,omitempty"`
+	Participants *[]ClassParticipant `json:"participants,omitempty"`
+
+	// Resources Rooms and equipment reserved for the class
+	Resources *[]string    `json:"resources,omitempty"`
+	StartTime time.Time    `json:"start_time"`
+	Status    *ClassStatus `json:"status,omitempty"`
+	Students  []string     `json:"students"`
+	Teachers  []string     `json:"teachers"`
+}
+
+// ClassStatus defines model for Class.Status.
+type ClassStatus string
+
+// ClassAttendance defines model for ClassAttendance.
+type ClassAttendance struct {
+	// Attended Whether the participant attended; absent until recorded
+	Attended   *bool               `json:"attended,omitempty"`
+	ClassId    string              `json:"class_id"`
+	Notes      *string             `json:"notes,omitempty"`
+	RecordedAt *time.Time          `json:"recorded_at,omitempty"`
+	Role       ClassAttendanceRole `json:"role"`
+	UserId     string              `json:"user_id"`
+}
+
+// ClassAttendanceRole defines model for ClassAttendance.Role.
+type ClassAttendanceRole string
+
+// ClassAttendanceRecord defines model for ClassAttendanceRecord.
+type ClassAttendanceRecord struct {
+	Attended bool    `json:"attended"`
+	Notes    *string `json:"notes,omitempty"`
+	UserId   string  `json:"user_id"`
+}
+
+// ClassAttendanceUpdate defines model for ClassAttendanceUpdate.
+type ClassAttendanceUpdate struct {
+	Records []ClassAttendanceRecord `json:"records"`
+}
+
+// ClassCancellation defines model for ClassCancellation.
+type ClassCancellation struct {
+	Note       *string                 `json:"note,omitempty"`
+	ReasonCode ClassCancellationReason `json:"reason_code"`
+}
+
+// ClassCancellationReason defines model for ClassCancellationReason.
+type ClassCancellationReason string
+
+// ClassHistoryEntry defines model for ClassHistoryEntry.
+type ClassHistoryEntry struct {
+	Action ClassHistoryEntryAction `json:"action"`
+
+	// ActorId User ID of the admin who made the change
+	ActorId           *string                  `json:"actor_id,omitempty"`
+	ClassId           string                   `json:"class_id"`
+	CreatedAt         time.Time                `json:"created_at"`
+	Duration          int                      `json:"duration"`
+	HistoryId         string                   `json:"history_id"`
+	Note              *string                  `json:"note,omitempty"`
+	PreviousDuration  *int                     `json:"previous_duration,omitempty"`
+	PreviousStartTime *time.Time               `json:"previous_start_time,omitempty"`
+	ReasonCode        *ClassCancellationReason `json:"reason_code,omitempty"`
+	StartTime         time.Time                `json:"start_time"`
+}
+
+// ClassHistoryEntryAction defines model for ClassHistoryEntry.Action.
+type ClassHistoryEntryAction string
+
+// ClassParticipant defines model for ClassParticipant.
+type ClassParticipant struct {
+	FirstName string               `json:"first_name"`
+	LastName  string               `json:"last_name"`
+	Role      ClassParticipantRole `json:"role"`
+	UserId    string               `json:"user_id"`
+}
+
+// ClassParticipantRole defines model for ClassParticipant.Role.
+type ClassParticipantRole string
+
+// ClassParticipantUpdate defines model for ClassParticipantUpdate.
+type ClassParticipantUpdate struct {
+	Students *CourseParticipantChanges `json:"students,omitempty"`
+	Teachers *CourseParticipantChanges `json:"teachers,omitempty"`
+}
+
+// ClassReschedule defines model for ClassReschedule.
+type ClassReschedule struct {
+	// Duration New duration in minutes. Keeps the current duration when omitted.
+	Duration  *int      `json:"duration,omitempty"`
+	Note      *string   `json:"note,omitempty"`
 	StartTime time.Time `json:"start_time"`
-	Students  []string  `json:"students"`
-	Teachers  []string  `json:"teachers"`
+}
+
+// ClassResourceChanges defines model for ClassResourceChanges.
+type ClassResourceChanges struct {
+	// Add Resources to reserve for the class
+	Add *[]string `json:"add,omitempty"`
+
+	// Remove Resources to release
+	Remove *[]string `json:"remove,omitempty"`
+}
+
+// ClassRestore defines model for ClassRestore.
+type ClassRestore struct {
+	Note *string `json:"note,omitempty"`
 }
 
 // Course defines model for Course.
@@ -91,9 +552,12 @@ type Course struct {
 	EndAt             time.Time      `json:"end_at"`
 	Frequency         int            `json:"frequency"`
 	Interval          CourseInterval `json:"interval"`
-	StartAt           time.Time      `json:"start_at"`
-	Students          []string       `json:"students"`
-	Tutors            []string       `json:"tutors"`
+
+	// MaxStudents Maximum number of active students. Students enrolled past the limit join the waitlist. Unlimited when omitted.
+	MaxStudents *int      `json:"max_students,omitempty"`
+	StartAt     time.Time `json:"start_at"`
+	Students    []string  `json:"students"`
+	Tutors      []string  `json:"tutors"`
 }
 
 // CourseInterval defines model for Course.Interval.
@@ -112,27 +576,737 @@ type CourseTrackersRequest struct {
 
 // CourseUpdate defines model for CourseUpdate.
 type CourseUpdate struct {
-	CourseName *string                   `json:"course_name,omitempty"`
-	EndAt      *time.Time                `json:"end_at,omitempty"`
-	Frequency  *int                      `json:"frequency,omitempty"`
-	Interval   *CourseUpdateInterval     `json:"interval,omitempty"`
-	StartAt    *time.Time                `json:"start_at,omitempty"`
-	Students   *CourseParticipantChanges `json:"students,omitempty"`
-	Teachers   *CourseParticipantChanges `json:"teachers,omitempty"`
+	CourseDescription *string               `json:"course_description,omitempty"`
+	CourseName        *string               `json:"course_name,omitempty"`
+	EndAt             *time.Time            `json:"end_at,omitempty"`
+	Frequency         *int                  `json:"frequency,omitempty"`
+	Interval          *CourseUpdateInterval `json:"interval,omitempty"`
+
+	// MaxStudents New student limit, 0 removes the limit. Raising it offers the new seats to the waitlist.
+	MaxStudents *int       `json:"max_students,omitempty"`
+	StartAt     *time.Time `json:"start_at,omitempty"`
+
+	// Students Students to enroll or drop. Students past the capacity join the waitlist.
+	Students *CourseParticipantChanges `json:"students,omitempty"`
+
+	// Teachers Tutors to enroll as teachers or drop
+	Teachers *CourseParticipantChanges `json:"teachers,omitempty"`
 }
 
 // CourseUpdateInterval defines model for CourseUpdate.Interval.
 type CourseUpdateInterval string
 
+// CreditNoteCreate defines model for CreditNoteCreate.
+type CreditNoteCreate struct {
+	// LineIds Invoice lines to credit. Every line not yet credited when omitted.
+	LineIds *[]string `json:"line_ids,omitempty"`
+	Reason  string    `json:"reason"`
+}
+
+// Enrollment defines model for Enrollment.
+type Enrollment struct {
+	CourseId        string           `json:"course_id"`
+	DropReason      *string          `json:"drop_reason,omitempty"`
+	EnrolledAt      *time.Time       `json:"enrolled_at,omitempty"`
+	Role            EnrollmentRole   `json:"role"`
+	Status          EnrollmentStatus `json:"status"`
+	StatusChangedAt *time.Time       `json:"status_changed_at,omitempty"`
+
+	// StatusChangedBy User who last changed the status
+	StatusChangedBy *string `json:"status_changed_by,omitempty"`
+	UserId          string  `json:"user_id"`
+}
+
+// EnrollmentRole defines model for Enrollment.Role.
+type EnrollmentRole string
+
+// EnrollmentBatch defines model for EnrollmentBatch.
+type EnrollmentBatch struct {
+	UserIds []string `json:"user_ids"`
+}
+
+// EnrollmentCreate defines model for EnrollmentCreate.
+type EnrollmentCreate struct {
+	// UserId Students are enrolled as students, tutors as teachers
+	UserId string `json:"user_id"`
+}
+
+// EnrollmentDrop defines model for EnrollmentDrop.
+type EnrollmentDrop struct {
+	Reason string `json:"reason"`
+}
+
+// EnrollmentHistoryEntry defines model for EnrollmentHistoryEntry.
+type EnrollmentHistoryEntry struct {
+	Action         EnrollmentHistoryEntryAction `json:"action"`
+	ActorId        *string                      `json:"actor_id,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	PreviousStatus *EnrollmentStatus            `json:"previous_status,omitempty"`
+	Reason         *string                      `json:"reason,omitempty"`
+	Status         EnrollmentStatus             `json:"status"`
+}
+
+// EnrollmentHistoryEntryAction defines model for EnrollmentHistoryEntry.Action.
+type EnrollmentHistoryEntryAction string
+
+// EnrollmentResult defines model for EnrollmentResult.
+type EnrollmentResult struct {
+	// Enrolled Enrollments created or re-activated
+	Enrolled []Enrollment `json:"enrolled"`
+
+	// Waitlisted Students who joined the waitlist because the course is full
+	Waitlisted []string `json:"waitlisted"`
+}
+
+// EnrollmentStatus defines model for EnrollmentStatus.
+type EnrollmentStatus string
+
+// Family defines model for Family.
+type Family struct {
+	BillingEmail *openapi_types.Email `json:"billing_email,omitempty"`
+	CreatedAt    *time.Time           `json:"created_at,omitempty"`
+	FamilyId     *string              `json:"family_id,omitempty"`
+	Name         string               `json:"name"`
+
+	// StudentIds Students billed on the family's invoices
+	StudentIds []string `json:"student_ids"`
+}
+
+// ImportFile defines model for ImportFile.
+type ImportFile string
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	AccountsCreated *int `json:"accounts_created,omitempty"`
+
+	// Committed Whether the import was written. Imports with errors write nothing.
+	Committed bool `json:"committed"`
+
+	// Courses Courses imported, or that would be imported
+	Courses int  `json:"courses"`
+	DryRun  bool `json:"dry_run"`
+
+	// Enrollments Enrollments imported, or that would be imported, including waitlisted students
+	Enrollments int              `json:"enrollments"`
+	Errors      []ImportRowError `json:"errors"`
+
+	// InvitationsSent Invitations queued for delivery
+	InvitationsSent *int `json:"invitations_sent,omitempty"`
+
+	// Users Users imported, or that would be imported
+	Users int `json:"users"`
+
+	// Waitlisted Students who joined a course waitlist because the course was full
+	Waitlisted *int `json:"waitlisted,omitempty"`
+}
+
+// ImportRequest CSV files with a header row. users: first_name, last_name, email,
+// role and optionally phone_number. courses: course_name and optionally
+// course_id, course_description, start_at, end_at, interval (week,
+// bi-weekly or month), frequency and max_students. enrollments: email
+// and course_id, naming users and courses of the organization or of
+// this import.
+type ImportRequest struct {
+	CoursesCsv *string `json:"courses_csv,omitempty"`
+
+	// CreateAccounts Create a sign-in account for each imported user once the import is written. Existing accounts with the same email are linked without changing their claims.
+	CreateAccounts *bool `json:"create_accounts,omitempty"`
+
+	// DryRun Only validate the files and report what would be imported
+	DryRun         *bool   `json:"dry_run,omitempty"`
+	EnrollmentsCsv *string `json:"enrollments_csv,omitempty"`
+
+	// SendInvitations Email each imported user a link to set their password. Needs create_accounts.
+	SendInvitations *bool   `json:"send_invitations,omitempty"`
+	UsersCsv        *string `json:"users_csv,omitempty"`
+}
+
+// ImportRowError defines model for ImportRowError.
+type ImportRowError struct {
+	Column  *string    `json:"column,omitempty"`
+	File    ImportFile `json:"file"`
+	Message string     `json:"message"`
+
+	// Row Line of the file, the header being line 1
+	Row int `json:"row"`
+}
+
+// Invoice defines model for Invoice.
+type Invoice struct {
+	// BillTo Name of the student or family billed
+	BillTo    string     `json:"bill_to"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// CreditedInvoiceId Invoice a credit note credits
+	CreditedInvoiceId *string       `json:"credited_invoice_id,omitempty"`
+	Currency          string        `json:"currency"`
+	FamilyId          *string       `json:"family_id,omitempty"`
+	InvoiceId         string        `json:"invoice_id"`
+	IssuedAt          *time.Time    `json:"issued_at,omitempty"`
+	Kind              InvoiceKind   `json:"kind"`
+	Lines             []InvoiceLine `json:"lines"`
+
+	// Number Given out when the invoice is issued
+	Number      *string    `json:"number,omitempty"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	PeriodEnd   time.Time  `json:"period_end"`
+	PeriodStart time.Time  `json:"period_start"`
+
+	// Reason Why a credit note was raised
+	Reason     *string       `json:"reason,omitempty"`
+	Status     InvoiceStatus `json:"status"`
+	StudentId  *string       `json:"student_id,omitempty"`
+	TotalCents int           `json:"total_cents"`
+	VoidedAt   *time.Time    `json:"voided_at,omitempty"`
+}
+
+// InvoiceKind defines model for Invoice.Kind.
+type InvoiceKind string
+
+// InvoiceCreate Bills either one student or every student of a family
+type InvoiceCreate struct {
+	FamilyId *string `json:"family_id,omitempty"`
+
+	// PeriodEnd End of the billing period, exclusive
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodStart time.Time `json:"period_start"`
+	StudentId   *string   `json:"student_id,omitempty"`
+}
+
+// InvoiceLine defines model for InvoiceLine.
+type InvoiceLine struct {
+	// AmountCents Negative on credit notes
+	AmountCents int     `json:"amount_cents"`
+	ClassId     *string `json:"class_id,omitempty"`
+
+	// CreditedLineId Invoice line a credit note line credits
+	CreditedLineId *string   `json:"credited_line_id,omitempty"`
+	Description    string    `json:"description"`
+	Duration       int       `json:"duration"`
+	LineId         string    `json:"line_id"`
+	RateCardId     *string   `json:"rate_card_id,omitempty"`
+	StartTime      time.Time `json:"start_time"`
+	StudentId      *string   `json:"student_id,omitempty"`
+	StudentName    *string   `json:"student_name,omitempty"`
+}
+
+// InvoiceStatus defines model for InvoiceStatus.
+type InvoiceStatus string
+
+// Job defines model for Job.
+type Job struct {
+	Attempts   int        `json:"attempts"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	JobId      string     `json:"job_id"`
+	Kind       string     `json:"kind"`
+	LastError  *string    `json:"last_error,omitempty"`
+	LockedBy   *string    `json:"locked_by,omitempty"`
+
+	// LockedUntil Lease of the replica running the job
+	LockedUntil *time.Time             `json:"locked_until,omitempty"`
+	MaxAttempts int                    `json:"max_attempts"`
+	Payload     map[string]interface{} `json:"payload"`
+
+	// RunAt When the job is due to run next
+	RunAt     time.Time  `json:"run_at"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	Status    JobStatus  `json:"status"`
+}
+
+// JobSchedule defines model for JobSchedule.
+type JobSchedule struct {
+	// Cron Cron expression, evaluated in UTC
+	Cron      string     `json:"cron"`
+	Kind      string     `json:"kind"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	Name      string     `json:"name"`
+	NextRunAt time.Time  `json:"next_run_at"`
+}
+
+// JobStatus defines model for JobStatus.
+type JobStatus string
+
+// LiveEvent Data of an event in the live event stream
+type LiveEvent struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data The class (class_id, course_id, start_time, duration, status) for
+	// class events, the user_id for availability.changed, and the
+	// class_id and records for attendance.recorded
+	Data map[string]interface{} `json:"data"`
+
+	// Id Increasing ID to resume the stream from
+	Id    int64  `json:"id"`
+	OrgId string `json:"org_id"`
+	Type  string `json:"type"`
+}
+
+// LtiPlatform defines model for LtiPlatform.
+type LtiPlatform struct {
+	AuthLoginUrl  string    `json:"auth_login_url"`
+	ClientId      string    `json:"client_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	DeploymentIds []string  `json:"deployment_ids"`
+	Issuer        string    `json:"issuer"`
+	JwksUrl       string    `json:"jwks_url"`
+	Name          string    `json:"name"`
+	PlatformId    string    `json:"platform_id"`
+
+	// ToolLaunchUrl Redirect URI and target link URI to configure in the platform
+	ToolLaunchUrl string `json:"tool_launch_url"`
+
+	// ToolLoginUrl OIDC login initiation URL to configure in the platform
+	ToolLoginUrl string     `json:"tool_login_url"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// LtiPlatformCreate defines model for LtiPlatformCreate.
+type LtiPlatformCreate struct {
+	// AuthLoginUrl The platform's OIDC authorization endpoint
+	AuthLoginUrl string `json:"auth_login_url"`
+
+	// ClientId Client ID the platform issued for bookSmart
+	ClientId      string   `json:"client_id"`
+	DeploymentIds []string `json:"deployment_ids"`
+
+	// Issuer Issuer of the platform's id_tokens, e.g. https://canvas.instructure.com
+	Issuer string `json:"issuer"`
+
+	// JwksUrl URL of the keys the platform signs id_tokens with
+	JwksUrl string `json:"jwks_url"`
+	Name    string `json:"name"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	// Attempts Number of delivery attempts so far
+	Attempts  int                 `json:"attempts"`
+	Channel   NotificationChannel `json:"channel"`
+	ClassId   *string             `json:"class_id,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+
+	// Event What the notification is about, e.g. class.rescheduled or class.reminder
+	Event string `json:"event"`
+
+	// LastError Error from the most recent failed delivery attempt
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptAt When delivery will next be attempted while the notification is pending
+	NextAttemptAt  time.Time          `json:"next_attempt_at"`
+	NotificationId string             `json:"notification_id"`
+	SentAt         *time.Time         `json:"sent_at,omitempty"`
+	Status         NotificationStatus `json:"status"`
+	UserId         string             `json:"user_id"`
+}
+
+// NotificationChannel defines model for NotificationChannel.
+type NotificationChannel string
+
+// NotificationPreferences defines model for NotificationPreferences.
+type NotificationPreferences struct {
+	// Email Receive notifications by email. Enabled by default.
+	Email bool `json:"email"`
+
+	// Sms Receive notifications by SMS. Disabled by default.
+	Sms bool `json:"sms"`
+}
+
+// NotificationPreferencesUpdate defines model for NotificationPreferencesUpdate.
+type NotificationPreferencesUpdate struct {
+	Email *bool `json:"email,omitempty"`
+	Sms   *bool `json:"sms,omitempty"`
+}
+
+// NotificationStatus defines model for NotificationStatus.
+type NotificationStatus string
+
 // Organization defines model for Organization.
 type Organization struct {
 	Name           string `json:"name"`
 	OrganizationId string `json:"organization_id"`
 }
 
+// PayRate Hourly pay of tutors. A rate naming both the tutor and the course
+// applies before one naming the tutor, then the course, then a rate
+// for the whole organization.
+type PayRate struct {
+	CourseId    *string    `json:"course_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	Currency    *string    `json:"currency,omitempty"`
+	HourlyCents int        `json:"hourly_cents"`
+	PayRateId   *string    `json:"pay_rate_id,omitempty"`
+	TutorId     *string    `json:"tutor_id,omitempty"`
+}
+
+// PayrollPolicy defines model for PayrollPolicy.
+type PayrollPolicy struct {
+	// LateCancellationHours Classes cancelled less than this many hours before they start
+	// are still paid, unless the tutor was unavailable
+	LateCancellationHours int `json:"late_cancellation_hours"`
+
+	// LateCancellationPayPercent Share of the class's pay a late cancellation earns
+	LateCancellationPayPercent int `json:"late_cancellation_pay_percent"`
+}
+
+// RateCard Price of one attended class. Criteria that are left out match any
+// class; when several cards match, the one matching the most criteria
+// wins.
+type RateCard struct {
+	// AmountCents Price per student per attended class
+	AmountCents int `json:"amount_cents"`
+
+	// ClassType Group classes have more than one student
+	ClassType *RateCardClassType `json:"class_type,omitempty"`
+	CourseId  *string            `json:"course_id,omitempty"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+	Currency  *string            `json:"currency,omitempty"`
+
+	// Duration Class length in minutes
+	Duration   *int    `json:"duration,omitempty"`
+	Name       string  `json:"name"`
+	RateCardId *string `json:"rate_card_id,omitempty"`
+	TutorId    *string `json:"tutor_id,omitempty"`
+}
+
+// RateCardClassType defines model for RateCard.ClassType.
+type RateCardClassType string
+
+// RecurringAvailability defines model for RecurringAvailability.
+type RecurringAvailability struct {
+	Rules []RecurringAvailabilityRule `json:"rules"`
+
+	// Timezone IANA time zone the rules are written in, e.g. America/Los_Angeles
+	Timezone string  `json:"timezone"`
+	UserId   *string `json:"user_id,omitempty"`
+}
+
+// RecurringAvailabilityRule defines model for RecurringAvailabilityRule.
+type RecurringAvailabilityRule struct {
+	// EndTime Local end time as HH:MM, on a 15 minute boundary; 24:00 for midnight
+	EndTime string `json:"end_time"`
+
+	// StartTime Local start time as HH:MM, on a 15 minute boundary
+	StartTime string `json:"start_time"`
+
+	// Weekday Day of the week, 0 = Sunday
+	Weekday int `json:"weekday"`
+}
+
+// ReportGrouping Weeks start on Monday
+type ReportGrouping string
+
+// Resource defines model for Resource.
+type Resource struct {
+	Active *bool `json:"active,omitempty"`
+
+	// Capacity How many people fit. Unlimited when omitted.
+	Capacity *int   `json:"capacity,omitempty"`
+	Name     string `json:"name"`
+
+	// OpeningHours When the resource can be booked. Always bookable when empty.
+	OpeningHours *[]ResourceOpeningHours `json:"opening_hours,omitempty"`
+	ResourceId   *string                 `json:"resource_id,omitempty"`
+
+	// Timezone IANA time zone of the opening hours
+	Timezone *string `json:"timezone,omitempty"`
+
+	// Type Kind of resource, e.g. room, projector or piano
+	Type string `json:"type"`
+}
+
+// ResourceOpeningHours defines model for ResourceOpeningHours.
+type ResourceOpeningHours struct {
+	// CloseTime Closing time in the resource timezone, up to 24:00
+	CloseTime string `json:"close_time"`
+
+	// OpenTime Opening time in the resource timezone, HH:MM on a 15 minute grid
+	OpenTime string `json:"open_time"`
+
+	// Weekday Day of the week, 0 = Sunday
+	Weekday int `json:"weekday"`
+}
+
+// ResourceReservation defines model for ResourceReservation.
+type ResourceReservation struct {
+	ClassId    string    `json:"class_id"`
+	CourseName *string   `json:"course_name,omitempty"`
+	EndTime    time.Time `json:"end_time"`
+	ResourceId string    `json:"resource_id"`
+	StartTime  time.Time `json:"start_time"`
+}
+
+// RosterFormat csv reads a zipped OneRoster CSV bundle from the URL; rest reads the
+// OneRoster REST API at the URL, e.g. https://example.com/ims/oneroster/v1p1
+type RosterFormat string
+
+// RosterRecordType defines model for RosterRecordType.
+type RosterRecordType string
+
+// RosterSource defines model for RosterSource.
+type RosterSource struct {
+	// Active Inactive sources are not synced nightly
+	Active    bool         `json:"active"`
+	ClientId  *string      `json:"client_id,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	Format    RosterFormat `json:"format"`
+
+	// HasClientSecret Whether a client secret is stored. The secret itself is never returned.
+	HasClientSecret bool       `json:"has_client_secret"`
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty"`
+	Name            string     `json:"name"`
+	SourceId        string     `json:"source_id"`
+
+	// TokenUrl OAuth 2 token URL for the client credentials grant
+	TokenUrl  *string   `json:"token_url,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Url       string    `json:"url"`
+}
+
+// RosterSourceCreate defines model for RosterSourceCreate.
+type RosterSourceCreate struct {
+	ClientId     *string      `json:"client_id,omitempty"`
+	ClientSecret *string      `json:"client_secret,omitempty"`
+	Format       RosterFormat `json:"format"`
+	Name         string       `json:"name"`
+	TokenUrl     *string      `json:"token_url,omitempty"`
+	Url          string       `json:"url"`
+}
+
+// RosterSyncCounts defines model for RosterSyncCounts.
+type RosterSyncCounts struct {
+	// Created Records synced for the first time
+	Created int `json:"created"`
+
+	// Deactivated Records that were removed from the roster or disappeared from a complete file
+	Deactivated int `json:"deactivated"`
+
+	// Skipped Records that are not synced, such as guardians, or that have an issue
+	Skipped   int `json:"skipped"`
+	Unchanged int `json:"unchanged"`
+
+	// Updated Records whose roster data changed, or that returned after being removed
+	Updated int `json:"updated"`
+}
+
+// RosterSyncIssue defines model for RosterSyncIssue.
+type RosterSyncIssue struct {
+	Message    string           `json:"message"`
+	RecordType RosterRecordType `json:"record_type"`
+	SourcedId  string           `json:"sourced_id"`
+}
+
+// RosterSyncReport defines model for RosterSyncReport.
+type RosterSyncReport struct {
+	Classes     RosterSyncCounts  `json:"classes"`
+	Enrollments RosterSyncCounts  `json:"enrollments"`
+	Issues      []RosterSyncIssue `json:"issues"`
+	Orgs        RosterSyncCounts  `json:"orgs"`
+	Users       RosterSyncCounts  `json:"users"`
+
+	// Waitlisted Students who joined a course waitlist because the course was full
+	Waitlisted int `json:"waitlisted"`
+}
+
+// RosterSyncRun defines model for RosterSyncRun.
+type RosterSyncRun struct {
+	DryRun     bool                `json:"dry_run"`
+	Error      *string             `json:"error,omitempty"`
+	FinishedAt time.Time           `json:"finished_at"`
+	Report     *RosterSyncReport   `json:"report,omitempty"`
+	RunId      string              `json:"run_id"`
+	SourceId   string              `json:"source_id"`
+	StartedAt  time.Time           `json:"started_at"`
+	Status     RosterSyncRunStatus `json:"status"`
+}
+
+// RosterSyncRunStatus defines model for RosterSyncRun.Status.
+type RosterSyncRunStatus string
+
+// ScimToken defines model for ScimToken.
+type ScimToken struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	Description *string    `json:"description,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+
+	// Token Bearer token for the identity provider. Only returned when the token is created.
+	Token   *string `json:"token,omitempty"`
+	TokenId string  `json:"token_id"`
+}
+
+// ScimTokenCreate defines model for ScimTokenCreate.
+type ScimTokenCreate struct {
+	// Description What the token is for, e.g. the identity provider it was given to
+	Description *string `json:"description,omitempty"`
+}
+
+// SelfBookingAudience Who may self-book. enrolled_students are students of the slot's
+// course, or of any course the tutor teaches for slots without one.
+type SelfBookingAudience string
+
+// SelfBookingPolicy defines model for SelfBookingPolicy.
+type SelfBookingPolicy struct {
+	Audience SelfBookingAudience `json:"audience"`
+
+	// CourseId Course the policy applies to, every course when omitted
+	CourseId *string `json:"course_id,omitempty"`
+	PolicyId *string `json:"policy_id,omitempty"`
+
+	// TutorId Tutor the policy applies to, every tutor when omitted
+	TutorId *string `json:"tutor_id,omitempty"`
+}
+
+// SelfBookingPolicySet defines model for SelfBookingPolicySet.
+type SelfBookingPolicySet struct {
+	Policies []SelfBookingPolicy `json:"policies"`
+}
+
+// SlotHold defines model for SlotHold.
+type SlotHold struct {
+	// ClassId Class created when the hold was confirmed
+	ClassId *string `json:"class_id,omitempty"`
+
+	// ExpiresAt When the hold lapses unless it is confirmed
+	ExpiresAt time.Time      `json:"expires_at"`
+	HoldId    string         `json:"hold_id"`
+	SlotId    string         `json:"slot_id"`
+	Status    SlotHoldStatus `json:"status"`
+	StudentId string         `json:"student_id"`
+}
+
+// SlotHoldStatus defines model for SlotHoldStatus.
+type SlotHoldStatus string
+
+// SubstituteAssignment defines model for SubstituteAssignment.
+type SubstituteAssignment struct {
+	Note *string `json:"note,omitempty"`
+
+	// SubstituteId Tutor taking over the class
+	SubstituteId string `json:"substitute_id"`
+
+	// TutorId Teacher being replaced. Defaults to the teacher with approved time off during the class.
+	TutorId *string `json:"tutor_id,omitempty"`
+}
+
+// SubstituteSuggestion defines model for SubstituteSuggestion.
+type SubstituteSuggestion struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+
+	// ScheduledMinutes Minutes the tutor already teaches in the week around the class
+	ScheduledMinutes int    `json:"scheduled_minutes"`
+	UserId           string `json:"user_id"`
+}
+
 // TimeInterval defines model for TimeInterval.
 type TimeInterval = []time.Time
 
+// TimeOffAffectedClass defines model for TimeOffAffectedClass.
+type TimeOffAffectedClass struct {
+	ClassId string `json:"class_id"`
+
+	// ClassStatus Current status of the class, scheduled or cancelled
+	ClassStatus *string                    `json:"class_status,omitempty"`
+	CourseName  *string                    `json:"course_name,omitempty"`
+	Duration    int                        `json:"duration"`
+	StartTime   time.Time                  `json:"start_time"`
+	Status      TimeOffAffectedClassStatus `json:"status"`
+
+	// SubstituteId Tutor who took over the class
+	SubstituteId *string `json:"substitute_id,omitempty"`
+}
+
+// TimeOffAffectedClassStatus defines model for TimeOffAffectedClassStatus.
+type TimeOffAffectedClassStatus string
+
+// TimeOffRequest defines model for TimeOffRequest.
+type TimeOffRequest struct {
+	// AffectedClasses Classes the tutor teaches during approved time off
+	AffectedClasses *[]TimeOffAffectedClass `json:"affected_classes,omitempty"`
+	CreatedAt       *time.Time              `json:"created_at,omitempty"`
+	EndTime         time.Time               `json:"end_time"`
+	Reason          *string                 `json:"reason,omitempty"`
+	RequestId       *string                 `json:"request_id,omitempty"`
+	ReviewNote      *string                 `json:"review_note,omitempty"`
+	ReviewedAt      *time.Time              `json:"reviewed_at,omitempty"`
+	ReviewedBy      *string                 `json:"reviewed_by,omitempty"`
+	StartTime       time.Time               `json:"start_time"`
+	Status          *TimeOffStatus          `json:"status,omitempty"`
+	TutorId         *string                 `json:"tutor_id,omitempty"`
+}
+
+// TimeOffReview defines model for TimeOffReview.
+type TimeOffReview struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// TimeOffStatus defines model for TimeOffStatus.
+type TimeOffStatus string
+
+// Timesheet defines model for Timesheet.
+type Timesheet struct {
+	ApprovedAt              *time.Time       `json:"approved_at,omitempty"`
+	ApprovedBy              *string          `json:"approved_by,omitempty"`
+	CreatedAt               *time.Time       `json:"created_at,omitempty"`
+	Currency                string           `json:"currency"`
+	Entries                 []TimesheetEntry `json:"entries"`
+	LateCancellationMinutes int              `json:"late_cancellation_minutes"`
+
+	// PaidMinutes Taught, late cancellation and adjusted minutes together
+	PaidMinutes   int             `json:"paid_minutes"`
+	PeriodEnd     time.Time       `json:"period_end"`
+	PeriodStart   time.Time       `json:"period_start"`
+	Status        TimesheetStatus `json:"status"`
+	TaughtMinutes int             `json:"taught_minutes"`
+	TimesheetId   string          `json:"timesheet_id"`
+	TotalCents    int             `json:"total_cents"`
+	TutorId       string          `json:"tutor_id"`
+	TutorName     *string         `json:"tutor_name,omitempty"`
+}
+
+// TimesheetAdjustment defines model for TimesheetAdjustment.
+type TimesheetAdjustment struct {
+	// AmountCents Negative to deduct
+	AmountCents int    `json:"amount_cents"`
+	Note        string `json:"note"`
+
+	// PaidMinutes Minutes the adjustment adds to the paid hours
+	PaidMinutes *int `json:"paid_minutes,omitempty"`
+}
+
+// TimesheetEntry defines model for TimesheetEntry.
+type TimesheetEntry struct {
+	AmountCents int     `json:"amount_cents"`
+	ClassId     *string `json:"class_id,omitempty"`
+	CourseName  *string `json:"course_name,omitempty"`
+
+	// Duration Length of the class in minutes
+	Duration    *int               `json:"duration,omitempty"`
+	EntryId     string             `json:"entry_id"`
+	HourlyCents *int               `json:"hourly_cents,omitempty"`
+	Kind        TimesheetEntryKind `json:"kind"`
+	Note        *string            `json:"note,omitempty"`
+	PaidMinutes int                `json:"paid_minutes"`
+	PayRateId   *string            `json:"pay_rate_id,omitempty"`
+	StartTime   *time.Time         `json:"start_time,omitempty"`
+}
+
+// TimesheetEntryKind defines model for TimesheetEntry.Kind.
+type TimesheetEntryKind string
+
+// TimesheetGenerate defines model for TimesheetGenerate.
+type TimesheetGenerate struct {
+	// PeriodEnd End of the pay period, exclusive
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodStart time.Time `json:"period_start"`
+
+	// TutorId Only compute this tutor's timesheet. Every tutor who taught in the period when omitted.
+	TutorId *string `json:"tutor_id,omitempty"`
+}
+
+// TimesheetStatus defines model for TimesheetStatus.
+type TimesheetStatus string
+
 // Tracker defines model for Tracker.
 type Tracker struct {
 	// Completed Array of class IDs
@@ -151,6 +1325,71 @@ type Tracker struct {
 // TrackerStatus defines model for Tracker.Status.
 type TrackerStatus string
 
+// TrackerFulfillmentRow defines model for TrackerFulfillmentRow.
+type TrackerFulfillmentRow struct {
+	CompletedClasses  int `json:"completed_classes"`
+	FulfilledTrackers int `json:"fulfilled_trackers"`
+
+	// FulfillmentRate Share of required classes completed, omitted when no classes are required
+	FulfillmentRate *float64 `json:"fulfillment_rate,omitempty"`
+
+	// Key Course ID, or the first day of the week or month
+	Key   *string `json:"key,omitempty"`
+	Label string  `json:"label"`
+
+	// OpenTrackers Trackers whose period has not been closed yet
+	OpenTrackers int `json:"open_trackers"`
+
+	// RequiredClasses Classes required by the trackers that were not skipped
+	RequiredClasses  int `json:"required_classes"`
+	ScheduledClasses int `json:"scheduled_classes"`
+	SkippedTrackers  int `json:"skipped_trackers"`
+	Trackers         int `json:"trackers"`
+}
+
+// TutorUtilization defines model for TutorUtilization.
+type TutorUtilization struct {
+	// AvailableMinutes Minutes of availability the tutor offered in the range
+	AvailableMinutes int `json:"available_minutes"`
+
+	// CancelledCount Classes the tutor was due to teach in the range that were cancelled
+	CancelledCount int `json:"cancelled_count"`
+
+	// ClassCount Scheduled classes the tutor teaches in the range
+	ClassCount int    `json:"class_count"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+
+	// MaxMinutesPerWeek The tutor's weekly limit, if any
+	MaxMinutesPerWeek *int `json:"max_minutes_per_week,omitempty"`
+
+	// ScheduledMinutes Minutes of scheduled classes the tutor teaches in the range
+	ScheduledMinutes int    `json:"scheduled_minutes"`
+	UserId           string `json:"user_id"`
+
+	// Utilization Share of available minutes spent teaching, omitted without availability
+	Utilization *float64 `json:"utilization,omitempty"`
+}
+
+// TutorWorkloadLimits defines model for TutorWorkloadLimits.
+type TutorWorkloadLimits struct {
+	// BreakMinutes Gap between two classes that counts as a break
+	BreakMinutes *int `json:"break_minutes,omitempty"`
+
+	// MaxConsecutiveClasses Most classes in a row without a break
+	MaxConsecutiveClasses *int `json:"max_consecutive_classes,omitempty"`
+
+	// MaxMinutesPerDay Most minutes the tutor may teach in one day, e.g. 360 for 6 hours
+	MaxMinutesPerDay *int `json:"max_minutes_per_day,omitempty"`
+
+	// MaxMinutesPerWeek Most minutes the tutor may teach in one week, starting Monday
+	MaxMinutesPerWeek *int `json:"max_minutes_per_week,omitempty"`
+
+	// Timezone IANA time zone days and weeks are counted in
+	Timezone *string `json:"timezone,omitempty"`
+	UserId   *string `json:"user_id,omitempty"`
+}
+
 // User defines model for User.
 type User struct {
 	Courses     *[]string            `json:"courses,omitempty"`
@@ -178,21 +1417,410 @@ type UserUpdate struct {
 // UserUpdateRole defines model for UserUpdate.Role.
 type UserUpdateRole string
 
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	// ClassId Set when waiting for a seat in a single class
+	ClassId *string `json:"class_id,omitempty"`
+
+	// CourseId Set when waiting for a seat in a course
+	CourseId  *string   `json:"course_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+
+	// OfferExpiresAt Deadline to accept an offered seat
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	OfferedAt      *time.Time `json:"offered_at,omitempty"`
+
+	// Position Order in the waitlist, lowest first
+	Position    int            `json:"position"`
+	RespondedAt *time.Time     `json:"responded_at,omitempty"`
+	Status      WaitlistStatus `json:"status"`
+	UserId      string         `json:"user_id"`
+	WaitlistId  string         `json:"waitlist_id"`
+}
+
+// WaitlistOrder defines model for WaitlistOrder.
+type WaitlistOrder struct {
+	// WaitlistIds Every waiting entry of the waitlist, in the new order
+	WaitlistIds []string `json:"waitlist_ids"`
+}
+
+// WaitlistStatus defines model for WaitlistStatus.
+type WaitlistStatus string
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// AttemptLog Every request made for the delivery, oldest first. Only included when fetching a single delivery.
+	AttemptLog     *[]WebhookDeliveryAttempt `json:"attempt_log,omitempty"`
+	Attempts       int                       `json:"attempts"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	DeliveredAt    *time.Time                `json:"delivered_at,omitempty"`
+	DeliveryId     string                    `json:"delivery_id"`
+	Event          string                    `json:"event"`
+	EventId        string                    `json:"event_id"`
+	LastError      *string                   `json:"last_error,omitempty"`
+	LastStatusCode *int                      `json:"last_status_code,omitempty"`
+	NextAttemptAt  time.Time                 `json:"next_attempt_at"`
+
+	// Payload The JSON body posted to the receiver
+	Payload        map[string]interface{} `json:"payload"`
+	Status         WebhookDeliveryStatus  `json:"status"`
+	SubscriptionId string                 `json:"subscription_id"`
+}
+
+// WebhookDeliveryAttempt defines model for WebhookDeliveryAttempt.
+type WebhookDeliveryAttempt struct {
+	AttemptId   string    `json:"attempt_id"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	DurationMs  int       `json:"duration_ms"`
+	Error       *string   `json:"error,omitempty"`
+
+	// StatusCode HTTP status returned by the receiver; absent if no response was received
+	StatusCode *int `json:"status_code,omitempty"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description *string   `json:"description,omitempty"`
+
+	// Events Event types delivered to this endpoint, or ["*"] for all of them
+	Events []string `json:"events"`
+
+	// Secret Signing secret. Only returned when the subscription is created.
+	Secret         *string   `json:"secret,omitempty"`
+	SubscriptionId string    `json:"subscription_id"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Url            string    `json:"url"`
+}
+
+// WebhookSubscriptionCreate defines model for WebhookSubscriptionCreate.
+type WebhookSubscriptionCreate struct {
+	Description *string  `json:"description,omitempty"`
+	Events      []string `json:"events"`
+	Url         string   `json:"url"`
+}
+
+// WebhookSubscriptionUpdate defines model for WebhookSubscriptionUpdate.
+type WebhookSubscriptionUpdate struct {
+	Active      *bool     `json:"active,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Events      *[]string `json:"events,omitempty"`
+	Url         *string   `json:"url,omitempty"`
+}
+
+// ListBlackoutsParams defines parameters for ListBlackouts.
+type ListBlackoutsParams struct {
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To   *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListCourseClassesParams defines parameters for ListCourseClasses.
+type ListCourseClassesParams struct {
+	// From Only include classes starting at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only include classes starting before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListCourseEnrollmentsParams defines parameters for ListCourseEnrollments.
+type ListCourseEnrollmentsParams struct {
+	Status *EnrollmentStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// LastEventId ID of the last event received, when the Last-Event-ID header cannot be sent
+	LastEventId *int64 `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+}
+
+// ListInvoicesParams defines parameters for ListInvoices.
+type ListInvoicesParams struct {
+	Status    *InvoiceStatus `form:"status,omitempty" json:"status,omitempty"`
+	StudentId *string        `form:"student_id,omitempty" json:"student_id,omitempty"`
+	FamilyId  *string        `form:"family_id,omitempty" json:"family_id,omitempty"`
+}
+
+// ListJobsParams defines parameters for ListJobs.
+type ListJobsParams struct {
+	Status *JobStatus `form:"status,omitempty" json:"status,omitempty"`
+	Kind   *string    `form:"kind,omitempty" json:"kind,omitempty"`
+
+	// Limit Maximum number of jobs to return, newest first (default 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListAtRiskStudentsParams defines parameters for ListAtRiskStudents.
+type ListAtRiskStudentsParams struct {
+	CourseId *string `form:"course_id,omitempty" json:"course_id,omitempty"`
+
+	// AsOf Time to judge the running periods at, now by default
+	AsOf *time.Time `form:"as_of,omitempty" json:"as_of,omitempty"`
+}
+
+// GetAttendanceReportParams defines parameters for GetAttendanceReport.
+type GetAttendanceReportParams struct {
+	// From Start of the range, inclusive
+	From time.Time `form:"from" json:"from"`
+
+	// To End of the range, exclusive
+	To time.Time `form:"to" json:"to"`
+
+	// GroupBy What to group by, course by default
+	GroupBy *ReportGrouping `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// Timezone IANA time zone weeks, months and hours are counted in, UTC by default
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// GetAvailabilityDemandReportParams defines parameters for GetAvailabilityDemandReport.
+type GetAvailabilityDemandReportParams struct {
+	// From Start of the range, inclusive
+	From time.Time `form:"from" json:"from"`
+
+	// To End of the range, exclusive
+	To time.Time `form:"to" json:"to"`
+
+	// Timezone IANA time zone weeks, months and hours are counted in, UTC by default
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// GetTrackerFulfillmentReportParams defines parameters for GetTrackerFulfillmentReport.
+type GetTrackerFulfillmentReportParams struct {
+	// From Start of the range, inclusive
+	From time.Time `form:"from" json:"from"`
+
+	// To End of the range, exclusive
+	To time.Time `form:"to" json:"to"`
+
+	// GroupBy What to group by, course by default
+	GroupBy *ReportGrouping `form:"group_by,omitempty" json:"group_by,omitempty"`
+
+	// Timezone IANA time zone weeks, months and hours are counted in, UTC by default
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// GetTutorUtilizationParams defines parameters for GetTutorUtilization.
+type GetTutorUtilizationParams struct {
+	// From Start of the range, inclusive
+	From time.Time `form:"from" json:"from"`
+
+	// To End of the range, exclusive
+	To time.Time `form:"to" json:"to"`
+}
+
+// ListResourcesParams defines parameters for ListResources.
+type ListResourcesParams struct {
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// FindAvailableResourcesParams defines parameters for FindAvailableResources.
+type FindAvailableResourcesParams struct {
+	StartTime time.Time `form:"start_time" json:"start_time"`
+
+	// Duration Length of the slot in minutes
+	Duration int `form:"duration" json:"duration"`
+
+	// Type Only include resources of this type, e.g. room
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+
+	// MinCapacity Only include resources that fit at least this many people
+	MinCapacity *int `form:"min_capacity,omitempty" json:"min_capacity,omitempty"`
+}
+
+// GetResourceCalendarParams defines parameters for GetResourceCalendar.
+type GetResourceCalendarParams struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+// ListRosterSyncRunsParams defines parameters for ListRosterSyncRuns.
+type ListRosterSyncRunsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SyncRosterSourceParams defines parameters for SyncRosterSource.
+type SyncRosterSourceParams struct {
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// ListBookableSlotsParams defines parameters for ListBookableSlots.
+type ListBookableSlotsParams struct {
+	From     time.Time `form:"from" json:"from"`
+	To       time.Time `form:"to" json:"to"`
+	TutorId  *string   `form:"tutor_id,omitempty" json:"tutor_id,omitempty"`
+	CourseId *string   `form:"course_id,omitempty" json:"course_id,omitempty"`
+}
+
+// ListTimeOffRequestsParams defines parameters for ListTimeOffRequests.
+type ListTimeOffRequestsParams struct {
+	Status *TimeOffStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListTimesheetsParams defines parameters for ListTimesheets.
+type ListTimesheetsParams struct {
+	TutorId *string          `form:"tutor_id,omitempty" json:"tutor_id,omitempty"`
+	Status  *TimesheetStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// From Only include pay periods ending after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only include pay periods starting before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportTimesheetsParams defines parameters for ExportTimesheets.
+type ExportTimesheetsParams struct {
+	From time.Time `form:"from" json:"from"`
+
+	// To End of the range, exclusive
+	To time.Time `form:"to" json:"to"`
+}
+
+// ListUserNotificationsParams defines parameters for ListUserNotifications.
+type ListUserNotificationsParams struct {
+	// Status Only include notifications with this delivery status
+	Status *NotificationStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListTutorSlotsParams defines parameters for ListTutorSlots.
+type ListTutorSlotsParams struct {
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To   *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Status Only include deliveries with this status
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
 // GetBatchAvailabilityJSONRequestBody defines body for GetBatchAvailability for application/json ContentType.
 type GetBatchAvailabilityJSONRequestBody = BatchAvailabilityRequest
 
+// CreateBlackoutJSONRequestBody defines body for CreateBlackout for application/json ContentType.
+type CreateBlackoutJSONRequestBody = BlackoutPeriod
+
+// ImportBlackoutsJSONRequestBody defines body for ImportBlackouts for application/json ContentType.
+type ImportBlackoutsJSONRequestBody = BlackoutImport
+
+// SetBlackoutPolicyJSONRequestBody defines body for SetBlackoutPolicy for application/json ContentType.
+type SetBlackoutPolicyJSONRequestBody = BlackoutPolicySettings
+
 // CreateClassJSONRequestBody defines body for CreateClass for application/json ContentType.
 type CreateClassJSONRequestBody = Class
 
+// RecordClassAttendanceJSONRequestBody defines body for RecordClassAttendance for application/json ContentType.
+type RecordClassAttendanceJSONRequestBody = ClassAttendanceUpdate
+
+// CancelClassJSONRequestBody defines body for CancelClass for application/json ContentType.
+type CancelClassJSONRequestBody = ClassCancellation
+
+// UpdateClassParticipantsJSONRequestBody defines body for UpdateClassParticipants for application/json ContentType.
+type UpdateClassParticipantsJSONRequestBody = ClassParticipantUpdate
+
+// RescheduleClassJSONRequestBody defines body for RescheduleClass for application/json ContentType.
+type RescheduleClassJSONRequestBody = ClassReschedule
+
+// UpdateClassResourcesJSONRequestBody defines body for UpdateClassResources for application/json ContentType.
+type UpdateClassResourcesJSONRequestBody = ClassResourceChanges
+
+// RestoreClassJSONRequestBody defines body for RestoreClass for application/json ContentType.
+type RestoreClassJSONRequestBody = ClassRestore
+
+// AssignSubstituteJSONRequestBody defines body for AssignSubstitute for application/json ContentType.
+type AssignSubstituteJSONRequestBody = SubstituteAssignment
+
+// ReorderClassWaitlistJSONRequestBody defines body for ReorderClassWaitlist for application/json ContentType.
+type ReorderClassWaitlistJSONRequestBody = WaitlistOrder
+
 // CreateCourseJSONRequestBody defines body for CreateCourse for application/json ContentType.
 type CreateCourseJSONRequestBody = Course
 
 // UpdateCourseJSONRequestBody defines body for UpdateCourse for application/json ContentType.
 type UpdateCourseJSONRequestBody = CourseUpdate
 
+// EnrollCourseUserJSONRequestBody defines body for EnrollCourseUser for application/json ContentType.
+type EnrollCourseUserJSONRequestBody = EnrollmentCreate
+
+// BulkEnrollCourseUsersJSONRequestBody defines body for BulkEnrollCourseUsers for application/json ContentType.
+type BulkEnrollCourseUsersJSONRequestBody = EnrollmentBatch
+
+// DropCourseEnrollmentJSONRequestBody defines body for DropCourseEnrollment for application/json ContentType.
+type DropCourseEnrollmentJSONRequestBody = EnrollmentDrop
+
+// ReorderCourseWaitlistJSONRequestBody defines body for ReorderCourseWaitlist for application/json ContentType.
+type ReorderCourseWaitlistJSONRequestBody = WaitlistOrder
+
+// CreateFamilyJSONRequestBody defines body for CreateFamily for application/json ContentType.
+type CreateFamilyJSONRequestBody = Family
+
+// UpdateFamilyJSONRequestBody defines body for UpdateFamily for application/json ContentType.
+type UpdateFamilyJSONRequestBody = Family
+
+// RunImportJSONRequestBody defines body for RunImport for application/json ContentType.
+type RunImportJSONRequestBody = ImportRequest
+
+// CreateInvoiceJSONRequestBody defines body for CreateInvoice for application/json ContentType.
+type CreateInvoiceJSONRequestBody = InvoiceCreate
+
+// CreateCreditNoteJSONRequestBody defines body for CreateCreditNote for application/json ContentType.
+type CreateCreditNoteJSONRequestBody = CreditNoteCreate
+
+// CreateLtiPlatformJSONRequestBody defines body for CreateLtiPlatform for application/json ContentType.
+type CreateLtiPlatformJSONRequestBody = LtiPlatformCreate
+
 // CreateOrgJSONRequestBody defines body for CreateOrg for application/json ContentType.
 type CreateOrgJSONRequestBody = Organization
 
+// CreatePayRateJSONRequestBody defines body for CreatePayRate for application/json ContentType.
+type CreatePayRateJSONRequestBody = PayRate
+
+// UpdatePayRateJSONRequestBody defines body for UpdatePayRate for application/json ContentType.
+type UpdatePayRateJSONRequestBody = PayRate
+
+// SetPayrollPolicyJSONRequestBody defines body for SetPayrollPolicy for application/json ContentType.
+type SetPayrollPolicyJSONRequestBody = PayrollPolicy
+
+// CreateRateCardJSONRequestBody defines body for CreateRateCard for application/json ContentType.
+type CreateRateCardJSONRequestBody = RateCard
+
+// UpdateRateCardJSONRequestBody defines body for UpdateRateCard for application/json ContentType.
+type UpdateRateCardJSONRequestBody = RateCard
+
+// CreateResourceJSONRequestBody defines body for CreateResource for application/json ContentType.
+type CreateResourceJSONRequestBody = Resource
+
+// UpdateResourceJSONRequestBody defines body for UpdateResource for application/json ContentType.
+type UpdateResourceJSONRequestBody = Resource
+
+// CreateRosterSourceJSONRequestBody defines body for CreateRosterSource for application/json ContentType.
+type CreateRosterSourceJSONRequestBody = RosterSourceCreate
+
+// CreateScimTokenJSONRequestBody defines body for CreateScimToken for application/json ContentType.
+type CreateScimTokenJSONRequestBody = ScimTokenCreate
+
+// SetSelfBookingPoliciesJSONRequestBody defines body for SetSelfBookingPolicies for application/json ContentType.
+type SetSelfBookingPoliciesJSONRequestBody = SelfBookingPolicySet
+
+// ApproveTimeOffRequestJSONRequestBody defines body for ApproveTimeOffRequest for application/json ContentType.
+type ApproveTimeOffRequestJSONRequestBody = TimeOffReview
+
+// RejectTimeOffRequestJSONRequestBody defines body for RejectTimeOffRequest for application/json ContentType.
+type RejectTimeOffRequestJSONRequestBody = TimeOffReview
+
+// GenerateTimesheetsJSONRequestBody defines body for GenerateTimesheets for application/json ContentType.
+type GenerateTimesheetsJSONRequestBody = TimesheetGenerate
+
+// AddTimesheetAdjustmentJSONRequestBody defines body for AddTimesheetAdjustment for application/json ContentType.
+type AddTimesheetAdjustmentJSONRequestBody = TimesheetAdjustment
+
 // GetTrackersJSONRequestBody defines body for GetTrackers for application/json ContentType.
 type GetTrackersJSONRequestBody = CourseTrackersRequest
 
@@ -207,3 +1835,24 @@ type UpdateAvailabilityJSONRequestBody = AvailabilityUpdate
 
 // CreateAvailabilityJSONRequestBody defines body for CreateAvailability for application/json ContentType.
 type CreateAvailabilityJSONRequestBody = Availability
+
+// SetRecurringAvailabilityJSONRequestBody defines body for SetRecurringAvailability for application/json ContentType.
+type SetRecurringAvailabilityJSONRequestBody = RecurringAvailability
+
+// UpdateNotificationPreferencesJSONRequestBody defines body for UpdateNotificationPreferences for application/json ContentType.
+type UpdateNotificationPreferencesJSONRequestBody = NotificationPreferencesUpdate
+
+// PublishTutorSlotsJSONRequestBody defines body for PublishTutorSlots for application/json ContentType.
+type PublishTutorSlotsJSONRequestBody = BookableSlotBatch
+
+// RequestTimeOffJSONRequestBody defines body for RequestTimeOff for application/json ContentType.
+type RequestTimeOffJSONRequestBody = TimeOffRequest
+
+// SetTutorWorkloadLimitsJSONRequestBody defines body for SetTutorWorkloadLimits for application/json ContentType.
+type SetTutorWorkloadLimitsJSONRequestBody = TutorWorkloadLimits
+
+// CreateWebhookSubscriptionJSONRequestBody defines body for CreateWebhookSubscription for application/json ContentType.
+type CreateWebhookSubscriptionJSONRequestBody = WebhookSubscriptionCreate
+
+// UpdateWebhookSubscriptionJSONRequestBody defines body for UpdateWebhookSubscription for application/json ContentType.
+type UpdateWebhookSubscriptionJSONRequestBody = WebhookSubscriptionUpdate
@@ -0,0 +1,37 @@
+-- Attendance of the classes of organization $1 starting in [$2, $3), grouped
+-- by $4: course, tutor, or the week or month in time zone $5 the class
+-- starts in. Classes with several teachers count towards each of them.
+with grouped as (
+	select
+		case $4::text
+			when 'course' then s.course_id::text
+			when 'tutor' then t.tutor_id::text
+			else to_char(date_trunc($4::text, s.start_time at time zone $5::text), 'YYYY-MM-DD')
+		end as key,
+		s.status,
+		s.students,
+		s.attended,
+		s.absent
+	from analytics_class_stats as s
+	left join lateral unnest(
+		case when $4::text = 'tutor' then s.teacher_ids else array[NULL]::uuid [] end
+	) as t (tutor_id) on true
+	where
+		s.org_id = $1
+		and s.start_time >= $2
+		and s.start_time < $3
+)
+
+select
+	g.key,
+	coalesce(co.course_name, u.first_name || ' ' || u.last_name, g.key) as label,
+	count(*) filter (where g.status = 'scheduled') as classes,
+	count(*) filter (where g.status = 'cancelled') as cancelled_classes,
+	coalesce(sum(g.students) filter (where g.status = 'scheduled'), 0) as students,
+	coalesce(sum(g.attended) filter (where g.status = 'scheduled'), 0) as attended,
+	coalesce(sum(g.absent) filter (where g.status = 'scheduled'), 0) as absent
+from grouped as g
+left join courses as co on $4::text = 'course' and g.key = co.course_id::text
+left join users as u on $4::text = 'tutor' and g.key = u.user_id::text
+group by g.key, co.course_name, u.first_name, u.last_name
+order by g.key is NULL, label, g.key;
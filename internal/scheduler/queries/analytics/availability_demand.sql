@@ -0,0 +1,16 @@
+-- Availability offered in organization $1 in [$2, $3) by tutors (supply) and
+-- students (demand), per hour of the week in time zone $4. Only hours with
+-- availability are returned.
+select
+	extract(dow from a.hour at time zone $4::text)::integer as weekday,
+	extract(hour from a.hour at time zone $4::text)::integer as hour,
+	coalesce(sum(a.minutes) filter (where a.role = 'tutor'), 0) as tutor_minutes,
+	coalesce(sum(a.minutes) filter (where a.role = 'student'), 0) as student_minutes,
+	count(distinct a.user_id) filter (where a.role = 'tutor') as tutors,
+	count(distinct a.user_id) filter (where a.role = 'student') as students
+from analytics_availability_hours as a
+where
+	a.org_id = $1
+	and a.hour >= $2
+	and a.hour < $3
+group by 1, 2;
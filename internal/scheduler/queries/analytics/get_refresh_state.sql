@@ -0,0 +1,4 @@
+select refreshed_at
+from analytics_refresh_state
+where name = $1
+for update;
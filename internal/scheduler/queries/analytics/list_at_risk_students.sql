@@ -0,0 +1,64 @@
+-- Active students of the courses of organization $1 (or only course $3) who
+-- cannot reach the required classes of the tracker period running at $2:
+-- the classes they attended plus those still to come fall short.
+with periods as (
+	select
+		t.tracking_id,
+		t.course_id,
+		t.period_start,
+		t.period_end,
+		t.required_classes
+	from trackers as t
+	inner join courses as c on t.course_id = c.course_id
+	where
+		c.org_id = $1
+		and ($3::uuid is NULL or t.course_id = $3)
+		and t.status is distinct from 'skipped'
+		and t.period_start <= $2
+		and t.period_end > $2
+),
+
+progress as (
+	select
+		p.tracking_id,
+		uc.user_id,
+		count(*) filter (where ca.attended) as attended_classes,
+		count(*) filter (where cl.start_time < $2 and ca.attended is not true) as missed_classes,
+		count(*) filter (where cl.start_time >= $2) as remaining_classes
+	from periods as p
+	inner join user_courses as uc on p.course_id = uc.course_id and uc.status = 'active'
+	inner join users as u on uc.user_id = u.user_id and u.role = 'student'
+	left join classes as cl
+		on
+			p.course_id = cl.course_id
+			and cl.status = 'scheduled'
+			and cl.start_time >= p.period_start
+			and cl.start_time < p.period_end
+			and exists (
+				select 1 from class_participants as cp
+				where cp.class_id = cl.class_id and cp.user_id = uc.user_id
+			)
+	left join class_attendance as ca on cl.class_id = ca.class_id and uc.user_id = ca.user_id
+	group by p.tracking_id, uc.user_id
+)
+
+select
+	u.user_id as student_id,
+	u.first_name,
+	u.last_name,
+	p.course_id,
+	co.course_name,
+	p.tracking_id,
+	p.period_start,
+	p.period_end,
+	p.required_classes,
+	pr.attended_classes,
+	pr.missed_classes,
+	pr.remaining_classes,
+	p.required_classes - pr.attended_classes - pr.remaining_classes as shortfall
+from progress as pr
+inner join periods as p on pr.tracking_id = p.tracking_id
+inner join users as u on pr.user_id = u.user_id
+inner join courses as co on p.course_id = co.course_id
+where pr.attended_classes + pr.remaining_classes < p.required_classes
+order by shortfall desc, p.period_end, u.last_name, u.first_name;
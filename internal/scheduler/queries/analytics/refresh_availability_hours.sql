@@ -0,0 +1,59 @@
+-- Counts again the hours of users whose availability changed since the last
+-- refresh. Changes are taken off the log, so changes committed while this
+-- runs are left for the next refresh.
+with changed as (
+	delete from analytics_availability_changes
+	returning user_id, start_time, end_time
+),
+
+changed_hours as (
+	select distinct
+		c.user_id,
+		h.hour
+	from changed as c
+	cross join lateral generate_series(
+		date_trunc('hour', c.start_time), c.end_time - interval '1 second', interval '1 hour'
+	) as h (hour)
+),
+
+hours as (
+	select
+		a.org_id,
+		a.user_id,
+		a.role,
+		ch.hour,
+		(sum(extract(epoch from (least(a.end_time, ch.hour + interval '1 hour') - greatest(a.start_time, ch.hour)))) / 60)::INTEGER as minutes
+	from changed_hours as ch
+	inner join availability as a
+		on
+			ch.user_id = a.user_id
+			and a.start_time < ch.hour + interval '1 hour'
+			and a.end_time > ch.hour
+	group by a.org_id, a.user_id, a.role, ch.hour
+),
+
+upserted as (
+	insert into analytics_availability_hours (org_id, user_id, role, hour, minutes)
+	select
+		org_id,
+		user_id,
+		role,
+		hour,
+		minutes
+	from hours
+	on conflict (user_id, hour, role) do update set
+		org_id = excluded.org_id,
+		minutes = excluded.minutes
+)
+
+-- Hours no availability is left in
+delete from analytics_availability_hours as r
+using changed_hours as ch
+where
+	r.user_id = ch.user_id
+	and r.hour = ch.hour
+	and not exists (
+		select 1
+		from hours as h
+		where h.user_id = r.user_id and h.hour = r.hour and h.role = r.role
+	);
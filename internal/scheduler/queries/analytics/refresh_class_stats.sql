@@ -0,0 +1,50 @@
+-- Counts again every class that was created, changed, had its participants
+-- changed or had attendance recorded since $1. A NULL $1 counts every class.
+with changed as (
+	select class_id
+	from classes
+	where $1::timestamptz is NULL or updated_at >= $1 or created_at >= $1
+	union
+	select class_id
+	from class_history
+	where created_at >= $1
+	union
+	select class_id
+	from class_participants
+	where created_at >= $1
+	union
+	select class_id
+	from class_attendance
+	where recorded_at >= $1
+)
+
+insert into analytics_class_stats (
+	class_id, org_id, course_id, start_time, duration, status, teacher_ids, students, attended, absent, refreshed_at
+)
+select
+	c.class_id,
+	c.org_id,
+	c.course_id,
+	c.start_time,
+	c.duration,
+	c.status,
+	coalesce(array_agg(cp.user_id) filter (where cp.role = 'teacher'), '{}') as teacher_ids,
+	count(*) filter (where cp.role = 'student') as students,
+	count(*) filter (where cp.role = 'student' and ca.attended) as attended,
+	count(*) filter (where cp.role = 'student' and not ca.attended) as absent,
+	$2 as refreshed_at
+from classes as c
+inner join changed on c.class_id = changed.class_id
+left join class_participants as cp on c.class_id = cp.class_id
+left join class_attendance as ca on cp.class_id = ca.class_id and cp.user_id = ca.user_id
+group by c.class_id
+on conflict (class_id) do update set
+	course_id = excluded.course_id,
+	start_time = excluded.start_time,
+	duration = excluded.duration,
+	status = excluded.status,
+	teacher_ids = excluded.teacher_ids,
+	students = excluded.students,
+	attended = excluded.attended,
+	absent = excluded.absent,
+	refreshed_at = excluded.refreshed_at;
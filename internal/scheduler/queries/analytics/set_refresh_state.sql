@@ -0,0 +1,3 @@
+update analytics_refresh_state
+set refreshed_at = $2
+where name = $1;
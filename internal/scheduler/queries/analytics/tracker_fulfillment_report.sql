@@ -0,0 +1,36 @@
+-- Trackers of organization $1 whose period starts in [$2, $3), grouped by
+-- $4: course, or the week or month in time zone $5 the period starts in.
+-- Skipped periods are counted but need no classes.
+with grouped as (
+	select
+		case $4::text
+			when 'course' then t.course_id::text
+			else to_char(date_trunc($4::text, t.period_start at time zone $5::text), 'YYYY-MM-DD')
+		end as key,
+		t.status,
+		t.closed_at,
+		t.required_classes,
+		t.scheduled_count,
+		t.completed_count
+	from trackers as t
+	inner join courses as c on t.course_id = c.course_id
+	where
+		c.org_id = $1
+		and t.period_start >= $2
+		and t.period_start < $3
+)
+
+select
+	g.key,
+	coalesce(co.course_name, g.key) as label,
+	count(*) as trackers,
+	count(*) filter (where g.status = 'fulfilled') as fulfilled_trackers,
+	count(*) filter (where g.status = 'skipped') as skipped_trackers,
+	count(*) filter (where g.closed_at is NULL) as open_trackers,
+	coalesce(sum(g.required_classes) filter (where g.status is distinct from 'skipped'), 0) as required_classes,
+	coalesce(sum(g.scheduled_count) filter (where g.status is distinct from 'skipped'), 0) as scheduled_classes,
+	coalesce(sum(g.completed_count) filter (where g.status is distinct from 'skipped'), 0) as completed_classes
+from grouped as g
+left join courses as co on $4::text = 'course' and g.key = co.course_id::text
+group by g.key, co.course_name
+order by label, g.key;
@@ -0,0 +1,11 @@
+select
+	cp.class_id,
+	cp.user_id,
+	cp.role,
+	ca.attended,
+	ca.notes,
+	ca.recorded_at
+from class_participants as cp
+left join class_attendance as ca on cp.class_id = ca.class_id and cp.user_id = ca.user_id
+where cp.class_id = $1
+order by cp.role desc, cp.user_id;
@@ -0,0 +1,16 @@
+insert into class_attendance (class_id, user_id, role, attended, notes, recorded_at)
+select
+	cp.class_id,
+	cp.user_id,
+	cp.role,
+	$3,
+	$4,
+	$5
+from class_participants as cp
+where cp.class_id = $1 and cp.user_id = $2
+on conflict (class_id, user_id) do update
+set
+	role = excluded.role,
+	attended = excluded.attended,
+	notes = excluded.notes,
+	recorded_at = excluded.recorded_at;
@@ -0,0 +1,2 @@
+insert into recurring_availability (org_id, user_id, weekday, start_minute, end_minute, timezone, created_at)
+values ($1, $2, $3, $4, $5, $6, $7);
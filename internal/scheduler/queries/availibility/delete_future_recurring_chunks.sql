@@ -0,0 +1,6 @@
+delete from availability
+where
+	user_id = $1
+	and source = 'recurring'
+	and not matched
+	and start_time >= $2;
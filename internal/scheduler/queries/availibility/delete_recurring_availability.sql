@@ -0,0 +1,2 @@
+delete from recurring_availability
+where user_id = $1;
@@ -0,0 +1,4 @@
+select coalesce(
+	(select version from availability_versions where user_id = $1),
+	0
+);
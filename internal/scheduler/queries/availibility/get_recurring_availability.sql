@@ -0,0 +1,8 @@
+select
+	weekday,
+	start_minute,
+	end_minute,
+	timezone
+from recurring_availability
+where user_id = $1
+order by weekday, start_minute;
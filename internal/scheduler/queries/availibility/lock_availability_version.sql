@@ -0,0 +1,4 @@
+insert into availability_versions (user_id, version)
+values ($1, 0)
+on conflict (user_id) do update set version = availability_versions.version
+returning version;
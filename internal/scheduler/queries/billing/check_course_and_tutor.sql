@@ -0,0 +1,8 @@
+-- Whether the course and the tutor, when given, belong to the organization
+select
+	($2::uuid is NULL or exists (
+		select 1 from courses where course_id = $2 and org_id = $1
+	))
+	and ($3::uuid is NULL or exists (
+		select 1 from users where user_id = $3 and org_id = $1 and role = 'tutor'
+	));
@@ -0,0 +1,5 @@
+update users
+set
+	family_id = NULL,
+	updated_at = $2
+where family_id = $1;
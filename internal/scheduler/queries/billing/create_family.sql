@@ -0,0 +1,2 @@
+insert into families (family_id, org_id, name, billing_email, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $5);
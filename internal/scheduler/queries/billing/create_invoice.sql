@@ -0,0 +1,4 @@
+insert into invoices (
+	invoice_id, org_id, kind, number, status, student_id, family_id, bill_to, period_start, period_end,
+	currency, total_cents, credited_invoice_id, reason, issued_at, created_by, created_at, updated_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $17);
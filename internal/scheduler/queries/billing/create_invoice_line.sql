@@ -0,0 +1,4 @@
+insert into invoice_lines (
+	line_id, invoice_id, class_id, student_id, description, start_time, duration, rate_card_id,
+	amount_cents, credited_line_id, created_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
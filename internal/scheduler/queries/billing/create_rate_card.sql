@@ -0,0 +1,3 @@
+insert into rate_cards (
+	rate_card_id, org_id, name, course_id, tutor_id, duration, class_type, amount_cents, currency, created_at, updated_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10);
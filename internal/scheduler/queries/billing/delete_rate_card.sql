@@ -0,0 +1,2 @@
+delete from rate_cards
+where rate_card_id = $1 and org_id = $2;
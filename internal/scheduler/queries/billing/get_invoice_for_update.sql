@@ -0,0 +1,21 @@
+select
+	invoice_id,
+	kind,
+	number,
+	status,
+	student_id,
+	family_id,
+	bill_to,
+	period_start,
+	period_end,
+	currency,
+	total_cents,
+	credited_invoice_id,
+	reason,
+	issued_at,
+	paid_at,
+	voided_at,
+	created_at
+from invoices
+where invoice_id = $1 and org_id = $2
+for update;
@@ -0,0 +1,3 @@
+select name
+from organizations
+where organization_id = $1;
@@ -0,0 +1,12 @@
+select
+	rate_card_id,
+	name,
+	course_id,
+	tutor_id,
+	duration,
+	class_type,
+	amount_cents,
+	currency,
+	created_at
+from rate_cards
+where rate_card_id = $1 and org_id = $2;
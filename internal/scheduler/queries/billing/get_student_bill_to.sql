@@ -0,0 +1,3 @@
+select first_name || ' ' || last_name
+from users
+where user_id = $1 and org_id = $2 and role = 'student';
@@ -0,0 +1,5 @@
+select exists (
+	select 1
+	from invoices
+	where credited_invoice_id = $1 and status != 'void'
+);
@@ -0,0 +1,42 @@
+-- Classes the students attended in [$3, $4) that are not on an invoice yet,
+-- with what rate cards are matched on. Lines of void invoices do not count.
+select
+	c.class_id,
+	ca.user_id as student_id,
+	u.first_name || ' ' || u.last_name as student_name,
+	c.course_id,
+	co.course_name,
+	c.start_time,
+	c.duration,
+	array(
+		select cp.user_id::text
+		from class_participants as cp
+		where cp.class_id = c.class_id and cp.role = 'teacher'
+	) as tutor_ids,
+	(
+		select count(*)
+		from class_participants as cp
+		where cp.class_id = c.class_id and cp.role = 'student'
+	) as student_count
+from class_attendance as ca
+join classes as c on ca.class_id = c.class_id
+join users as u on ca.user_id = u.user_id
+left join courses as co on c.course_id = co.course_id
+where
+	c.org_id = $1
+	and ca.user_id = any($2::uuid[])
+	and ca.role = 'student'
+	and ca.attended
+	and c.status = 'scheduled'
+	and c.start_time >= $3
+	and c.start_time < $4
+	and not exists (
+		select 1
+		from invoice_lines as il
+		where
+			il.class_id = c.class_id
+			and il.student_id = ca.user_id
+			and il.credited_line_id is NULL
+			and not il.void
+	)
+order by c.start_time, u.last_name, u.first_name;
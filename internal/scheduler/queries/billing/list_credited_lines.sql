@@ -0,0 +1,5 @@
+-- Lines of the invoice that a credit note which is not void already credits
+select cl.credited_line_id
+from invoice_lines as il
+join invoice_lines as cl on cl.credited_line_id = il.line_id
+where il.invoice_id = $1 and not cl.void;
@@ -0,0 +1,14 @@
+select
+	f.family_id,
+	f.name,
+	f.billing_email,
+	f.created_at,
+	array(
+		select u.user_id::text
+		from users as u
+		where u.family_id = f.family_id
+		order by u.last_name, u.first_name
+	) as student_ids
+from families as f
+where f.org_id = $1
+order by f.name, f.created_at;
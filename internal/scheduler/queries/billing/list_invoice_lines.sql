@@ -0,0 +1,16 @@
+select
+	il.invoice_id,
+	il.line_id,
+	il.class_id,
+	il.student_id,
+	u.first_name || ' ' || u.last_name as student_name,
+	il.description,
+	il.start_time,
+	il.duration,
+	il.rate_card_id,
+	il.amount_cents,
+	il.credited_line_id
+from invoice_lines as il
+left join users as u on il.student_id = u.user_id
+where il.invoice_id = any($1::uuid[])
+order by il.start_time, il.created_at;
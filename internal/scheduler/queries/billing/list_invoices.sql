@@ -0,0 +1,27 @@
+-- Invoices and credit notes of an organization, optionally filtered by
+-- status ($2), student ($3) and family ($4).
+select
+	invoice_id,
+	kind,
+	number,
+	status,
+	student_id,
+	family_id,
+	bill_to,
+	period_start,
+	period_end,
+	currency,
+	total_cents,
+	credited_invoice_id,
+	reason,
+	issued_at,
+	paid_at,
+	voided_at,
+	created_at
+from invoices
+where
+	org_id = $1
+	and ($2::text is NULL or status = $2)
+	and ($3::uuid is NULL or student_id = $3)
+	and ($4::uuid is NULL or family_id = $4)
+order by created_at desc, number desc;
@@ -0,0 +1,13 @@
+select
+	rate_card_id,
+	name,
+	course_id,
+	tutor_id,
+	duration,
+	class_type,
+	amount_cents,
+	currency,
+	created_at
+from rate_cards
+where org_id = $1
+order by name, created_at;
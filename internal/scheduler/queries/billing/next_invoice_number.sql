@@ -0,0 +1,11 @@
+-- Locks the organization so numbers of one kind are given out one at a time
+-- and returns the next one.
+with org as (
+	select organization_id
+	from organizations
+	where organization_id = $1
+	for update
+)
+select count(i.invoice_id) + 1
+from org
+left join invoices as i on i.org_id = org.organization_id and i.kind = $2 and i.number is not NULL;
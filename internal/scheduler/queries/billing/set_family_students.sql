@@ -0,0 +1,7 @@
+-- Moves the students into the family, out of any family they were in. Only
+-- students of the organization are moved.
+update users
+set
+	family_id = $1,
+	updated_at = $4
+where user_id = any($2::uuid[]) and org_id = $3 and role = 'student';
@@ -0,0 +1,11 @@
+-- Moves an invoice to status $2 at $4, stamping the time of the change.
+-- $3 is the number given out when it is issued.
+update invoices
+set
+	status = $2,
+	number = coalesce($3, number),
+	issued_at = case when $2 = 'issued' then $4 else issued_at end,
+	paid_at = case when $2 = 'paid' then $4 else paid_at end,
+	voided_at = case when $2 = 'void' then $4 else voided_at end,
+	updated_at = $4
+where invoice_id = $1;
@@ -0,0 +1,6 @@
+update families
+set
+	name = $3,
+	billing_email = $4,
+	updated_at = $5
+where family_id = $1 and org_id = $2;
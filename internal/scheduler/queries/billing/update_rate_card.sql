@@ -0,0 +1,11 @@
+update rate_cards
+set
+	name = $3,
+	course_id = $4,
+	tutor_id = $5,
+	duration = $6,
+	class_type = $7,
+	amount_cents = $8,
+	currency = $9,
+	updated_at = $10
+where rate_card_id = $1 and org_id = $2;
@@ -0,0 +1,3 @@
+update invoice_lines
+set void = TRUE
+where invoice_id = $1;
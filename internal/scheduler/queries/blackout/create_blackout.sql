@@ -0,0 +1,3 @@
+insert into blackout_periods (
+	blackout_id, org_id, name, start_time, end_time, source, created_by, created_at, updated_at
+) values ($1, $2, $3, $4, $5, 'manual', $6, $7, $7);
@@ -0,0 +1,3 @@
+delete from blackout_periods
+where blackout_id = $1 and org_id = $2
+returning start_time, end_time;
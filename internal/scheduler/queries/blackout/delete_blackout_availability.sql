@@ -0,0 +1,9 @@
+-- Removes unmatched availability expanded from recurring rules that overlaps
+-- a blackout period. Availability users entered themselves is kept.
+delete from availability
+where
+	org_id = $1
+	and source = 'recurring'
+	and not matched
+	and start_time < $3
+	and end_time > $2;
@@ -0,0 +1,9 @@
+select
+	blackout_id,
+	name,
+	start_time,
+	end_time,
+	source,
+	created_at
+from blackout_periods
+where blackout_id = $1 and org_id = $2;
@@ -0,0 +1,3 @@
+select blackout_policy as policy
+from organizations
+where organization_id = $1;
@@ -0,0 +1,7 @@
+-- The period imported from an event before, if any, locked until the import
+-- has replaced it.
+select start_time, end_time
+from blackout_periods
+where org_id = $1
+	and ics_uid = $2
+for update;
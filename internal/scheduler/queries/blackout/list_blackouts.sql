@@ -0,0 +1,15 @@
+-- Blackout periods of an organization overlapping [$2, $3). Either bound may
+-- be NULL to leave that side open.
+select
+	blackout_id,
+	name,
+	start_time,
+	end_time,
+	source,
+	created_at
+from blackout_periods
+where
+	org_id = $1
+	and ($2::timestamptz is NULL or end_time > $2)
+	and ($3::timestamptz is NULL or start_time < $3)
+order by start_time, end_time;
@@ -0,0 +1,14 @@
+-- Trackers of an organization's courses that are not closed yet and overlap
+-- [$2, $3).
+select
+	t.tracking_id,
+	t.period_start,
+	t.period_end,
+	t.status
+from trackers as t
+inner join courses as co on t.course_id = co.course_id
+where
+	co.org_id = $1
+	and t.closed_at is NULL
+	and t.period_end > $2
+	and t.period_start < $3;
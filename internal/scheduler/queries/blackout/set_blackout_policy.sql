@@ -0,0 +1,3 @@
+update organizations
+set blackout_policy = $2, updated_at = $3
+where organization_id = $1;
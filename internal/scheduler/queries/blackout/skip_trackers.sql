@@ -0,0 +1,19 @@
+-- Marks trackers whose whole period is blacked out as skipped.
+with previous as (
+	select tracking_id, status
+	from trackers
+	where tracking_id = any($1) and status is distinct from 'skipped'
+)
+
+update trackers as t
+set status = 'skipped', updated_at = $2
+from previous, courses as co
+where
+	t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
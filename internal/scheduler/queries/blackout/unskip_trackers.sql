@@ -0,0 +1,26 @@
+-- Gives skipped trackers that are no longer blacked out the status their
+-- classes call for.
+with previous as (
+	select tracking_id, status
+	from trackers
+	where tracking_id = any($1) and status = 'skipped'
+)
+
+update trackers as t
+set
+	status = case
+		when t.completed_count >= t.required_classes then 'fulfilled'
+		when t.scheduled_count >= t.required_classes then 'scheduled'
+		else 'unscheduled'
+	end,
+	updated_at = $2
+from previous, courses as co
+where
+	t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
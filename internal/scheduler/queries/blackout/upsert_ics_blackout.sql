@@ -0,0 +1,17 @@
+-- Adds an imported event, or updates the period imported from it before.
+insert into blackout_periods (
+	org_id, name, start_time, end_time, source, ics_uid, created_by, created_at, updated_at
+) values ($1, $2, $3, $4, 'ics', $5, $6, $7, $7)
+on conflict (org_id, ics_uid) where ics_uid is not NULL do update
+set
+	name = excluded.name,
+	start_time = excluded.start_time,
+	end_time = excluded.end_time,
+	updated_at = excluded.updated_at
+returning
+	blackout_id,
+	name,
+	start_time,
+	end_time,
+	source,
+	created_at;
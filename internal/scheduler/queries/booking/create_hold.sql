@@ -0,0 +1,2 @@
+insert into slot_holds (hold_id, slot_id, org_id, student_id, status, expires_at, created_at, updated_at)
+values ($1, $2, $3, $4, 'active', $5, $6, $6);
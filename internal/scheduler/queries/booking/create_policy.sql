@@ -0,0 +1,2 @@
+insert into self_booking_policies (policy_id, org_id, tutor_id, course_id, audience, created_at)
+values ($1, $2, $3, $4, $5, $6);
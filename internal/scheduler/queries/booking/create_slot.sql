@@ -0,0 +1,2 @@
+insert into bookable_slots (slot_id, org_id, tutor_id, course_id, start_time, end_time, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $7);
@@ -0,0 +1,2 @@
+delete from self_booking_policies
+where org_id = $1;
@@ -0,0 +1,17 @@
+-- Expires holds past their deadline and opens their slots again.
+with expired as (
+	update slot_holds
+	set
+		status = 'expired',
+		updated_at = $1
+	where status = 'active'
+		and expires_at <= $1
+	returning slot_id
+)
+update bookable_slots as bs
+set
+	status = 'open',
+	updated_at = $1
+from expired
+where bs.slot_id = expired.slot_id
+	and bs.status = 'held';
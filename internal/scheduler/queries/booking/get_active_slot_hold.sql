@@ -0,0 +1,11 @@
+select
+	hold_id,
+	slot_id,
+	student_id,
+	status,
+	expires_at,
+	class_id
+from slot_holds
+where slot_id = $1
+	and status = 'active'
+for update;
@@ -0,0 +1,10 @@
+select
+	hold_id,
+	slot_id,
+	student_id,
+	status,
+	expires_at,
+	class_id
+from slot_holds
+where hold_id = $1
+for update;
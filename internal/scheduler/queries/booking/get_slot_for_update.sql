@@ -0,0 +1,11 @@
+select
+	slot_id,
+	org_id,
+	tutor_id,
+	course_id,
+	start_time,
+	end_time,
+	status
+from bookable_slots
+where slot_id = $1
+for update;
@@ -0,0 +1,8 @@
+select exists (
+	select 1
+	from user_courses
+	where user_id = $1
+		and course_id = $2
+		and role = 'teacher'
+		and status = 'active'
+);
@@ -0,0 +1,10 @@
+-- Unmatched availability chunks of a user overlapping a time range.
+select
+	start_time,
+	end_time
+from availability
+where user_id = $1
+	and not matched
+	and start_time < $3
+	and end_time > $2
+order by start_time;
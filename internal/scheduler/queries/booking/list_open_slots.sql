@@ -0,0 +1,16 @@
+select
+	slot_id,
+	tutor_id,
+	course_id,
+	start_time,
+	(extract(epoch from end_time - start_time) / 60)::integer as duration,
+	status,
+	class_id
+from bookable_slots
+where org_id = $1
+	and status = 'open'
+	and start_time >= greatest($2::timestamptz, $4::timestamptz)
+	and start_time < $3
+	and ($5::uuid is null or tutor_id = $5)
+	and ($6::uuid is null or course_id = $6)
+order by start_time;
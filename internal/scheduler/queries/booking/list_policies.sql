@@ -0,0 +1,8 @@
+select
+	policy_id,
+	tutor_id,
+	course_id,
+	audience
+from self_booking_policies
+where org_id = $1
+order by tutor_id nulls first, course_id nulls first;
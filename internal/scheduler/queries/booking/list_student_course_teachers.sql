@@ -0,0 +1,13 @@
+-- Active courses of a student with the tutors teaching them, one row per
+-- course and tutor. tutor_id is NULL for courses without a teacher.
+select
+	s.course_id,
+	t.user_id as tutor_id
+from user_courses as s
+left join user_courses as t
+	on s.course_id = t.course_id
+	and t.role = 'teacher'
+	and t.status = 'active'
+where s.user_id = $1
+	and s.role = 'student'
+	and s.status = 'active';
@@ -0,0 +1,14 @@
+select
+	slot_id,
+	tutor_id,
+	course_id,
+	start_time,
+	(extract(epoch from end_time - start_time) / 60)::integer as duration,
+	status,
+	class_id
+from bookable_slots
+where tutor_id = $1
+	and org_id = $2
+	and ($3::timestamptz is null or end_time > $3)
+	and ($4::timestamptz is null or start_time < $4)
+order by start_time;
@@ -0,0 +1,6 @@
+update slot_holds
+set
+	status = $2,
+	class_id = $3,
+	updated_at = $4
+where hold_id = $1;
@@ -0,0 +1,6 @@
+update bookable_slots
+set
+	status = $2,
+	class_id = $3,
+	updated_at = $4
+where slot_id = $1;
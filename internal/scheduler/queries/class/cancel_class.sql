@@ -0,0 +1,7 @@
+update classes
+set
+	status = 'cancelled',
+	cancel_reason = $2,
+	cancelled_at = $3,
+	updated_at = $3
+where class_id = $1;
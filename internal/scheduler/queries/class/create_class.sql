@@ -1,2 +1,2 @@
-insert into classes (class_id, course_id, org_id, start_time, duration, created_at, updated_at)
-values ($1, $2, $3, $4, $5, $6, $7);
+insert into classes (class_id, course_id, org_id, start_time, duration, max_students, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8);
@@ -0,0 +1,15 @@
+insert into class_history (
+	history_id,
+	class_id,
+	org_id,
+	action,
+	previous_start_time,
+	previous_duration,
+	start_time,
+	duration,
+	reason_code,
+	note,
+	actor_id,
+	created_at
+)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
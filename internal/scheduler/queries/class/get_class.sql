@@ -0,0 +1,12 @@
+select
+	c.class_id,
+	c.course_id,
+	co.course_name,
+	c.start_time,
+	c.duration,
+	c.max_students,
+	c.status,
+	c.cancel_reason
+from classes as c
+left join courses as co on c.course_id = co.course_id
+where c.class_id = $1;
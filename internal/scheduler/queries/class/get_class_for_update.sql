@@ -0,0 +1,12 @@
+select
+	class_id,
+	course_id,
+	org_id,
+	start_time,
+	duration,
+	status,
+	cancel_reason,
+	version
+from classes
+where class_id = $1
+for update;
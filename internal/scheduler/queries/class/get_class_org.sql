@@ -0,0 +1 @@
+select org_id from classes where class_id = $1;
@@ -0,0 +1,10 @@
+select
+	cp.class_id,
+	cp.user_id,
+	cp.role,
+	u.first_name,
+	u.last_name
+from class_participants as cp
+inner join users as u on cp.user_id = u.user_id
+where cp.class_id = any($1)
+order by cp.class_id, cp.role, u.last_name, u.first_name;
@@ -0,0 +1,3 @@
+select version
+from classes
+where class_id = $1;
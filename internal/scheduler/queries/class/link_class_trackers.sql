@@ -0,0 +1,45 @@
+with linked as (
+	insert into tracker_classes (tracking_id, class_id, status, created_at)
+	select
+		t.tracking_id,
+		c.class_id,
+		'scheduled',
+		$2
+	from classes as c
+	inner join trackers as t on c.course_id = t.course_id
+	where
+		c.class_id = $1
+		and c.status = 'scheduled'
+		and c.start_time >= t.period_start
+		and c.start_time < t.period_end
+		and t.scheduled_count < t.required_classes
+	on conflict (tracking_id, class_id) do nothing
+	returning tracking_id
+),
+
+previous as (
+	select tracking_id, status
+	from trackers
+	where tracking_id in (select tracking_id from linked)
+)
+
+update trackers as t
+set
+	scheduled_count = t.scheduled_count + 1,
+	status = case
+		when t.status = 'skipped' then t.status
+		when t.scheduled_count + 1 >= t.required_classes then 'scheduled'
+		else 'unscheduled'
+	end,
+	updated_at = $2
+from linked, previous, courses as co
+where
+	t.tracking_id = linked.tracking_id
+	and t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
@@ -0,0 +1,15 @@
+select
+	history_id,
+	class_id,
+	action,
+	previous_start_time,
+	previous_duration,
+	start_time,
+	duration,
+	reason_code,
+	note,
+	actor_id,
+	created_at
+from class_history
+where class_id = $1
+order by created_at, history_id;
@@ -0,0 +1,16 @@
+select
+	c.class_id,
+	c.start_time,
+	c.duration,
+	c.max_students,
+	c.course_id,
+	co.course_name,
+	c.status,
+	c.cancel_reason
+from classes as c
+inner join courses as co on c.course_id = co.course_id
+where
+	c.course_id = $1
+	and ($2::timestamptz is null or c.start_time >= $2)
+	and ($3::timestamptz is null or c.start_time < $3)
+order by c.start_time;
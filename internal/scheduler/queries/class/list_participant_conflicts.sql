@@ -0,0 +1,17 @@
+-- Lists the other scheduled classes that participants of a class take part
+-- in during the slot from $2 to $3.
+select
+	cp.user_id,
+	other.class_id,
+	other.start_time,
+	other.duration
+from class_participants as cp
+inner join class_participants as other_cp on cp.user_id = other_cp.user_id
+inner join classes as other on other_cp.class_id = other.class_id
+where
+	cp.class_id = $1
+	and other.class_id <> $1
+	and other.status = 'scheduled'
+	and other.start_time < $3
+	and other.start_time + make_interval(mins => other.duration) > $2
+order by other.start_time, cp.user_id;
@@ -2,8 +2,13 @@ select
 	c.class_id,
 	c.start_time,
 	c.duration,
-	c.course_id
+	c.max_students,
+	c.course_id,
+	co.course_name,
+	c.status,
+	c.cancel_reason
 from classes as c
 inner join class_participants as cp on c.class_id = cp.class_id
+left join courses as co on c.course_id = co.course_id
 where cp.user_id = $1
 order by c.start_time;
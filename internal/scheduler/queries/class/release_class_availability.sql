@@ -0,0 +1,24 @@
+-- Unmatches the availability of a class's participants in the slot the
+-- class is leaving, except where another scheduled class of the participant
+-- still covers it.
+update availability as a
+set
+	matched = false,
+	updated_at = $4
+from class_participants as cp
+where
+	cp.class_id = $1
+	and a.user_id = cp.user_id
+	and a.start_time >= $2
+	and a.end_time <= $3
+	and not exists (
+		select 1
+		from class_participants as other_cp
+		inner join classes as other on other_cp.class_id = other.class_id
+		where
+			other_cp.user_id = a.user_id
+			and other.class_id <> $1
+			and other.status = 'scheduled'
+			and other.start_time < a.end_time
+			and other.start_time + make_interval(mins => other.duration) > a.start_time
+	);
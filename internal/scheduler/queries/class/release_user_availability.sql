@@ -0,0 +1,21 @@
+-- Unmatches the availability of users leaving a class in its slot, except
+-- where another scheduled class of the user still covers it.
+update availability as a
+set
+	matched = false,
+	updated_at = $5
+where
+	a.user_id = any($1)
+	and a.start_time >= $3
+	and a.end_time <= $4
+	and not exists (
+		select 1
+		from class_participants as other_cp
+		inner join classes as other on other_cp.class_id = other.class_id
+		where
+			other_cp.user_id = a.user_id
+			and other.class_id <> $2
+			and other.status = 'scheduled'
+			and other.start_time < a.end_time
+			and other.start_time + make_interval(mins => other.duration) > a.start_time
+	);
@@ -0,0 +1,2 @@
+delete from class_participants
+where class_id = $1 and user_id = $2 and role = $3;
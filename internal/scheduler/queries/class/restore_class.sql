@@ -0,0 +1,7 @@
+update classes
+set
+	status = 'scheduled',
+	cancel_reason = null,
+	cancelled_at = null,
+	updated_at = $2
+where class_id = $1;
@@ -0,0 +1,10 @@
+update availability as a
+set
+	matched = $4,
+	updated_at = $5
+from class_participants as cp
+where
+	cp.class_id = $1
+	and a.user_id = cp.user_id
+	and a.start_time >= $2
+	and a.end_time <= $3;
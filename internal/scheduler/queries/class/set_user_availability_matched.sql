@@ -0,0 +1,8 @@
+update availability
+set
+	matched = $4,
+	updated_at = $5
+where
+	user_id = any($1)
+	and start_time >= $2
+	and end_time <= $3;
@@ -0,0 +1,32 @@
+with removed as (
+	delete from tracker_classes
+	where class_id = $1 and status = 'scheduled'
+	returning tracking_id
+),
+
+previous as (
+	select tracking_id, status
+	from trackers
+	where tracking_id in (select tracking_id from removed)
+)
+
+update trackers as t
+set
+	scheduled_count = greatest(t.scheduled_count - 1, t.completed_count),
+	status = case
+		when t.status = 'skipped' then t.status
+		when t.completed_count >= t.required_classes then 'fulfilled'
+		else 'unscheduled'
+	end,
+	updated_at = $2
+from removed, previous, courses as co
+where
+	t.tracking_id = removed.tracking_id
+	and t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
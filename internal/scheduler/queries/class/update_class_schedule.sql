@@ -0,0 +1,6 @@
+update classes
+set
+	start_time = $2,
+	duration = $3,
+	updated_at = $4
+where class_id = $1;
@@ -1,4 +1,4 @@
 insert into courses (
-	course_id, org_id, course_name, course_description, start_at, end_at, interval, frequency, created_at
+	course_id, org_id, course_name, course_description, start_at, end_at, interval, frequency, max_students, created_at
 )
-values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
@@ -0,0 +1,3 @@
+select org_id
+from courses
+where course_id = $1;
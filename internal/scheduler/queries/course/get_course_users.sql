@@ -1,3 +1,5 @@
 select user_id
 from user_courses
-where course_id = $1;
+where course_id = $1
+	and role = $2
+	and status = 'active';
@@ -0,0 +1,3 @@
+select version
+from courses
+where course_id = $1;
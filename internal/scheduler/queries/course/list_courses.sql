@@ -1,6 +1,7 @@
 select
 	course_id,
 	course_name,
-	course_description
+	course_description,
+	max_students
 from courses
 where org_id = $1;
@@ -0,0 +1,4 @@
+select version
+from courses
+where course_id = $1
+for update;
@@ -6,5 +6,7 @@ set
 	end_at = coalesce($5, end_at),
 	interval = coalesce($6, interval),
 	frequency = coalesce($7, frequency),
+	max_students = case when $9::integer is null then max_students else nullif($9, 0) end,
 	updated_at = $8
-where course_id = $1;
+where course_id = $1
+returning org_id;
@@ -0,0 +1,21 @@
+-- Enrolls a user, or re-activates their earlier enrollment keeping the date
+-- they first enrolled.
+insert into user_courses (
+	user_id, course_id, role, status, enrolled_at, status_changed_at, status_changed_by, created_at
+) values ($1, $2, $3, 'active', $4, $4, $5, $4)
+on conflict (user_id, course_id) do update
+set
+	role = excluded.role,
+	status = 'active',
+	status_changed_at = excluded.status_changed_at,
+	status_changed_by = excluded.status_changed_by,
+	drop_reason = NULL
+returning
+	user_id,
+	course_id,
+	role,
+	status,
+	enrolled_at,
+	status_changed_at,
+	status_changed_by,
+	drop_reason;
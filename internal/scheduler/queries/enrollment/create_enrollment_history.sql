@@ -0,0 +1,3 @@
+insert into enrollment_history (
+	org_id, user_id, course_id, action, previous_status, status, reason, actor_id, created_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9);
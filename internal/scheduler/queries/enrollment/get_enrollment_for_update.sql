@@ -0,0 +1,12 @@
+select
+	user_id,
+	course_id,
+	role,
+	status,
+	enrolled_at,
+	status_changed_at,
+	status_changed_by,
+	drop_reason
+from user_courses
+where course_id = $1 and user_id = $2
+for update;
@@ -0,0 +1,10 @@
+select
+	action,
+	previous_status,
+	status,
+	reason,
+	actor_id,
+	created_at
+from enrollment_history
+where course_id = $1 and user_id = $2
+order by created_at, history_id;
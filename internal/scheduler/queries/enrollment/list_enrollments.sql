@@ -0,0 +1,14 @@
+select
+	user_id,
+	course_id,
+	role,
+	status,
+	enrolled_at,
+	status_changed_at,
+	status_changed_by,
+	drop_reason
+from user_courses
+where
+	course_id = $1
+	and ($2::text is NULL or status = $2)
+order by role, enrolled_at, user_id;
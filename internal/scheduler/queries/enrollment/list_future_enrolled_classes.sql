@@ -0,0 +1,17 @@
+-- Scheduled classes of a course that a user takes part in and that have not
+-- started yet.
+select
+	c.class_id,
+	c.org_id,
+	c.start_time,
+	c.duration,
+	cp.role
+from classes as c
+inner join class_participants as cp on c.class_id = cp.class_id
+where
+	c.course_id = $1
+	and cp.user_id = $2
+	and c.status = 'scheduled'
+	and c.start_time > $3
+order by c.start_time
+for update of c;
@@ -0,0 +1,16 @@
+update user_courses
+set
+	status = $3,
+	status_changed_at = $6,
+	status_changed_by = $5,
+	drop_reason = case when $3 = 'dropped' then $4 end
+where course_id = $1 and user_id = $2
+returning
+	user_id,
+	course_id,
+	role,
+	status,
+	enrolled_at,
+	status_changed_at,
+	status_changed_by,
+	drop_reason;
@@ -0,0 +1,5 @@
+select
+	course_id,
+	org_id
+from courses
+where course_id = any($1::uuid []);
@@ -0,0 +1,8 @@
+select
+	user_id,
+	course_id
+from user_courses
+where
+	status = 'active'
+	and user_id = any($1::uuid [])
+	and course_id = any($2::uuid []);
@@ -0,0 +1,10 @@
+-- Users with any of the lowercased emails $1. When an email is used in
+-- several organizations, the user of organization $2 comes last.
+select
+	user_id,
+	org_id,
+	lower(email) as email,
+	role
+from users
+where lower(email) = any($1::text [])
+order by org_id = $2;
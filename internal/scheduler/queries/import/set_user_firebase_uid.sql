@@ -0,0 +1,5 @@
+update users
+set
+	firebase_uid = $2,
+	updated_at = $3
+where user_id = $1;
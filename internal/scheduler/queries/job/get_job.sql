@@ -0,0 +1,17 @@
+select
+	job_id,
+	kind,
+	payload,
+	status,
+	attempts,
+	max_attempts,
+	run_at,
+	locked_until,
+	locked_by,
+	last_error,
+	started_at,
+	finished_at,
+	created_at
+from jobs
+where job_id = $1
+	and org_id = $2;
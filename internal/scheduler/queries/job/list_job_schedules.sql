@@ -0,0 +1,8 @@
+select
+	name,
+	kind,
+	cron,
+	next_run_at,
+	last_run_at
+from job_schedules
+order by name;
@@ -0,0 +1,21 @@
+select
+	job_id,
+	kind,
+	payload,
+	status,
+	attempts,
+	max_attempts,
+	run_at,
+	locked_until,
+	locked_by,
+	last_error,
+	started_at,
+	finished_at,
+	created_at
+from jobs
+where
+	org_id = $1
+	and ($2::text is null or status = $2)
+	and ($3::text is null or kind = $3)
+order by created_at desc
+limit $4;
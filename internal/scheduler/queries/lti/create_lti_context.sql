@@ -0,0 +1,2 @@
+insert into lti_contexts (platform_id, context_id, course_id, created_at)
+values ($1, $2, $3, $4);
@@ -0,0 +1,6 @@
+with expired as (
+	delete from lti_launch_states
+	where expires_at <= $5
+)
+insert into lti_launch_states (state, nonce, platform_id, expires_at)
+values ($1, $2, $3, $4);
@@ -0,0 +1,2 @@
+insert into lti_platforms (platform_id, org_id, name, issuer, client_id, deployment_ids, auth_login_url, jwks_url, created_by, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10);
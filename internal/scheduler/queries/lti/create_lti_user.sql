@@ -0,0 +1,2 @@
+insert into lti_users (platform_id, subject, user_id, created_at)
+values ($1, $2, $3, $4);
@@ -0,0 +1,2 @@
+delete from lti_platforms
+where platform_id = $1 and org_id = $2;
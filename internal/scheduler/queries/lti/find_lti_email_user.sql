@@ -0,0 +1,8 @@
+select user_id, role
+from users
+where
+	org_id = $1
+	and lower(email) = lower($2)
+	and deprovisioned_at is NULL
+order by created_at
+limit 1;
@@ -0,0 +1,12 @@
+select
+	platform_id,
+	org_id,
+	issuer,
+	client_id,
+	deployment_ids,
+	auth_login_url,
+	jwks_url
+from lti_platforms
+where
+	issuer = $1
+	and ($2 = '' or client_id = $2);
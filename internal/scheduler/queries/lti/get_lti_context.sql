@@ -0,0 +1,3 @@
+select course_id
+from lti_contexts
+where platform_id = $1 and context_id = $2;
@@ -0,0 +1,11 @@
+select
+	user_id,
+	role,
+	first_name,
+	last_name,
+	coalesce(email, '') as email,
+	coalesce(status, 'active') as status,
+	firebase_uid
+from users
+where user_id = $1
+for update;
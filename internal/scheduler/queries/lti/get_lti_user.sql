@@ -0,0 +1,3 @@
+select user_id
+from lti_users
+where platform_id = $1 and subject = $2;
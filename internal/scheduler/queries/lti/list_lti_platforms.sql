@@ -0,0 +1,15 @@
+select
+	platform_id,
+	name,
+	issuer,
+	client_id,
+	deployment_ids,
+	auth_login_url,
+	jwks_url,
+	created_at,
+	updated_at
+from lti_platforms
+where
+	org_id = $1
+	and ($2::uuid is NULL or platform_id = $2)
+order by created_at;
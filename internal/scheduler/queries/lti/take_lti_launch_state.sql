@@ -0,0 +1,15 @@
+delete from lti_launch_states s
+using lti_platforms p
+where
+	s.state = $1
+	and s.expires_at > $2
+	and p.platform_id = s.platform_id
+returning
+	s.nonce,
+	p.platform_id,
+	p.org_id,
+	p.issuer,
+	p.client_id,
+	p.deployment_ids,
+	p.auth_login_url,
+	p.jwks_url;
@@ -0,0 +1,28 @@
+-- Settles the status of periods that have ended: fulfilled if enough classes
+-- were completed, skipped if none were scheduled, otherwise unscheduled.
+with previous as (
+	select tracking_id, status
+	from trackers
+	where period_end <= $1 and closed_at is null
+)
+
+update trackers as t
+set
+	status = case
+		when t.status = 'skipped' then t.status
+		when t.completed_count >= t.required_classes then 'fulfilled'
+		when t.scheduled_count = 0 then 'skipped'
+		else 'unscheduled'
+	end,
+	closed_at = $1,
+	updated_at = $1
+from previous, courses as co
+where
+	t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
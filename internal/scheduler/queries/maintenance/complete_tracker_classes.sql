@@ -0,0 +1,46 @@
+-- Marks tracked classes that have ended as completed and counts them
+-- towards their trackers.
+with done as (
+	update tracker_classes as tc
+	set status = 'completed'
+	from classes as c
+	where
+		tc.class_id = c.class_id
+		and tc.status = 'scheduled'
+		and c.status = 'scheduled'
+		and c.start_time + c.duration * interval '1 minute' <= $1
+	returning tc.tracking_id
+),
+
+counts as (
+	select tracking_id, count(*) as completed
+	from done
+	group by tracking_id
+),
+
+previous as (
+	select tracking_id, status
+	from trackers
+	where tracking_id in (select tracking_id from counts)
+)
+
+update trackers as t
+set
+	completed_count = least(t.completed_count + counts.completed, t.scheduled_count),
+	status = case
+		when t.status = 'skipped' then t.status
+		when t.completed_count + counts.completed >= t.required_classes then 'fulfilled'
+		else t.status
+	end,
+	updated_at = $1
+from counts, previous, courses as co
+where
+	t.tracking_id = counts.tracking_id
+	and t.tracking_id = previous.tracking_id
+	and t.course_id = co.course_id
+returning
+	t.tracking_id,
+	t.course_id,
+	co.org_id,
+	previous.status as previous_status,
+	t.status;
@@ -0,0 +1,42 @@
+-- Creates the tracker for one course period and links the classes already
+-- scheduled in it. Does nothing if the period already has a tracker. $6
+-- marks a period that is blacked out completely as skipped.
+with period_classes as (
+	select class_id
+	from classes
+	where
+		course_id = $1
+		and status = 'scheduled'
+		and start_time >= $2
+		and start_time < $3
+	order by start_time
+	limit $4
+),
+
+tracker as (
+	insert into trackers (
+		course_id, period_start, period_end, required_classes, scheduled_count, completed_count, status, created_at, updated_at
+	)
+	select
+		$1,
+		$2,
+		$3,
+		$4,
+		count(*),
+		0,
+		case
+			when $6 then 'skipped'
+			when count(*) >= $4 then 'scheduled'
+			else 'unscheduled'
+		end,
+		$5,
+		$5
+	from period_classes
+	on conflict (course_id, period_start) do nothing
+	returning tracking_id
+)
+
+insert into tracker_classes (tracking_id, class_id, status, created_at)
+select tracker.tracking_id, period_classes.class_id, 'scheduled', $5
+from tracker
+cross join period_classes;
@@ -0,0 +1,3 @@
+insert into availability (org_id, user_id, role, start_time, end_time, matched, source, created_at, updated_at)
+values ($1, $2, $3, $4, $5, false, 'recurring', $6, $6)
+on conflict (user_id, start_time, end_time) do nothing;
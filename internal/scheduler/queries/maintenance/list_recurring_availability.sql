@@ -0,0 +1,12 @@
+select
+	ra.user_id,
+	ra.org_id,
+	u.role,
+	ra.weekday,
+	ra.start_minute,
+	ra.end_minute,
+	ra.timezone
+from recurring_availability as ra
+inner join users as u on ra.user_id = u.user_id
+where $1::uuid is null or ra.user_id = $1
+order by ra.user_id;
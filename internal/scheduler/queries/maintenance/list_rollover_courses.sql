@@ -0,0 +1,13 @@
+select
+	course_id,
+	org_id,
+	start_at,
+	end_at,
+	interval,
+	frequency
+from courses
+where
+	start_at is not null
+	and interval is not null
+	and frequency > 0
+	and (end_at is null or end_at > $1);
@@ -0,0 +1,7 @@
+delete from availability
+where availability_id in (
+	select availability_id
+	from availability
+	where end_time < $1 and not matched
+	limit $2
+);
@@ -0,0 +1,14 @@
+select
+	notification_id,
+	user_id,
+	channel,
+	event,
+	payload ->> 'class_id' as class_id,
+	status,
+	attempts,
+	next_attempt_at,
+	last_error,
+	sent_at,
+	created_at
+from notifications
+where notification_id = $1;
@@ -0,0 +1,5 @@
+select
+	coalesce(bool_or(enabled) filter (where channel = 'email'), true) as email,
+	coalesce(bool_or(enabled) filter (where channel = 'sms'), false) as sms
+from notification_preferences
+where user_id = $1;
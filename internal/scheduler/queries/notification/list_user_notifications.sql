@@ -0,0 +1,17 @@
+select
+	notification_id,
+	user_id,
+	channel,
+	event,
+	payload ->> 'class_id' as class_id,
+	status,
+	attempts,
+	next_attempt_at,
+	last_error,
+	sent_at,
+	created_at
+from notifications
+where
+	user_id = $1
+	and ($2::text is null or status = $2)
+order by created_at desc;
@@ -0,0 +1,6 @@
+insert into notification_preferences (user_id, channel, enabled, updated_at)
+values ($1, $2, $3, $4)
+on conflict (user_id, channel) do update
+set
+	enabled = excluded.enabled,
+	updated_at = excluded.updated_at;
@@ -0,0 +1,7 @@
+update timesheets
+set
+	status = 'approved',
+	approved_by = $2,
+	approved_at = $3,
+	updated_at = $3
+where timesheet_id = $1;
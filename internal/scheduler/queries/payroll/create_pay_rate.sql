@@ -0,0 +1,2 @@
+insert into pay_rates (pay_rate_id, org_id, tutor_id, course_id, hourly_cents, currency, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $7);
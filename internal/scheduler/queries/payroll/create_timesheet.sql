@@ -0,0 +1,2 @@
+insert into timesheets (timesheet_id, org_id, tutor_id, period_start, period_end, currency, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $7);
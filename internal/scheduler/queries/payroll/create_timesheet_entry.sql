@@ -0,0 +1,4 @@
+insert into timesheet_entries (
+	entry_id, timesheet_id, kind, class_id, course_name, start_time, duration, paid_minutes, pay_rate_id,
+	hourly_cents, amount_cents, note, created_by, created_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
@@ -0,0 +1,3 @@
+-- Removes the computed entries of a timesheet, keeping its adjustments
+delete from timesheet_entries
+where timesheet_id = $1 and kind != 'adjustment';
@@ -0,0 +1,2 @@
+delete from pay_rates
+where pay_rate_id = $1 and org_id = $2;
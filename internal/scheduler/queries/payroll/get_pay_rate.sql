@@ -0,0 +1,9 @@
+select
+	pay_rate_id,
+	tutor_id,
+	course_id,
+	hourly_cents,
+	currency,
+	created_at
+from pay_rates
+where pay_rate_id = $1 and org_id = $2;
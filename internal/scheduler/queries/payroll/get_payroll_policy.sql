@@ -0,0 +1,5 @@
+select
+	late_cancellation_hours,
+	late_cancellation_pay_percent
+from organizations
+where organization_id = $1;
@@ -0,0 +1,7 @@
+select
+	timesheet_id,
+	status,
+	currency
+from timesheets
+where tutor_id = $1 and period_start = $2 and period_end = $3
+for update;
@@ -0,0 +1,18 @@
+select
+	t.timesheet_id,
+	t.tutor_id,
+	u.first_name || ' ' || u.last_name as tutor_name,
+	t.period_start,
+	t.period_end,
+	t.status,
+	t.currency,
+	t.taught_minutes,
+	t.late_cancellation_minutes,
+	t.paid_minutes,
+	t.total_cents,
+	t.approved_by,
+	t.approved_at,
+	t.created_at
+from timesheets as t
+join users as u on t.tutor_id = u.user_id
+where t.timesheet_id = $1 and t.org_id = $2;
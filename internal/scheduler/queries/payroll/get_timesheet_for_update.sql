@@ -0,0 +1,8 @@
+select
+	timesheet_id,
+	tutor_id,
+	status,
+	currency
+from timesheets
+where timesheet_id = $1 and org_id = $2
+for update;
@@ -0,0 +1,21 @@
+-- Whether an approved timesheet of a teacher of class $1, or of one of the
+-- tutors $3, covers any of the times $2.
+select exists (
+	select 1
+	from timesheets as t
+	where
+		t.status = 'approved'
+		and (
+			t.tutor_id in (
+				select cp.user_id
+				from class_participants as cp
+				where cp.class_id = $1 and cp.role = 'teacher'
+			)
+			or t.tutor_id = any($3::uuid[])
+		)
+		and exists (
+			select 1
+			from unnest($2::timestamptz[]) as times (at)
+			where times.at >= t.period_start and times.at < t.period_end
+		)
+);
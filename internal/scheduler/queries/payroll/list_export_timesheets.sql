@@ -0,0 +1,22 @@
+-- Approved timesheets of an organization whose pay period starts in [$2, $3)
+select
+	t.timesheet_id,
+	t.tutor_id,
+	u.first_name || ' ' || u.last_name as tutor_name,
+	u.email as tutor_email,
+	t.period_start,
+	t.period_end,
+	t.currency,
+	t.taught_minutes,
+	t.late_cancellation_minutes,
+	t.paid_minutes,
+	t.total_cents,
+	t.approved_at
+from timesheets as t
+join users as u on t.tutor_id = u.user_id
+where
+	t.org_id = $1
+	and t.status = 'approved'
+	and t.period_start >= $2
+	and t.period_start < $3
+order by t.period_start, u.last_name, u.first_name;
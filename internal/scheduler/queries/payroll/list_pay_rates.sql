@@ -0,0 +1,10 @@
+select
+	pay_rate_id,
+	tutor_id,
+	course_id,
+	hourly_cents,
+	currency,
+	created_at
+from pay_rates
+where org_id = $1
+order by tutor_id nulls first, course_id nulls first;
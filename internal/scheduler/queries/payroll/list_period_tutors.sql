@@ -0,0 +1,25 @@
+-- Tutors of the organization who taught a class starting in [$2, $3) or
+-- already have a timesheet for exactly that period.
+select u.user_id
+from users as u
+where
+	u.org_id = $1
+	and u.role = 'tutor'
+	and (
+		exists (
+			select 1
+			from class_participants as cp
+			join classes as c on cp.class_id = c.class_id
+			where
+				cp.user_id = u.user_id
+				and cp.role = 'teacher'
+				and c.start_time >= $2
+				and c.start_time < $3
+		)
+		or exists (
+			select 1
+			from timesheets as t
+			where t.tutor_id = u.user_id and t.period_start = $2 and t.period_end = $3
+		)
+	)
+order by u.last_name, u.first_name;
@@ -0,0 +1,16 @@
+select
+	timesheet_id,
+	entry_id,
+	kind,
+	class_id,
+	course_name,
+	start_time,
+	duration,
+	paid_minutes,
+	pay_rate_id,
+	hourly_cents,
+	amount_cents,
+	note
+from timesheet_entries
+where timesheet_id = any($1::uuid[])
+order by start_time nulls last, created_at;
@@ -0,0 +1,26 @@
+-- Timesheets of an organization, optionally filtered by tutor ($2), status
+-- ($3) and pay periods overlapping [$4, $5).
+select
+	t.timesheet_id,
+	t.tutor_id,
+	u.first_name || ' ' || u.last_name as tutor_name,
+	t.period_start,
+	t.period_end,
+	t.status,
+	t.currency,
+	t.taught_minutes,
+	t.late_cancellation_minutes,
+	t.paid_minutes,
+	t.total_cents,
+	t.approved_by,
+	t.approved_at,
+	t.created_at
+from timesheets as t
+join users as u on t.tutor_id = u.user_id
+where
+	t.org_id = $1
+	and ($2::uuid is NULL or t.tutor_id = $2)
+	and ($3::text is NULL or t.status = $3)
+	and ($4::timestamptz is NULL or t.period_end > $4)
+	and ($5::timestamptz is NULL or t.period_start < $5)
+order by t.period_start desc, u.last_name, u.first_name;
@@ -0,0 +1,22 @@
+-- Classes starting in [$2, $3) that the tutor teaches, with the tutor's
+-- attendance.
+select
+	c.class_id,
+	c.course_id,
+	co.course_name,
+	c.start_time,
+	c.duration,
+	c.status,
+	c.cancel_reason,
+	c.cancelled_at,
+	ca.attended
+from class_participants as cp
+join classes as c on cp.class_id = c.class_id
+left join courses as co on c.course_id = co.course_id
+left join class_attendance as ca on cp.class_id = ca.class_id and cp.user_id = ca.user_id
+where
+	cp.user_id = $1
+	and cp.role = 'teacher'
+	and c.start_time >= $2
+	and c.start_time < $3
+order by c.start_time;
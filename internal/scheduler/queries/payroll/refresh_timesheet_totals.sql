@@ -0,0 +1,19 @@
+-- Recomputes the totals of a timesheet from its entries, in currency $2
+update timesheets as t
+set
+	currency = $2,
+	taught_minutes = totals.taught_minutes,
+	late_cancellation_minutes = totals.late_cancellation_minutes,
+	paid_minutes = totals.paid_minutes,
+	total_cents = totals.total_cents,
+	updated_at = $3
+from (
+	select
+		coalesce(sum(paid_minutes) filter (where kind = 'taught'), 0) as taught_minutes,
+		coalesce(sum(paid_minutes) filter (where kind = 'late_cancellation'), 0) as late_cancellation_minutes,
+		coalesce(sum(paid_minutes), 0) as paid_minutes,
+		coalesce(sum(amount_cents), 0) as total_cents
+	from timesheet_entries
+	where timesheet_id = $1
+) as totals
+where t.timesheet_id = $1;
@@ -0,0 +1,6 @@
+update organizations
+set
+	late_cancellation_hours = $2,
+	late_cancellation_pay_percent = $3,
+	updated_at = $4
+where organization_id = $1;
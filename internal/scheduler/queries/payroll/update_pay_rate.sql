@@ -0,0 +1,8 @@
+update pay_rates
+set
+	tutor_id = $3,
+	course_id = $4,
+	hourly_cents = $5,
+	currency = $6,
+	updated_at = $7
+where pay_rate_id = $1 and org_id = $2;
@@ -0,0 +1,16 @@
+select
+	(
+		select count(*)
+		from users
+		where org_id = $1 and status = 'active'
+	) as users,
+	(
+		select count(*)
+		from courses
+		where org_id = $1
+	) as courses,
+	(
+		select count(*)
+		from classes
+		where org_id = $1 and status <> 'cancelled' and start_time >= $2 and start_time < $3
+	) as monthly_classes;
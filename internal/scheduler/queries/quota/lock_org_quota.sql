@@ -0,0 +1,8 @@
+select
+	plan,
+	max_users,
+	max_courses,
+	max_monthly_classes
+from org_quotas
+where org_id = $1
+for update;
@@ -0,0 +1,2 @@
+insert into resource_opening_hours (resource_id, weekday, open_minute, close_minute)
+values ($1, $2, $3, $4);
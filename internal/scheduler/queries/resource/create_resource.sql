@@ -0,0 +1,2 @@
+insert into resources (resource_id, org_id, name, type, capacity, timezone, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $7);
@@ -0,0 +1,7 @@
+update resources
+set
+	active = false,
+	updated_at = $3
+where resource_id = $1
+	and org_id = $2
+	and active;
@@ -0,0 +1,2 @@
+delete from resource_opening_hours
+where resource_id = $1;
@@ -0,0 +1,10 @@
+select
+	resource_id,
+	name,
+	type,
+	capacity,
+	timezone,
+	active
+from resources
+where resource_id = $1
+	and org_id = $2;
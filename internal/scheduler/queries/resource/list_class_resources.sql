@@ -0,0 +1,6 @@
+select
+	class_id,
+	resource_id
+from class_resources
+where class_id = any($1)
+order by class_id, created_at, resource_id;
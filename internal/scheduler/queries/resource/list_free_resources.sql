@@ -0,0 +1,22 @@
+-- Active resources of the organization without a reservation overlapping
+-- the slot. Opening hours are checked by the caller.
+select
+	r.resource_id,
+	r.name,
+	r.type,
+	r.capacity,
+	r.timezone,
+	r.active
+from resources as r
+where r.org_id = $1
+	and r.active
+	and ($4::text is null or r.type = $4)
+	and ($5::integer is null or r.capacity is null or r.capacity >= $5)
+	and not exists (
+		select 1
+		from class_resources as cr
+		where cr.resource_id = r.resource_id
+			and cr.active
+			and cr.during && tstzrange($2, $3)
+	)
+order by r.capacity nulls last, r.name;
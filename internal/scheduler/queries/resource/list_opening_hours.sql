@@ -0,0 +1,8 @@
+select
+	resource_id,
+	weekday,
+	open_minute,
+	close_minute
+from resource_opening_hours
+where resource_id = any($1)
+order by resource_id, weekday, open_minute;
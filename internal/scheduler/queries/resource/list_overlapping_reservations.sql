@@ -0,0 +1,14 @@
+select
+	cr.resource_id,
+	cr.class_id,
+	co.course_name,
+	lower(cr.during) as start_time,
+	upper(cr.during) as end_time
+from class_resources as cr
+join classes as c on cr.class_id = c.class_id
+left join courses as co on c.course_id = co.course_id
+where cr.resource_id = any($1)
+	and cr.active
+	and cr.during && tstzrange($2, $3)
+	and ($4::uuid is null or cr.class_id <> $4)
+order by lower(cr.during);
@@ -0,0 +1,12 @@
+select
+	resource_id,
+	name,
+	type,
+	capacity,
+	timezone,
+	active
+from resources
+where org_id = $1
+	and active
+	and ($2::text is null or type = $2)
+order by type, name;
@@ -0,0 +1,12 @@
+select
+	resource_id,
+	name,
+	type,
+	capacity,
+	timezone,
+	active
+from resources
+where resource_id = any($1)
+	and org_id = $2
+order by resource_id
+for update;
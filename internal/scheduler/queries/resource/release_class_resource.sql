@@ -0,0 +1,3 @@
+delete from class_resources
+where class_id = $1
+	and resource_id = $2;
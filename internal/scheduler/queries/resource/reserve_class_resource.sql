@@ -0,0 +1,6 @@
+insert into class_resources (class_id, resource_id, org_id, during, active, created_at)
+values ($1, $2, $3, tstzrange($4, $5), true, $6)
+on conflict (class_id, resource_id) do update
+set
+	during = excluded.during,
+	active = true;
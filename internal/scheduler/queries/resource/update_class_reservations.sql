@@ -0,0 +1,6 @@
+-- Moves or (de)activates every reservation of a class.
+update class_resources
+set
+	during = tstzrange($2, $3),
+	active = $4
+where class_id = $1;
@@ -0,0 +1,10 @@
+update resources
+set
+	name = $3,
+	type = $4,
+	capacity = $5,
+	timezone = $6,
+	updated_at = $7
+where resource_id = $1
+	and org_id = $2
+	and active;
@@ -0,0 +1,3 @@
+insert into roster_sources (
+	source_id, org_id, name, format, url, token_url, client_id, client_secret, created_by, created_at, updated_at
+) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10);
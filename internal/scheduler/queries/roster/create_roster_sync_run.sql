@@ -0,0 +1,2 @@
+insert into roster_sync_runs (run_id, source_id, org_id, dry_run, status, report, error, started_at, finished_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9);
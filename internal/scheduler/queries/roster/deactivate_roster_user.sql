@@ -0,0 +1,5 @@
+update users
+set
+	status = 'inactive',
+	updated_at = $3
+where user_id = $1 and org_id = $2 and status = 'active';
@@ -0,0 +1,2 @@
+delete from roster_sources
+where source_id = $1 and org_id = $2;
@@ -0,0 +1,16 @@
+-- The source with its credentials. $2 limits it to an organization unless
+-- NULL.
+select
+	source_id,
+	org_id,
+	name,
+	format,
+	url,
+	token_url,
+	client_id,
+	client_secret,
+	created_by
+from roster_sources
+where
+	source_id = $1
+	and ($2::uuid is NULL or org_id = $2);
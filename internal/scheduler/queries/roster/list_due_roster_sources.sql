@@ -0,0 +1,14 @@
+-- Sources synced nightly: active ones whose admin still exists
+select
+	source_id,
+	org_id,
+	name,
+	format,
+	url,
+	token_url,
+	client_id,
+	client_secret,
+	created_by
+from roster_sources
+where active and created_by is not NULL
+order by created_at;
@@ -0,0 +1,8 @@
+select
+	sourced_id,
+	local_id,
+	course_id,
+	checksum,
+	removed_at
+from roster_records
+where source_id = $1 and record_type = $2;
@@ -0,0 +1,17 @@
+select
+	source_id,
+	name,
+	format,
+	url,
+	token_url,
+	client_id,
+	client_secret is not NULL as has_client_secret,
+	active,
+	last_synced_at,
+	created_at,
+	updated_at
+from roster_sources
+where
+	org_id = $1
+	and ($2::uuid is NULL or source_id = $2)
+order by created_at;
@@ -0,0 +1,13 @@
+select
+	run_id,
+	source_id,
+	dry_run,
+	status,
+	report,
+	error,
+	started_at,
+	finished_at
+from roster_sync_runs
+where source_id = $1
+order by started_at desc
+limit $2;
@@ -0,0 +1,6 @@
+-- Users of the organization among $2, with their role
+select
+	user_id,
+	role
+from users
+where org_id = $1 and user_id = any($2::uuid []);
@@ -0,0 +1,4 @@
+select source_id
+from roster_sources
+where source_id = $1
+for update;
@@ -0,0 +1,5 @@
+update roster_records
+set
+	removed_at = $4,
+	synced_at = $4
+where source_id = $1 and record_type = $2 and sourced_id = $3;
@@ -0,0 +1,5 @@
+update roster_sources
+set
+	last_synced_at = $2,
+	updated_at = $2
+where source_id = $1;
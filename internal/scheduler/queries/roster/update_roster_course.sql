@@ -0,0 +1,5 @@
+update courses
+set
+	course_name = $3,
+	updated_at = $4
+where course_id = $1 and org_id = $2;
@@ -0,0 +1,10 @@
+update users
+set
+	first_name = $3,
+	last_name = $4,
+	email = $5,
+	phone_number = $6,
+	status = 'active',
+	updated_at = $7
+where user_id = $1 and org_id = $2
+returning role;
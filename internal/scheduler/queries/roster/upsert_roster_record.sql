@@ -0,0 +1,9 @@
+insert into roster_records (source_id, record_type, sourced_id, local_id, course_id, checksum, synced_at)
+values ($1, $2, $3, $4, $5, $6, $7)
+on conflict (source_id, record_type, sourced_id) do update
+set
+	local_id = excluded.local_id,
+	course_id = excluded.course_id,
+	checksum = excluded.checksum,
+	removed_at = NULL,
+	synced_at = excluded.synced_at;
@@ -0,0 +1,5 @@
+-- The organization of the token with hash $1, marking the token used
+update scim_tokens
+set last_used_at = $2
+where token_hash = $1
+returning org_id;
@@ -0,0 +1,2 @@
+insert into scim_tokens (token_id, org_id, description, token_hash, created_by, created_at)
+values ($1, $2, $3, $4, $5, $6);
@@ -0,0 +1,2 @@
+insert into users (user_id, org_id, role, first_name, last_name, phone_number, email, status, external_id, created_at, updated_at)
+values ($1, $2, 'student', $3, $4, $5, $6, $7, $8, $9, $9);
@@ -0,0 +1,2 @@
+delete from scim_tokens
+where token_id = $1 and org_id = $2;
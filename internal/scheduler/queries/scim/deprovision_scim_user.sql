@@ -0,0 +1,7 @@
+update users
+set
+	status = 'inactive',
+	deprovisioned_at = $3,
+	updated_at = $3
+where user_id = $1 and org_id = $2 and deprovisioned_at is NULL
+returning firebase_uid;
@@ -0,0 +1,10 @@
+-- The user of the organization with email $2, preferring one that has not
+-- been deprovisioned
+select
+	user_id,
+	deprovisioned_at is not NULL as deprovisioned
+from users
+where org_id = $1 and lower(email) = lower($2)
+order by deprovisioned_at nulls first, created_at
+limit 1
+for update;
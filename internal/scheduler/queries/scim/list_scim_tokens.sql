@@ -0,0 +1,10 @@
+select
+	token_id,
+	description,
+	last_used_at,
+	created_at
+from scim_tokens
+where
+	org_id = $1
+	and ($2::uuid is NULL or token_id = $2)
+order by created_at;
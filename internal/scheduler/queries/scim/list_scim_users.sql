@@ -0,0 +1,19 @@
+-- Users of the organization that have not been deprovisioned, or only user $2
+select
+	user_id,
+	role,
+	first_name,
+	last_name,
+	coalesce(email, '') as email,
+	coalesce(phone_number, '') as phone_number,
+	coalesce(status, 'active') as status,
+	coalesce(external_id, '') as external_id,
+	firebase_uid,
+	created_at,
+	updated_at
+from users
+where
+	org_id = $1
+	and deprovisioned_at is NULL
+	and ($2::uuid is NULL or user_id = $2)
+order by created_at, user_id;
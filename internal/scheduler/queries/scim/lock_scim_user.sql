@@ -0,0 +1,4 @@
+select user_id
+from users
+where user_id = $1 and org_id = $2 and deprovisioned_at is NULL
+for update;
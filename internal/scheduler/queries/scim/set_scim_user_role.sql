@@ -0,0 +1,8 @@
+-- Gives users among $1 role $3, returning those found with their sign-in
+-- account
+update users
+set
+	role = $3,
+	updated_at = $4
+where user_id = any($1::uuid []) and org_id = $2 and deprovisioned_at is NULL
+returning user_id, firebase_uid;
@@ -0,0 +1,12 @@
+-- Also restores a deprovisioned user provisioned again
+update users
+set
+	first_name = $3,
+	last_name = $4,
+	phone_number = $5,
+	email = $6,
+	status = $7,
+	external_id = $8,
+	deprovisioned_at = NULL,
+	updated_at = $9
+where user_id = $1 and org_id = $2;
@@ -0,0 +1,5 @@
+update time_off_requests
+set
+	status = 'cancelled',
+	updated_at = $2
+where request_id = $1;
@@ -0,0 +1,27 @@
+-- Removes the tutor's open availability during approved time off and
+-- withdraws their unbooked slots, releasing any holds on them.
+with removed_availability as (
+	delete from availability
+	where user_id = $1
+		and not matched
+		and start_time < $3
+		and end_time > $2
+),
+withdrawn_slots as (
+	update bookable_slots
+	set
+		status = 'withdrawn',
+		updated_at = $4
+	where tutor_id = $1
+		and status in ('open', 'held')
+		and start_time < $3
+		and end_time > $2
+	returning slot_id
+)
+update slot_holds as h
+set
+	status = 'released',
+	updated_at = $4
+from withdrawn_slots as w
+where h.slot_id = w.slot_id
+	and h.status = 'active';
@@ -0,0 +1,2 @@
+insert into time_off_requests (request_id, org_id, tutor_id, start_time, end_time, reason, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7, $7);
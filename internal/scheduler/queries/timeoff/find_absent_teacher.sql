@@ -0,0 +1,13 @@
+-- Teacher of the class with approved time off during it, if any.
+select cp.user_id
+from class_participants as cp
+inner join time_off_affected_classes as a on cp.class_id = a.class_id
+inner join time_off_requests as t on a.request_id = t.request_id
+where
+	cp.class_id = $1
+	and cp.role = 'teacher'
+	and t.tutor_id = cp.user_id
+	and t.status = 'approved'
+	and a.status = 'needs_substitute'
+order by t.created_at
+limit 1;
@@ -0,0 +1,16 @@
+-- Flags every scheduled class the tutor teaches that overlaps the time off.
+insert into time_off_affected_classes (request_id, class_id, org_id, created_at)
+select
+	$1,
+	c.class_id,
+	c.org_id,
+	$5
+from classes as c
+inner join class_participants as cp on c.class_id = cp.class_id
+where
+	cp.user_id = $2
+	and cp.role = 'teacher'
+	and c.status = 'scheduled'
+	and c.start_time < $4
+	and c.start_time + make_interval(mins => c.duration) > $3
+on conflict (request_id, class_id) do nothing;
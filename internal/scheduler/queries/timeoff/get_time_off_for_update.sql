@@ -0,0 +1,15 @@
+select
+	request_id,
+	org_id,
+	tutor_id,
+	start_time,
+	end_time,
+	reason,
+	status,
+	reviewed_by,
+	reviewed_at,
+	review_note,
+	created_at
+from time_off_requests
+where request_id = $1
+for update;
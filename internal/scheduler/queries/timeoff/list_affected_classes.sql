@@ -0,0 +1,14 @@
+select
+	a.request_id,
+	a.class_id,
+	co.course_name,
+	c.start_time,
+	c.duration,
+	c.status as class_status,
+	a.status,
+	a.substitute_id
+from time_off_affected_classes as a
+inner join classes as c on a.class_id = c.class_id
+left join courses as co on c.course_id = co.course_id
+where a.request_id = any($1)
+order by c.start_time;
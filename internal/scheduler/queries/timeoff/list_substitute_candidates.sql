@@ -0,0 +1,72 @@
+-- Tutors of the class's organization who could take it over: not already in
+-- the class, teaching its course (any tutor for classes without a course),
+-- without approved time off or another scheduled class during it, with the
+-- minutes they teach in the week around the class. Availability and
+-- workload limits are checked, and the tutors ranked, by the caller. $2, if
+-- set, restricts the result to that tutor.
+with target as (
+	select
+		class_id,
+		org_id,
+		course_id,
+		start_time,
+		start_time + make_interval(mins => duration) as end_time
+	from classes
+	where class_id = $1
+)
+select
+	u.user_id,
+	u.first_name,
+	u.last_name,
+	coalesce((
+		select sum(oc.duration)
+		from classes as oc
+		inner join class_participants as ocp on oc.class_id = ocp.class_id
+		where
+			ocp.user_id = u.user_id
+			and ocp.role = 'teacher'
+			and oc.status = 'scheduled'
+			and oc.start_time >= cl.start_time - interval '3 days 12 hours'
+			and oc.start_time < cl.start_time + interval '3 days 12 hours'
+	), 0)::integer as scheduled_minutes
+from users as u
+cross join target as cl
+where
+	u.org_id = cl.org_id
+	and u.role = 'tutor'
+	and ($2::uuid is NULL or u.user_id = $2)
+	and coalesce(u.status, 'active') = 'active'
+	and not exists (
+		select 1
+		from class_participants as cp
+		where cp.class_id = cl.class_id
+			and cp.user_id = u.user_id
+	)
+	and (
+		cl.course_id is NULL
+		or exists (
+			select 1
+			from user_courses as uc
+			where uc.user_id = u.user_id
+				and uc.course_id = cl.course_id
+				and uc.role = 'teacher'
+				and uc.status = 'active'
+		)
+	)
+	and not exists (
+		select 1
+		from time_off_requests as t
+		where t.tutor_id = u.user_id
+			and t.status = 'approved'
+			and t.start_time < cl.end_time
+			and t.end_time > cl.start_time
+	)
+	and not exists (
+		select 1
+		from classes as oc
+		inner join class_participants as ocp on oc.class_id = ocp.class_id
+		where ocp.user_id = u.user_id
+			and oc.status = 'scheduled'
+			and oc.start_time < cl.end_time
+			and oc.start_time + make_interval(mins => oc.duration) > cl.start_time
+	);
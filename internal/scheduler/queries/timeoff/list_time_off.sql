@@ -0,0 +1,16 @@
+select
+	request_id,
+	tutor_id,
+	start_time,
+	end_time,
+	reason,
+	status,
+	reviewed_by,
+	reviewed_at,
+	review_note,
+	created_at
+from time_off_requests
+where org_id = $1
+	and ($2::uuid is null or tutor_id = $2)
+	and ($3::text is null or status = $3)
+order by start_time desc, created_at desc;
@@ -0,0 +1,13 @@
+-- Marks a class as taken over for every approved time off of the replaced
+-- teacher that flagged it.
+update time_off_affected_classes as a
+set
+	status = 'reassigned',
+	substitute_id = $3,
+	reassigned_at = $4
+from time_off_requests as t
+where
+	a.request_id = t.request_id
+	and a.class_id = $1
+	and t.tutor_id = $2
+	and a.status = 'needs_substitute';
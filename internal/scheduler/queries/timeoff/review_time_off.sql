@@ -0,0 +1,8 @@
+update time_off_requests
+set
+	status = $2,
+	reviewed_by = $3,
+	reviewed_at = $4,
+	review_note = $5,
+	updated_at = $4
+where request_id = $1;
@@ -0,0 +1,3 @@
+select org_id
+from users
+where user_id = $1;
@@ -0,0 +1,12 @@
+insert into waitlist_entries (org_id, course_id, class_id, user_id, position, created_at, updated_at)
+select
+	$1,
+	$2,
+	$3,
+	$4,
+	coalesce(max(position), 0) + 1,
+	$5,
+	$5
+from waitlist_entries
+where course_id = $2 or class_id = $3
+on conflict do nothing;
@@ -0,0 +1,10 @@
+update waitlist_entries as w
+set
+	status = 'expired',
+	updated_at = $1
+where
+	(w.status = 'offered' and w.offer_expires_at <= $1)
+	or (
+		w.status in ('waiting', 'offered')
+		and w.class_id in (select class_id from classes where start_time <= $1)
+	);
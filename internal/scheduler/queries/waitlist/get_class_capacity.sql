@@ -0,0 +1,16 @@
+select
+	c.org_id,
+	c.max_students,
+	(
+		select count(*)
+		from class_participants as cp
+		where cp.class_id = c.class_id and cp.role = 'student'
+	) + (
+		select count(*)
+		from waitlist_entries as w
+		where w.class_id = c.class_id and w.status = 'offered'
+	) as taken,
+	c.status = 'scheduled' and c.start_time > $2 as open
+from classes as c
+where c.class_id = $1
+for update of c;
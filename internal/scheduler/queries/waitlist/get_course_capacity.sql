@@ -0,0 +1,16 @@
+select
+	c.org_id,
+	c.max_students,
+	(
+		select count(*)
+		from user_courses as uc
+		where uc.course_id = c.course_id and uc.role = 'student' and uc.status = 'active'
+	) + (
+		select count(*)
+		from waitlist_entries as w
+		where w.course_id = c.course_id and w.status = 'offered'
+	) as taken,
+	true as open
+from courses as c
+where c.course_id = $1
+for update of c;
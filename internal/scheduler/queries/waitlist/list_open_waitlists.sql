@@ -0,0 +1,5 @@
+select distinct
+	course_id,
+	class_id
+from waitlist_entries
+where status = 'waiting';
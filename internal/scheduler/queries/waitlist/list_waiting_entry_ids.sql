@@ -0,0 +1,4 @@
+select waitlist_id
+from waitlist_entries
+where (course_id = $1 or class_id = $2) and status = 'waiting'
+order by position, created_at;
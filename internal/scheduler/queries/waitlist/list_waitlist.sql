@@ -0,0 +1,17 @@
+select
+	w.waitlist_id,
+	w.course_id,
+	w.class_id,
+	w.user_id,
+	u.first_name,
+	u.last_name,
+	w.position,
+	w.status,
+	w.offered_at,
+	w.offer_expires_at,
+	w.responded_at,
+	w.created_at
+from waitlist_entries as w
+inner join users as u on w.user_id = u.user_id
+where (w.course_id = $1 or w.class_id = $2) and w.status in ('waiting', 'offered')
+order by w.status <> 'offered', w.position, w.created_at;
@@ -0,0 +1,23 @@
+with next as (
+	select waitlist_id
+	from waitlist_entries
+	where (course_id = $1 or class_id = $2) and status = 'waiting'
+	order by position, created_at
+	limit $3
+	for update
+)
+
+update waitlist_entries as w
+set
+	status = 'offered',
+	offered_at = $4,
+	offer_expires_at = $5,
+	updated_at = $4
+from next
+where w.waitlist_id = next.waitlist_id
+returning
+	w.waitlist_id,
+	w.course_id,
+	w.class_id,
+	w.user_id,
+	w.offer_expires_at;
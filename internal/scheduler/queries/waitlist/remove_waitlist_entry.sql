@@ -0,0 +1,5 @@
+update waitlist_entries
+set
+	status = 'removed',
+	updated_at = $2
+where waitlist_id = $1 and status in ('waiting', 'offered');
@@ -0,0 +1,6 @@
+update waitlist_entries as w
+set
+	position = o.position,
+	updated_at = $2
+from unnest($1::uuid []) with ordinality as o (waitlist_id, position)
+where w.waitlist_id = o.waitlist_id and w.status = 'waiting';
@@ -0,0 +1,9 @@
+update waitlist_entries
+set
+	status = 'accepted',
+	responded_at = $4,
+	updated_at = $4
+where
+	(course_id = $1 or class_id = $2)
+	and user_id = any($3)
+	and status in ('waiting', 'offered');
@@ -0,0 +1,9 @@
+update waitlist_entries
+set
+	status = $2,
+	responded_at = $3,
+	updated_at = $3
+where
+	waitlist_id = $1
+	and status = 'offered'
+	and ($2 <> 'accepted' or offer_expires_at > $3);
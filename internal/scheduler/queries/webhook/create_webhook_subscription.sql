@@ -0,0 +1,2 @@
+insert into webhook_subscriptions (subscription_id, org_id, url, secret, events, description, active, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, true, $7, $7);
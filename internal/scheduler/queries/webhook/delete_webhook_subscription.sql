@@ -0,0 +1,2 @@
+delete from webhook_subscriptions
+where subscription_id = $1 and org_id = $2;
@@ -0,0 +1,15 @@
+select
+	delivery_id,
+	subscription_id,
+	event,
+	event_id,
+	payload,
+	status,
+	attempts,
+	next_attempt_at,
+	last_status_code,
+	last_error,
+	delivered_at,
+	created_at
+from webhook_deliveries
+where delivery_id = $1 and org_id = $2;
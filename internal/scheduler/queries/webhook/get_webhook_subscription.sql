@@ -0,0 +1,10 @@
+select
+	subscription_id,
+	url,
+	events,
+	description,
+	active,
+	created_at,
+	updated_at
+from webhook_subscriptions
+where subscription_id = $1 and org_id = $2;
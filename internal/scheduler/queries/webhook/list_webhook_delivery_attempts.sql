@@ -0,0 +1,9 @@
+select
+	attempt_id,
+	status_code,
+	error,
+	duration_ms,
+	attempted_at
+from webhook_delivery_attempts
+where delivery_id = $1
+order by attempted_at;
@@ -0,0 +1,11 @@
+select
+	subscription_id,
+	url,
+	events,
+	description,
+	active,
+	created_at,
+	updated_at
+from webhook_subscriptions
+where org_id = $1
+order by created_at;
@@ -0,0 +1,8 @@
+update webhook_subscriptions
+set
+	url = coalesce($3, url),
+	events = coalesce($4, events),
+	description = coalesce($5, description),
+	active = coalesce($6, active),
+	updated_at = $7
+where subscription_id = $1 and org_id = $2;
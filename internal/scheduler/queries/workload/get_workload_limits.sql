@@ -0,0 +1,9 @@
+select
+	user_id,
+	max_minutes_per_day,
+	max_minutes_per_week,
+	max_consecutive_classes,
+	break_minutes,
+	timezone
+from tutor_workload_limits
+where user_id = $1;
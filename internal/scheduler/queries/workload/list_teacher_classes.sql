@@ -0,0 +1,14 @@
+select
+	cp.user_id,
+	c.start_time,
+	c.duration
+from classes as c
+inner join class_participants as cp on c.class_id = cp.class_id
+where
+	cp.user_id = any($1)
+	and cp.role = 'teacher'
+	and c.status = 'scheduled'
+	and c.start_time >= $2
+	and c.start_time < $3
+	and ($4::uuid is null or c.class_id <> $4)
+order by c.start_time;
@@ -0,0 +1,11 @@
+select
+	user_id,
+	max_minutes_per_day,
+	max_minutes_per_week,
+	max_consecutive_classes,
+	break_minutes,
+	timezone
+from tutor_workload_limits
+where user_id = any($1)
+order by user_id
+for update;
@@ -0,0 +1,44 @@
+-- Read from the analytics rollups, so the report trails changes until the
+-- next analytics refresh.
+with teaching as (
+	select
+		t.user_id,
+		count(*) filter (where s.status = 'scheduled') as class_count,
+		coalesce(sum(s.duration) filter (where s.status = 'scheduled'), 0) as scheduled_minutes,
+		count(*) filter (where s.status = 'cancelled') as cancelled_count
+	from analytics_class_stats as s
+	cross join lateral unnest(s.teacher_ids) as t (user_id)
+	where
+		s.org_id = $1
+		and s.start_time >= $2
+		and s.start_time < $3
+	group by t.user_id
+),
+
+available as (
+	select
+		a.user_id,
+		sum(a.minutes) as available_minutes
+	from analytics_availability_hours as a
+	where
+		a.org_id = $1
+		and a.hour >= $2
+		and a.hour < $3
+	group by a.user_id
+)
+
+select
+	u.user_id,
+	u.first_name,
+	u.last_name,
+	coalesce(t.class_count, 0) as class_count,
+	coalesce(t.scheduled_minutes, 0) as scheduled_minutes,
+	coalesce(t.cancelled_count, 0) as cancelled_count,
+	coalesce(av.available_minutes, 0) as available_minutes,
+	l.max_minutes_per_week
+from users as u
+left join teaching as t on u.user_id = t.user_id
+left join available as av on u.user_id = av.user_id
+left join tutor_workload_limits as l on u.user_id = l.user_id
+where u.org_id = $1 and u.role = 'tutor'
+order by scheduled_minutes desc, u.last_name, u.first_name;
@@ -0,0 +1,13 @@
+insert into tutor_workload_limits (
+	user_id, org_id, max_minutes_per_day, max_minutes_per_week, max_consecutive_classes, break_minutes, timezone,
+	created_at, updated_at
+)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+on conflict (user_id) do update
+set
+	max_minutes_per_day = excluded.max_minutes_per_day,
+	max_minutes_per_week = excluded.max_minutes_per_week,
+	max_consecutive_classes = excluded.max_consecutive_classes,
+	break_minutes = excluded.break_minutes,
+	timezone = excluded.timezone,
+	updated_at = excluded.updated_at;
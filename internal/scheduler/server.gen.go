@@ -23,15 +23,72 @@ type ServerInterface interface {
 	// Get availability for multiple users (batch)
 	// (POST /v1/availability/)
 	GetBatchAvailability(c *gin.Context)
+	// List the blackout periods of the organization
+	// (GET /v1/blackouts/)
+	ListBlackouts(c *gin.Context, params ListBlackoutsParams)
+	// Add a blackout period
+	// (POST /v1/blackouts/)
+	CreateBlackout(c *gin.Context)
+	// Import blackout periods from an iCalendar file
+	// (POST /v1/blackouts/import/)
+	ImportBlackouts(c *gin.Context)
+	// Get the blackout policy of the organization
+	// (GET /v1/blackouts/policy/)
+	GetBlackoutPolicy(c *gin.Context)
+	// Set the blackout policy of the organization
+	// (PUT /v1/blackouts/policy/)
+	SetBlackoutPolicy(c *gin.Context)
+	// Remove a blackout period
+	// (DELETE /v1/blackouts/{blackout_id}/)
+	DeleteBlackout(c *gin.Context, blackoutId string)
 	// Create a new class
 	// (POST /v1/class/)
 	CreateClass(c *gin.Context)
 	// List classes for a course
 	// (GET /v1/class/course/{course_id}/)
-	ListCourseClasses(c *gin.Context, courseId string)
+	ListCourseClasses(c *gin.Context, courseId string, params ListCourseClassesParams)
 	// List classes for a user
 	// (GET /v1/class/user/{user_id}/)
 	ListUserClasses(c *gin.Context, userId string)
+	// Get a class by ID
+	// (GET /v1/class/{class_id}/)
+	GetClass(c *gin.Context, classId string)
+	// List attendance of a class
+	// (GET /v1/class/{class_id}/attendance/)
+	ListClassAttendance(c *gin.Context, classId string)
+	// Record attendance for class participants
+	// (PUT /v1/class/{class_id}/attendance/)
+	RecordClassAttendance(c *gin.Context, classId string)
+	// Cancel a class
+	// (POST /v1/class/{class_id}/cancel/)
+	CancelClass(c *gin.Context, classId string)
+	// List the change history of a class
+	// (GET /v1/class/{class_id}/history/)
+	ListClassHistory(c *gin.Context, classId string)
+	// Add or remove students and teachers of a class
+	// (PATCH /v1/class/{class_id}/participants/)
+	UpdateClassParticipants(c *gin.Context, classId string)
+	// Reschedule a class
+	// (POST /v1/class/{class_id}/reschedule/)
+	RescheduleClass(c *gin.Context, classId string)
+	// Reserve or release resources for a class
+	// (PATCH /v1/class/{class_id}/resources/)
+	UpdateClassResources(c *gin.Context, classId string)
+	// Restore a cancelled class
+	// (POST /v1/class/{class_id}/restore/)
+	RestoreClass(c *gin.Context, classId string)
+	// Hand a class over to a substitute tutor
+	// (POST /v1/class/{class_id}/substitute/)
+	AssignSubstitute(c *gin.Context, classId string)
+	// Suggest substitute tutors for a class
+	// (GET /v1/class/{class_id}/substitutes/)
+	SuggestSubstitutes(c *gin.Context, classId string)
+	// List the waitlist of a class
+	// (GET /v1/class/{class_id}/waitlist/)
+	ListClassWaitlist(c *gin.Context, classId string)
+	// Reorder the waitlist of a class
+	// (PUT /v1/class/{class_id}/waitlist/)
+	ReorderClassWaitlist(c *gin.Context, classId string)
 	// Get all courses
 	// (GET /v1/course/)
 	ListCourses(c *gin.Context)
@@ -44,12 +101,249 @@ type ServerInterface interface {
 	// Update a course
 	// (POST /v1/course/{course_id}/)
 	UpdateCourse(c *gin.Context, courseId string)
+	// List the enrollments of a course
+	// (GET /v1/course/{course_id}/enrollments/)
+	ListCourseEnrollments(c *gin.Context, courseId string, params ListCourseEnrollmentsParams)
+	// Enroll a user in a course
+	// (POST /v1/course/{course_id}/enrollments/)
+	EnrollCourseUser(c *gin.Context, courseId string)
+	// Enroll several users in a course
+	// (POST /v1/course/{course_id}/enrollments/bulk/)
+	BulkEnrollCourseUsers(c *gin.Context, courseId string)
+	// Mark an enrollment as completed
+	// (POST /v1/course/{course_id}/enrollments/{user_id}/complete/)
+	CompleteCourseEnrollment(c *gin.Context, courseId string, userId string)
+	// Drop an enrollment
+	// (POST /v1/course/{course_id}/enrollments/{user_id}/drop/)
+	DropCourseEnrollment(c *gin.Context, courseId string, userId string)
+	// List the status changes of an enrollment
+	// (GET /v1/course/{course_id}/enrollments/{user_id}/history/)
+	ListEnrollmentHistory(c *gin.Context, courseId string, userId string)
+	// Re-activate a dropped or completed enrollment
+	// (POST /v1/course/{course_id}/enrollments/{user_id}/reactivate/)
+	ReactivateCourseEnrollment(c *gin.Context, courseId string, userId string)
+	// List the waitlist of a course
+	// (GET /v1/course/{course_id}/waitlist/)
+	ListCourseWaitlist(c *gin.Context, courseId string)
+	// Reorder the waitlist of a course
+	// (PUT /v1/course/{course_id}/waitlist/)
+	ReorderCourseWaitlist(c *gin.Context, courseId string)
+	// Stream live schedule changes
+	// (GET /v1/events/stream/)
+	StreamEvents(c *gin.Context, params StreamEventsParams)
+	// List the families of the organization
+	// (GET /v1/families/)
+	ListFamilies(c *gin.Context)
+	// Create a family
+	// (POST /v1/families/)
+	CreateFamily(c *gin.Context)
+	// Update a family and replace its students
+	// (PUT /v1/families/{family_id}/)
+	UpdateFamily(c *gin.Context, familyId string)
+	// Release a hold
+	// (DELETE /v1/holds/{hold_id}/)
+	ReleaseSlotHold(c *gin.Context, holdId string)
+	// Confirm a hold into a class
+	// (POST /v1/holds/{hold_id}/confirm/)
+	ConfirmSlotHold(c *gin.Context, holdId string)
+	// Import users, courses and enrollments from CSV files
+	// (POST /v1/imports/)
+	RunImport(c *gin.Context)
+	// List invoices and credit notes
+	// (GET /v1/invoices/)
+	ListInvoices(c *gin.Context, params ListInvoicesParams)
+	// Generate a draft invoice from attendance
+	// (POST /v1/invoices/)
+	CreateInvoice(c *gin.Context)
+	// Get an invoice or credit note
+	// (GET /v1/invoices/{invoice_id}/)
+	GetInvoice(c *gin.Context, invoiceId string)
+	// Credit lines of an issued or paid invoice
+	// (POST /v1/invoices/{invoice_id}/credit-notes/)
+	CreateCreditNote(c *gin.Context, invoiceId string)
+	// Render an invoice or credit note as printable HTML
+	// (GET /v1/invoices/{invoice_id}/html/)
+	RenderInvoice(c *gin.Context, invoiceId string)
+	// Issue a draft invoice
+	// (POST /v1/invoices/{invoice_id}/issue/)
+	IssueInvoice(c *gin.Context, invoiceId string)
+	// Mark an issued invoice or credit note as paid
+	// (POST /v1/invoices/{invoice_id}/pay/)
+	PayInvoice(c *gin.Context, invoiceId string)
+	// Void a draft or issued invoice
+	// (POST /v1/invoices/{invoice_id}/void/)
+	VoidInvoice(c *gin.Context, invoiceId string)
+	// List background jobs
+	// (GET /v1/jobs/)
+	ListJobs(c *gin.Context, params ListJobsParams)
+	// List the cron schedules that enqueue jobs
+	// (GET /v1/jobs/schedules/)
+	ListJobSchedules(c *gin.Context)
+	// Get a background job
+	// (GET /v1/jobs/{job_id}/)
+	GetJob(c *gin.Context, jobId string)
+	// Cancel a queued job
+	// (POST /v1/jobs/{job_id}/cancel/)
+	CancelJob(c *gin.Context, jobId string)
+	// Run a failed or cancelled job again
+	// (POST /v1/jobs/{job_id}/retry/)
+	RetryJob(c *gin.Context, jobId string)
+	// List the organization's LTI platforms
+	// (GET /v1/lti/platforms/)
+	ListLtiPlatforms(c *gin.Context)
+	// Register an LTI platform
+	// (POST /v1/lti/platforms/)
+	CreateLtiPlatform(c *gin.Context)
+	// Remove an LTI platform
+	// (DELETE /v1/lti/platforms/{platform_id}/)
+	DeleteLtiPlatform(c *gin.Context, platformId string)
+	// Get the delivery status of a notification
+	// (GET /v1/notifications/{notification_id}/)
+	GetNotification(c *gin.Context, notificationId string)
 	// Delete an organization
 	// (DELETE /v1/org/{org_id}/)
 	DeleteOrg(c *gin.Context, orgId string)
 	// Create a new organization
 	// (POST /v1/org/{org_id}/)
 	CreateOrg(c *gin.Context, orgId string)
+	// List the pay rates of the organization
+	// (GET /v1/pay-rates/)
+	ListPayRates(c *gin.Context)
+	// Create a pay rate
+	// (POST /v1/pay-rates/)
+	CreatePayRate(c *gin.Context)
+	// Delete a pay rate
+	// (DELETE /v1/pay-rates/{pay_rate_id}/)
+	DeletePayRate(c *gin.Context, payRateId string)
+	// Update a pay rate
+	// (PUT /v1/pay-rates/{pay_rate_id}/)
+	UpdatePayRate(c *gin.Context, payRateId string)
+	// Get the payroll policy of the organization
+	// (GET /v1/payroll/policy/)
+	GetPayrollPolicy(c *gin.Context)
+	// Set the payroll policy of the organization
+	// (PUT /v1/payroll/policy/)
+	SetPayrollPolicy(c *gin.Context)
+	// List the rate cards of the organization
+	// (GET /v1/rate-cards/)
+	ListRateCards(c *gin.Context)
+	// Create a rate card
+	// (POST /v1/rate-cards/)
+	CreateRateCard(c *gin.Context)
+	// Delete a rate card
+	// (DELETE /v1/rate-cards/{rate_card_id}/)
+	DeleteRateCard(c *gin.Context, rateCardId string)
+	// Update a rate card
+	// (PUT /v1/rate-cards/{rate_card_id}/)
+	UpdateRateCard(c *gin.Context, rateCardId string)
+	// List students at risk of missing their required classes
+	// (GET /v1/reports/at-risk-students/)
+	ListAtRiskStudents(c *gin.Context, params ListAtRiskStudentsParams)
+	// Report attendance over a date range
+	// (GET /v1/reports/attendance/)
+	GetAttendanceReport(c *gin.Context, params GetAttendanceReportParams)
+	// Report availability supply and demand by hour of the week
+	// (GET /v1/reports/availability-demand/)
+	GetAvailabilityDemandReport(c *gin.Context, params GetAvailabilityDemandReportParams)
+	// Report how well trackers were fulfilled over a date range
+	// (GET /v1/reports/tracker-fulfillment/)
+	GetTrackerFulfillmentReport(c *gin.Context, params GetTrackerFulfillmentReportParams)
+	// Report how much each tutor teaches over a date range
+	// (GET /v1/reports/tutor-utilization/)
+	GetTutorUtilization(c *gin.Context, params GetTutorUtilizationParams)
+	// List the active resources of the organization
+	// (GET /v1/resources/)
+	ListResources(c *gin.Context, params ListResourcesParams)
+	// Create a resource
+	// (POST /v1/resources/)
+	CreateResource(c *gin.Context)
+	// Find resources free for a time slot
+	// (GET /v1/resources/available/)
+	FindAvailableResources(c *gin.Context, params FindAvailableResourcesParams)
+	// Retire a resource
+	// (DELETE /v1/resources/{resource_id}/)
+	DeleteResource(c *gin.Context, resourceId string)
+	// Get a resource
+	// (GET /v1/resources/{resource_id}/)
+	GetResource(c *gin.Context, resourceId string)
+	// Replace a resource
+	// (PUT /v1/resources/{resource_id}/)
+	UpdateResource(c *gin.Context, resourceId string)
+	// List the reservations of a resource
+	// (GET /v1/resources/{resource_id}/calendar/)
+	GetResourceCalendar(c *gin.Context, resourceId string, params GetResourceCalendarParams)
+	// List the organization's OneRoster sources
+	// (GET /v1/roster/sources/)
+	ListRosterSources(c *gin.Context)
+	// Add a OneRoster source
+	// (POST /v1/roster/sources/)
+	CreateRosterSource(c *gin.Context)
+	// Delete a roster source
+	// (DELETE /v1/roster/sources/{source_id}/)
+	DeleteRosterSource(c *gin.Context, sourceId string)
+	// List the sync runs of a roster source, newest first
+	// (GET /v1/roster/sources/{source_id}/runs/)
+	ListRosterSyncRuns(c *gin.Context, sourceId string, params ListRosterSyncRunsParams)
+	// Sync users, courses and enrollments from a roster source
+	// (POST /v1/roster/sources/{source_id}/sync/)
+	SyncRosterSource(c *gin.Context, sourceId string, params SyncRosterSourceParams)
+	// List the organization's SCIM tokens
+	// (GET /v1/scim/tokens/)
+	ListScimTokens(c *gin.Context)
+	// Create a SCIM token
+	// (POST /v1/scim/tokens/)
+	CreateScimToken(c *gin.Context)
+	// Revoke a SCIM token
+	// (DELETE /v1/scim/tokens/{token_id}/)
+	DeleteScimToken(c *gin.Context, tokenId string)
+	// List the self-booking policies of the organization
+	// (GET /v1/self-booking-policies/)
+	ListSelfBookingPolicies(c *gin.Context)
+	// Replace the self-booking policies of the organization
+	// (PUT /v1/self-booking-policies/)
+	SetSelfBookingPolicies(c *gin.Context)
+	// List open slots the current user may book
+	// (GET /v1/slots/)
+	ListBookableSlots(c *gin.Context, params ListBookableSlotsParams)
+	// Withdraw a slot that is not booked
+	// (DELETE /v1/slots/{slot_id}/)
+	WithdrawSlot(c *gin.Context, slotId string)
+	// Hold a slot for the current student
+	// (POST /v1/slots/{slot_id}/hold/)
+	HoldSlot(c *gin.Context, slotId string)
+	// List the time-off requests of the organization
+	// (GET /v1/time-off/)
+	ListTimeOffRequests(c *gin.Context, params ListTimeOffRequestsParams)
+	// Cancel a pending time-off request
+	// (DELETE /v1/time-off/{request_id}/)
+	CancelTimeOffRequest(c *gin.Context, requestId string)
+	// Get a time-off request with its affected classes
+	// (GET /v1/time-off/{request_id}/)
+	GetTimeOffRequest(c *gin.Context, requestId string)
+	// Approve a time-off request
+	// (POST /v1/time-off/{request_id}/approve/)
+	ApproveTimeOffRequest(c *gin.Context, requestId string)
+	// Reject a time-off request
+	// (POST /v1/time-off/{request_id}/reject/)
+	RejectTimeOffRequest(c *gin.Context, requestId string)
+	// List timesheets
+	// (GET /v1/timesheets/)
+	ListTimesheets(c *gin.Context, params ListTimesheetsParams)
+	// Compute timesheets for a pay period
+	// (POST /v1/timesheets/)
+	GenerateTimesheets(c *gin.Context)
+	// Export approved timesheets as CSV
+	// (GET /v1/timesheets/export/)
+	ExportTimesheets(c *gin.Context, params ExportTimesheetsParams)
+	// Get a timesheet
+	// (GET /v1/timesheets/{timesheet_id}/)
+	GetTimesheet(c *gin.Context, timesheetId string)
+	// Add an adjustment to a draft timesheet
+	// (POST /v1/timesheets/{timesheet_id}/adjustments/)
+	AddTimesheetAdjustment(c *gin.Context, timesheetId string)
+	// Approve a timesheet
+	// (POST /v1/timesheets/{timesheet_id}/approve/)
+	ApproveTimesheet(c *gin.Context, timesheetId string)
 	// Get trackers for a course
 	// (GET /v1/trackers/course/)
 	GetTrackers(c *gin.Context)
@@ -77,6 +371,78 @@ type ServerInterface interface {
 	// Create availability for a user
 	// (POST /v1/user/{user_id}/availability/)
 	CreateAvailability(c *gin.Context, userId string)
+	// Get the weekly recurring availability of a user
+	// (GET /v1/user/{user_id}/availability/recurring/)
+	GetRecurringAvailability(c *gin.Context, userId string)
+	// Replace the weekly recurring availability of a user
+	// (PUT /v1/user/{user_id}/availability/recurring/)
+	SetRecurringAvailability(c *gin.Context, userId string)
+	// Get the notification channels a user receives
+	// (GET /v1/user/{user_id}/notification-preferences/)
+	GetNotificationPreferences(c *gin.Context, userId string)
+	// Turn notification channels on or off for a user
+	// (PUT /v1/user/{user_id}/notification-preferences/)
+	UpdateNotificationPreferences(c *gin.Context, userId string)
+	// List notifications sent or queued for a user
+	// (GET /v1/user/{user_id}/notifications/)
+	ListUserNotifications(c *gin.Context, userId string, params ListUserNotificationsParams)
+	// List the bookable slots of a tutor
+	// (GET /v1/user/{user_id}/slots/)
+	ListTutorSlots(c *gin.Context, userId string, params ListTutorSlotsParams)
+	// Publish bookable slots out of a tutor's availability
+	// (POST /v1/user/{user_id}/slots/)
+	PublishTutorSlots(c *gin.Context, userId string)
+	// List the time-off requests of a tutor
+	// (GET /v1/user/{user_id}/time-off/)
+	ListUserTimeOff(c *gin.Context, userId string)
+	// Request time off
+	// (POST /v1/user/{user_id}/time-off/)
+	RequestTimeOff(c *gin.Context, userId string)
+	// List the waitlist entries of a user
+	// (GET /v1/user/{user_id}/waitlist/)
+	ListUserWaitlist(c *gin.Context, userId string)
+	// Get the workload limits of a tutor
+	// (GET /v1/user/{user_id}/workload-limits/)
+	GetTutorWorkloadLimits(c *gin.Context, userId string)
+	// Set the workload limits of a tutor
+	// (PUT /v1/user/{user_id}/workload-limits/)
+	SetTutorWorkloadLimits(c *gin.Context, userId string)
+	// Remove an entry from its waitlist
+	// (DELETE /v1/waitlist/{waitlist_id}/)
+	RemoveWaitlistEntry(c *gin.Context, waitlistId string)
+	// Accept an offered seat
+	// (POST /v1/waitlist/{waitlist_id}/accept/)
+	AcceptWaitlistOffer(c *gin.Context, waitlistId string)
+	// Decline an offered seat
+	// (POST /v1/waitlist/{waitlist_id}/decline/)
+	DeclineWaitlistOffer(c *gin.Context, waitlistId string)
+	// List the organization's webhook subscriptions
+	// (GET /v1/webhooks/)
+	ListWebhookSubscriptions(c *gin.Context)
+	// Subscribe an endpoint to events
+	// (POST /v1/webhooks/)
+	CreateWebhookSubscription(c *gin.Context)
+	// Get a webhook delivery and its attempts
+	// (GET /v1/webhooks/deliveries/{delivery_id}/)
+	GetWebhookDelivery(c *gin.Context, deliveryId string)
+	// Send a webhook delivery again
+	// (POST /v1/webhooks/deliveries/{delivery_id}/replay/)
+	ReplayWebhookDelivery(c *gin.Context, deliveryId string)
+	// Delete a webhook subscription and its delivery log
+	// (DELETE /v1/webhooks/{subscription_id}/)
+	DeleteWebhookSubscription(c *gin.Context, subscriptionId string)
+	// Get a webhook subscription
+	// (GET /v1/webhooks/{subscription_id}/)
+	GetWebhookSubscription(c *gin.Context, subscriptionId string)
+	// Update a webhook subscription
+	// (PATCH /v1/webhooks/{subscription_id}/)
+	UpdateWebhookSubscription(c *gin.Context, subscriptionId string)
+	// List deliveries of a webhook subscription
+	// (GET /v1/webhooks/{subscription_id}/deliveries/)
+	ListWebhookDeliveries(c *gin.Context, subscriptionId string, params ListWebhookDeliveriesParams)
+	// Send a test ping to a webhook subscription
+	// (POST /v1/webhooks/{subscription_id}/ping/)
+	PingWebhookSubscription(c *gin.Context, subscriptionId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -103,6 +469,128 @@ func (siw *ServerInterfaceWrapper) GetBatchAvailability(c *gin.Context) {
 	siw.Handler.GetBatchAvailability(c)
 }
 
+// ListBlackouts operation middleware
+func (siw *ServerInterfaceWrapper) ListBlackouts(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBlackoutsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListBlackouts(c, params)
+}
+
+// CreateBlackout operation middleware
+func (siw *ServerInterfaceWrapper) CreateBlackout(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateBlackout(c)
+}
+
+// ImportBlackouts operation middleware
+func (siw *ServerInterfaceWrapper) ImportBlackouts(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportBlackouts(c)
+}
+
+// GetBlackoutPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetBlackoutPolicy(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetBlackoutPolicy(c)
+}
+
+// SetBlackoutPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetBlackoutPolicy(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetBlackoutPolicy(c)
+}
+
+// DeleteBlackout operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBlackout(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "blackout_id" -------------
+	var blackoutId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "blackout_id", c.Param("blackout_id"), &blackoutId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter blackout_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteBlackout(c, blackoutId)
+}
+
 // CreateClass operation middleware
 func (siw *ServerInterfaceWrapper) CreateClass(c *gin.Context) {
 
@@ -134,6 +622,25 @@ func (siw *ServerInterfaceWrapper) ListCourseClasses(c *gin.Context) {
 
 	c.Set(FirebaseAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCourseClassesParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -141,14 +648,12 @@ func (siw *ServerInterfaceWrapper) ListCourseClasses(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListCourseClasses(c, courseId)
+	siw.Handler.ListCourseClasses(c, courseId, params)
 }
 
 // ListUserClasses operation middleware
 func (siw *ServerInterfaceWrapper) ListUserClasses(c *gin.Context) {
 
-	c.Set(FirebaseAuthScopes, []string{})
-
 	var err error
 
 	// ------------- Path parameter "user_id" -------------
@@ -160,6 +665,8 @@ func (siw *ServerInterfaceWrapper) ListUserClasses(c *gin.Context) {
 		return
 	}
 
+	c.Set(FirebaseAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -170,8 +677,19 @@ func (siw *ServerInterfaceWrapper) ListUserClasses(c *gin.Context) {
 	siw.Handler.ListUserClasses(c, userId)
 }
 
-// ListCourses operation middleware
-func (siw *ServerInterfaceWrapper) ListCourses(c *gin.Context) {
+// GetClass operation middleware
+func (siw *ServerInterfaceWrapper) GetClass(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "class_id" -------------
+	var classId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(FirebaseAuthScopes, []string{})
 
@@ -182,11 +700,22 @@ func (siw *ServerInterfaceWrapper) ListCourses(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListCourses(c)
+	siw.Handler.GetClass(c, classId)
 }
 
-// CreateCourse operation middleware
-func (siw *ServerInterfaceWrapper) CreateCourse(c *gin.Context) {
+// ListClassAttendance operation middleware
+func (siw *ServerInterfaceWrapper) ListClassAttendance(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "class_id" -------------
+	var classId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(FirebaseAuthScopes, []string{})
 
@@ -197,20 +726,20 @@ func (siw *ServerInterfaceWrapper) CreateCourse(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateCourse(c)
+	siw.Handler.ListClassAttendance(c, classId)
 }
 
-// GetCourse operation middleware
-func (siw *ServerInterfaceWrapper) GetCourse(c *gin.Context) {
+// RecordClassAttendance operation middleware
+func (siw *ServerInterfaceWrapper) RecordClassAttendance(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "course_id" -------------
-	var courseId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -223,20 +752,20 @@ func (siw *ServerInterfaceWrapper) GetCourse(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetCourse(c, courseId)
+	siw.Handler.RecordClassAttendance(c, classId)
 }
 
-// UpdateCourse operation middleware
-func (siw *ServerInterfaceWrapper) UpdateCourse(c *gin.Context) {
+// CancelClass operation middleware
+func (siw *ServerInterfaceWrapper) CancelClass(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "course_id" -------------
-	var courseId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -249,20 +778,20 @@ func (siw *ServerInterfaceWrapper) UpdateCourse(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateCourse(c, courseId)
+	siw.Handler.CancelClass(c, classId)
 }
 
-// DeleteOrg operation middleware
-func (siw *ServerInterfaceWrapper) DeleteOrg(c *gin.Context) {
+// ListClassHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListClassHistory(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "org_id" -------------
-	var orgId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "org_id", c.Param("org_id"), &orgId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter org_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -275,20 +804,20 @@ func (siw *ServerInterfaceWrapper) DeleteOrg(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteOrg(c, orgId)
+	siw.Handler.ListClassHistory(c, classId)
 }
 
-// CreateOrg operation middleware
-func (siw *ServerInterfaceWrapper) CreateOrg(c *gin.Context) {
+// UpdateClassParticipants operation middleware
+func (siw *ServerInterfaceWrapper) UpdateClassParticipants(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "org_id" -------------
-	var orgId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "org_id", c.Param("org_id"), &orgId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter org_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -301,11 +830,22 @@ func (siw *ServerInterfaceWrapper) CreateOrg(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateOrg(c, orgId)
+	siw.Handler.UpdateClassParticipants(c, classId)
 }
 
-// GetTrackers operation middleware
-func (siw *ServerInterfaceWrapper) GetTrackers(c *gin.Context) {
+// RescheduleClass operation middleware
+func (siw *ServerInterfaceWrapper) RescheduleClass(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "class_id" -------------
+	var classId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(FirebaseAuthScopes, []string{})
 
@@ -316,11 +856,22 @@ func (siw *ServerInterfaceWrapper) GetTrackers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTrackers(c)
+	siw.Handler.RescheduleClass(c, classId)
 }
 
-// ListUsers operation middleware
-func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
+// UpdateClassResources operation middleware
+func (siw *ServerInterfaceWrapper) UpdateClassResources(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "class_id" -------------
+	var classId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(FirebaseAuthScopes, []string{})
 
@@ -331,20 +882,20 @@ func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListUsers(c)
+	siw.Handler.UpdateClassResources(c, classId)
 }
 
-// DeleteUser operation middleware
-func (siw *ServerInterfaceWrapper) DeleteUser(c *gin.Context) {
+// RestoreClass operation middleware
+func (siw *ServerInterfaceWrapper) RestoreClass(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -357,20 +908,20 @@ func (siw *ServerInterfaceWrapper) DeleteUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteUser(c, userId)
+	siw.Handler.RestoreClass(c, classId)
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(c *gin.Context) {
+// AssignSubstitute operation middleware
+func (siw *ServerInterfaceWrapper) AssignSubstitute(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -383,20 +934,20 @@ func (siw *ServerInterfaceWrapper) GetUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUser(c, userId)
+	siw.Handler.AssignSubstitute(c, classId)
 }
 
-// UpdateUser operation middleware
-func (siw *ServerInterfaceWrapper) UpdateUser(c *gin.Context) {
+// SuggestSubstitutes operation middleware
+func (siw *ServerInterfaceWrapper) SuggestSubstitutes(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -409,20 +960,20 @@ func (siw *ServerInterfaceWrapper) UpdateUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateUser(c, userId)
+	siw.Handler.SuggestSubstitutes(c, classId)
 }
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(c *gin.Context) {
+// ListClassWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) ListClassWaitlist(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -435,20 +986,20 @@ func (siw *ServerInterfaceWrapper) CreateUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateUser(c, userId)
+	siw.Handler.ListClassWaitlist(c, classId)
 }
 
-// GetAvailability operation middleware
-func (siw *ServerInterfaceWrapper) GetAvailability(c *gin.Context) {
+// ReorderClassWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) ReorderClassWaitlist(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "class_id" -------------
+	var classId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "class_id", c.Param("class_id"), &classId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter class_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -461,23 +1012,27 @@ func (siw *ServerInterfaceWrapper) GetAvailability(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetAvailability(c, userId)
+	siw.Handler.ReorderClassWaitlist(c, classId)
 }
 
-// UpdateAvailability operation middleware
-func (siw *ServerInterfaceWrapper) UpdateAvailability(c *gin.Context) {
-
-	var err error
+// ListCourses operation middleware
+func (siw *ServerInterfaceWrapper) ListCourses(c *gin.Context) {
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	c.Set(FirebaseAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
+	siw.Handler.ListCourses(c)
+}
+
+// CreateCourse operation middleware
+func (siw *ServerInterfaceWrapper) CreateCourse(c *gin.Context) {
+
 	c.Set(FirebaseAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -487,20 +1042,20 @@ func (siw *ServerInterfaceWrapper) UpdateAvailability(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateAvailability(c, userId)
+	siw.Handler.CreateCourse(c)
 }
 
-// CreateAvailability operation middleware
-func (siw *ServerInterfaceWrapper) CreateAvailability(c *gin.Context) {
+// GetCourse operation middleware
+func (siw *ServerInterfaceWrapper) GetCourse(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId string
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -513,7 +1068,3071 @@ func (siw *ServerInterfaceWrapper) CreateAvailability(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateAvailability(c, userId)
+	siw.Handler.GetCourse(c, courseId)
+}
+
+// UpdateCourse operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCourse(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateCourse(c, courseId)
+}
+
+// ListCourseEnrollments operation middleware
+func (siw *ServerInterfaceWrapper) ListCourseEnrollments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCourseEnrollmentsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCourseEnrollments(c, courseId, params)
+}
+
+// EnrollCourseUser operation middleware
+func (siw *ServerInterfaceWrapper) EnrollCourseUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EnrollCourseUser(c, courseId)
+}
+
+// BulkEnrollCourseUsers operation middleware
+func (siw *ServerInterfaceWrapper) BulkEnrollCourseUsers(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.BulkEnrollCourseUsers(c, courseId)
+}
+
+// CompleteCourseEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) CompleteCourseEnrollment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CompleteCourseEnrollment(c, courseId, userId)
+}
+
+// DropCourseEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) DropCourseEnrollment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DropCourseEnrollment(c, courseId, userId)
+}
+
+// ListEnrollmentHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListEnrollmentHistory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListEnrollmentHistory(c, courseId, userId)
+}
+
+// ReactivateCourseEnrollment operation middleware
+func (siw *ServerInterfaceWrapper) ReactivateCourseEnrollment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReactivateCourseEnrollment(c, courseId, userId)
+}
+
+// ListCourseWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) ListCourseWaitlist(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCourseWaitlist(c, courseId)
+}
+
+// ReorderCourseWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) ReorderCourseWaitlist(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", c.Param("course_id"), &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReorderCourseWaitlist(c, courseId)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "last_event_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_event_id", c.Request.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StreamEvents(c, params)
+}
+
+// ListFamilies operation middleware
+func (siw *ServerInterfaceWrapper) ListFamilies(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListFamilies(c)
+}
+
+// CreateFamily operation middleware
+func (siw *ServerInterfaceWrapper) CreateFamily(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateFamily(c)
+}
+
+// UpdateFamily operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFamily(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "family_id" -------------
+	var familyId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "family_id", c.Param("family_id"), &familyId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter family_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateFamily(c, familyId)
+}
+
+// ReleaseSlotHold operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseSlotHold(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "hold_id" -------------
+	var holdId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hold_id", c.Param("hold_id"), &holdId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter hold_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReleaseSlotHold(c, holdId)
+}
+
+// ConfirmSlotHold operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmSlotHold(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "hold_id" -------------
+	var holdId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hold_id", c.Param("hold_id"), &holdId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter hold_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ConfirmSlotHold(c, holdId)
+}
+
+// RunImport operation middleware
+func (siw *ServerInterfaceWrapper) RunImport(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RunImport(c)
+}
+
+// ListInvoices operation middleware
+func (siw *ServerInterfaceWrapper) ListInvoices(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListInvoicesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "student_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "student_id", c.Request.URL.Query(), &params.StudentId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter student_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "family_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "family_id", c.Request.URL.Query(), &params.FamilyId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter family_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListInvoices(c, params)
+}
+
+// CreateInvoice operation middleware
+func (siw *ServerInterfaceWrapper) CreateInvoice(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateInvoice(c)
+}
+
+// GetInvoice operation middleware
+func (siw *ServerInterfaceWrapper) GetInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetInvoice(c, invoiceId)
+}
+
+// CreateCreditNote operation middleware
+func (siw *ServerInterfaceWrapper) CreateCreditNote(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateCreditNote(c, invoiceId)
+}
+
+// RenderInvoice operation middleware
+func (siw *ServerInterfaceWrapper) RenderInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RenderInvoice(c, invoiceId)
+}
+
+// IssueInvoice operation middleware
+func (siw *ServerInterfaceWrapper) IssueInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.IssueInvoice(c, invoiceId)
+}
+
+// PayInvoice operation middleware
+func (siw *ServerInterfaceWrapper) PayInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PayInvoice(c, invoiceId)
+}
+
+// VoidInvoice operation middleware
+func (siw *ServerInterfaceWrapper) VoidInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "invoice_id" -------------
+	var invoiceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invoice_id", c.Param("invoice_id"), &invoiceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter invoice_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.VoidInvoice(c, invoiceId)
+}
+
+// ListJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListJobs(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListJobsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", c.Request.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter kind: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListJobs(c, params)
+}
+
+// ListJobSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListJobSchedules(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListJobSchedules(c)
+}
+
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", c.Param("job_id"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetJob(c, jobId)
+}
+
+// CancelJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", c.Param("job_id"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CancelJob(c, jobId)
+}
+
+// RetryJob operation middleware
+func (siw *ServerInterfaceWrapper) RetryJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", c.Param("job_id"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter job_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RetryJob(c, jobId)
+}
+
+// ListLtiPlatforms operation middleware
+func (siw *ServerInterfaceWrapper) ListLtiPlatforms(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListLtiPlatforms(c)
+}
+
+// CreateLtiPlatform operation middleware
+func (siw *ServerInterfaceWrapper) CreateLtiPlatform(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateLtiPlatform(c)
+}
+
+// DeleteLtiPlatform operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLtiPlatform(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "platform_id" -------------
+	var platformId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "platform_id", c.Param("platform_id"), &platformId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter platform_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteLtiPlatform(c, platformId)
+}
+
+// GetNotification operation middleware
+func (siw *ServerInterfaceWrapper) GetNotification(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "notification_id" -------------
+	var notificationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "notification_id", c.Param("notification_id"), &notificationId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter notification_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotification(c, notificationId)
+}
+
+// DeleteOrg operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOrg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "org_id" -------------
+	var orgId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "org_id", c.Param("org_id"), &orgId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter org_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteOrg(c, orgId)
+}
+
+// CreateOrg operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrg(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "org_id" -------------
+	var orgId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "org_id", c.Param("org_id"), &orgId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter org_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateOrg(c, orgId)
+}
+
+// ListPayRates operation middleware
+func (siw *ServerInterfaceWrapper) ListPayRates(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListPayRates(c)
+}
+
+// CreatePayRate operation middleware
+func (siw *ServerInterfaceWrapper) CreatePayRate(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreatePayRate(c)
+}
+
+// DeletePayRate operation middleware
+func (siw *ServerInterfaceWrapper) DeletePayRate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pay_rate_id" -------------
+	var payRateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pay_rate_id", c.Param("pay_rate_id"), &payRateId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pay_rate_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeletePayRate(c, payRateId)
+}
+
+// UpdatePayRate operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePayRate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "pay_rate_id" -------------
+	var payRateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pay_rate_id", c.Param("pay_rate_id"), &payRateId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pay_rate_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdatePayRate(c, payRateId)
+}
+
+// GetPayrollPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetPayrollPolicy(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPayrollPolicy(c)
+}
+
+// SetPayrollPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetPayrollPolicy(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetPayrollPolicy(c)
+}
+
+// ListRateCards operation middleware
+func (siw *ServerInterfaceWrapper) ListRateCards(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRateCards(c)
+}
+
+// CreateRateCard operation middleware
+func (siw *ServerInterfaceWrapper) CreateRateCard(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateRateCard(c)
+}
+
+// DeleteRateCard operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRateCard(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "rate_card_id" -------------
+	var rateCardId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rate_card_id", c.Param("rate_card_id"), &rateCardId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rate_card_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRateCard(c, rateCardId)
+}
+
+// UpdateRateCard operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRateCard(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "rate_card_id" -------------
+	var rateCardId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rate_card_id", c.Param("rate_card_id"), &rateCardId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rate_card_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateRateCard(c, rateCardId)
+}
+
+// ListAtRiskStudents operation middleware
+func (siw *ServerInterfaceWrapper) ListAtRiskStudents(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAtRiskStudentsParams
+
+	// ------------- Optional query parameter "course_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "course_id", c.Request.URL.Query(), &params.CourseId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "as_of" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", c.Request.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter as_of: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAtRiskStudents(c, params)
+}
+
+// GetAttendanceReport operation middleware
+func (siw *ServerInterfaceWrapper) GetAttendanceReport(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAttendanceReportParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", c.Request.URL.Query(), &params.Timezone)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timezone: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAttendanceReport(c, params)
+}
+
+// GetAvailabilityDemandReport operation middleware
+func (siw *ServerInterfaceWrapper) GetAvailabilityDemandReport(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAvailabilityDemandReportParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", c.Request.URL.Query(), &params.Timezone)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timezone: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAvailabilityDemandReport(c, params)
+}
+
+// GetTrackerFulfillmentReport operation middleware
+func (siw *ServerInterfaceWrapper) GetTrackerFulfillmentReport(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTrackerFulfillmentReportParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", c.Request.URL.Query(), &params.Timezone)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timezone: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTrackerFulfillmentReport(c, params)
+}
+
+// GetTutorUtilization operation middleware
+func (siw *ServerInterfaceWrapper) GetTutorUtilization(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTutorUtilizationParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTutorUtilization(c, params)
+}
+
+// ListResources operation middleware
+func (siw *ServerInterfaceWrapper) ListResources(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListResourcesParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListResources(c, params)
+}
+
+// CreateResource operation middleware
+func (siw *ServerInterfaceWrapper) CreateResource(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateResource(c)
+}
+
+// FindAvailableResources operation middleware
+func (siw *ServerInterfaceWrapper) FindAvailableResources(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FindAvailableResourcesParams
+
+	// ------------- Required query parameter "start_time" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "start_time", c.Request.URL.Query(), &params.StartTime)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter start_time: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "duration" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "duration", c.Request.URL.Query(), &params.Duration)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter duration: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "min_capacity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_capacity", c.Request.URL.Query(), &params.MinCapacity)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_capacity: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FindAvailableResources(c, params)
+}
+
+// DeleteResource operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "resource_id" -------------
+	var resourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resource_id", c.Param("resource_id"), &resourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteResource(c, resourceId)
+}
+
+// GetResource operation middleware
+func (siw *ServerInterfaceWrapper) GetResource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "resource_id" -------------
+	var resourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resource_id", c.Param("resource_id"), &resourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetResource(c, resourceId)
+}
+
+// UpdateResource operation middleware
+func (siw *ServerInterfaceWrapper) UpdateResource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "resource_id" -------------
+	var resourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resource_id", c.Param("resource_id"), &resourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateResource(c, resourceId)
+}
+
+// GetResourceCalendar operation middleware
+func (siw *ServerInterfaceWrapper) GetResourceCalendar(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "resource_id" -------------
+	var resourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resource_id", c.Param("resource_id"), &resourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResourceCalendarParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetResourceCalendar(c, resourceId, params)
+}
+
+// ListRosterSources operation middleware
+func (siw *ServerInterfaceWrapper) ListRosterSources(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRosterSources(c)
+}
+
+// CreateRosterSource operation middleware
+func (siw *ServerInterfaceWrapper) CreateRosterSource(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateRosterSource(c)
+}
+
+// DeleteRosterSource operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRosterSource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "source_id" -------------
+	var sourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "source_id", c.Param("source_id"), &sourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRosterSource(c, sourceId)
+}
+
+// ListRosterSyncRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListRosterSyncRuns(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "source_id" -------------
+	var sourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "source_id", c.Param("source_id"), &sourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListRosterSyncRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListRosterSyncRuns(c, sourceId, params)
+}
+
+// SyncRosterSource operation middleware
+func (siw *ServerInterfaceWrapper) SyncRosterSource(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "source_id" -------------
+	var sourceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "source_id", c.Param("source_id"), &sourceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SyncRosterSourceParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dry_run: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SyncRosterSource(c, sourceId, params)
+}
+
+// ListScimTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListScimTokens(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListScimTokens(c)
+}
+
+// CreateScimToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateScimToken(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateScimToken(c)
+}
+
+// DeleteScimToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScimToken(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "token_id" -------------
+	var tokenId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token_id", c.Param("token_id"), &tokenId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteScimToken(c, tokenId)
+}
+
+// ListSelfBookingPolicies operation middleware
+func (siw *ServerInterfaceWrapper) ListSelfBookingPolicies(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSelfBookingPolicies(c)
+}
+
+// SetSelfBookingPolicies operation middleware
+func (siw *ServerInterfaceWrapper) SetSelfBookingPolicies(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetSelfBookingPolicies(c)
+}
+
+// ListBookableSlots operation middleware
+func (siw *ServerInterfaceWrapper) ListBookableSlots(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBookableSlotsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tutor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tutor_id", c.Request.URL.Query(), &params.TutorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tutor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "course_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "course_id", c.Request.URL.Query(), &params.CourseId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter course_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListBookableSlots(c, params)
+}
+
+// WithdrawSlot operation middleware
+func (siw *ServerInterfaceWrapper) WithdrawSlot(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "slot_id" -------------
+	var slotId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slot_id", c.Param("slot_id"), &slotId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter slot_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WithdrawSlot(c, slotId)
+}
+
+// HoldSlot operation middleware
+func (siw *ServerInterfaceWrapper) HoldSlot(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "slot_id" -------------
+	var slotId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slot_id", c.Param("slot_id"), &slotId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter slot_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HoldSlot(c, slotId)
+}
+
+// ListTimeOffRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListTimeOffRequests(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTimeOffRequestsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTimeOffRequests(c, params)
+}
+
+// CancelTimeOffRequest operation middleware
+func (siw *ServerInterfaceWrapper) CancelTimeOffRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "request_id" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "request_id", c.Param("request_id"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter request_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CancelTimeOffRequest(c, requestId)
+}
+
+// GetTimeOffRequest operation middleware
+func (siw *ServerInterfaceWrapper) GetTimeOffRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "request_id" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "request_id", c.Param("request_id"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter request_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimeOffRequest(c, requestId)
+}
+
+// ApproveTimeOffRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveTimeOffRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "request_id" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "request_id", c.Param("request_id"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter request_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ApproveTimeOffRequest(c, requestId)
+}
+
+// RejectTimeOffRequest operation middleware
+func (siw *ServerInterfaceWrapper) RejectTimeOffRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "request_id" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "request_id", c.Param("request_id"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter request_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RejectTimeOffRequest(c, requestId)
+}
+
+// ListTimesheets operation middleware
+func (siw *ServerInterfaceWrapper) ListTimesheets(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTimesheetsParams
+
+	// ------------- Optional query parameter "tutor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tutor_id", c.Request.URL.Query(), &params.TutorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tutor_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTimesheets(c, params)
+}
+
+// GenerateTimesheets operation middleware
+func (siw *ServerInterfaceWrapper) GenerateTimesheets(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GenerateTimesheets(c)
+}
+
+// ExportTimesheets operation middleware
+func (siw *ServerInterfaceWrapper) ExportTimesheets(c *gin.Context) {
+
+	var err error
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportTimesheetsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportTimesheets(c, params)
+}
+
+// GetTimesheet operation middleware
+func (siw *ServerInterfaceWrapper) GetTimesheet(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "timesheet_id" -------------
+	var timesheetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "timesheet_id", c.Param("timesheet_id"), &timesheetId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timesheet_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimesheet(c, timesheetId)
+}
+
+// AddTimesheetAdjustment operation middleware
+func (siw *ServerInterfaceWrapper) AddTimesheetAdjustment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "timesheet_id" -------------
+	var timesheetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "timesheet_id", c.Param("timesheet_id"), &timesheetId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timesheet_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddTimesheetAdjustment(c, timesheetId)
+}
+
+// ApproveTimesheet operation middleware
+func (siw *ServerInterfaceWrapper) ApproveTimesheet(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "timesheet_id" -------------
+	var timesheetId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "timesheet_id", c.Param("timesheet_id"), &timesheetId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timesheet_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ApproveTimesheet(c, timesheetId)
+}
+
+// GetTrackers operation middleware
+func (siw *ServerInterfaceWrapper) GetTrackers(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTrackers(c)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListUsers(c)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUser(c, userId)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUser(c, userId)
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateUser(c, userId)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateUser(c, userId)
+}
+
+// GetAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAvailability(c, userId)
+}
+
+// UpdateAvailability operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateAvailability(c, userId)
+}
+
+// CreateAvailability operation middleware
+func (siw *ServerInterfaceWrapper) CreateAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateAvailability(c, userId)
+}
+
+// GetRecurringAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurringAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRecurringAvailability(c, userId)
+}
+
+// SetRecurringAvailability operation middleware
+func (siw *ServerInterfaceWrapper) SetRecurringAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetRecurringAvailability(c, userId)
+}
+
+// GetNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationPreferences(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationPreferences(c, userId)
+}
+
+// UpdateNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNotificationPreferences(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateNotificationPreferences(c, userId)
+}
+
+// ListUserNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ListUserNotifications(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserNotificationsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListUserNotifications(c, userId, params)
+}
+
+// ListTutorSlots operation middleware
+func (siw *ServerInterfaceWrapper) ListTutorSlots(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTutorSlotsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTutorSlots(c, userId, params)
+}
+
+// PublishTutorSlots operation middleware
+func (siw *ServerInterfaceWrapper) PublishTutorSlots(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PublishTutorSlots(c, userId)
+}
+
+// ListUserTimeOff operation middleware
+func (siw *ServerInterfaceWrapper) ListUserTimeOff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListUserTimeOff(c, userId)
+}
+
+// RequestTimeOff operation middleware
+func (siw *ServerInterfaceWrapper) RequestTimeOff(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RequestTimeOff(c, userId)
+}
+
+// ListUserWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) ListUserWaitlist(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListUserWaitlist(c, userId)
+}
+
+// GetTutorWorkloadLimits operation middleware
+func (siw *ServerInterfaceWrapper) GetTutorWorkloadLimits(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTutorWorkloadLimits(c, userId)
+}
+
+// SetTutorWorkloadLimits operation middleware
+func (siw *ServerInterfaceWrapper) SetTutorWorkloadLimits(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetTutorWorkloadLimits(c, userId)
+}
+
+// RemoveWaitlistEntry operation middleware
+func (siw *ServerInterfaceWrapper) RemoveWaitlistEntry(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "waitlist_id" -------------
+	var waitlistId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "waitlist_id", c.Param("waitlist_id"), &waitlistId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter waitlist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveWaitlistEntry(c, waitlistId)
+}
+
+// AcceptWaitlistOffer operation middleware
+func (siw *ServerInterfaceWrapper) AcceptWaitlistOffer(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "waitlist_id" -------------
+	var waitlistId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "waitlist_id", c.Param("waitlist_id"), &waitlistId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter waitlist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AcceptWaitlistOffer(c, waitlistId)
+}
+
+// DeclineWaitlistOffer operation middleware
+func (siw *ServerInterfaceWrapper) DeclineWaitlistOffer(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "waitlist_id" -------------
+	var waitlistId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "waitlist_id", c.Param("waitlist_id"), &waitlistId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter waitlist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeclineWaitlistOffer(c, waitlistId)
+}
+
+// ListWebhookSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookSubscriptions(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhookSubscriptions(c)
+}
+
+// CreateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhookSubscription(c *gin.Context) {
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateWebhookSubscription(c)
+}
+
+// GetWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDelivery(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "delivery_id", c.Param("delivery_id"), &deliveryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delivery_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhookDelivery(c, deliveryId)
+}
+
+// ReplayWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) ReplayWebhookDelivery(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "delivery_id", c.Param("delivery_id"), &deliveryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delivery_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReplayWebhookDelivery(c, deliveryId)
+}
+
+// DeleteWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookSubscription(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Param("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter subscription_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWebhookSubscription(c, subscriptionId)
+}
+
+// GetWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookSubscription(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Param("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter subscription_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhookSubscription(c, subscriptionId)
+}
+
+// UpdateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhookSubscription(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Param("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter subscription_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateWebhookSubscription(c, subscriptionId)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Param("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter subscription_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhookDeliveries(c, subscriptionId, params)
+}
+
+// PingWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) PingWebhookSubscription(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Param("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter subscription_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(FirebaseAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PingWebhookSubscription(c, subscriptionId)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -544,15 +4163,113 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	}
 
 	router.POST(options.BaseURL+"/v1/availability/", wrapper.GetBatchAvailability)
+	router.GET(options.BaseURL+"/v1/blackouts/", wrapper.ListBlackouts)
+	router.POST(options.BaseURL+"/v1/blackouts/", wrapper.CreateBlackout)
+	router.POST(options.BaseURL+"/v1/blackouts/import/", wrapper.ImportBlackouts)
+	router.GET(options.BaseURL+"/v1/blackouts/policy/", wrapper.GetBlackoutPolicy)
+	router.PUT(options.BaseURL+"/v1/blackouts/policy/", wrapper.SetBlackoutPolicy)
+	router.DELETE(options.BaseURL+"/v1/blackouts/:blackout_id/", wrapper.DeleteBlackout)
 	router.POST(options.BaseURL+"/v1/class/", wrapper.CreateClass)
 	router.GET(options.BaseURL+"/v1/class/course/:course_id/", wrapper.ListCourseClasses)
 	router.GET(options.BaseURL+"/v1/class/user/:user_id/", wrapper.ListUserClasses)
+	router.GET(options.BaseURL+"/v1/class/:class_id/", wrapper.GetClass)
+	router.GET(options.BaseURL+"/v1/class/:class_id/attendance/", wrapper.ListClassAttendance)
+	router.PUT(options.BaseURL+"/v1/class/:class_id/attendance/", wrapper.RecordClassAttendance)
+	router.POST(options.BaseURL+"/v1/class/:class_id/cancel/", wrapper.CancelClass)
+	router.GET(options.BaseURL+"/v1/class/:class_id/history/", wrapper.ListClassHistory)
+	router.PATCH(options.BaseURL+"/v1/class/:class_id/participants/", wrapper.UpdateClassParticipants)
+	router.POST(options.BaseURL+"/v1/class/:class_id/reschedule/", wrapper.RescheduleClass)
+	router.PATCH(options.BaseURL+"/v1/class/:class_id/resources/", wrapper.UpdateClassResources)
+	router.POST(options.BaseURL+"/v1/class/:class_id/restore/", wrapper.RestoreClass)
+	router.POST(options.BaseURL+"/v1/class/:class_id/substitute/", wrapper.AssignSubstitute)
+	router.GET(options.BaseURL+"/v1/class/:class_id/substitutes/", wrapper.SuggestSubstitutes)
+	router.GET(options.BaseURL+"/v1/class/:class_id/waitlist/", wrapper.ListClassWaitlist)
+	router.PUT(options.BaseURL+"/v1/class/:class_id/waitlist/", wrapper.ReorderClassWaitlist)
 	router.GET(options.BaseURL+"/v1/course/", wrapper.ListCourses)
 	router.POST(options.BaseURL+"/v1/course/", wrapper.CreateCourse)
 	router.GET(options.BaseURL+"/v1/course/:course_id/", wrapper.GetCourse)
 	router.POST(options.BaseURL+"/v1/course/:course_id/", wrapper.UpdateCourse)
+	router.GET(options.BaseURL+"/v1/course/:course_id/enrollments/", wrapper.ListCourseEnrollments)
+	router.POST(options.BaseURL+"/v1/course/:course_id/enrollments/", wrapper.EnrollCourseUser)
+	router.POST(options.BaseURL+"/v1/course/:course_id/enrollments/bulk/", wrapper.BulkEnrollCourseUsers)
+	router.POST(options.BaseURL+"/v1/course/:course_id/enrollments/:user_id/complete/", wrapper.CompleteCourseEnrollment)
+	router.POST(options.BaseURL+"/v1/course/:course_id/enrollments/:user_id/drop/", wrapper.DropCourseEnrollment)
+	router.GET(options.BaseURL+"/v1/course/:course_id/enrollments/:user_id/history/", wrapper.ListEnrollmentHistory)
+	router.POST(options.BaseURL+"/v1/course/:course_id/enrollments/:user_id/reactivate/", wrapper.ReactivateCourseEnrollment)
+	router.GET(options.BaseURL+"/v1/course/:course_id/waitlist/", wrapper.ListCourseWaitlist)
+	router.PUT(options.BaseURL+"/v1/course/:course_id/waitlist/", wrapper.ReorderCourseWaitlist)
+	router.GET(options.BaseURL+"/v1/events/stream/", wrapper.StreamEvents)
+	router.GET(options.BaseURL+"/v1/families/", wrapper.ListFamilies)
+	router.POST(options.BaseURL+"/v1/families/", wrapper.CreateFamily)
+	router.PUT(options.BaseURL+"/v1/families/:family_id/", wrapper.UpdateFamily)
+	router.DELETE(options.BaseURL+"/v1/holds/:hold_id/", wrapper.ReleaseSlotHold)
+	router.POST(options.BaseURL+"/v1/holds/:hold_id/confirm/", wrapper.ConfirmSlotHold)
+	router.POST(options.BaseURL+"/v1/imports/", wrapper.RunImport)
+	router.GET(options.BaseURL+"/v1/invoices/", wrapper.ListInvoices)
+	router.POST(options.BaseURL+"/v1/invoices/", wrapper.CreateInvoice)
+	router.GET(options.BaseURL+"/v1/invoices/:invoice_id/", wrapper.GetInvoice)
+	router.POST(options.BaseURL+"/v1/invoices/:invoice_id/credit-notes/", wrapper.CreateCreditNote)
+	router.GET(options.BaseURL+"/v1/invoices/:invoice_id/html/", wrapper.RenderInvoice)
+	router.POST(options.BaseURL+"/v1/invoices/:invoice_id/issue/", wrapper.IssueInvoice)
+	router.POST(options.BaseURL+"/v1/invoices/:invoice_id/pay/", wrapper.PayInvoice)
+	router.POST(options.BaseURL+"/v1/invoices/:invoice_id/void/", wrapper.VoidInvoice)
+	router.GET(options.BaseURL+"/v1/jobs/", wrapper.ListJobs)
+	router.GET(options.BaseURL+"/v1/jobs/schedules/", wrapper.ListJobSchedules)
+	router.GET(options.BaseURL+"/v1/jobs/:job_id/", wrapper.GetJob)
+	router.POST(options.BaseURL+"/v1/jobs/:job_id/cancel/", wrapper.CancelJob)
+	router.POST(options.BaseURL+"/v1/jobs/:job_id/retry/", wrapper.RetryJob)
+	router.GET(options.BaseURL+"/v1/lti/platforms/", wrapper.ListLtiPlatforms)
+	router.POST(options.BaseURL+"/v1/lti/platforms/", wrapper.CreateLtiPlatform)
+	router.DELETE(options.BaseURL+"/v1/lti/platforms/:platform_id/", wrapper.DeleteLtiPlatform)
+	router.GET(options.BaseURL+"/v1/notifications/:notification_id/", wrapper.GetNotification)
 	router.DELETE(options.BaseURL+"/v1/org/:org_id/", wrapper.DeleteOrg)
 	router.POST(options.BaseURL+"/v1/org/:org_id/", wrapper.CreateOrg)
+	router.GET(options.BaseURL+"/v1/pay-rates/", wrapper.ListPayRates)
+	router.POST(options.BaseURL+"/v1/pay-rates/", wrapper.CreatePayRate)
+	router.DELETE(options.BaseURL+"/v1/pay-rates/:pay_rate_id/", wrapper.DeletePayRate)
+	router.PUT(options.BaseURL+"/v1/pay-rates/:pay_rate_id/", wrapper.UpdatePayRate)
+	router.GET(options.BaseURL+"/v1/payroll/policy/", wrapper.GetPayrollPolicy)
+	router.PUT(options.BaseURL+"/v1/payroll/policy/", wrapper.SetPayrollPolicy)
+	router.GET(options.BaseURL+"/v1/rate-cards/", wrapper.ListRateCards)
+	router.POST(options.BaseURL+"/v1/rate-cards/", wrapper.CreateRateCard)
+	router.DELETE(options.BaseURL+"/v1/rate-cards/:rate_card_id/", wrapper.DeleteRateCard)
+	router.PUT(options.BaseURL+"/v1/rate-cards/:rate_card_id/", wrapper.UpdateRateCard)
+	router.GET(options.BaseURL+"/v1/reports/at-risk-students/", wrapper.ListAtRiskStudents)
+	router.GET(options.BaseURL+"/v1/reports/attendance/", wrapper.GetAttendanceReport)
+	router.GET(options.BaseURL+"/v1/reports/availability-demand/", wrapper.GetAvailabilityDemandReport)
+	router.GET(options.BaseURL+"/v1/reports/tracker-fulfillment/", wrapper.GetTrackerFulfillmentReport)
+	router.GET(options.BaseURL+"/v1/reports/tutor-utilization/", wrapper.GetTutorUtilization)
+	router.GET(options.BaseURL+"/v1/resources/", wrapper.ListResources)
+	router.POST(options.BaseURL+"/v1/resources/", wrapper.CreateResource)
+	router.GET(options.BaseURL+"/v1/resources/available/", wrapper.FindAvailableResources)
+	router.DELETE(options.BaseURL+"/v1/resources/:resource_id/", wrapper.DeleteResource)
+	router.GET(options.BaseURL+"/v1/resources/:resource_id/", wrapper.GetResource)
+	router.PUT(options.BaseURL+"/v1/resources/:resource_id/", wrapper.UpdateResource)
+	router.GET(options.BaseURL+"/v1/resources/:resource_id/calendar/", wrapper.GetResourceCalendar)
+	router.GET(options.BaseURL+"/v1/roster/sources/", wrapper.ListRosterSources)
+	router.POST(options.BaseURL+"/v1/roster/sources/", wrapper.CreateRosterSource)
+	router.DELETE(options.BaseURL+"/v1/roster/sources/:source_id/", wrapper.DeleteRosterSource)
+	router.GET(options.BaseURL+"/v1/roster/sources/:source_id/runs/", wrapper.ListRosterSyncRuns)
+	router.POST(options.BaseURL+"/v1/roster/sources/:source_id/sync/", wrapper.SyncRosterSource)
+	router.GET(options.BaseURL+"/v1/scim/tokens/", wrapper.ListScimTokens)
+	router.POST(options.BaseURL+"/v1/scim/tokens/", wrapper.CreateScimToken)
+	router.DELETE(options.BaseURL+"/v1/scim/tokens/:token_id/", wrapper.DeleteScimToken)
+	router.GET(options.BaseURL+"/v1/self-booking-policies/", wrapper.ListSelfBookingPolicies)
+	router.PUT(options.BaseURL+"/v1/self-booking-policies/", wrapper.SetSelfBookingPolicies)
+	router.GET(options.BaseURL+"/v1/slots/", wrapper.ListBookableSlots)
+	router.DELETE(options.BaseURL+"/v1/slots/:slot_id/", wrapper.WithdrawSlot)
+	router.POST(options.BaseURL+"/v1/slots/:slot_id/hold/", wrapper.HoldSlot)
+	router.GET(options.BaseURL+"/v1/time-off/", wrapper.ListTimeOffRequests)
+	router.DELETE(options.BaseURL+"/v1/time-off/:request_id/", wrapper.CancelTimeOffRequest)
+	router.GET(options.BaseURL+"/v1/time-off/:request_id/", wrapper.GetTimeOffRequest)
+	router.POST(options.BaseURL+"/v1/time-off/:request_id/approve/", wrapper.ApproveTimeOffRequest)
+	router.POST(options.BaseURL+"/v1/time-off/:request_id/reject/", wrapper.RejectTimeOffRequest)
+	router.GET(options.BaseURL+"/v1/timesheets/", wrapper.ListTimesheets)
+	router.POST(options.BaseURL+"/v1/timesheets/", wrapper.GenerateTimesheets)
+	router.GET(options.BaseURL+"/v1/timesheets/export/", wrapper.ExportTimesheets)
+	router.GET(options.BaseURL+"/v1/timesheets/:timesheet_id/", wrapper.GetTimesheet)
+	router.POST(options.BaseURL+"/v1/timesheets/:timesheet_id/adjustments/", wrapper.AddTimesheetAdjustment)
+	router.POST(options.BaseURL+"/v1/timesheets/:timesheet_id/approve/", wrapper.ApproveTimesheet)
 	router.GET(options.BaseURL+"/v1/trackers/course/", wrapper.GetTrackers)
 	router.GET(options.BaseURL+"/v1/user/", wrapper.ListUsers)
 	router.DELETE(options.BaseURL+"/v1/user/:user_id/", wrapper.DeleteUser)
@@ -562,39 +4279,370 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/v1/user/:user_id/availability/", wrapper.GetAvailability)
 	router.PATCH(options.BaseURL+"/v1/user/:user_id/availability/", wrapper.UpdateAvailability)
 	router.POST(options.BaseURL+"/v1/user/:user_id/availability/", wrapper.CreateAvailability)
+	router.GET(options.BaseURL+"/v1/user/:user_id/availability/recurring/", wrapper.GetRecurringAvailability)
+	router.PUT(options.BaseURL+"/v1/user/:user_id/availability/recurring/", wrapper.SetRecurringAvailability)
+	router.GET(options.BaseURL+"/v1/user/:user_id/notification-preferences/", wrapper.GetNotificationPreferences)
+	router.PUT(options.BaseURL+"/v1/user/:user_id/notification-preferences/", wrapper.UpdateNotificationPreferences)
+	router.GET(options.BaseURL+"/v1/user/:user_id/notifications/", wrapper.ListUserNotifications)
+	router.GET(options.BaseURL+"/v1/user/:user_id/slots/", wrapper.ListTutorSlots)
+	router.POST(options.BaseURL+"/v1/user/:user_id/slots/", wrapper.PublishTutorSlots)
+	router.GET(options.BaseURL+"/v1/user/:user_id/time-off/", wrapper.ListUserTimeOff)
+	router.POST(options.BaseURL+"/v1/user/:user_id/time-off/", wrapper.RequestTimeOff)
+	router.GET(options.BaseURL+"/v1/user/:user_id/waitlist/", wrapper.ListUserWaitlist)
+	router.GET(options.BaseURL+"/v1/user/:user_id/workload-limits/", wrapper.GetTutorWorkloadLimits)
+	router.PUT(options.BaseURL+"/v1/user/:user_id/workload-limits/", wrapper.SetTutorWorkloadLimits)
+	router.DELETE(options.BaseURL+"/v1/waitlist/:waitlist_id/", wrapper.RemoveWaitlistEntry)
+	router.POST(options.BaseURL+"/v1/waitlist/:waitlist_id/accept/", wrapper.AcceptWaitlistOffer)
+	router.POST(options.BaseURL+"/v1/waitlist/:waitlist_id/decline/", wrapper.DeclineWaitlistOffer)
+	router.GET(options.BaseURL+"/v1/webhooks/", wrapper.ListWebhookSubscriptions)
+	router.POST(options.BaseURL+"/v1/webhooks/", wrapper.CreateWebhookSubscription)
+	router.GET(options.BaseURL+"/v1/webhooks/deliveries/:delivery_id/", wrapper.GetWebhookDelivery)
+	router.POST(options.BaseURL+"/v1/webhooks/deliveries/:delivery_id/replay/", wrapper.ReplayWebhookDelivery)
+	router.DELETE(options.BaseURL+"/v1/webhooks/:subscription_id/", wrapper.DeleteWebhookSubscription)
+	router.GET(options.BaseURL+"/v1/webhooks/:subscription_id/", wrapper.GetWebhookSubscription)
+	router.PATCH(options.BaseURL+"/v1/webhooks/:subscription_id/", wrapper.UpdateWebhookSubscription)
+	router.GET(options.BaseURL+"/v1/webhooks/:subscription_id/deliveries/", wrapper.ListWebhookDeliveries)
+	router.POST(options.BaseURL+"/v1/webhooks/:subscription_id/ping/", wrapper.PingWebhookSubscription)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RaS4/bOBL+KwR3D7uA0nYeh4VvSRoIDCyQYCY5BY0GWyrbTCRSISlnPA3/9wEfkkmJ",
-	"etixGsnMrS1Kxarv++pBoh9xyouSM2BK4tUjlukOCmL+fL0nNCcPNKfqoH+XgpcgFAWzSuxqDveKFnBP",
-	"mQKxJ7lZowoK88e/BWzwCv9rcdpk4XZYfKQFrN1X+JhgdSgBrzARghz070qCuKeZNuOWpBKUbfHxmGAB",
-	"3yoqIMOrz82LSb9Pd415/vAFUqXt++G93RG2tXG1osyyqwUkoOB7uJK540hEn8qMKOgGlJ4iHdo8Bs5l",
-	"nNQbxih4Q1S687f6Db5VIFXXbWcvVFfLhQhEEZfinrzNiYzQn/JKSIhHnOCsEkRRzvRiBjIVtLQ/8a1b",
-	"QZShgrJKgcTNrlqWWxDaglREKCNWbWPDRUEUXmFN3TPzNOnuKlWV1dk6FYoEKyDpDsSPAOg568XuOeTt",
-	"EsXYgNkLcoBgxLdhLtwqIxbKzjqw7J6o6ShvdOTA0oNnzSOuLi16FVhVaHy+A3zNDzjBBWdqZ/56oM/c",
-	"07sokxrQc7y6kPtK8R9i/gR9CHTIvd3FC6tB3cPLR7ZfJB+IUDSlJWFqcmkehSFSf8dh6HHwoyDpVxCy",
-	"t2ANqbUX3X5Aesv5P072Q12rVzytAniZjZga3ostYfTPpg2E7PTSwr3PJmmk/UFibccEE8wNvtinYV2Q",
-	"P9b2ixcJLig7/Wjnk8uBmCiLMgcFWbcxvtYfI75BqW64aH2rC8b0FB7uASUIyrN7YNn0cN03RpPTvzpR",
-	"E8sSLaWsymeIXyqiKuln36bKNzTXe/n7Jrhi/i/5lZYlZNF0VJpGyraTdOi/nAR9IQAy4MKzEPp40kkT",
-	"WUzQn2RcZHrvMzshFITmAc32SawSUiFVf2HNydAqF9teke44g3tWFQ82qq62eA4+wyQrqDdm1Z02yuUF",
-	"87lzNQjYD8851EdMX2N6KqRnhLNb74+mBW54JK8/rNGGC1QQRraUbZEzjwjLkNkAOeVr0wlWVGm38O8u",
-	"HQR6/WGNE7wHIa3F5zfLm6XRUgmMlBSv8Mub5c1LnVtE7QzIi/3zBfGOTwvDA7fziGbD9It1hlf4HajO",
-	"actlJkj1hmcHm1RMaVD0eFWWOU2NgcUXabub7ZFjHbT3VHcMVahEBeaBLDlzqfxiuTzLjyb1f6bLiU6i",
-	"tKZKfXQM1GMQQz6TKCOKGGOyKgoiDpbC8B0juCpXtMwBaY8k+s+DNvVfrTCylVrvAeF32qJWjWk/A3J5",
-	"K4AosKfieVRibU+SxPNuvpmvUWq8zJCs0hSk3FR5bth5ZVXUBjlDolGiD6wNFhHE4LttzB5+1s8WcLb5",
-	"LB6bBng0WG4hAuX/qVR2qDSmzE1ASQQpQJlx9PMjpto/ndX1XLcKWmuIT+IB3Nbe3bXSaQJz47K2UVtE",
-	"3QB9Al3DUi8ZJRNkYx7DXgt98egScAR33aHOQf3UFn9VzHXE0xHX8Q7gbVU+Qdm2SMwOgtXHBBRMqHrK",
-	"dt5FCmmeN6seAHaHOz1YDNXFWqizFEYX5aWV0SXdHKWxk581XKFgJpXFd6AaGH/WcjiVpigDGShCc2lB",
-	"f9VLFOMKbXjFsphIHeTo4YDWt+fo1I7lTwPwXCngjhbTp8YovpWxEkuE8zmxHkU7VSsTuNguHu3RyuZA",
-	"Bvqk2yXq1jx/L7aTWGoOaz+SA5G4/WssZF2diFjwZR9uNkhEGPLvsTz4gmu0seo7N1jX13MQ3qWFPUB6",
-	"jvI+jRsncOUuwEeHhHeg6svyWRtm+0Z+zsPm4MnR3YpOn49rKCMNoF7qG5DrzRpazHA8OhI/zbhm7u3O",
-	"GNbMCbZnVKuc13XgxnYYdXgkGK63n+zc+1QHgkjdNHP6WZXWfDFWYdsTvcMp6c3NJ0bievOXVVfPAWhs",
-	"9hrE0k5eOt7O3NXAWRKV7vrGrvkxvX4R9e5yLx24DKhnjVuDNDTDVp+kh2aEX5WCi6cDe+6fYSqIox8v",
-	"vJ276L6q07qF/vWqTxBAXxUiwUsXl6L2lW8nI1pXvCPV6emwv36KRP7D7dJq5ZuaoWpdQNpAOfu7cHZx",
-	"eQvYunKZO5cqYwXEvoa/Nc3ylOQogz3kvCyAKWTfxQmuRI5XeKdUuVoscv3ejku1+t9yucTHu+NfAQAA",
-	"//+SAH1PiysAAA==",
+	"H4sIAAAAAAAC/+y9a3MbN7YA+FdQ3K3KZIuiZSczNePUflBkZ6K5fq0kJ1t7lVKBbJBE1AQ4AFoyryv/",
+	"fescPBrdjX6QIik7d77MOCIaj/PCwXl+Hs3kai0FE0aPXn4e6dmSrSj+88xccn13ZYqMCQN/WCu5Zspw",
+	"hj9TY5jIWHY7y6nW9m8Z0zPF14ZLMXo5Orc/EDknZsnImikuM/yntpMSP8doPDKbNRu9HHFh2IKp0R/j",
+	"0UwWSrNbnsHM7mdtFBeL6FdBVyz5+5wrbdp/zmnXryuuddfJPlBtyKz3eA9UEzrV8M+5kisiFVlSTYR0",
+	"B6dixshcquTx7Xy3TOD551KtqBm9HGXUsBPDV2w0bu7bfaMNVWb4V4qtKBdcLNoPfDVbsqzIWTbg1HDE",
+	"DTPESGLoHUseTrF/F1xVQdwcpZdSmTnN8+aGLt0EYT/xDmZUCGmIYnS2JOyeCQdvLhbwn2pDwpHt98k9",
+	"utnaCNAoOrsDoCV/j844evnf8VwV0owJMab4Kn1XF6uhuUIpCciOm5zaIPAUDcTg/y3AR05/ZzMD5z8L",
+	"JHzJ1lKZS/mQEBJI/Wnsljxwq6hhCaJbUsWA0BSbSZWxzCNYk4elDMJjTOSKG8My8sDNUhamHF8uMRpH",
+	"zCCLaR7RpShW03hLLEtveAYT5Xkf1W7BRFwg3S6ULNZJGrxjm4RcRdIAaWIKIxW5eDXG/1gygqRFMrrx",
+	"/PnA2B38uJLCLCfkvYPUXKqwCQ81SmblxMA6TKWkRU6nLG/dFNDr2O3L/nvQzlILeWQnAOl+AfkCPLym",
+	"ynhY6jqQx3AsAYeWIG7XTHXwfCE87XSs2kFmY8LFLC+yIFlYkIVLul4zkVi1JiosfEsyStFdBJyIaMee",
+	"3SrnSPLuPeU5nfKcm02Cae2vObuFG+MWNqruaY6/ccNW+I//U7H56OXo/3hW6g/PnPLw7Jqv2IX7avRH",
+	"WJ8qRTcIZc3UILHpB47b99R3vPMlFQumE6fMsr0dSLGVvGd7mu6PnhO9Yisqsp9loZqHWrq/VikXxnq2",
+	"y+hmTE6BIF9813ntrbgoTEqMvbU/wIRBj4u2R/6S4Qa/7Zq9i6nlfM6AGqqzOvbGAyYnLtbrfAM3CZfN",
+	"ya9RILkjoQzwW7d/a94hqaMNu0RQ+A0CH46sAc+eIw08HK9bTrcr4EAOZzRxzbyqymqgmv+bXBUCBvdK",
+	"MT/reOQXrkClSWbhdBGN9PH2x3XmFIcqF8xKnu9iw5SY2E06+QVTG/6RmtkyXgpUV6YTLyo3X1XONvXO",
+	"urBIbKllJzmd3cnCXKxAX0uAjeZwjanU5S6M401CBeHnbiT5y4TP9LdkzvPkywJk9f9I4XS7OS1yM3o5",
+	"+nh9PhrXSdiNxBXy/AQUBdDcjSZUZAQmijWV6//v4tVoPGKf6Gqdw5qvCzjKsx+ZyrlobqUGpnDSfjBd",
+	"Ml3kCWBN3ajht6Kf9wOq66lrhOOKKd3jtQNFBuqGVKRAyk+/m/UdX69Tk1yyWaFQQEgFeooE2Bnm4WyW",
+	"1JAHphiBx1PYSy+rRyP90uMIPF0gdqBoBa5jQ8Vo9l7km9FLowqWoLOZYgCOW9r+6u2dg4kMdYvh72Zv",
+	"Piip8FfUS8hS5jyjG536SMtCzexnolgB/FZUFDQfjUd8htDq3Sm++7baaw1j7lEZzRMdvxNfMuezxFXx",
+	"K5COVXDhbcYEPCZAVyX1+w2ek5pwoXnGCCUe0TfCPmJfkgeqBMmZ0YQbYpZKFoslMj6h5Feq8M2+ZDRj",
+	"akymuZzdEcVgmzB+ciNG4wBXmAkpUc7uRr81YFI/1RUzhotFQlNch1MPYm87ug5zN0kSulLegWp7lcuU",
+	"VAY4OkZI2NfsoyeX1to0lfKOZYQLI4fQfMXElnzPhdm5hkfjhMAmE29GqhhZMMEUzUEP4TOrcehJigey",
+	"AtU00Vz1DRMLs/Rqh11YkFJHCKz23WlS9OVysMzYnovwG1P0C/oIn1f2i6AWDtpcw3gU8WmAXR8lod7R",
+	"JCcA0RZ3VjRhr/Jhp+7b11WAoWdUad/FS5bjzYEkPBqPgMYyRR9EknmR+FM6DLyVbxWjWoq+0+Ec5/hF",
+	"jkC9tJ95I87g62cbS3XvbO3c8cr9UuWJJhus6Kfb9ofWW/qJr4oVsS+W6BmnJyQ8wqymsabaICfmfMUN",
+	"+V26x8QD5Sbn2kzIR4E/scyKffeKmqCJUcAyo5fPU1tcU2X4jK+p2IIaEV8fyi/t0zsJzuhpbm/cBCAu",
+	"pVxZ9RKIeL1iwhDFNFP3zkIGR/W2oqEK+WMFi2eKYMeKDUAD1YMS88N37Sx+j3l8pOVUxVYVVklJCURv",
+	"aVZudzmlFBBmlsxiLCKtYCP+wfthCmF4Hix3JSqmUuaMNli/qfNJw9Kg8XN2KaHNj2Re0QUdqEpIJWXf",
+	"4BdqOMo4eqzimgPgf4kH6sZCE3rtANrF6ueXGrDdNmOAxcuWQqYBhT7a96u0bjS+ZpqbBKi1UBXcSLcz",
+	"mbGdb7PGVsspB233MlymnkytLlOIYJWNDDq1v1oxho4lKeY5nxm42hk11r3gnkqj8UiaNnLHDf3MtZFq",
+	"81oYlTJZzwyvbtE9CVELTstS/MVIhf+M76Nb/7pObYbOSjWuKoM+aga+GK+80mzFBTqqVjSzirQ1E6Xk",
+	"QKfMGfC67VQimrfv0gKzS8glf1grds9loW+7pw/DdrkK90Hxe3gjRyAax3LUUdq49baLsNXKXbEO06Dl",
+	"R0UuHPhCqd0irb7sISdvE9ix/tKJfdSrowkjG26szew2xx9tJ7gM4qS59XbV/R17IFlTfZ+Q/2JsbR/x",
+	"YJxjwpTD6hp1k9FaGfXR5B9N8FsHJFC17nOx1a2Q9isNPiinbj9C2y5db53L5IxqtsXEXeg3UrHBV3hy",
+	"IqS65hTupVg5yOdHPjeTds5tbpI5kAUT1gDWJEHviY2FDjh/crjT0beP/5ryE/fXlPzZ9sEKQviepd6t",
+	"TCiZ5wd9ulrW2AaEO77Igrdvx/dYezhR/CIrvW7uWIFEIuTGZPBbK0Un5Giv230LBn8U7+IGryGMiind",
+	"6oLrYq1W6LYDpNVBuRWj/4lYGa5B96tlTPAqWwzrklsn5JJyDZZ+bqxX2/4o4GtGDcr0CjPHTHu6f6b9",
+	"wtQQxTJu3knDzlHXbNJXzgXznuQqAi7EveQzgLOwV+MMJ5uQ1xgXCX9G99+GGfdTQj5uw7zh6RicZDYW",
+	"I2dzU175hFGVb3q1EjddiuFeo+BfJUOVuy/MTMl1ZDBOcJm9Uo5g2BnmXyiPWnoX7Je39oWZbUnolU+n",
+	"m5anLTxlcwx8tiNdyC3uYD9vivjCcu8LN383xltcHYeOpyg30MaHEQxagp2oYqXOQnVQaVwMpYa/BSky",
+	"HgjJ7s2+UnKdMpM1OfWtBEu4kYQKtM6QGROGqd59DGLTbQ06HkrWrwkKoLPvAP/aoAMf0dBvudmLjSU2",
+	"c+zIth1iZ9dJa7iI7RWWU3sMFOWMbaEvARPNMJXwrSZuGSIVUewkRtggM2w5Vepe8Rd/d5zuUqLe70SV",
+	"/4ZM2YwWzrft3Nfg3S7yfLsHYgzmiDqjvXXDt+kJRSixCiWX9J2i6Z/oiucJ9pnyHG2ubEV5XqFo+5cD",
+	"xdDMcTtD3abN+JnrJSP/pGrGKbFTdShmaf0mIB8gANRnX312tm804Vb/0btjuvqEar0cbPTYT7yqBoCE",
+	"1uGm0yOvXaxq8Y7lXnwUWjpkj84wuF3fOuy1ZCTIlVXeuh1nNpAL40geFIwXE2KXtwEfhCkllf0RtcQl",
+	"F4tJ2ofmztcSVqJDeJnLD4DIM1nkGZmyrsgzYIbNrSpE2vcUw7JTNg1YPY7kLzmaxC/nxuYsfAZ7mhxq",
+	"5cNr+C4ZECjuuUFjoL71iTQNfd6PIP8uWOE81xnLOSj0yW1aIkwqebsjZluJHCKHusTyAw1yuScI0dNF",
+	"TOzjHnYLCGvn38hQUKPjq18w6lX72DQbk0aUfJgQXPYlKW3jYxJM42OCEnh8AxthGH0gcVKa5xuyXkrB",
+	"bq2ha+KgoF+SyBBQ++JGBKV5TJpmhTHxD98xsdaCMfHPfPIXDOu+EeFBH9Jxvh2TYCvABeNX/YREQHxp",
+	"j3MjYFS0FUFXwDkICVL+FvIGpVpQwf/HGrolGPVuhFlyT382ki/1itO3M33focLdepFYiTme01yzesyx",
+	"1doJJZovxAkXxH2KHIS5g57i8Rw2fygSlDySk68/cW3gxH55SxcwWgPWEEqo7edc3EV5BviYgg/NknFM",
+	"TeIrnRaqkewLB7NXa/VccOmSewqOVcNc8lXOLBoUszK+h7HTQrUV9BpoKxJW/bB/jfBIAJkigODRoZlx",
+	"QFlTrR+kyibkHWOZJjVMp6GFpNey4z/aGd5L44QRIS9WoiXXOGfDhD3qA2AlY1rTRZsL7yERGMltbLzH",
+	"5hj/5YTOlAEBoe3meb+odMH6sEy5kaQItLpSWru8NYk0m3d0FXbpzXxSOe3LqWQ76p6pb9A6detUuuQ7",
+	"2x0BbhscDloLc/9OWi2sE65iIW1Rbxu/VvfR/FnrYssj3nGRxdqjW2HkD38Lp0lqjWjeG66I2HmBxlJa",
+	"iL2OmsD9J4fUahBiaCBEyejgDYIcz1tJ0rh498vJ6enp6fcvks9pyrd8fx8xQ94/0+va86ZGWqCxKMp1",
+	"ms6HvekdOmLjXncmujQ0v515tbepm91Lvl1gWj3Do6RsR5SROcELg+609MBX1e16Su0QP6VtrQr7Hzlk",
+	"EjCOzxcpKgLHJvmHP8xJ9J6sBVt0snSVwurvicyLOvfadsUQxoR9muWFti/5Q5JlJ13UcNiOnA7Yo0Ro",
+	"PjpXcO2WBFd38SwoemeliDkj/WLqi3uyEt65M7q9GTVGxD91CPo+31t3hFO0o8aXCnSTGVVZ24DdAoQ7",
+	"RYD/ucVVWE8xd7uvQqE9pKmC8A5yaRq0MkXn6Ej2lwEI+ZGVSMmL619ymo44Xa3bpNsuysOcC66XW370",
+	"u5y2wT/c1OGuM87VPAHtWd631VLQ5pZ5fbP5s5zdBY9M268Y0ZzKpKE66GKKrXM+o0QVQri3BvldTgeL",
+	"J3j+dSNhTTe5pDFwSupQhXBwbtiehN8KaAxZwTBYpxBEsE9m8O6QbHdyevXdxP+S0xazuqOFcB3680cX",
+	"YwBYDX4BIL2meFi+NdZsplIaybmSgrBPa8W0xvc/u6d5AYsQLojNvG2l3jR9lujbLi2y+QP7tO1sadur",
+	"gzlCoDptGxQbgsnayiwugCUAccVsxpjNCphTnki9aJzoDb9nmJqbyp431KVLY36tz8MHu5z7izaK0VXT",
+	"zrFLuC811EXXcGsa+hDNmTISXAfX+1/8JTyODTjlXTAOEYlj5+79FiwkN8J+zu6dv3LJiHNAknkt7XPi",
+	"XMZjm8y9ZO5jGEvRKoHR8/a7EHo/sX9m2Y0YJfCaVggAehg6cvHKBRgWK5fGiODGSlyxaOHC/O37pHIi",
+	"lS/uFAYXBU8q9vYPsfzH802qsec9WnY2Cmu6sQ6xvYLijeEfcmpgm4nbszDL21wuuLgtVJ7WtHLeoVzs",
+	"RJBsncvNKvLTDA8aQWUhfSP+/nCnW0/RKnjWDjbtDyiZ3+a0ELOln7weTJpxxWaGfLy8sCRM1YIZa6qC",
+	"vxlJIL2BLwrFPKf7VUfjthVjnNRMeBevzgn+Trjghls76cfLN1uv5HIZdpe5MfDGXgI7FMWU08D5uE55",
+	"EfoaEGgiYRuib4u/aJJ+Uwj6832jCUIdvpHKm6aZyNaS28CdTq6pp2bDTyiDoiWcTQTFHKS5Xq2oSs68",
+	"DfesuLiwPz7vYqWanMS/hyJ+JQh4dmvkHRN6TNhkMSFLY9b65bNnMyruqZ5woY0qZqZQbDKTSYKLWbTm",
+	"YLp841e8YxtdBY3mCxEtj/bxLbSLtKLwWDJNkd07aficz1oSuWItufYuDtHL3jlH/GCiJZnTdHkeuDkF",
+	"y/s01XhX5+6TQ+QVsbS2gwUgAJ8i2gfo9HQqC+NoqXElEl+FbqLYiotsyCOpZoSBP9vimrD6Smosy4YV",
+	"N1GFawA7SVOgQbrf218qYaYHnuf4QgHfifsMoyR5zpJAWNvak4MfNPH3rS9+oN/9v3hiOioNkINj+eo7",
+	"H9eKJAFZeiJKP5XquOi9BlKUH4eNuXgXvUrHV8Sff1BszhQTs1TgegilaRTVYaDSxyfXZLqxHr8JeS0g",
+	"EzKDvziPWNpdpVd6i8mv3l5NyCuuh8xdQ1EaIGl4RgBpC2UPYGk9UmI/nYs2H2sl/7jyhu55lsLn+8ix",
+	"3Nxtq4oY+6MHUXr9A3czpSD6gW4ukyZsqMsHTn9XaA0DPifkjChqmPegT6VzI+PP/v3kXmo3gq7XOWea",
+	"TNlcKoZ2cPdh+AafZiL6yv2B4jo3wudePSxlXnXMt3vhH3Gl9FfTiFxwUdGwq2rBL/vfjY+XCNLSNh0V",
+	"jDk9TReiwKKBbGjQWlzGpSeDNN5KC12AcbAsqlQL4rd25DK79RZm7KizHWwVJGdYGIgC1rkmKyo2thyP",
+	"pxOzZBv7xL8RVDGiDdxpYJwdk0K4zz3NgU8ryupGoghwffF9CqrNvQOc10zNkupDqPQbEgK+0cgWlMBM",
+	"JJ6JMKpEpRbQ8xRmG1VV08Ds22oKb8DM51Ql1P4Pis/wHMCHvniBU3HIueKGKU5tVBUcGHMgwH+6ggB2",
+	"QsXG2UV+sB5Vze6xoBI4E7QdZO0sMD3+p2d0VHxmboEb8cCFTjFvt+fG7j4ujgn/rh4jhvv3f02zlFU6",
+	"vUmk5jNWsliH0rhLeg97R4KkIvbiRXW8fHVkLjJ+z7OC5knB/4ULpvbUYGRfktvKV+lqV39LwrkZP3t1",
+	"dk0+KLYmz18+T22i7prao7RzL65eV1EoQthdg1gV+RYBDMlJL4s8Gc5QLUpZeRWfvTvDUpMEfreekwIj",
+	"pxTzoV6EC/ecOVsxxWf02Rupb8/EguXscXkwYV9jd/rB4LtMOgjigoY135Cc0ZwwV1eTUE1+/vnl27dj",
+	"cNlS8vyvjgTJVBYio2rzA3nx/cvTU7RbrHgm+GJpKjT//O8vvzsd9RZZS+0CRwzcR3XNv708Ta556IK2",
+	"w8sm2qhtFHmwt+azkrE77SAgBXkr3VZCDRMUaT5Jd2SP5nMxk0LQZ72nE2ruuwqQxdHbdE1nji3rGuuD",
+	"1SbWTK5zRub8EYnUTfkFFbnI89Ok7JJrhv0JWpSg4Ez0Bb9AaYAHuq0nNyFn+QPd2AqJoMXYvcIrczMZ",
+	"mpPiofvebuVn3Mkf7TXHBkvZwWVyaxLKB9Pa/RCv0rS6J6qT/Re3ISx+u06uKSlXY7JWEshYKjDSrDkV",
+	"ssJ9MGiouw4HpfkjAc9EAUypWYsMOc8lOnsQJLxGAB6sY1KsseD591ZilKd4cdoiQwCiLUu6zfYtiZKs",
+	"LsgWilcj4k7/vl8htrKlEkYv/9adEN0q3MqDj2PAd2HvEmt4tDy4uw2QA/Lbty0eVOG8/ddFiReo1AXa",
+	"4laQ2jD1k1u7jt+ZvicgMDSh5H+wmjJ5L5j9hkDWwbQQWc5Km+fHyzc/AAEa9xl6V8tPLl9fXZOzDxfE",
+	"GWg/Xr6pmfYdNYI9/xlf6WdSMIXfPrt/vn5eKakL0dQIZJO+ffAzWzHt2ntE3adSLZw10MOtko3RMd9V",
+	"741W9wHbX4gvAENdSWu9ETOWEVRf4tSySu2/fTtC5wHPnTdLTBNgxqD61u1Fs5lipj1zixI7kNiBhGti",
+	"q5pNCLi3/F+NZvkcfhTwqCSKmUKJSl2hCAxocrfg2k/oRzdXosunxRF6VpgleUFwCDpAy1JBeOqZYhkT",
+	"htNck4WiaU/d9k7Q8SjtZa6Jg1gYuNvOzW4nSGFyHKV5lgRV2WS72LDM0Obv7CHfOkHtiVhbsV7B624A",
+	"TgG1AzobMTsP2T/JsJqkkR2DT5x4mFcaFlWpI05EjHLQW6csS+rbGidZKbatiAXlKuOarteMKv8zpMa5",
+	"0vzVxgoDi/yXS1cl35joAsxMmiwKqjJOhS6z/NAUQ4V1Ubf0RnJRPOkIQEe+7Tt6WEodzp1RQ0mICvKb",
+	"8FKJ0LkJeS0OdP1PtLLkY9keIauUCiiPUEKwm5rQUd4kps78HTxvsH/1c1J0YQZRmQ0yGcRLVb7sTuwp",
+	"j9eWVxz1MOvff8R1zSTcbT9H+tvC8lPDVOI1JtVip52ENNltP3ziVFg8b5T7GnqGVXNfo10GqPcQS5FQ",
+	"8LsTsltDm3cKwFaBWoehxFG3C0FuexL0Pxh2ji0O1X+aoaW/9b40CudXjHWMMs05+M6jHVbBmkLm1Yyv",
+	"ruFWbr0ft4zy606iQD2y0FvOavwGawk/jCqmnCbob2mOyp/ZgL3inmeQNY3pr+EmCalp9jseCpNMWpce",
+	"Zq31I3sDFALM27S2GhRbImvC/ufg0sUXXBIAhNsSDgvMzsPErAHZr1csn0OXBrAsFxlnrv56fS9QzHhD",
+	"4ClxAoa0SahdFLLDiXUnhl5uoZHHN9rnqo9twjf4u7x0K52NttgRnhI/K3uNSMGqDV5ont9G9RgaWxmN",
+	"R0JOZZYuVBeduM0DSyNIdEmcFPAGd1exjWGId+QbOXbpa17wRzbVZOoYfr+LQyfVIq9zR84d3LmhGpsE",
+	"EP7WTXShB09L+x2+hU7QxGxfTZewRHKXuTQ/yzzbvjWPL4EUZNBS5hkyJ4bwqlUao+zTmiumu3NmcKqc",
+	"rjXT3mPP8fEfTz1M3sJUrfdf2VFnx4gyD76B6ayNIIrcKbR+J5Xvo3swgloXFjuLLpWQcxWDszBxOtro",
+	"qphqw01h2JnWfCHS9QfbyzSHz7tY0ja5hRyySo3kbXgbhWr5oFrndAYGolfW2RDqaRo3ztY0WcOVwpyT",
+	"UM7nkAfiAw5sTEMv+1cPmMRLGHFVLBZMp83Ij6qJHuJN+5tyRoFWOcjSTbiPfPlgxu4IVeCQTCGjVmVn",
+	"y/qHrY3BmydIgbLSVjYWloOT/Fwg+Ys4qvxF80UFC72fz8/mczYzLGtrxdRp+scfSwlSE5+uFLr9vRIY",
+	"NCbV8OGoncPW/oXuHN/DNQhLwS8Sj0OEAjwfjZR3vWKhvSNMe7ue1pKbHRuPBKpgLNO35TFsbA3KxxYx",
+	"6uZtLctM3YK3rb3VfQxcU5F0QqshzYY6fZPEPqDx1NP0pEyV8XwlZ0aqbzTAQHJhVixtJVcW/ENVScXu",
+	"OXu49ZfbwPGPhEiYxCZFP2GXP0cXe2/w1+k7DIwCUHhUD4Lq9pPB1p5lEC+/I/X3ZsTCtHrJUnq8n24r",
+	"W0D4qCUJfqfKQV01fpgwapvHRjiwLWSbsD82A0wjNSQVkMw7FJVrWiyWZpwIi6UiIzT7vcAyhSuvz8gF",
+	"c52WEisdrX7OcI5CUEY8hcftBpjxn+1cH6cjytH/OCztrLKVaN7u2jhlZd6oSE714F1UVCOZeoEdT9Bt",
+	"4gT3e4aEk369DCz3YiTJWFbMTGenmqjWq6L3LPcvj0t+zxQ2BZ7R1TpdUbzJGS5U6nTcoc7TcDRCsyw8",
+	"dWCyesBUm0W9AgF3lk5wthW1rkFy23o4W6izXW11cZG+HqIMjtC2k3qWRfPzeukyS88pOh6NRyWKkndK",
+	"ezuyGkH0ZnfsPx4ogCmqBFJhxt6A6EA0/2SCqaSBeGAZKkiYOHYJqnabA5rhQcoXWISSa6uXf6NJkJK+",
+	"64QpnzRIJiGvHbfU1gdrL4Wu6ndOonZRUIOS+o4t85MqF+kreDcgcwaaASDNMuLFq+06XnUnOhyzKJ4H",
+	"evL17F/pez9/0702L/K5ry1ZrjseFSL+r6bDP1oD0AjRxYNcP9HgatOKrls+zFDdY1zpvevlbensJ3tS",
+	"rM4vHzqoLn4pN5ETAHbry1R1joPlblUyhTFkbvnjhdyesJWx513LyEKGIfBpBJaS7GQxjeNeXAFKuFbY",
+	"ptWZcvHKBZL42J2sGiobqhunk8ynLB2mZCNiIzDVFHL3iwtvcSJrSTUG3kwZEwTjaDOyYWnVyEOg37gR",
+	"YDzd4Kn8rqI4I1jUE/q4iy27CcRN0UMeXb828u+mmPodPkkSYWLhOgIS8EqdapxghSRfwdXz0fC8NWU4",
+	"5D32W4/Bpxml5UTGKGxiZWtwwR9VtQ9srAD61/Ut1jYeYuh6oKF0mrEFlaNFItJImEobmmfLolfB5Dpr",
+	"tbP1Hu1RNnyooObgf7tm6hYYOl3PxWsZrqS56zLG0d3cwxJDEKz3AYp238B4VFSJsUXcBqoMT329ZsLY",
+	"LXCxiISuc5/HlDlE2G7vpYhJKAXWJnWPE9zVyqW/SnUHNfbeAEYT/oapYvQu+T58/tf6A/GfdE2mzDww",
+	"Joh5kBEuqSGugDvVhBKctLe7HFDnTArNZgW8hdsl+VvM03WLcczBlw8liprLPW9bLmaGZKIILrVq+LUg",
+	"fiOICSkY3JIunOS7v9kUv7+Fd/F220jz5NB92GwWVJ3AXh+S4br3sHPqVAbJYGAze8AkPGArxDsK6Z48",
+	"zn7rboOCoblGW2O6LcujbdHT51Eityx/1/gpblMxqA01NkMvXfchpXFPzahD1bxW2YQb+q0FMb21TQ4M",
+	"6QOCM0WLv7rIzxYrVXtUyxVzpd4hdhSYFOs1YktOK8w0F4u8I0KhIyKqd+4yGXYvaTmP4gzQ5m67wnRe",
+	"MZphBWojCZ3N2NoQKoIWCIcabJdxH211urXUPK1AvFcYIljthTwmuXxg2th3U8sjRa+lyA5SZdfT45B6",
+	"U2Xc8iAREQ8eD9FhAuSGN8zz20fQNtkp2kKqK5WtKOZoHq2J4c0asMNFaH8rcZGdm5dVNtN1mKY5zO1x",
+	"FCgSE5mAtPGfGZsBwccBU753c9rk8iubLqW8e+U7VbWV0oPKfG1wcx5rsqJZ2UPe12kbE5lngapdXLBt",
+	"6uVtEXPmaqgE0eU/HpwTXjvGmd106t7efxVzt9ndvmo17ocSf+lf2j7rq2UOP/tWszJjaSgkKvENlHhl",
+	"/fHmk/BfV+/fEQgEJmuJ7lHnA1K2vJpKVRceKLqq2K8G8fhdDJJUMVaan5c18wIO+mqOb11Ir4WSW/my",
+	"hQ7cz9sSpfNd3a5a+KOdsmpEVStYcX39wYeRhZyA6aaC/h8InWomDBgJBJaMXkvhUm7cmAHZZxFYqsep",
+	"gWQA6Dsr4A1JJQkzXkV01JVBnciEPkA6CNJu+hIUhsBwTYJMs0zKdSjBi9bd/74Z/V83o9+sdpjn7q5c",
+	"bedAaMmovuILrOxgf29NJIl5sy+fpF8MHDI5uSFEChXKb+pd85ATlDUwsaWDHoYjb9DJK+cceIi2R2AX",
+	"kxz1gLVDWEIuFDcbMM+u7G5/4opNqWaQNg//PWVUlcUmRv/69bphGPGf2Ir5kGQkp4Zy4dORw+8wJxPG",
+	"1z62Jr4VggRXKQl1acx69Mcf2BRtnugTB/UosJgUFRSbH/qCc1jeEu1DznzIxeJG3AjsTer7E+ixfWG6",
+	"loYVk/uMKrWBt9bra7qYkCsmMsINmdLZHeHiRlzMT95JwU7eYsU9I8mCGfLd6ffknTTkrcz4nMelfDdo",
+	"GCpEpX+Bm8dOIQWxDKP9bN8/fwFF2GZS2F4M5CdbiZgLbRjNsNUlRO0+KKv0+zxoq8VqLmbM53ApRrMJ",
+	"nP/D+6trr+1qtJ2Fg15kbLWWhonZ5uS/2Mb3BJRz8hyL3vz1r2StuDC2dOPZ1fnFBSyo6MwgTLW0B3Xl",
+	"khQzCkCg6ZzlG6xGygwWPY7aWd6IO7ZxnRusBr5gRkeut3CH0gXlYkxWVPmmlzci7NicXLJ1Tjcse0mM",
+	"KtiEXLJCW5jAAtYEkHF8bwgTFrMyWd+I71+8ILw8/+0d29wqVmiPKeo37/EZtmcnwloZPM9vhM2MWFKR",
+	"AarcAuT703805ufidq3kQjGo6fhfbGNth3dsbWxXjBff+1KbZVVeQOGlxx4MxwqvvmrWdOOLWdAsg3nH",
+	"rhWmyCrlWMcOfpqtaTmBTZUDStFj25rZssUUyVPJwjANgLUI0ZZwbgQUsURL+gn+75iUf7hkK8oFOhHi",
+	"P2qG/Bl/atOrHMnp8ALDfVm3pzZAhD7vBNwjFjU3IlCzvHdtP+EjZKAX/0AA3YayYkB7l4DJk7O5YQrh",
+	"GRf61bbQ0jqnwg7+dyEN1Xa20+/sf96yT1Zzslc53nkoXoqKcAlFyZl2HVINvUPiWZE11WClx+mc6sEV",
+	"Lotb8o9SR/sAaY7YIP/viUP/ycUrB66ykifqnp6/AmDsLm1OF3Ly8xd/JzkzlmszvgDUS0VuRie3k5c3",
+	"I1SPqDUSYKbk/1NIYyUJTGRzpy1vrZWc5mzleT+wgxMBc8xusUaHG5HLha4SsE0BRfb69btz9EIDRcIp",
+	"nPD5C8px+Ls21LBv/Uo34uzDxTea6DUVumxOZVeHbHj8w4zmOcNAIZjB5nwabmyFS+dVUlDTaDQeYdQg",
+	"3inPJ6eTU++wp2s+ejn6bnI6+Q4fSmaJt+Oz++fP4uvimc0vtCkHcOkjNV1k4CFi5kfgoLOq28xt9keZ",
+	"bawBXxj3WsZ0SXs1PvvdxeDbZ2LfI7KxjoO0vUFLvQZEZDDFObfBi9PTrfYR9JA2J7fhK3brW0RvFwYd",
+	"co9SWk2XUb+hnlW//qOuaY1+tPVy44sfG+X8gSr3akXVxqKwOgZVjiI3fJ0z15r6Lygmv8WI24UG3bGC",
+	"8N9gRqCaaU5nd7IwGklmwRIU84Zr86MfhlSn6IoZpmDazyMOG/93YZuyW9PyyPUiKtEzLAIxPZmR20/1",
+	"277IqZO+HVA+YGzMIPy6L1w4jb0jcrpe+/RDdK2PCaMq56Xhuop+wAcOnjZma/YgjwjALz76zVrSTaqk",
+	"Ddg1NSkEVmFGjcHVZa1SHBcY0xwFM9pu6upO3wgQVMRU44dsEXY3lmu7d5ZhlWiqfWiPlYlV8rOPsLD5",
+	"A4mqGiqHCKjnB129k3BAoWJIcd9bwm60D6U5z9xgO+y7lkhWdLlhjXX7ainJStfo7iyDm7FGdGn6aggX",
+	"2wu9ci3VXk5Zpl02vm0i52O6ac5ERpWLWqgujnG2rjbDTSDZ6YZ8hJg5LV0LdvcYCTOB6h4eN9xozz8p",
+	"6rPtzWPxd0jys6sd4n4cvvol00VuUkR47iEY+ur3EWCAOSjQPqJhT+Rod9sUgrbUmCA8bNcXGxtAp7ZE",
+	"RPtdCNqTZ1YcOjoCXkINCaBk3S0e7Kaa+kL1vsBRW10XRQIYV0lgHFA4J+BwfC7ZGhvEV23bE+FfPRad",
+	"Dar/7P95y7M/ntkN5sywjqjgqAwfyaVYMFW50hd2j1x5HwUYqsIDOa1SaOjvSrElAxc+m8lddyCyU+L5",
+	"Fe4zUg5Syik8k0p1MjrrqE4/sZ7Zr1J+n6jlVLunvaN4d9TDhwMWEtLY922NWKxGt+W9jWaCjjekVcnO",
+	"XTTMIVjezn1kNSxatKvqDXqqtIaidZvWG/BHGmyIrci/rnErsIArioXx/JBnhCsHuwwqKzkVrWRxXRbW",
+	"4zZSPy6nHq31jSOtfyRUsbJcCpqJrHnJsfODC1Z1NroxmiLLwvQ2NwB4XDN1b0tZlKl5c5rn2r8dGiRJ",
+	"CpHZmg83YgpNwJ1guxE1mrbk52xCISbLUbNFYo2ULUiefQ5xWn/EV3xdE0Vu1Iz5elW4d6+Pukpf+BPA",
+	"luWaEQlsHM5p40E31r62psoQLiYN0QXPOJvhcR5i+/ulV5yVM1x2jZNyx8WMhE2HGFVq0OaGtUpthpt9",
+	"Ze/1qb/NhkJ3p+69fLGWglBco89A4FJ+HABa2dwNC0Kf1C2MFSUgYT7wELaeiDIEsYuJCs3Us8/O4NXB",
+	"QOjTwtssd5YKroh8EH7Rsb/wDFoGXME8mNcdg6vK/tOsA6tswzhljNxjrvynpA04cZUyEnfKObabyjcE",
+	"CmmQjSwqoG8lKJy7TYdIUIyrd99JL599wO0fne8pr0YMkH1ljZ/j4fARCkPGDOU5nM05kmA+8N824f+L",
+	"NffXKkIBrKuOXbCyeRftqOvYsKfvWjUE3B7XpQPYuWdhbdggMGJl4XZJhFO1UQ5aqt1y0w25eLUFzZT9",
+	"8rtN0zjPWRj85VLScGkQnWaAXChHA/nEOgvoHnzG11SYHRGIrE8rC9BWlavFTGDLgB8XTQd6kZT7d1E1",
+	"h/RkHY5MbHn1oU+ZM3c3WyqhMVVV5NWgRy6N6wv6h4bVFuyuImobTrMtL5lzL+fKFNH667i2JIrcBvPo",
+	"LcSWXarr8YwDjnHrHYgJzuMiJ0c2wvU90T2eq5T9VBfwfojXV+yMiHg8+v75i667HfL0O692d6tX39W4",
+	"QIeAb6P5JddGqs2Ae/pnO/LPcEm7o7SUR2ujUAeqag7HYy5npFbEtJ+7+5Juw2Es7qz0QgppoNJefDjb",
+	"h6qE/ApFWXSCw13oj5BnMVK8G+HPJNpKeY0t4K1bewvzI35GgrFRQZtNromRkugVzfNDSUpwhWNwIhrX",
+	"yxYBIitVmp24UDFfXqBDg7gMg75iLaI8xBfGcyUKvhhWG+A4Knddmtmc7X03F4M3KeKw8jTEBggZeQDl",
+	"HJwJ27ke4qcIMDIVEnsH2q3iQantdTYOoqLmqwjS41CyoiT03eQB7nfolex7l369QsH2AUQg6y9PMljg",
+	"krIP25ctEoCwy20/mmXPSv6J3iWegcauINi4vH/tJWkQe4djLzwlroS9LSI8OffG9kxnpOq5gWHE1339",
+	"wgkcPT0hR8EuvrKLFra8p1t2f7coDP3z36QIeloedGveLrs3dLC37XlzFTd6+LpYPNm+50u5TMvNEd88",
+	"o2YWrjx7hTSYvPiEYiFhFI8OEQzjriQiygPl/h7Y0Ejfn2hwMJiFDilJVh9E6XYCTFdOVIiyEp8cIkQO",
+	"xfQ/23xHO4NtCiMJjXeLYI9EgOsAMUgI6PYwBqzNZ/uZIg5LivpG+xirv0DkgkX7PE6v83XvfGfAb8e2",
+	"DzFmRjRSd8zS50c4ghWy2tblRkCXKqCtVpkMUNKGbsogqiaKJjfCH8onvebQMx6T8hi7g9K2LpM0FWvp",
+	"+lhdReT4dZtxky26Blhyy++IDh/qwWydQ3sjwPtOjF0NBbbLN7ihTfPtZw1fSGmALd+XVPrKqaBaNW4A",
+	"+n91Ra0wjdlVPnPNKECO+UpbrqTVUKrAeB0Pff1Yj4CfKG2FDJjr8Nnj7o+J5/1rQdUCZk/lqt+JvhB3",
+	"XOA7oSyP1qaM2EBikPuZZJY6cAKWKMLGPtGZyTdEii00EWXJ4dH06chqOxL1osrG8HbLJRyjR0dxAuJa",
+	"QxBaBmXrKK2tN1ATg6fy3H8aP3Ls0u15nNUwcB+47iy6iNdvdE+MpYvx95GhBzFEOAgOjvJPx8oeMSzf",
+	"wTTxjO8IUG8E13rsVQm7JTi9GS/p5ztUsPhBIyYjlCex+YiYSReZf7CgycBPe4iarMVvp8Mm7aBG3GST",
+	"+ZPugKOQyaGEwtbe+CR8kx7znjA3I13v9/Jd74s4SWW162Sm3XBUd72T7eCdHsoWZMlo/n6BY4+8YqKv",
+	"EISd63U5/LBZK6ksj1AUchhBlXv1VSyP864o1x2iI5Sj9bgMKIiig4Y+ISJM7ip+wisimstpaXXSig7Z",
+	"ro3Y61DXphxbl80J1qHD3zlWqoRRyIlQKMwOZ9mNsClAULfLsyPU1dFVVdKXIgs1DUuhDcyfsmrYEzip",
+	"Y7MavjKJWeLAAvrYaZPl+u2lAwCyAZloUHQo6ygi8LESdTxcCA/gFCfhgcz0dpL79B/tG/UOUX/OGltZ",
+	"OLnkmXrt9xQ/DRPX0yK/6yisYbOx6lvD1O2czeGFAVW1yJUXOVgKzD5R1nTGzeZGhAJWEZ852GdMkQW/",
+	"Z8nk7B+L/K7OXvpr5q8f3bV7VD/KUPbS2/JXPa7/S+CwFMNoMGNQN9f++KbMZPQdtbqC9t2QuvpzBO3n",
+	"6dMYh2o5XVpN2TZvMAn5LwapNNFK/QI7Guyp3xbDrdLfW6ruSKBlHE6j/n/7Ir9MyXWHAPc1uYJehAVu",
+	"SuXmG9BtTFGGJJSGaW2T6TWjxqWkzxVjvkp8MOZF0h/gDvuBASvN8nuWrIr0Ssn118sKh7wiADJPd0P0",
+	"8CBgds2y/uRhJIGQPMwqL5mjsR+Assp+++K4Qfkq5QpbJa185bJ+y6fttkkw9gnujBs6lQUzPKd9ny9d",
+	"XdmXrUlwAMJTzL92O4ML/aD/aBu7SjorUGzdqC28XMEYcWidw7/H3D59lZyKtaLhOyt3R70ohy+DQvIo",
+	"gh3o+McPtvMIP4kT4s/o+h8oy2qO1fpTabjz/6i4/o/7/0/h/u+m0Q7/fweZOqFlm44800YxumqP27vC",
+	"3zUp86HcpU41wfhkdYKF6e10UQuFmRSCzWCWG6EN3WgM14seR6GJh/b1Df0kcbypq/51I+LyXyHAtdrU",
+	"o1Q3mmWPfrgRDgUL5nEZl8atV0nCQv0Qp2gH2SBuwsVMMYq9Jy5eTcg59mNw5RsVc0eOivS/odqcYFXd",
+	"uKK/VMS2QXNNusau30QNDPbcK65towqNlSEJtT+U7ST+8ubil9e3r395/e769vL19et31xfv30EQOjS2",
+	"n25uhGsv8W2o74sdFlwzCXTG54wC5YgZC8X+NV25jUAh/xtBlYKr1TzwGSN0pqTWhJZnDnMDgdNcS+I+",
+	"0BgTCSxSGAi8hPYxSLVj54u7eGV7XQB+RVmarCS0mVxh5wktb4TfMxS4roMqaAELaaybt4YgqJd3I4yU",
+	"ZE4VmbIlF9Zym3HtjuFipxXTxYqVcHigGySIH5V80Ezpb+xpryoh+9jrwCxtAxypfLiDR7qW5IFNyyPA",
+	"Xu0KyGGWaLDTYDJaFAe9vk/7CGvlgl95DgIqc0j0HcrGpTcnRZxR/oG2ik/KZ1ih3nSROC7M375PtUPr",
+	"V0UM+2SseDqxsKneE40wg3FSZLnqPQBq2LXLbZi4+BbXG2gSpaeOb4T9WxTMHQZhXo3/7zig/tZ5xcc3",
+	"IhZGE+9wpiIuiDLxtWLGZdDwG37PEAs3gmrbhDCjht6I3nrQEXovXtVjWS0QcuTBmuyO1VnX/MrfCnO6",
+	"4jlnPW7rn9yoo0SG4WKD7nq/LStgbO1ypNht68XyPHfvuJRGOA/LdJcIdrO0h5ZYB6M732G0Ng+84/ow",
+	"41UTKNr4KLNeCp/b4Rh9vWLQ+DrhYHmEX6UT4SHubO4x1ERtnW0+27Eh7Cz5IrCxJQHv/Q+BMOmX8BDY",
+	"hqROj0dSlbLgXxBJtaj2btdtqn0IP3LbtVoJJjzhM8btVHcR5VLmmX72Gf4vUYm8/kjFZN+rXJqfZZ4N",
+	"oko38f7LfsMOfPpxmcymc2nwDcGyHktUsHC6OUojJ4KkFSe4br/tCYfZdDRXqz1pcHcgJRRXjRB1xfL5",
+	"j1LedSJrJsWcq1WnExVHPAHKjlYjfCql7weIJGAd6TRUuitpwzEutR1IEPnub/1U4kBdo5JDpSJvQ2Su",
+	"RbqNQNCBJcLWLDyGZBNWLzZ3YEuZtj1AM2cgSaa2V4nucLD+AlIWA9DsI1vJBy9PoXOI9qIMpsG/MqWk",
+	"0hPyUeRM27A1fSMoyeDjQozx3Y3LhkxA+wkgAzoZGiYwsp/aJ+wPhIobEX3hh7uuh0IaaOaeemVdFsL1",
+	"jDnM1elbwhysb9uQxcueOFXEvbLwdrgBwtwnp/etDlY3jzMa8Np6m7+TjpowXAoZgot7blyfR6pBbkDs",
+	"uicZOpvJIrgfBtzjdjOB1fzeKq0eq7IhxHphXIFUzgrXIhtevDgqbC20MG3WsQPwoeOFOsj/etoSUd3E",
+	"0JhMC0O0XDF/QhtYNJOFk3NTZpFENF+IEy4iVKQ6ENXabFIRO2JcP6Lzq1/sgSJxZT+PnrJc3Es+63vK",
+	"XrhRwzrxbRks7SYPkdKtIdh4U9VtKQMDuOPHwRN7gNx5h7zVPdzHYJHvd1pv/1L36EcKmimWcfQrMt3z",
+	"RK9lOPE89zeZvekjdaNM9vc2bX/RIUrAJISWnzJZLOruhybJ0FUkVAhx2yYbaC0PBmhnlYKha8VnsTo0",
+	"BbhhnzbgYez1O6Oqo+2fx8+Bbjc7+9OETQfSS11sdB7oYbDpwZGWQxmiWjCOOJKKTKVZArZL1kWklry4",
+	"5+fiVRlNOm+8HFtUyHdOuENnZZ7n41D3AZwZQiYoZ1z+E+V41FR7VijFxIy7Gj1+KrgJYHKwr0phB5m8",
+	"2TJNMOU97TEyUJrTuIx562s2iPPP7l+9qX0ltfc/jMo5v5jojA6Kdj9ZSQB3bs4F0/umOb9KZ16dCLiU",
+	"Khaz26PSfnwCH3e9L3z6C6ysdcEqwt1ChBLBFhRDQQAwqAdalxeOZBn+uTVJFwe9k+bwlHOAfL+w+S9O",
+	"DJ9HaOoTwm+51q4XvMYAEOz8LqI2XFYpL/WNAxN+i4j1I922HEFKRdaUl8905E70NIbi444QmxZnAJEd",
+	"b6PmqlOGE2/NXUuzytul5SUTGVNfhMBE7x/sdkuv38/Xb9+QTM4Kmw2nzSZ3T8C14sK4i/DDq5+OLSYt",
+	"bNslJaHabhGrQ8ExtscuUkmH0Pwn9zHvfg8YC1OA+XtCLiyN+anx8KVx0zoNm9ISv/rz37GWAb8YEeM0",
+	"qPrbGTZZ1662J6M13XQYnD/QzZ8f3SBlv7D7pCWPhle4NiVUKM+2p4F7ybMOSXIdBWZJQSiB8eXjikII",
+	"j38R2D6z5EN0cbnm4jOplA23cQpbvHWXdpx6xv4iefbnp0EA6RciclDpkCooLrC1Gj0CToLokapGl10E",
+	"+LucdlQMBCuOvbNgHPl3wQp3obeW/JmQt5QDjrD5EH5mQ/MKgR9aO078hWu6bEM8WZLmYB//ktPDWAj/",
+	"Jad91sE7LnrtgjXtmX7iq2Ll7ndQJC0oJFHMFEpUbW7kLy5AkDw/Pf22LeKLr7hJ7WKbwK49WBj/JadD",
+	"rIuAr7plsWkihC7aCwWsgACKSBWWqZKpj6HqMScDPv3I0ZEAchU6PwwCTAgH060NcJQU5SjLQkwgA/YD",
+	"6vPvctprmoHPhshvO9cXI7uR+pJAbZW6APDuwkRVKhwG24Ft0f50YK517RoA8JYrDkY5JcteLG3Nu+yv",
+	"wzGjmFGbznQ0ozZ/Prw4MEVxOftAzZxyV4ChteVgIQhNjQOMue204C03/Nk6pwZClnuk+hvDP/iRR5Hq",
+	"0YJDpPqb6wsSjrKt3lj7OH0lVJvd174pAfzm+qLDmXbJFlwbLKBCckaVAKOM3QVm8umNNmxl75ucQl04",
+	"ZmOArlZUGSxXfSNg6eeT7ybkjR8RMvj9jtDTHPmiuXBJ+jciPoct85xXpqHlJIjRTzbkLMonvBErirmC",
+	"RhJuIJyez5ZxXUhnm7S6lZ293SMXI/owFuFohacxCVdouZt2iXIUMsA/Z4eqnYIzE/zyj5aAB3jM2Dgz",
+	"m7gB+SuxJTfecc3sZ3+Ap3q8XoNbkuLos/9nb+TmK/x7lY76b5Zo+v3Hb9ZQCgU32u/ryuB2GypMMhSU",
+	"tseBJVb97HP8n72a6bto8CBI1mb/Yi7rykESfFf9vQU58aBONRYkXsYg20RtfPo/ZiKKKjw9viqrB8RJ",
+	"tXj2WarFQKJ/rxaDUGRn3D+dv48NCXarqeqYfV+2wdUeEoi+JcMknqU3zeTQwNr/zfW+Wsx5t7LGFUjv",
+	"Xty4vSDxMNw4Al/TzYmips+K8IFuLqk5kgXBLTZEz/xANxghsrWOuaYbCOFr0y7Xft6elKoPbpo+WvdH",
+	"OgxdBoAdV4+qLJvGy+DgJg/wnfHY2tLOzxwMtZhA6wL3ReaUaMI+2cT3NF+F7aVQ32Clz2u6uYV/Drw2",
+	"SuoYoCeVU+///ghoc3fHo7DRNX/fDdMN8FDXoqYe8xXTS8ZM1FNdrtYFCNg7xmx+tvKlajfkgSlWjgC/",
+	"T9OnbNOejoagJ5YNp0eVDUMT5PYiGwZS40HESMidGyhG4A/P1jLns03n08B9+wFHjg6LzWihNE5hAFn7",
+	"ESl1fF0ZtM3NmspZvUqd/yD8Uz/6UbloG7hvw1EVdO1Dd7p6HJYd9QN3nGC4b7dCCsLlHIYdRSP1qw1R",
+	"SS/LgOXDZv1HkdH7yfsPpzwMJ5VAPK6aWl23BVmDFdUA9T2n9JfzdsQnRNzxGXUM+PdAJTPCbr8SE0++",
+	"fzWzhPqOemZfZEm5QK+i2Q33Fk3zIsQO+Qo/Lp8gUjTpCnPKYlXTPfa7NM0jYumpOfz0yBw+9HJ8PIfv",
+	"TJVBURwmDWzO8jNqThTXdyc+6asvgskVpYxzxGwyglVhH5ZSMxISxXxs3TovXL3puAwauh604Xke4jNs",
+	"SBTUtiJ6KRUWr3hYuiR1VQhwst0Io+jsjimfgOaIRbfFO52ZS67vrsoiEwMin+JqhVsEK8EzkhhJfi+y",
+	"BYs37faqCTVjIuQD3OsuUqklSInqWzlPl6MCRJ8YvmKjcWNLRwlcqkB0WG1fRy/UEKC3Mcmpsu1JAc2I",
+	"8B0a2jgqTmk5urYiUNLKJWLYsgJexngqjdjlktXSb0t28dld7YxyHmR3oH9tqMKgfZc5qahYsLLuX5g0",
+	"lO+7EX7/E/ITXxTKlo7zOWaC5hvDZ5qAAlysNVFsrphessxFBT7/K9TaE4VJc8U/mTkLi9rD9tVhuzJU",
+	"hVoyuP8x4WKWF5rfsxYShs123i2DKHrcLJeb1TbCPnVvxMgDbONXlEuSQLjTmkw346grWzdr4xe3083g",
+	"wEqLon/CZy2buTh7d4btnsn/SMGwZbMe27IeNml4CXtDiYv56hjTOiYfr8/7twvTwqxPn5JdJ9pL+TBE",
+	"+pSfgRi2COu9yvE2dfS1cJDHHCgP5H2IKnuMigS4Z4rQaPVBYikq1neSsRUVWZd8Wq2pchk08Ze+gKmv",
+	"XhxSsyuD5LwUTmOE5xJLzViGBMLD8plq0/g74drF7GJs8m5yjfSItWirrxAQ/xFvu23jf4lEadDLz7JQ",
+	"Qzv3Pv/b3x0YIjIfl9f9VSGgbi01ZMUzAbVjtxA7BxM2MTfrYr12Fdms3ADk1Rl3iAhyOvnJvMjn3NY3",
+	"GaQiue+0ezo4lR4hqCvq0oRc+6FlWVcUzNZKhsJrkpIJ7rufyp39Ryb8R+X5SgRUgniHKT3uQxIx5Beq",
+	"/SzlA3lgeR7JAqaY3zjLdtOJUB6cFIbnzqrdLo4eqYkkZQ6s/rFc/D+y5kuwWzSwMoCPouGuCrdyl03Z",
+	"6jIOw54WmtdKQA3isz0z1KqYLa1pzu7VFpnU2/KSxqrwfQ41P2yYbQ0h/uSC1W96kF/OHzB2y8FHeM9Y",
+	"/1za0ebspaqcoNPdFjbV62/zAw9kjQ/AObK/rbJuGgnD3W0RhrexxQdktfrbSuAnENfgHadw56ydi37i",
+	"Ijvzw7ZkJ9SVb51Y3fdl8IaJhVl6qsWqxVz4m6/lOsgK5em6161UZtyOk9jBOzBrMBDXyH5jwiaLCVFS",
+	"rlr20itrhi5rm25wU3YYwV2sqNiQNZPrvO1yXHFx6/slfwE5x9uIvfdrJiIQhOqj8Dem7t2deM9UTtdr",
+	"Z1hHGhkTvYKU9sHXIHxU4zZgiWhx7E46x9pJqIPiF8P477P/51Bvd8neA/yo5dwHcHZ7kaeYgWkn5MLU",
+	"CtpMmcMGy36wEWS21FRAkA7NdSaPEIRtbkm/wfZcF9h4r8wct8apPREyTo97pWXMUJ4/As42+7oPyB0N",
+	"HI4H56fWVo6MWhc6MCGvhzDnYdWZ3bnYNmvYWvWpit4ZzZnIqOoMTPXTnbvBByXI8efDvqy/6teyx8Rl",
+	"Sa8DH0slect5FNsRSi3VVIbo/bvFU3kXQi4DIGubHErZUhumng17FePYq6DKHwFh0YqDMIXjSUU8bCFX",
+	"al8PyrV/L1ht1Qjc+EP/0zc+5YEulGiJp8kzr6KyB3WDX8QfL9+4gsdGQoPCj5dvIq0+5ITvlRLOsozQ",
+	"Bt5TaE/z2OdW3b1WGBv/7kqaW7CEisJ6I2bEdXYv26zgoAx7Ntpq/zfCdrSD/h3dhfu58TAvL/CEQ9q9",
+	"J6oE23+bHfJFUaGbSght7+j+eNjHY1gVYphY3YjZZSH0AeE5/jyweJh3Jb18cToerWy5stHL56fwX1y4",
+	"/xo/1Xs/BtegUD3gFUDDI6ki3ADaT+ju2fjrWlmznUgG5u+o8XjJaGalglsZeHlF1/FdZCurGBn6dfgo",
+	"VejS2iICjAx5W+Vfx0Qx24fN6TY3ohQzIVgWu9JSg5CBcVjZxz0UNFEU6/KbJXT+yQpLAVawrSbk0koo",
+	"FDu24ZPrzek7oXLlD2obb/rfbYPa0EgV1i5nc3UtyuozHlZQ3zJjvrl89tKBClZf0YwRLnw7/Rp4XBP6",
+	"CTkjmdrciLIZj7YRvbb5jN0dft3f0Qip+DjCtIX5M7W5VYVIs/+c5poFBptKmTMqDm1dqLJ3OzuXl2HZ",
+	"EmnQHW/ZF5fZQSSE9jvHOe11SbqVnj2K0eyHdIOgaiIaHHZI057hl52e8dUzVLd6LrarGV9d47ijPBbC",
+	"coMuhfOLt1Zn1OOgMWL4Dv4N/rnSLL/f/hURzTzwCRF/ETV4O79421GtKzRaIFNGFVN2Aqw8nDFhuNmQ",
+	"tZL3PGPK/kPjy7BRZusbX4bLBUcqWSyWuEvc1ovJKTn7cAFOAov4+xcTcg2T2PW49r0cbRikdSFjXKTF",
+	"d3txrRJfh3nzhPmf5sETkWMX+VWfOvsgs+DVK4c0ySrBy5/x/we6FGLk9V9Zfub9q/8RIBW7l3cekN1D",
+	"282CMMVA0LF8fjK1HRhPML+3tzF31LPxg/vgOJKxtu6gdt1X0fmIP992MR06PUWLVp0a3BNdUG2C2Z4b",
+	"3wb4AwidOqiv2EFaSR4Z7767cb9FRioSVPxtU+o1M4Noxlvv90M2np9zaXr4F76BeAZo6jswluGLN7y3",
+	"TV0YqXZseTgs1fAoxoIYY4MDBJASoGetfHCBUW10No7DpzBsBX35W1n+m9JQlpuAlV3bNtu3dEU3WHN1",
+	"GDV/hv/rvdF/5WaZKfpwZSMQBrxA7awHuM1zaZsCw3YEPlQgHAX7IEtBuAlNlltlyrVN+Zn59tF+NmdJ",
+	"gAbSCJt2NQH20F8EB4fxMundNsOuodNDllBctNJV0n2wJR4BFB31q6Ev9ZHRuEeN2fdKT91KAL4lyzNS",
+	"CMNzZIxl2YS7RzEpc2cHMDXSDs69V0LBzYOYkCsmBSMMbCuOCsYR0ZdhuGvaqG0I4PHEFHptOPngDtlH",
+	"USCgTuR83n3VQd73+/ncNcM+THcNt0bosHGciOnKwQblHTiA+XKTGnK9zfZNcVEldl3Xk4Urgyps6iv2",
+	"6DPuTAkcf3Yz9N4BtqJ+DTjDAhX8AoeIFsO5620FErA+9+LeDiUbTPh6EAhKyP/s8LDbNfoZ2Y/kOopW",
+	"WzORJQrZ+P4E7ucGRpPIa48Ze0q87M+0W2e9flbrxzcy1aOwnYg6q2OrNDXT+dw2xmqWWBjIhHQN1riu",
+	"boA/5XThm1rXQk2sdKikP2SF98yE049vhHV/aK/0gEJZyZJE94S7cvyoQtjLyF57N6LSFDtlxjuzRzky",
+	"ce7/zR4OAMQEZPnHkzKB+4k4SnHKQaX8BuqRgjHUBYqpNtwUW5SEVHjSJ5KNjmoSbLY9Myn2O5uZzn4u",
+	"MOA/FHoYCrXgZ9nXQXiWFnagO1tGtz3b0j058T7SjEUvzfLrSbKQU1mkd2Ci2SOsMjso57i1uAFeR2IJ",
+	"VHP1JaGc0kPnhimX22KNTx0Wssfm9bRuJtQumLK5VKx3P0Zuv5ujvVwQI0MfLTi4fK1YgPQ8WkJdK1eS",
+	"IPCrnSz5YomJuKsWfKNaq3al1lC1oV6xocViaUhF+xiTQuSsov880BDdBtfgVGNfaXRtw5y5rRmH7waE",
+	"eqoc65puoOzKK+yNWR4jJIfRld8AxocoFupjU1EWN4TiVjT7vdAG3ek/hIubSN9YPHhHCx+9kq72Yusm",
+	"VqTCoa4TXMCv+GQeih1p2mPIImdQzWyHR6ks/ey/tL6l4iXFi2hFzQwjM3yR6zH+L/raScaxHpEwznTj",
+	"LcnRkXxEuSZUSBs95Q/vz+6OUX13WvqMadlmmJUQ6Ko3XH72jH1aS9VR8OS9YETJB5i0pPdyj672SQn3",
+	"VP2TBgO8xkW3vRT/l1UNwA78M32/ZQP+86tfyJznZcn2IAtdlMghMvs7a2RbbCeoRxOqyfnVLwNJ9XP4",
+	"d2/7qFLcDIpaiOb9oowoTmJ2SMjSYsGEUV2O+w5Lir/yW5T0crF+a4oH+g74jK7WjofeWZaF/ZyFL46A",
+	"5gPeztE5jhy71Elk5bYIzbKOkIC3rjqpkPtvV5EkvrZOgWEs10HcpJI6BCmJjRgZ2qY/loJ7TX5v5OxO",
+	"BxsefjlOVFMdB3ug/RFNgFir7UbE5iFu22t63RmjsbHbJYaRogKqewx6/zukZEkMXwp1Om9yG5VWbGf9",
+	"JOnKTz2zERndV6Mbe6AnxznuwC9SsS09xbPD7mPIo8NuPFTyStxw/ienaltYxzYlt1hAS6GZ6va+ftQe",
+	"E4eGxEc9DAw2MGVuY4VT13yeu9/Kg3/UjVN/hv8dGGKKnw+RQG7O/fsfYQfbdY7EL3rTygp7shqc2t1/",
+	"R4bE/sSwpa5ECTILWFsiYjxaMprhqT6PXl/TRROsvzClXbkyuOfgmGNkt4v5yTsp2MlbeG/jpXgxt//R",
+	"aSKFHX2XFNxudpDFRSLTCLYH12tl3d2owSrHuNh0AzmqCYJY4/QtBS4OTxX7vwZgz3b3W8j+BFBdDYoq",
+	"Uz4RIQ1C/Xj0/fMXHfQGdqNOcnOUlu6Q0SZQurLOv1by2bnXLKJk9x6zbfGGtbAc1OIqpfIQv/8upKEu",
+	"Kxs6ZIuuprVpdKbv0dit36ngxYWhv8rLpHKAtkuFVk+5rUCIPz/cDROvcqybJl7SKqo1KquAt/fyOR41",
+	"7V+KxHt/5GUUT5W+lHbCmBfsOyCtQ+L/WXC28w1QwdbjboJhV/7pP3o2AX2CNOFC8wyE/zSnsztZBF9t",
+	"ITJmrfXTXM7u4nyaNm2iQjM7aRX+KtqW+AbcT4qBx4uLRU/BLDfqq7+y0idJRtS4gdULbEujffXbZNtZ",
+	"KCqP6bqp9Ww9i14Rk2oC+FNhClUjmrIPYKjCsFbsnstCE1XkTNv+KbZQg3Pwc4PJ7Og/ZZk1wqJZs7SH",
+	"goZkP6cKegyvKbZmc67FKZ3dQX13kXSxXz0JdR2iQGArYR2zWuCjqJtoej+k6DGYuwcV6g9cYS/iNr7Y",
+	"5UKOMw4fzUJpQSmk4XOHiJO1Yhgi0FmT7Z/MvIs++lB+81UKy7azJAgqHkrW8djtBGbl06S8jLGCV6lg",
+	"ufZ2G8VmjN9XosDjnfVVKH0S5O1fFrUcY2vN+kshog49fhuh005b14USLYQlMf8LYlOTGleNuvoFie73",
+	"M8RzHo72uuM1K1v24e5cg+2d2zwIH7y6h8jW+MDHzT2roG9Ipw53JZWwqZUX21LcVaZKhXJW8aCZMECO",
+	"/y5YwbJHUOSA1HqMoe7Kq98LCe41/virCh7eNhEeUQE6NYv4rzXdPZ3GOHVrulx21Ixs1GJHBY+k/eJD",
+	"Mc25Xh6BSA50R8bg/9G9ufce0XNAQlhbBAxQ1wHXIDS8F01IE9A+NGffrZZO2U8G32qXZC0Lg6aUECD7",
+	"jU5kv0nVjK9NNGxwVNeg48JEtPyNrtucO7Ova5JxWDI23AM+M+VL1ey/sOTr3gTRLTKvm1IrztltSzzD",
+	"74+CtQPmm20RpvP8yHnCJEKTy8h4oBwzfVBTwVApmvcnB4TEgG1EVqAvt4NeEnOnKX/uSDqrCQk4Vs61",
+	"6RcSv7qRX7eU8Kd4LYwaVLLq3FXAABpYU8jXdjP46OfHqc312dqER31c0hYUUNSKbanuckmzE6zL3G3+",
+	"wYvzV/fBGxz/VZp+UudI4NmPIBY0Y8JWa7OxhYKFFAwNwpptj+DqvG2m8+qo9M1Qhl60lKI7OsoOcDu0",
+	"Yet4Zp7dCMZanCfk/Yobw8JfbYYeuiH6m9Z45KsQQDT0ykhVu2tQ3i7G6asdCNTJnnC1fPb/6g3OvERI",
+	"VWX0EAKOFth/pCZuIyCRnBHNqPHOJN/+fyGxArpzIn2ycnoz2SJ7HaZPXActSPs1HrgZ4Bq2p6iksnuV",
+	"Rip/jIZegZuiwq2CrjbA/kOpC7TfPS34p7MZW3fVVTjDAX6+97CzIxPB/mRJTd1IVcSkhliQOEUzsL4t",
+	"oz2kMJH9vrxzEJt7pJ130tF8Se9ORuF/YiiArZXWSCqwO6MifAgT7UI2GZvlXLAOunllR/xvIhwHFEs4",
+	"HsINKdRPQG6eY1JQI37d7mArSmHTpZR3PdbfX+2oq2IaNnSc9IfEwkMeHO4zrL1TbnhYtfeH5LcRDO3v",
+	"vdFcqa0fRt9LrPQ0JdWT2BqGHR/u5ZrQY30S1Aj5QsC/NZspZoZUNxbgngfL4Cx0nyrEnQCOZPc2V7Oi",
+	"nNlNTN0Vna0lt8mFODiN+DrvODcYZ/rZZ/fvTW+us5vulRs/SMpGk385UrZ2jg6EZ2FMm0ysDexJznio",
+	"DwcRji8GY+Dp+UjsYU3tTUda6Hsr9/3nrscOeKVZZu/3OeVYHI4K2xQEJoRXzLihtMMvT0sSL45JEv43",
+	"77w0EgCEHk1Mh92JRNoyOJesHM410YZDXQVbBWlMtISHSJTc2dhS4z0nsiT14caHkNznWPQNTLdL3yf9",
+	"BFJbav9vu6Q472v0lvyoNzEvdTcHpg94yOWi5b7ukcVPDNvTL+Ei3g/GqvJZV+Ga1KS6khmeDD1H0dWe",
+	"JhDrMbpaewDWY1WzfdBeyLobTH794jnSEYY8lF6F4Qen0+7ArXLfUdTWPoO1alf9ceO1GnpG/8uwxEzK",
+	"5/RY0sM3ZQRztPDukQzXPjWjJQyHi8Wf9Crr0iU/2AeiMD/ge74MSlzKB+j9yFx7S+YDglVpLeR7wrzT",
+	"CW0hR9gPZkYMRz1MxmaF4maDGPqJKzalmp0VZjl6+d+/AYw1U/ceg436NDQnGbtnuVxjdRw7djQeFSof",
+	"vRwtjVm/fPYsh3FLqc3Lv5+eno7++O2P/38AGROyxJv8AQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,9 +1,26 @@
 package scheduler
 
 import (
+	"context"
 	"database/sql"
+	"embed"
+	"net/http"
+	"os"
 	"scheduler-api/internal/auth"
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/logging"
+	"scheduler-api/internal/lti"
+	"scheduler-api/internal/netguard"
+	"scheduler-api/internal/tracing"
+	"scheduler-api/internal/webhooks"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
@@ -13,15 +30,169 @@ type Service struct {
 	pgxPool         *pgxpool.Pool
 	sqlDB           *sql.DB
 	firebaseService *auth.FirebaseService
+
+	webhookDispatcher *webhooks.Dispatcher
+	// waitlistOfferTTL is the MaintenanceConfig one, so seats offered by
+	// requests and by the waitlist job are held equally long.
+	waitlistOfferTTL time.Duration
+	slotHoldTTL      time.Duration
+
+	// ltiVerifier validates LTI launches, and ltiAppURL is the app that
+	// launches are sent on to with their session.
+	ltiVerifier *lti.Verifier
+	ltiAppURL   string
+
+	// liveBroker streams published events to the clients of this instance.
+	liveBroker *live.Broker
 }
 
-func NewService(logger *zap.Logger, pgxPool *pgxpool.Pool, sqlDB *sql.DB, firebaseService *auth.FirebaseService) *Service {
+func NewService(logger *zap.Logger, pgxPool *pgxpool.Pool, sqlDB *sql.DB, firebaseService *auth.FirebaseService, webhookDispatcher *webhooks.Dispatcher, liveBroker *live.Broker, waitlistOfferTTL time.Duration) *Service {
+	// Platforms are registered by admins, so their key sets may not be on
+	// the deployment's own network outside of local development.
+	ltiAllowPrivate, _ := strconv.ParseBool(os.Getenv("LTI_ALLOW_PRIVATE_NETWORKS"))
+
 	return &Service{
-		logger:          logger,
-		pgxPool:         pgxPool,
-		sqlDB:           sqlDB,
-		firebaseService: firebaseService,
+		logger:            logger,
+		pgxPool:           pgxPool,
+		sqlDB:             sqlDB,
+		firebaseService:   firebaseService,
+		webhookDispatcher: webhookDispatcher,
+		waitlistOfferTTL:  waitlistOfferTTL,
+		slotHoldTTL:       background.DurationFromEnv("SLOT_HOLD_TTL", defaultSlotHoldTTL),
+		ltiVerifier:       lti.NewVerifier(netguard.NewClient(10*time.Second, ltiAllowPrivate)),
+		ltiAppURL:         os.Getenv("LTI_APP_URL"),
+		liveBroker:        liveBroker,
 	}
 }
 
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("scheduler", queryFiles)
+}
+
 var _ ServerInterface = (*Service)(nil)
+
+// log returns the logger of the request ctx belongs to, whose lines carry
+// its request ID, or the service's logger outside requests.
+func (s *Service) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// BatchRoutes are the routes that read or write many rows per request, as
+// "METHOD /path/". They are rate limited apart from other reads and writes.
+var BatchRoutes = []string{
+	"POST /v1/availability/",
+	"POST /v1/blackouts/import/",
+	"POST /v1/course/:course_id/enrollments/bulk/",
+	"POST /v1/imports/",
+	"POST /v1/roster/sources/:source_id/sync/",
+	"POST /v1/timesheets/",
+	"GET /v1/timesheets/export/",
+	"GET /v1/reports/at-risk-students/",
+	"GET /v1/reports/attendance/",
+	"GET /v1/reports/availability-demand/",
+	"GET /v1/reports/tracker-fulfillment/",
+	"GET /v1/reports/tutor-utilization/",
+}
+
+// requireAdmin writes an error response and returns false unless the current
+// user is an admin. action completes the message "Only admin can ...".
+func (s *Service) requireAdmin(c *gin.Context, action string) (*auth.User, bool) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return nil, false
+	}
+
+	if currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin can " + action,
+		})
+		return nil, false
+	}
+
+	return currentUser, true
+}
+
+// notModified sets the ETag of the resource a response carries. For a GET,
+// it writes a 304 response and returns true when the request's
+// If-None-Match matches it. Read the version before the resource, so the
+// ETag is never newer than the representation it is sent with.
+func notModified(c *gin.Context, version int64) bool {
+	etag := versionETag(version)
+	c.Header("ETag", etag)
+
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+	if header := c.GetHeader("If-None-Match"); header != "" && etagMatches(header, etag, true) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+
+	return false
+}
+
+// checkIfMatch writes a 412 response and returns false when the request has
+// an If-Match that does not match the version of the resource it changes.
+// Read the version with the resource locked, so it cannot change before the
+// update. what completes the message "... has changed since it was read".
+func checkIfMatch(c *gin.Context, version int64, what string) bool {
+	if header := c.GetHeader("If-Match"); header != "" && !etagMatches(header, versionETag(version), false) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{
+			"error":   "precondition_failed",
+			"message": what + " has changed since it was read",
+		})
+		return false
+	}
+
+	return true
+}
+
+// versionETag is the strong ETag of a resource at a row version.
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header lists
+// etag or is "*". Weak ETags in the header only match with the weak
+// comparison If-None-Match uses.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dbExecutor is satisfied by both *pgxpool.Pool and pgx.Tx, so query helpers
+// can run on their own or as part of a larger transaction.
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+var (
+	_ dbExecutor = (*pgxpool.Pool)(nil)
+	_ dbExecutor = (pgx.Tx)(nil)
+)
@@ -0,0 +1,256 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// analyticsRefreshOverlap is how far before the last refresh changes are
+// looked for again, so rows written by transactions that were still open at
+// the last refresh are not missed.
+const analyticsRefreshOverlap = 10 * time.Minute
+
+type AnalyticsService interface {
+	GetAttendanceReport(*gin.Context, GetAttendanceReportParams)
+	GetTrackerFulfillmentReport(*gin.Context, GetTrackerFulfillmentReportParams)
+	ListAtRiskStudents(*gin.Context, ListAtRiskStudentsParams)
+	GetAvailabilityDemandReport(*gin.Context, GetAvailabilityDemandReportParams)
+}
+
+var _ AnalyticsService = (*Service)(nil)
+
+func (s *Service) GetAttendanceReport(c *gin.Context, params GetAttendanceReportParams) {
+	currentUser, ok := s.requireAdmin(c, "view reports")
+	if !ok {
+		return
+	}
+
+	grouping, timezone, ok := reportParams(c, params.From, params.To, params.GroupBy, params.Timezone,
+		ReportGroupingCourse, ReportGroupingTutor, ReportGroupingWeek, ReportGroupingMonth)
+	if !ok {
+		return
+	}
+
+	report := []AttendanceReportRow{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &report, queryAttendanceReportSQL, currentUser.OrgID, params.From, params.To,
+		grouping, timezone)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range report {
+		report[i].Unrecorded = report[i].Students - report[i].Attended - report[i].Absent
+		report[i].AttendanceRate = ratio(report[i].Attended, report[i].Attended+report[i].Absent)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (s *Service) GetTrackerFulfillmentReport(c *gin.Context, params GetTrackerFulfillmentReportParams) {
+	currentUser, ok := s.requireAdmin(c, "view reports")
+	if !ok {
+		return
+	}
+
+	grouping, timezone, ok := reportParams(c, params.From, params.To, params.GroupBy, params.Timezone,
+		ReportGroupingCourse, ReportGroupingWeek, ReportGroupingMonth)
+	if !ok {
+		return
+	}
+
+	report := []TrackerFulfillmentRow{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &report, queryTrackerFulfillmentReportSQL, currentUser.OrgID, params.From, params.To,
+		grouping, timezone)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range report {
+		report[i].FulfillmentRate = ratio(report[i].CompletedClasses, report[i].RequiredClasses)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (s *Service) ListAtRiskStudents(c *gin.Context, params ListAtRiskStudentsParams) {
+	currentUser, ok := s.requireAdmin(c, "view reports")
+	if !ok {
+		return
+	}
+
+	asOf := time.Now()
+	if params.AsOf != nil {
+		asOf = *params.AsOf
+	}
+
+	students := []AtRiskStudent{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &students, queryListAtRiskStudentsSQL, currentUser.OrgID, asOf, params.CourseId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, students)
+}
+
+func (s *Service) GetAvailabilityDemandReport(c *gin.Context, params GetAvailabilityDemandReportParams) {
+	currentUser, ok := s.requireAdmin(c, "view reports")
+	if !ok {
+		return
+	}
+
+	_, timezone, ok := reportParams(c, params.From, params.To, nil, params.Timezone)
+	if !ok {
+		return
+	}
+
+	reported := []AvailabilityDemandHour{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &reported, queryAvailabilityDemandSQL, currentUser.OrgID, params.From, params.To, timezone)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hoursOfWeek(reported))
+}
+
+// reportParams checks the date range, grouping and time zone of a report and
+// returns the grouping, course unless given, and the time zone, UTC unless
+// given. It writes an error response and returns false when they are
+// invalid.
+func reportParams(c *gin.Context, from, to time.Time, groupBy *ReportGrouping, timezone *string, allowed ...ReportGrouping) (ReportGrouping, string, bool) {
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return "", "", false
+	}
+
+	grouping := ReportGroupingCourse
+	if groupBy != nil {
+		grouping = *groupBy
+	}
+	if groupBy != nil && !slices.Contains(allowed, grouping) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot group by " + string(grouping)})
+		return "", "", false
+	}
+
+	tz := "UTC"
+	if timezone != nil {
+		tz = *timezone
+	}
+	if _, err := time.LoadLocation(tz); err != nil || tz == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown timezone " + tz})
+		return "", "", false
+	}
+
+	return grouping, tz, true
+}
+
+// refreshAnalytics brings the analytics rollups up to date. Only classes
+// changed since the last refresh are counted again, and only the hours of
+// users whose availability changed.
+func refreshAnalytics(ctx context.Context, pool *pgxpool.Pool, now time.Time) error {
+	err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		var refreshedAt *time.Time
+		if err := tx.QueryRow(ctx, queryGetRefreshStateSQL, "class_stats").Scan(&refreshedAt); err != nil {
+			return err
+		}
+
+		var since *time.Time
+		if refreshedAt != nil {
+			t := refreshedAt.Add(-analyticsRefreshOverlap)
+			since = &t
+		}
+
+		if _, err := tx.Exec(ctx, refreshClassStatsSQL, since, now); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, setRefreshStateSQL, "class_stats", now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The state row is locked so concurrent refreshes do not count the same
+	// hours from different snapshots.
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		var refreshedAt *time.Time
+		if err := tx.QueryRow(ctx, queryGetRefreshStateSQL, "availability_hours").Scan(&refreshedAt); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, refreshAvailabilityHoursSQL); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, setRefreshStateSQL, "availability_hours", now)
+		return err
+	})
+}
+
+//go:embed queries/analytics/attendance_report.sql
+var queryAttendanceReportSQL string
+
+//go:embed queries/analytics/tracker_fulfillment_report.sql
+var queryTrackerFulfillmentReportSQL string
+
+//go:embed queries/analytics/list_at_risk_students.sql
+var queryListAtRiskStudentsSQL string
+
+//go:embed queries/analytics/availability_demand.sql
+var queryAvailabilityDemandSQL string
+
+//go:embed queries/analytics/get_refresh_state.sql
+var queryGetRefreshStateSQL string
+
+//go:embed queries/analytics/set_refresh_state.sql
+var setRefreshStateSQL string
+
+//go:embed queries/analytics/refresh_class_stats.sql
+var refreshClassStatsSQL string
+
+//go:embed queries/analytics/refresh_availability_hours.sql
+var refreshAvailabilityHoursSQL string
+
+// ratio returns part / whole, or nil when whole is zero.
+func ratio(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	r := float64(part) / float64(whole)
+	return &r
+}
+
+// hoursOfWeek returns every hour of the week in order, starting Sunday at
+// midnight, taking the figures of the hours reported and filling in the
+// supply ratio.
+func hoursOfWeek(reported []AvailabilityDemandHour) []AvailabilityDemandHour {
+	hours := make([]AvailabilityDemandHour, 7*24)
+	for i := range hours {
+		hours[i] = AvailabilityDemandHour{Weekday: i / 24, Hour: i % 24}
+	}
+
+	for _, hour := range reported {
+		if hour.Weekday < 0 || hour.Weekday > 6 || hour.Hour < 0 || hour.Hour > 23 {
+			continue
+		}
+		hours[hour.Weekday*24+hour.Hour] = hour
+	}
+
+	for i := range hours {
+		hours[i].SupplyRatio = ratio(hours[i].TutorMinutes, hours[i].StudentMinutes)
+	}
+
+	return hours
+}
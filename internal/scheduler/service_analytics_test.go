@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func TestRatio(t *testing.T) {
+	if got := ratio(3, 0); got != nil {
+		t.Errorf("expected no ratio without a whole, got %v", *got)
+	}
+	if got := ratio(3, 4); got == nil || *got != 0.75 {
+		t.Errorf("expected 0.75, got %v", got)
+	}
+}
+
+func TestHoursOfWeek(t *testing.T) {
+	hours := hoursOfWeek([]AvailabilityDemandHour{
+		{Weekday: 1, Hour: 9, TutorMinutes: 120, StudentMinutes: 60, Tutors: 2, Students: 1},
+		{Weekday: 6, Hour: 23, TutorMinutes: 30},
+		{Weekday: 7, Hour: 0, TutorMinutes: 60},
+	})
+
+	if len(hours) != 168 {
+		t.Fatalf("expected 168 hours, got %d", len(hours))
+	}
+
+	for i, hour := range hours {
+		if hour.Weekday*24+hour.Hour != i {
+			t.Fatalf("hour %d is day %d hour %d", i, hour.Weekday, hour.Hour)
+		}
+	}
+
+	monday := hours[24+9]
+	if monday.TutorMinutes != 120 || monday.Tutors != 2 || monday.SupplyRatio == nil || *monday.SupplyRatio != 2 {
+		t.Errorf("unexpected Monday 9:00 figures: %+v", monday)
+	}
+
+	saturday := hours[6*24+23]
+	if saturday.TutorMinutes != 30 || saturday.SupplyRatio != nil {
+		t.Errorf("expected tutor minutes and no ratio without demand, got %+v", saturday)
+	}
+
+	if hours[0].TutorMinutes != 0 {
+		t.Errorf("expected an invalid weekday to be ignored, got %+v", hours[0])
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/jobs"
+	"scheduler-api/internal/live"
 	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -11,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
 type AvailabilityService interface {
@@ -18,6 +22,8 @@ type AvailabilityService interface {
 	GetAvailability(*gin.Context, string)
 	UpdateAvailability(*gin.Context, string)
 	GetBatchAvailability(*gin.Context)
+	GetRecurringAvailability(*gin.Context, string)
+	SetRecurringAvailability(*gin.Context, string)
 }
 
 var _ AvailabilityService = (*Service)(nil)
@@ -37,7 +43,6 @@ func (s *Service) CreateAvailability(c *gin.Context, userID string) {
 	}
 
 	var (
-		orgID   = "00000000-0000-0000-0000-000000000001"
 		role    = UserRoleStudent
 		matched = false
 		now     = time.Now()
@@ -54,11 +59,59 @@ func (s *Service) CreateAvailability(c *gin.Context, userID string) {
 		return
 	}
 
-	err = batchUpsertAvailability(c.Request.Context(), s.pgxPool, userID, orgID, role, matched, now, chunks)
+	ctx := c.Request.Context()
+	tx, err := s.pgxPool.Begin(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Availability belongs to the organization of its user, whose blackouts
+	// apply to it.
+	var orgID string
+	if err := pgxscan.Get(ctx, tx, &orgID, queryGetUserOrgSQL, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !checkBlackouts(c, tx, orgID, chunks) {
+		return
+	}
+
+	var version int64
+	if err := pgxscan.Get(ctx, tx, &version, lockAvailabilityVersionSQL, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !checkIfMatch(c, version, "The availability") {
+		return
+	}
+
+	if err := batchUpsertAvailability(ctx, tx, userID, orgID, role, matched, now, chunks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	availabilityChunksWritten.Add(float64(len(chunks)), sourceAPI)
+
+	if err := publishAvailabilityChanged(ctx, s.pgxPool, userID, now); err != nil {
+		s.log(c.Request.Context()).Error("Failed to publish availability.changed event", zap.String("user_id", userID), zap.Error(err))
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Availability created successfully"})
 }
@@ -69,7 +122,19 @@ var queryGetAvailabilitySQL string
 //go:embed queries/availibility/batch_upsert_availability.sql
 var queryBatchUpsertAvailabilitySQL string
 
+//go:embed queries/availibility/get_availability_version.sql
+var queryGetAvailabilityVersionSQL string
+
+//go:embed queries/availibility/lock_availability_version.sql
+var lockAvailabilityVersionSQL string
+
 func (s *Service) GetAvailability(c *gin.Context, userID string) {
+	var version int64
+	if err := pgxscan.Get(c.Request.Context(), s.pgxPool, &version, queryGetAvailabilityVersionSQL, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	availabilityRecords, err := getAvailability(c.Request.Context(), s.pgxPool, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -88,6 +153,10 @@ func (s *Service) GetAvailability(c *gin.Context, userID string) {
 	// Group consecutive chunks back into larger intervals
 	availableTimeIntervals := groupConsecutiveChunks(chunks)
 
+	if notModified(c, version) {
+		return
+	}
+
 	c.JSON(http.StatusOK, Availability{
 		AvailableTimeIntervals: availableTimeIntervals,
 		UserId:                 userID,
@@ -140,12 +209,220 @@ func (s *Service) GetBatchAvailability(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (s *Service) GetRecurringAvailability(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own availability",
+		})
+		return
+	}
+
+	recurring, err := getRecurringAvailability(c.Request.Context(), s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+func (s *Service) SetRecurringAvailability(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only update your own availability",
+		})
+		return
+	}
+
+	request := RecurringAvailability{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := time.LoadLocation(request.Timezone); err != nil || request.Timezone == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown timezone " + request.Timezone})
+		return
+	}
+
+	type ruleMinutes struct{ weekday, start, end int }
+	rules := make([]ruleMinutes, len(request.Rules))
+	for i, rule := range request.Rules {
+		start, err := parseClockTime(rule.StartTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		end, err := parseClockTime(rule.EndTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if rule.Weekday < 0 || rule.Weekday > 6 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "weekday must be between 0 (Sunday) and 6"})
+			return
+		}
+		if end <= start {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+			return
+		}
+		rules[i] = ruleMinutes{rule.Weekday, start, end}
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var orgID string
+	if err := pgxscan.Get(ctx, tx, &orgID, queryGetUserOrgSQL, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, deleteRecurringAvailabilitySQL, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, rule := range rules {
+		if _, err := tx.Exec(ctx, createRecurringAvailabilitySQL, orgID, userID, rule.weekday, rule.start, rule.end, request.Timezone, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// Drop what the old rules generated and let the job runner expand the
+	// new ones.
+	if _, err := tx.Exec(ctx, deleteFutureRecurringChunksSQL, userID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := jobs.EnqueueForOrg(ctx, tx, orgID, JobExpandRecurringAvailability, map[string]string{"user_id": userID}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.Publish(ctx, tx, orgID, live.EventAvailabilityChanged, []string{userID}, map[string]string{"user_id": userID}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurring, err := getRecurringAvailability(ctx, s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
+
+//go:embed queries/availibility/get_recurring_availability.sql
+var queryGetRecurringAvailabilitySQL string
+
+//go:embed queries/availibility/delete_recurring_availability.sql
+var deleteRecurringAvailabilitySQL string
+
+//go:embed queries/availibility/create_recurring_availability.sql
+var createRecurringAvailabilitySQL string
+
+//go:embed queries/availibility/delete_future_recurring_chunks.sql
+var deleteFutureRecurringChunksSQL string
+
+//go:embed queries/user/get_user_org.sql
+var queryGetUserOrgSQL string
+
+// publishAvailabilityChanged streams a change of the user's availability to
+// the user and the admins of their organization.
+func publishAvailabilityChanged(ctx context.Context, db dbExecutor, userID string, now time.Time) error {
+	var orgID string
+	if err := pgxscan.Get(ctx, db, &orgID, queryGetUserOrgSQL, userID); err != nil {
+		return err
+	}
+
+	return live.Publish(ctx, db, orgID, live.EventAvailabilityChanged, []string{userID}, map[string]string{"user_id": userID}, now)
+}
+
+type recurringAvailabilityRecord struct {
+	Weekday     int
+	StartMinute int
+	EndMinute   int
+	Timezone    string
+}
+
+// getRecurringAvailability returns a user's rules. Users without rules get
+// an empty list in UTC.
+func getRecurringAvailability(ctx context.Context, db dbExecutor, userID string) (RecurringAvailability, error) {
+	records := []recurringAvailabilityRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryGetRecurringAvailabilitySQL, userID); err != nil {
+		return RecurringAvailability{}, err
+	}
+
+	recurring := RecurringAvailability{
+		UserId:   &userID,
+		Timezone: "UTC",
+		Rules:    make([]RecurringAvailabilityRule, len(records)),
+	}
+	for i, record := range records {
+		recurring.Timezone = record.Timezone
+		recurring.Rules[i] = RecurringAvailabilityRule{
+			Weekday:   record.Weekday,
+			StartTime: formatClockTime(record.StartMinute),
+			EndTime:   formatClockTime(record.EndMinute),
+		}
+	}
+
+	return recurring, nil
+}
+
 func getAvailability(ctx context.Context, pgxPool *pgxpool.Pool, userID string) ([]AvailabilityRecord, error) {
 	availability := []AvailabilityRecord{}
 	return availability, pgxscan.Select(ctx, pgxPool, &availability, queryGetAvailabilitySQL, userID)
 }
 
-func batchUpsertAvailability(ctx context.Context, pgxPool *pgxpool.Pool, userID, orgID string, role UserRole, matched bool, now time.Time, chunks []TimeInterval) error {
+func batchUpsertAvailability(ctx context.Context, db dbExecutor, userID, orgID string, role UserRole, matched bool, now time.Time, chunks []TimeInterval) error {
 	batch := &pgx.Batch{}
 
 	for _, interval := range chunks {
@@ -163,7 +440,7 @@ func batchUpsertAvailability(ctx context.Context, pgxPool *pgxpool.Pool, userID,
 		)
 	}
 
-	batchResult := pgxPool.SendBatch(ctx, batch)
+	batchResult := db.SendBatch(ctx, batch)
 	defer func() {
 		_ = batchResult.Close()
 	}()
@@ -0,0 +1,1236 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// errAlreadyInvoiced is returned when the database rejects an invoice line
+// for a class that another invoice billed in the meantime.
+var errAlreadyInvoiced = errors.New("a class was billed by another invoice in the meantime")
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type BillingService interface {
+	ListFamilies(*gin.Context)
+	CreateFamily(*gin.Context)
+	UpdateFamily(*gin.Context, string)
+	ListRateCards(*gin.Context)
+	CreateRateCard(*gin.Context)
+	UpdateRateCard(*gin.Context, string)
+	DeleteRateCard(*gin.Context, string)
+	ListInvoices(*gin.Context, ListInvoicesParams)
+	CreateInvoice(*gin.Context)
+	GetInvoice(*gin.Context, string)
+	RenderInvoice(*gin.Context, string)
+	IssueInvoice(*gin.Context, string)
+	PayInvoice(*gin.Context, string)
+	VoidInvoice(*gin.Context, string)
+	CreateCreditNote(*gin.Context, string)
+}
+
+var _ BillingService = (*Service)(nil)
+
+func (s *Service) ListFamilies(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	families := []Family{}
+	if err := pgxscan.Select(c.Request.Context(), s.pgxPool, &families, queryListFamiliesSQL, currentUser.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, families)
+}
+
+func (s *Service) CreateFamily(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := Family{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(request.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	var (
+		ctx      = c.Request.Context()
+		now      = time.Now()
+		familyID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, createFamilySQL, familyID, currentUser.OrgID, request.Name, request.BillingEmail, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !s.setFamilyStudents(c, tx, familyID, currentUser.OrgID, request.StudentIds, now) {
+		return
+	}
+
+	family := Family{}
+	if err := pgxscan.Get(ctx, tx, &family, queryGetFamilySQL, familyID, currentUser.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, family)
+}
+
+func (s *Service) UpdateFamily(c *gin.Context, familyID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := Family{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(request.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, updateFamilySQL, familyID, currentUser.OrgID, request.Name, request.BillingEmail, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondFamilyNotFound(c)
+		return
+	}
+
+	if _, err := tx.Exec(ctx, clearFamilyStudentsSQL, familyID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !s.setFamilyStudents(c, tx, familyID, currentUser.OrgID, request.StudentIds, now) {
+		return
+	}
+
+	family := Family{}
+	if err := pgxscan.Get(ctx, tx, &family, queryGetFamilySQL, familyID, currentUser.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, family)
+}
+
+// setFamilyStudents moves the students into the family. It writes an error
+// response and returns false when one of them is not a student of the
+// organization.
+func (s *Service) setFamilyStudents(c *gin.Context, db dbExecutor, familyID, orgID string, studentIDs []string, now time.Time) bool {
+	studentIDs = uniqueIDs(studentIDs)
+	if len(studentIDs) == 0 {
+		return true
+	}
+
+	tag, err := db.Exec(c.Request.Context(), setFamilyStudentsSQL, familyID, studentIDs, orgID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if tag.RowsAffected() != int64(len(studentIDs)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "student_ids must be students of the organization"})
+		return false
+	}
+
+	return true
+}
+
+func (s *Service) ListRateCards(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	cards, err := listRateCards(c.Request.Context(), s.pgxPool, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+func (s *Service) CreateRateCard(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := RateCard{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	currency, ok := s.validateRateCard(c, currentUser.OrgID, request)
+	if !ok {
+		return
+	}
+
+	rateCardID := uuid.New().String()
+	_, err := s.pgxPool.Exec(ctx, createRateCardSQL, rateCardID, currentUser.OrgID, request.Name, request.CourseId, request.TutorId,
+		request.Duration, request.ClassType, request.AmountCents, currency, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithRateCard(c, rateCardID, currentUser.OrgID, http.StatusCreated)
+}
+
+func (s *Service) UpdateRateCard(c *gin.Context, rateCardID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := RateCard{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	currency, ok := s.validateRateCard(c, currentUser.OrgID, request)
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(ctx, updateRateCardSQL, rateCardID, currentUser.OrgID, request.Name, request.CourseId, request.TutorId,
+		request.Duration, request.ClassType, request.AmountCents, currency, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondRateCardNotFound(c)
+		return
+	}
+
+	s.respondWithRateCard(c, rateCardID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) DeleteRateCard(c *gin.Context, rateCardID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deleteRateCardSQL, rateCardID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondRateCardNotFound(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// validateRateCard checks a rate card and returns its currency, USD unless
+// given. It writes an error response and returns false when the card is
+// invalid.
+func (s *Service) validateRateCard(c *gin.Context, orgID string, card RateCard) (string, bool) {
+	currency := "USD"
+	if card.Currency != nil {
+		currency = *card.Currency
+	}
+
+	var problem string
+	switch {
+	case strings.TrimSpace(card.Name) == "":
+		problem = "name is required"
+	case card.AmountCents < 0:
+		problem = "amount_cents must not be negative"
+	case card.Duration != nil && *card.Duration <= 0:
+		problem = "duration must be greater than zero"
+	case card.ClassType != nil && *card.ClassType != Group && *card.ClassType != Individual:
+		problem = "class_type must be group or individual"
+	case !currencyPattern.MatchString(currency):
+		problem = "currency must be a three letter ISO 4217 code, e.g. USD"
+	}
+	if problem != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": problem})
+		return "", false
+	}
+
+	found, err := courseAndTutorInOrg(c.Request.Context(), s.pgxPool, orgID, card.CourseId, card.TutorId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return "", false
+	}
+	if !found {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "course_id and tutor_id must be a course and a tutor of the organization"})
+		return "", false
+	}
+
+	return currency, true
+}
+
+func (s *Service) respondWithRateCard(c *gin.Context, rateCardID, orgID string, status int) {
+	card := RateCard{}
+	if err := pgxscan.Get(c.Request.Context(), s.pgxPool, &card, queryGetRateCardSQL, rateCardID, orgID); err != nil {
+		if pgxscan.NotFound(err) {
+			respondRateCardNotFound(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, card)
+}
+
+func (s *Service) ListInvoices(c *gin.Context, params ListInvoicesParams) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	invoices := []Invoice{}
+	err := pgxscan.Select(ctx, s.pgxPool, &invoices, queryListInvoicesSQL, currentUser.OrgID, params.Status, params.StudentId, params.FamilyId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := loadInvoiceLines(ctx, s.pgxPool, invoices); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+func (s *Service) CreateInvoice(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := InvoiceCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if (request.StudentId == nil) == (request.FamilyId == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of student_id and family_id is required"})
+		return
+	}
+
+	if !request.PeriodEnd.After(request.PeriodStart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period_end must be after period_start"})
+		return
+	}
+
+	var (
+		ctx       = c.Request.Context()
+		now       = time.Now()
+		invoiceID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var (
+		billTo     string
+		studentIDs []string
+	)
+	if request.StudentId != nil {
+		if err := tx.QueryRow(ctx, queryGetStudentBillToSQL, *request.StudentId, currentUser.OrgID).Scan(&billTo); err != nil {
+			if pgxscan.NotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error":   "student_not_found",
+					"message": "Student not found",
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		studentIDs = []string{*request.StudentId}
+	} else {
+		family := Family{}
+		if err := pgxscan.Get(ctx, tx, &family, queryGetFamilySQL, *request.FamilyId, currentUser.OrgID); err != nil {
+			respondFamilyLookupError(c, err)
+			return
+		}
+		billTo, studentIDs = family.Name, family.StudentIds
+	}
+
+	classes := []billableClass{}
+	err = pgxscan.Select(ctx, tx, &classes, queryListBillableClassesSQL, currentUser.OrgID, studentIDs, request.PeriodStart, request.PeriodEnd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(classes) == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "nothing_to_bill",
+			"message": "No attended classes in the period are waiting to be billed",
+		})
+		return
+	}
+
+	cards, err := listRateCards(ctx, tx, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	priced, unpriced := priceClasses(cards, classes)
+	if len(unpriced) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "no_rate_card",
+			"message":   "No rate card matches some of the classes",
+			"class_ids": unpriced,
+		})
+		return
+	}
+
+	currency, total := *priced[0].Card.Currency, 0
+	for _, class := range priced {
+		if *class.Card.Currency != currency {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "mixed_currencies",
+				"message": "The classes are priced in more than one currency",
+			})
+			return
+		}
+		total += class.Card.AmountCents
+	}
+
+	_, err = tx.Exec(ctx, createInvoiceSQL, invoiceID, currentUser.OrgID, InvoiceKindInvoice, nil, InvoiceStatusDraft, request.StudentId, request.FamilyId,
+		billTo, request.PeriodStart, request.PeriodEnd, currency, total, nil, nil, nil, currentUser.UserID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, class := range priced {
+		line := InvoiceLine{
+			LineId:      uuid.New().String(),
+			ClassId:     &class.ClassID,
+			StudentId:   &class.StudentID,
+			Description: class.description(),
+			StartTime:   class.StartTime,
+			Duration:    class.Duration,
+			RateCardId:  class.Card.RateCardId,
+			AmountCents: class.Card.AmountCents,
+		}
+		if err := createInvoiceLine(ctx, tx, invoiceID, line, now); err != nil {
+			respondInvoiceWriteError(c, err)
+			return
+		}
+	}
+
+	invoice, err := getInvoice(ctx, tx, invoiceID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		respondInvoiceWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+func (s *Service) GetInvoice(c *gin.Context, invoiceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	invoice, err := getInvoice(c.Request.Context(), s.pgxPool, invoiceID, currentUser.OrgID)
+	if err != nil {
+		respondInvoiceLookupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+func (s *Service) RenderInvoice(c *gin.Context, invoiceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	invoice, err := getInvoice(ctx, s.pgxPool, invoiceID, currentUser.OrgID)
+	if err != nil {
+		respondInvoiceLookupError(c, err)
+		return
+	}
+
+	var organization string
+	if err := s.pgxPool.QueryRow(ctx, queryGetOrganizationNameSQL, currentUser.OrgID).Scan(&organization); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := renderInvoice(invoice, organization)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+func (s *Service) IssueInvoice(c *gin.Context, invoiceID string) {
+	s.changeInvoiceStatus(c, invoiceID, InvoiceStatusIssued)
+}
+
+func (s *Service) PayInvoice(c *gin.Context, invoiceID string) {
+	s.changeInvoiceStatus(c, invoiceID, InvoiceStatusPaid)
+}
+
+func (s *Service) VoidInvoice(c *gin.Context, invoiceID string) {
+	s.changeInvoiceStatus(c, invoiceID, InvoiceStatusVoid)
+}
+
+// changeInvoiceStatus moves an invoice or credit note to status. Issuing
+// gives it its number and voiding frees its classes to be billed again.
+func (s *Service) changeInvoiceStatus(c *gin.Context, invoiceID string, status InvoiceStatus) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	invoice := Invoice{}
+	if err := pgxscan.Get(ctx, tx, &invoice, queryGetInvoiceForUpdateSQL, invoiceID, currentUser.OrgID); err != nil {
+		respondInvoiceLookupError(c, err)
+		return
+	}
+
+	if !invoiceTransitionAllowed(invoice.Status, status) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "invalid_invoice_status",
+			"message": fmt.Sprintf("A %s invoice cannot be marked %s", invoice.Status, status),
+		})
+		return
+	}
+
+	var number *string
+	switch status {
+	case InvoiceStatusIssued:
+		issued, err := nextInvoiceNumber(ctx, tx, currentUser.OrgID, invoice.Kind)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		number = &issued
+	case InvoiceStatusVoid:
+		var credited bool
+		if err := tx.QueryRow(ctx, queryHasOpenCreditNotesSQL, invoiceID).Scan(&credited); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if credited {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "invoice_credited",
+				"message": "Void the invoice's credit notes first",
+			})
+			return
+		}
+		if _, err := tx.Exec(ctx, voidInvoiceLinesSQL, invoiceID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if _, err := tx.Exec(ctx, setInvoiceStatusSQL, invoiceID, status, number, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := getInvoice(ctx, tx, invoiceID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := enqueueInvoiceStatusChange(ctx, tx, currentUser.OrgID, updated, &invoice.Status, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (s *Service) CreateCreditNote(c *gin.Context, invoiceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage billing")
+	if !ok {
+		return
+	}
+
+	request := CreditNoteCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(request.Reason) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	var (
+		ctx          = c.Request.Context()
+		now          = time.Now()
+		creditNoteID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	invoice := Invoice{}
+	if err := pgxscan.Get(ctx, tx, &invoice, queryGetInvoiceForUpdateSQL, invoiceID, currentUser.OrgID); err != nil {
+		respondInvoiceLookupError(c, err)
+		return
+	}
+
+	if invoice.Kind != InvoiceKindInvoice || (invoice.Status != InvoiceStatusIssued && invoice.Status != InvoiceStatusPaid) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "invoice_not_creditable",
+			"message": "Only issued or paid invoices can be credited",
+		})
+		return
+	}
+
+	invoices := []Invoice{invoice}
+	if err := loadInvoiceLines(ctx, tx, invoices); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	credited := []string{}
+	if err := pgxscan.Select(ctx, tx, &credited, queryListCreditedLinesSQL, invoiceID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	lines, problem := creditedLines(invoices[0].Lines, credited, request.LineIds)
+	if problem != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": problem})
+		return
+	}
+	if len(lines) == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "already_credited",
+			"message": "The invoice lines are already credited",
+		})
+		return
+	}
+
+	total := 0
+	for _, line := range lines {
+		total -= line.AmountCents
+	}
+
+	number, err := nextInvoiceNumber(ctx, tx, currentUser.OrgID, InvoiceKindCreditNote)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = tx.Exec(ctx, createInvoiceSQL, creditNoteID, currentUser.OrgID, InvoiceKindCreditNote, number, InvoiceStatusIssued, invoice.StudentId, invoice.FamilyId,
+		invoice.BillTo, invoice.PeriodStart, invoice.PeriodEnd, invoice.Currency, total, invoiceID, request.Reason, now, currentUser.UserID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, line := range lines {
+		credit := InvoiceLine{
+			LineId:         uuid.New().String(),
+			ClassId:        line.ClassId,
+			StudentId:      line.StudentId,
+			Description:    line.Description,
+			StartTime:      line.StartTime,
+			Duration:       line.Duration,
+			RateCardId:     line.RateCardId,
+			AmountCents:    -line.AmountCents,
+			CreditedLineId: &line.LineId,
+		}
+		if err := createInvoiceLine(ctx, tx, creditNoteID, credit, now); err != nil {
+			respondInvoiceWriteError(c, err)
+			return
+		}
+	}
+
+	creditNote, err := getInvoice(ctx, tx, creditNoteID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := enqueueInvoiceStatusChange(ctx, tx, currentUser.OrgID, creditNote, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		respondInvoiceWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, creditNote)
+}
+
+// invoiceStatusChange is the payload of the invoice.status_changed webhook.
+type invoiceStatusChange struct {
+	InvoiceID         string         `json:"invoice_id"`
+	Kind              InvoiceKind    `json:"kind"`
+	Number            *string        `json:"number"`
+	PreviousStatus    *InvoiceStatus `json:"previous_status"`
+	Status            InvoiceStatus  `json:"status"`
+	StudentID         *string        `json:"student_id,omitempty"`
+	FamilyID          *string        `json:"family_id,omitempty"`
+	CreditedInvoiceID *string        `json:"credited_invoice_id,omitempty"`
+	Currency          string         `json:"currency"`
+	TotalCents        int            `json:"total_cents"`
+}
+
+func enqueueInvoiceStatusChange(ctx context.Context, db dbExecutor, orgID string, invoice Invoice, previous *InvoiceStatus, now time.Time) error {
+	change := invoiceStatusChange{
+		InvoiceID:         invoice.InvoiceId,
+		Kind:              invoice.Kind,
+		Number:            invoice.Number,
+		PreviousStatus:    previous,
+		Status:            invoice.Status,
+		StudentID:         invoice.StudentId,
+		FamilyID:          invoice.FamilyId,
+		CreditedInvoiceID: invoice.CreditedInvoiceId,
+		Currency:          invoice.Currency,
+		TotalCents:        invoice.TotalCents,
+	}
+	return webhooks.Enqueue(ctx, db, orgID, webhooks.EventInvoiceStatusChanged, change, now)
+}
+
+// courseAndTutorInOrg reports whether the course and the tutor, when given,
+// belong to the organization.
+func courseAndTutorInOrg(ctx context.Context, db dbExecutor, orgID string, courseID, tutorID *string) (bool, error) {
+	var found bool
+	return found, db.QueryRow(ctx, checkCourseAndTutorSQL, orgID, courseID, tutorID).Scan(&found)
+}
+
+func listRateCards(ctx context.Context, db dbExecutor, orgID string) ([]RateCard, error) {
+	cards := []RateCard{}
+	return cards, pgxscan.Select(ctx, db, &cards, queryListRateCardsSQL, orgID)
+}
+
+func getInvoice(ctx context.Context, db dbExecutor, invoiceID, orgID string) (Invoice, error) {
+	invoice := Invoice{}
+	if err := pgxscan.Get(ctx, db, &invoice, queryGetInvoiceSQL, invoiceID, orgID); err != nil {
+		return Invoice{}, err
+	}
+
+	invoices := []Invoice{invoice}
+	return invoices[0], loadInvoiceLines(ctx, db, invoices)
+}
+
+type invoiceLineRecord struct {
+	InvoiceID string
+	InvoiceLine
+}
+
+// loadInvoiceLines fills in the lines of each invoice.
+func loadInvoiceLines(ctx context.Context, db dbExecutor, invoices []Invoice) error {
+	invoiceIDs := make([]string, len(invoices))
+	for i := range invoices {
+		invoiceIDs[i] = invoices[i].InvoiceId
+		invoices[i].Lines = []InvoiceLine{}
+	}
+
+	records := []invoiceLineRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListInvoiceLinesSQL, invoiceIDs); err != nil {
+		return err
+	}
+
+	byInvoice := map[string][]InvoiceLine{}
+	for _, record := range records {
+		byInvoice[record.InvoiceID] = append(byInvoice[record.InvoiceID], record.InvoiceLine)
+	}
+	for i := range invoices {
+		if lines, ok := byInvoice[invoices[i].InvoiceId]; ok {
+			invoices[i].Lines = lines
+		}
+	}
+
+	return nil
+}
+
+func createInvoiceLine(ctx context.Context, db dbExecutor, invoiceID string, line InvoiceLine, now time.Time) error {
+	_, err := db.Exec(ctx, createInvoiceLineSQL, line.LineId, invoiceID, line.ClassId, line.StudentId, line.Description, line.StartTime,
+		line.Duration, line.RateCardId, line.AmountCents, line.CreditedLineId, now)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return errAlreadyInvoiced
+	}
+	return err
+}
+
+// nextInvoiceNumber returns the number the next invoice or credit note of
+// the organization is issued with. It locks the organization until the
+// transaction ends so two documents never get the same number.
+func nextInvoiceNumber(ctx context.Context, db dbExecutor, orgID string, kind InvoiceKind) (string, error) {
+	var sequence int
+	if err := db.QueryRow(ctx, queryNextInvoiceNumberSQL, orgID, kind).Scan(&sequence); err != nil {
+		return "", err
+	}
+	return formatInvoiceNumber(kind, sequence), nil
+}
+
+//go:embed templates/invoice.html
+var invoiceTemplateHTML string
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": formatCents,
+	"date": func(t time.Time) string {
+		return t.UTC().Format("Jan 2, 2006")
+	},
+	// lastDay formats the exclusive end of a period as the day it ends on.
+	"lastDay": func(t time.Time) string {
+		return t.Add(-time.Nanosecond).UTC().Format("Jan 2, 2006")
+	},
+}).Parse(invoiceTemplateHTML))
+
+// renderInvoice renders an invoice or credit note as a printable HTML page.
+func renderInvoice(invoice Invoice, organization string) ([]byte, error) {
+	data := struct {
+		Title        string
+		Number       string
+		Organization string
+		Invoice      Invoice
+	}{
+		Title:        "Invoice",
+		Number:       "(draft)",
+		Organization: organization,
+		Invoice:      invoice,
+	}
+	if invoice.Kind == InvoiceKindCreditNote {
+		data.Title = "Credit note"
+	}
+	if invoice.Number != nil {
+		data.Number = *invoice.Number
+	}
+
+	var page bytes.Buffer
+	if err := invoiceTemplate.Execute(&page, data); err != nil {
+		return nil, err
+	}
+	return page.Bytes(), nil
+}
+
+func respondFamilyNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "family_not_found",
+		"message": "Family not found",
+	})
+}
+
+func respondFamilyLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		respondFamilyNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func respondRateCardNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "rate_card_not_found",
+		"message": "Rate card not found",
+	})
+}
+
+func respondInvoiceLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "invoice_not_found",
+			"message": "Invoice not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// respondInvoiceWriteError writes the error of a failed invoice line write,
+// turning a class billed twice by concurrent requests into a conflict.
+func respondInvoiceWriteError(c *gin.Context, err error) {
+	if errors.Is(err, errAlreadyInvoiced) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "already_invoiced",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+//go:embed queries/billing/list_families.sql
+var queryListFamiliesSQL string
+
+//go:embed queries/billing/get_family.sql
+var queryGetFamilySQL string
+
+//go:embed queries/billing/create_family.sql
+var createFamilySQL string
+
+//go:embed queries/billing/update_family.sql
+var updateFamilySQL string
+
+//go:embed queries/billing/clear_family_students.sql
+var clearFamilyStudentsSQL string
+
+//go:embed queries/billing/set_family_students.sql
+var setFamilyStudentsSQL string
+
+//go:embed queries/billing/list_rate_cards.sql
+var queryListRateCardsSQL string
+
+//go:embed queries/billing/get_rate_card.sql
+var queryGetRateCardSQL string
+
+//go:embed queries/billing/create_rate_card.sql
+var createRateCardSQL string
+
+//go:embed queries/billing/update_rate_card.sql
+var updateRateCardSQL string
+
+//go:embed queries/billing/delete_rate_card.sql
+var deleteRateCardSQL string
+
+//go:embed queries/billing/check_course_and_tutor.sql
+var checkCourseAndTutorSQL string
+
+//go:embed queries/billing/get_student_bill_to.sql
+var queryGetStudentBillToSQL string
+
+//go:embed queries/billing/list_billable_classes.sql
+var queryListBillableClassesSQL string
+
+//go:embed queries/billing/create_invoice.sql
+var createInvoiceSQL string
+
+//go:embed queries/billing/create_invoice_line.sql
+var createInvoiceLineSQL string
+
+//go:embed queries/billing/list_invoices.sql
+var queryListInvoicesSQL string
+
+//go:embed queries/billing/get_invoice.sql
+var queryGetInvoiceSQL string
+
+//go:embed queries/billing/get_invoice_for_update.sql
+var queryGetInvoiceForUpdateSQL string
+
+//go:embed queries/billing/list_invoice_lines.sql
+var queryListInvoiceLinesSQL string
+
+//go:embed queries/billing/list_credited_lines.sql
+var queryListCreditedLinesSQL string
+
+//go:embed queries/billing/next_invoice_number.sql
+var queryNextInvoiceNumberSQL string
+
+//go:embed queries/billing/set_invoice_status.sql
+var setInvoiceStatusSQL string
+
+//go:embed queries/billing/void_invoice_lines.sql
+var voidInvoiceLinesSQL string
+
+//go:embed queries/billing/has_open_credit_notes.sql
+var queryHasOpenCreditNotesSQL string
+
+//go:embed queries/billing/get_organization_name.sql
+var queryGetOrganizationNameSQL string
+
+// billableClass is a class a student attended that is waiting to be billed,
+// with what rate cards are matched on.
+type billableClass struct {
+	ClassID      string
+	StudentID    string
+	StudentName  string
+	CourseID     *string
+	CourseName   *string
+	StartTime    time.Time
+	Duration     int
+	TutorIDs     []string
+	StudentCount int
+}
+
+// classType returns whether the class is taught to a group or one to one.
+func (b billableClass) classType() RateCardClassType {
+	if b.StudentCount > 1 {
+		return Group
+	}
+	return Individual
+}
+
+// description returns the text of the class's invoice line.
+func (b billableClass) description() string {
+	name := "Tutoring"
+	if b.CourseName != nil && *b.CourseName != "" {
+		name = *b.CourseName
+	}
+	return fmt.Sprintf("%s, %s (%d min)", name, b.StartTime.UTC().Format("Mon Jan 2, 2006 15:04 MST"), b.Duration)
+}
+
+// matchRateCard returns the rate card pricing a class: of the cards whose
+// criteria all match the class, the one with the most criteria. Ties go to
+// the card listed first.
+func matchRateCard(cards []RateCard, class billableClass) (RateCard, bool) {
+	match, best := RateCard{}, -1
+	for _, card := range cards {
+		specificity := 0
+		if card.CourseId != nil {
+			if class.CourseID == nil || *card.CourseId != *class.CourseID {
+				continue
+			}
+			specificity++
+		}
+		if card.TutorId != nil {
+			if !slices.Contains(class.TutorIDs, *card.TutorId) {
+				continue
+			}
+			specificity++
+		}
+		if card.Duration != nil {
+			if *card.Duration != class.Duration {
+				continue
+			}
+			specificity++
+		}
+		if card.ClassType != nil {
+			if *card.ClassType != class.classType() {
+				continue
+			}
+			specificity++
+		}
+
+		if specificity > best {
+			match, best = card, specificity
+		}
+	}
+
+	return match, best >= 0
+}
+
+// pricedClass is a billable class with the rate card it is billed at.
+type pricedClass struct {
+	billableClass
+	Card RateCard
+}
+
+// priceClasses prices each class with its rate card. It also returns the
+// IDs of the classes no rate card matches.
+func priceClasses(cards []RateCard, classes []billableClass) ([]pricedClass, []string) {
+	priced, unpriced := []pricedClass{}, []string{}
+	for _, class := range classes {
+		card, ok := matchRateCard(cards, class)
+		if !ok {
+			unpriced = append(unpriced, class.ClassID)
+			continue
+		}
+		priced = append(priced, pricedClass{class, card})
+	}
+
+	return priced, uniqueIDs(unpriced)
+}
+
+// invoiceTransitionAllowed reports whether an invoice or credit note may
+// move from one status to another. Paid invoices are corrected with credit
+// notes rather than voided.
+func invoiceTransitionAllowed(from, to InvoiceStatus) bool {
+	switch to {
+	case InvoiceStatusIssued:
+		return from == InvoiceStatusDraft
+	case InvoiceStatusPaid:
+		return from == InvoiceStatusIssued
+	case InvoiceStatusVoid:
+		return from == InvoiceStatusDraft || from == InvoiceStatusIssued
+	default:
+		return false
+	}
+}
+
+// formatInvoiceNumber returns the number of the sequence-th invoice or
+// credit note of an organization, e.g. INV-000042.
+func formatInvoiceNumber(kind InvoiceKind, sequence int) string {
+	prefix := "INV"
+	if kind == InvoiceKindCreditNote {
+		prefix = "CN"
+	}
+	return fmt.Sprintf("%s-%06d", prefix, sequence)
+}
+
+// formatCents formats an amount in cents of a currency, e.g. -45.00 USD.
+func formatCents(cents int, currency string) string {
+	return formatAmount(cents) + " " + currency
+}
+
+// formatAmount formats an amount in cents with two decimals, e.g. -45.00.
+func formatAmount(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// creditedLines returns the lines a credit note credits: the requested ones,
+// or every line not credited yet when none are requested. problem is set
+// when a requested line is not on the invoice or is already credited.
+func creditedLines(lines []InvoiceLine, credited []string, requested *[]string) ([]InvoiceLine, string) {
+	isCredited := map[string]bool{}
+	for _, lineID := range credited {
+		isCredited[lineID] = true
+	}
+
+	if requested == nil {
+		open := []InvoiceLine{}
+		for _, line := range lines {
+			if !isCredited[line.LineId] {
+				open = append(open, line)
+			}
+		}
+		return open, ""
+	}
+
+	byID := map[string]InvoiceLine{}
+	for _, line := range lines {
+		byID[line.LineId] = line
+	}
+
+	selected := []InvoiceLine{}
+	for _, lineID := range uniqueIDs(*requested) {
+		line, ok := byID[lineID]
+		if !ok {
+			return nil, "line " + lineID + " is not on the invoice"
+		}
+		if isCredited[lineID] {
+			return nil, "line " + lineID + " is already credited"
+		}
+		selected = append(selected, line)
+	}
+
+	return selected, ""
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchRateCard(t *testing.T) {
+	var (
+		course      = "course-1"
+		otherCourse = "course-2"
+		tutor       = "tutor-1"
+		hour        = 60
+		group       = Group
+	)
+
+	cards := []RateCard{
+		{Name: "default", AmountCents: 4000},
+		{Name: "course", CourseId: &course, AmountCents: 5000},
+		{Name: "other course", CourseId: &otherCourse, AmountCents: 9000},
+		{Name: "course and tutor", CourseId: &course, TutorId: &tutor, AmountCents: 6000},
+		{Name: "hour", Duration: &hour, AmountCents: 5500},
+		{Name: "group", ClassType: &group, AmountCents: 2500},
+	}
+
+	tests := []struct {
+		name  string
+		class billableClass
+		want  string
+	}{
+		{"no criteria match", billableClass{Duration: 45, StudentCount: 1}, "default"},
+		{"course", billableClass{CourseID: &course, Duration: 45, StudentCount: 1}, "course"},
+		{"course and tutor", billableClass{CourseID: &course, TutorIDs: []string{"tutor-2", tutor}, Duration: 45, StudentCount: 1}, "course and tutor"},
+		{"tie goes to the first card", billableClass{CourseID: &course, Duration: 60, StudentCount: 1}, "course"},
+		{"group", billableClass{Duration: 45, StudentCount: 3}, "group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchRateCard(cards, tt.class)
+			if !ok || got.Name != tt.want {
+				t.Errorf("expected %q, got %q (matched %v)", tt.want, got.Name, ok)
+			}
+		})
+	}
+
+	if _, ok := matchRateCard(cards[1:2], billableClass{Duration: 60}); ok {
+		t.Error("expected a course rate card not to match a class without a course")
+	}
+}
+
+func TestPriceClasses(t *testing.T) {
+	hour := 60
+	cards := []RateCard{{Name: "hour", Duration: &hour, AmountCents: 5000}}
+	classes := []billableClass{
+		{ClassID: "a", StudentID: "s1", Duration: 60},
+		{ClassID: "b", StudentID: "s1", Duration: 30},
+		{ClassID: "b", StudentID: "s2", Duration: 30},
+	}
+
+	priced, unpriced := priceClasses(cards, classes)
+	if len(priced) != 1 || priced[0].ClassID != "a" || priced[0].Card.AmountCents != 5000 {
+		t.Errorf("expected class a to be priced at 5000, got %+v", priced)
+	}
+	if len(unpriced) != 1 || unpriced[0] != "b" {
+		t.Errorf("expected class b to be reported once, got %v", unpriced)
+	}
+}
+
+func TestInvoiceTransitionAllowed(t *testing.T) {
+	tests := []struct {
+		from, to InvoiceStatus
+		want     bool
+	}{
+		{InvoiceStatusDraft, InvoiceStatusIssued, true},
+		{InvoiceStatusDraft, InvoiceStatusPaid, false},
+		{InvoiceStatusDraft, InvoiceStatusVoid, true},
+		{InvoiceStatusIssued, InvoiceStatusPaid, true},
+		{InvoiceStatusIssued, InvoiceStatusVoid, true},
+		{InvoiceStatusIssued, InvoiceStatusIssued, false},
+		{InvoiceStatusPaid, InvoiceStatusVoid, false},
+		{InvoiceStatusVoid, InvoiceStatusIssued, false},
+		{InvoiceStatusIssued, InvoiceStatusDraft, false},
+	}
+
+	for _, tt := range tests {
+		if got := invoiceTransitionAllowed(tt.from, tt.to); got != tt.want {
+			t.Errorf("invoiceTransitionAllowed(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := []struct {
+		cents int
+		want  string
+	}{
+		{4500, "45.00 USD"},
+		{5, "0.05 USD"},
+		{0, "0.00 USD"},
+		{-12345, "-123.45 USD"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCents(tt.cents, "USD"); got != tt.want {
+			t.Errorf("formatCents(%d) = %q, want %q", tt.cents, got, tt.want)
+		}
+	}
+
+	if got := formatInvoiceNumber(InvoiceKindCreditNote, 42); got != "CN-000042" {
+		t.Errorf("expected CN-000042, got %q", got)
+	}
+}
+
+func TestCreditedLines(t *testing.T) {
+	lines := []InvoiceLine{{LineId: "a"}, {LineId: "b"}, {LineId: "c"}}
+	lineIDs := func(lines []InvoiceLine) []string {
+		ids := []string{}
+		for _, line := range lines {
+			ids = append(ids, line.LineId)
+		}
+		return ids
+	}
+
+	got, problem := creditedLines(lines, []string{"b"}, nil)
+	if problem != "" || !slices.Equal(lineIDs(got), []string{"a", "c"}) {
+		t.Errorf("expected every line not credited yet, got %v %q", lineIDs(got), problem)
+	}
+
+	got, problem = creditedLines(lines, []string{"b"}, &[]string{"c", "a", "c"})
+	if problem != "" || !slices.Equal(lineIDs(got), []string{"c", "a"}) {
+		t.Errorf("expected the requested lines once each, got %v %q", lineIDs(got), problem)
+	}
+
+	if _, problem := creditedLines(lines, []string{"b"}, &[]string{"b"}); problem == "" {
+		t.Error("expected a credited line to be rejected")
+	}
+	if _, problem := creditedLines(lines, nil, &[]string{"x"}); problem == "" {
+		t.Error("expected a line that is not on the invoice to be rejected")
+	}
+}
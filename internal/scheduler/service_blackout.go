@@ -0,0 +1,642 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/jobs"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type BlackoutService interface {
+	ListBlackouts(*gin.Context, ListBlackoutsParams)
+	CreateBlackout(*gin.Context)
+	ImportBlackouts(*gin.Context)
+	DeleteBlackout(*gin.Context, string)
+	GetBlackoutPolicy(*gin.Context)
+	SetBlackoutPolicy(*gin.Context)
+}
+
+var _ BlackoutService = (*Service)(nil)
+
+func (s *Service) ListBlackouts(c *gin.Context, params ListBlackoutsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	blackouts, err := listBlackouts(c.Request.Context(), s.pgxPool, currentUser.OrgID, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, blackouts)
+}
+
+func (s *Service) CreateBlackout(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage blackouts")
+	if !ok {
+		return
+	}
+
+	request := BlackoutPeriod{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	if !request.EndTime.After(request.StartTime) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
+
+	var (
+		ctx        = c.Request.Context()
+		now        = time.Now()
+		blackoutID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, createBlackoutSQL, blackoutID, currentUser.OrgID, request.Name, request.StartTime, request.EndTime, currentUser.UserID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := applyBlackout(ctx, tx, currentUser.OrgID, request.StartTime, request.EndTime, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	blackout := BlackoutPeriod{}
+	if err := pgxscan.Get(ctx, tx, &blackout, queryGetBlackoutSQL, blackoutID, currentUser.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, blackout)
+}
+
+func (s *Service) ImportBlackouts(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage blackouts")
+	if !ok {
+		return
+	}
+
+	request := BlackoutImport{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timezone := "UTC"
+	if request.Timezone != nil {
+		timezone = *request.Timezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown timezone " + timezone})
+		return
+	}
+
+	events, err := parseICSEvents(request.Calendar, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	result := BlackoutImportResult{Blackouts: []BlackoutPeriod{}}
+	moved := false
+	for _, event := range events {
+		if !event.importable() {
+			result.Skipped++
+			continue
+		}
+
+		name := event.Summary
+		if name == "" {
+			name = "Imported blackout"
+		}
+
+		var previousStart, previousEnd time.Time
+		err := tx.QueryRow(ctx, queryGetICSBlackoutForUpdateSQL, currentUser.OrgID, event.UID).Scan(&previousStart, &previousEnd)
+		if err != nil && err != pgx.ErrNoRows {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		reimported := err == nil
+
+		blackout := BlackoutPeriod{}
+		err = pgxscan.Get(ctx, tx, &blackout, upsertICSBlackoutSQL, currentUser.OrgID, name, event.Start, event.End, event.UID, currentUser.UserID, now)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := applyBlackout(ctx, tx, currentUser.OrgID, event.Start, event.End, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// An event the calendar moved gives the time it left back, as if
+		// that period had been deleted.
+		if reimported && (!previousStart.Equal(event.Start) || !previousEnd.Equal(event.End)) {
+			if err := refreshBlackoutTrackers(ctx, tx, currentUser.OrgID, previousStart, previousEnd, now); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			moved = true
+		}
+
+		result.Imported++
+		result.Blackouts = append(result.Blackouts, blackout)
+	}
+
+	if moved {
+		if err := jobs.Enqueue(ctx, tx, JobExpandRecurringAvailability, map[string]string{}, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Service) DeleteBlackout(c *gin.Context, blackoutID string) {
+	currentUser, ok := s.requireAdmin(c, "manage blackouts")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var period struct {
+		StartTime time.Time
+		EndTime   time.Time
+	}
+	if err := pgxscan.Get(ctx, tx, &period, deleteBlackoutSQL, blackoutID, currentUser.OrgID); err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "blackout_not_found",
+				"message": "Blackout period not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := refreshBlackoutTrackers(ctx, tx, currentUser.OrgID, period.StartTime, period.EndTime, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Give recurring availability back the time the blackout took out of it.
+	if err := jobs.Enqueue(ctx, tx, JobExpandRecurringAvailability, map[string]string{}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) GetBlackoutPolicy(c *gin.Context) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	policy, err := getBlackoutPolicy(c.Request.Context(), s.pgxPool, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, BlackoutPolicySettings{Policy: policy})
+}
+
+func (s *Service) SetBlackoutPolicy(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage blackouts")
+	if !ok {
+		return
+	}
+
+	request := BlackoutPolicySettings{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Policy != Warn && request.Policy != Block {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "policy must be warn or block"})
+		return
+	}
+
+	if _, err := s.pgxPool.Exec(c.Request.Context(), setBlackoutPolicySQL, currentUser.OrgID, request.Policy, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+//go:embed queries/blackout/list_blackouts.sql
+var queryListBlackoutsSQL string
+
+//go:embed queries/blackout/get_blackout.sql
+var queryGetBlackoutSQL string
+
+//go:embed queries/blackout/create_blackout.sql
+var createBlackoutSQL string
+
+//go:embed queries/blackout/upsert_ics_blackout.sql
+var upsertICSBlackoutSQL string
+
+//go:embed queries/blackout/get_ics_blackout_for_update.sql
+var queryGetICSBlackoutForUpdateSQL string
+
+//go:embed queries/blackout/delete_blackout.sql
+var deleteBlackoutSQL string
+
+//go:embed queries/blackout/get_blackout_policy.sql
+var queryGetBlackoutPolicySQL string
+
+//go:embed queries/blackout/set_blackout_policy.sql
+var setBlackoutPolicySQL string
+
+//go:embed queries/blackout/delete_blackout_availability.sql
+var deleteBlackoutAvailabilitySQL string
+
+//go:embed queries/blackout/list_open_trackers.sql
+var queryListOpenTrackersSQL string
+
+//go:embed queries/blackout/skip_trackers.sql
+var skipTrackersSQL string
+
+//go:embed queries/blackout/unskip_trackers.sql
+var unskipTrackersSQL string
+
+func listBlackouts(ctx context.Context, db dbExecutor, orgID string, from, to *time.Time) ([]BlackoutPeriod, error) {
+	blackouts := []BlackoutPeriod{}
+	return blackouts, pgxscan.Select(ctx, db, &blackouts, queryListBlackoutsSQL, orgID, from, to)
+}
+
+// listBlackoutIntervals returns the blackout periods of an organization
+// overlapping from to to as intervals.
+func listBlackoutIntervals(ctx context.Context, db dbExecutor, orgID string, from, to time.Time) ([]TimeInterval, error) {
+	blackouts, err := listBlackouts(ctx, db, orgID, &from, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	intervals := make([]TimeInterval, len(blackouts))
+	for i, blackout := range blackouts {
+		intervals[i] = TimeInterval{blackout.StartTime, blackout.EndTime}
+	}
+
+	return intervals, nil
+}
+
+func getBlackoutPolicy(ctx context.Context, db dbExecutor, orgID string) (BlackoutPolicy, error) {
+	settings := BlackoutPolicySettings{}
+	if err := pgxscan.Get(ctx, db, &settings, queryGetBlackoutPolicySQL, orgID); err != nil {
+		if pgxscan.NotFound(err) {
+			return Warn, nil
+		}
+		return "", err
+	}
+
+	return settings.Policy, nil
+}
+
+// applyBlackout brings the schedule in line with a new blackout period: it
+// removes the unmatched recurring availability inside it and skips the
+// trackers it now blacks out completely.
+func applyBlackout(ctx context.Context, db dbExecutor, orgID string, start, end, now time.Time) error {
+	if _, err := db.Exec(ctx, deleteBlackoutAvailabilitySQL, orgID, start, end); err != nil {
+		return err
+	}
+
+	return refreshBlackoutTrackers(ctx, db, orgID, start, end, now)
+}
+
+type openTrackerRecord struct {
+	TrackingID  string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Status      *string
+}
+
+// refreshBlackoutTrackers marks the open trackers overlapping from to to as
+// skipped if blackouts cover their whole period, and gives skipped ones that
+// are no longer covered their status back.
+func refreshBlackoutTrackers(ctx context.Context, db dbExecutor, orgID string, from, to, now time.Time) error {
+	trackers := []openTrackerRecord{}
+	if err := pgxscan.Select(ctx, db, &trackers, queryListOpenTrackersSQL, orgID, from, to); err != nil {
+		return err
+	}
+	if len(trackers) == 0 {
+		return nil
+	}
+
+	periods := make([]TimeInterval, len(trackers))
+	for i, tracker := range trackers {
+		periods[i] = TimeInterval{tracker.PeriodStart, tracker.PeriodEnd}
+	}
+
+	periodsStart, periodsEnd := intervalsSpan(periods)
+	blackouts, err := listBlackoutIntervals(ctx, db, orgID, periodsStart, periodsEnd)
+	if err != nil {
+		return err
+	}
+
+	var skip, unskip []string
+	for _, tracker := range trackers {
+		skipped := tracker.Status != nil && *tracker.Status == "skipped"
+		blackedOut := intervalsCover(blackouts, tracker.PeriodStart, tracker.PeriodEnd)
+		switch {
+		case blackedOut && !skipped:
+			skip = append(skip, tracker.TrackingID)
+		case !blackedOut && skipped:
+			unskip = append(unskip, tracker.TrackingID)
+		}
+	}
+
+	for _, update := range []struct {
+		query string
+		ids   []string
+	}{{skipTrackersSQL, skip}, {unskipTrackersSQL, unskip}} {
+		if len(update.ids) == 0 {
+			continue
+		}
+
+		changes := []trackerStatusChange{}
+		if err := pgxscan.Select(ctx, db, &changes, update.query, update.ids, now); err != nil {
+			return err
+		}
+		if err := enqueueTrackerStatusChanges(ctx, db, changes, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkBlackouts looks for blackout periods overlapping the intervals. Under
+// the block policy it responds with a conflict and returns false; under the
+// warn policy it names the periods in Warning headers and returns true.
+func checkBlackouts(c *gin.Context, db dbExecutor, orgID string, intervals []TimeInterval) bool {
+	if len(intervals) == 0 {
+		return true
+	}
+
+	from, to := intervalsSpan(intervals)
+
+	ctx := c.Request.Context()
+
+	blackouts, err := listBlackouts(ctx, db, orgID, &from, &to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	blackouts = overlappingBlackouts(blackouts, intervals)
+	if len(blackouts) == 0 {
+		return true
+	}
+
+	policy, err := getBlackoutPolicy(ctx, db, orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if policy == Block {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "blackout_conflict",
+			"message":   "Scheduling is blocked during blackout periods",
+			"blackouts": blackouts,
+		})
+		return false
+	}
+
+	for _, blackout := range blackouts {
+		c.Writer.Header().Add("Warning", fmt.Sprintf("299 - %q", "Falls inside blackout period "+blackout.Name))
+	}
+
+	return true
+}
+
+// icsEvent is an event read from an iCalendar file.
+type icsEvent struct {
+	UID       string
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	Recurring bool
+}
+
+// importable reports whether an event can become a blackout period.
+// Recurring events and their overrides are not expanded.
+func (e icsEvent) importable() bool {
+	return !e.Recurring && !e.Start.IsZero() && e.End.After(e.Start)
+}
+
+// parseICSEvents reads the VEVENTs of an iCalendar file. All-day events and
+// times without a TZID or UTC suffix are taken to be in loc. An all-day event
+// without DTEND lasts one day; a timed one without DTEND is left without an
+// end. Events without a UID get one made from their start and summary.
+func parseICSEvents(calendar string, loc *time.Location) ([]icsEvent, error) {
+	// Lines starting with a space or tab continue the previous one.
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(calendar, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+
+	var (
+		events     []icsEvent
+		event      *icsEvent
+		allDay     bool
+		isCalendar bool
+	)
+	for i, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			switch strings.ToUpper(value) {
+			case "VCALENDAR":
+				isCalendar = true
+			case "VEVENT":
+				event, allDay = &icsEvent{}, false
+			}
+		case "END":
+			if strings.EqualFold(value, "VEVENT") && event != nil {
+				if event.End.IsZero() && allDay && !event.Start.IsZero() {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+				if event.UID == "" {
+					event.UID = event.Start.UTC().Format("20060102T150405Z") + "/" + event.Summary
+				}
+				events = append(events, *event)
+				event = nil
+			}
+		}
+
+		if event == nil {
+			continue
+		}
+
+		switch strings.ToUpper(name) {
+		case "UID":
+			event.UID = value
+		case "SUMMARY":
+			event.Summary = unescapeICSText(value)
+		case "RRULE", "RDATE", "RECURRENCE-ID":
+			event.Recurring = true
+		case "DTSTART", "DTEND":
+			t, date, err := parseICSTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				event.Start, allDay = t, date
+			} else {
+				event.End = t
+			}
+		}
+	}
+
+	if !isCalendar {
+		return nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	return events, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME value and reports whether it was a
+// date.
+func parseICSTime(value, params string, loc *time.Location) (time.Time, bool, error) {
+	for _, param := range strings.Split(params, ";") {
+		key, tzid, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			l, err := time.LoadLocation(strings.Trim(tzid, `"`))
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("unknown timezone %s", tzid)
+			}
+			loc = l
+		}
+	}
+
+	if len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+func unescapeICSText(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// overlappingBlackouts returns the blackout periods that overlap any of the
+// intervals.
+func overlappingBlackouts(blackouts []BlackoutPeriod, intervals []TimeInterval) []BlackoutPeriod {
+	var overlapping []BlackoutPeriod
+	for _, blackout := range blackouts {
+		for _, interval := range intervals {
+			if blackout.StartTime.Before(interval[1]) && blackout.EndTime.After(interval[0]) {
+				overlapping = append(overlapping, blackout)
+				break
+			}
+		}
+	}
+
+	return overlapping
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICSEvents(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:winter@example.com",
+		"SUMMARY:Winter holidays\\, all centers",
+		"DTSTART;VALUE=DATE:20241223",
+		"DTEND;VALUE=DATE:20250106",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:exam@example.com",
+		"SUMMARY:Exam",
+		"  week",
+		"DTSTART;TZID=Europe/Berlin:20250310T080000",
+		"DTEND:20250314T170000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Staff day",
+		"DTSTART;VALUE=DATE:20250203",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly@example.com",
+		"DTSTART:20250101T090000Z",
+		"DTEND:20250101T100000Z",
+		"RRULE:FREQ=WEEKLY",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := parseICSEvents(calendar, berlin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	winter := events[0]
+	if winter.Summary != "Winter holidays, all centers" || winter.UID != "winter@example.com" {
+		t.Errorf("unexpected winter event %+v", winter)
+	}
+	if !winter.Start.Equal(time.Date(2024, 12, 23, 0, 0, 0, 0, berlin)) || !winter.End.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, berlin)) {
+		t.Errorf("all-day dates should be midnight in the default location, got %v - %v", winter.Start, winter.End)
+	}
+
+	exam := events[1]
+	if exam.Summary != "Exam week" {
+		t.Errorf("folded summary = %q, want %q", exam.Summary, "Exam week")
+	}
+	if !exam.Start.Equal(time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC)) || !exam.End.Equal(time.Date(2025, 3, 14, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected exam period %v - %v", exam.Start, exam.End)
+	}
+
+	staff := events[2]
+	if !staff.End.Equal(staff.Start.AddDate(0, 0, 1)) || staff.UID == "" {
+		t.Errorf("all-day event without DTEND should last a day and get a UID, got %+v", staff)
+	}
+
+	for i, want := range []bool{true, true, true, false} {
+		if got := events[i].importable(); got != want {
+			t.Errorf("event %d importable = %v, want %v", i, got, want)
+		}
+	}
+
+	if _, err := parseICSEvents("not a calendar", berlin); err == nil {
+		t.Error("expected an error for text that is not a calendar")
+	}
+}
+
+func TestOverlappingBlackouts(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	blackouts := []BlackoutPeriod{
+		{Name: "first", StartTime: at(1), EndTime: at(3)},
+		{Name: "second", StartTime: at(5), EndTime: at(6)},
+	}
+
+	got := overlappingBlackouts(blackouts, []TimeInterval{{at(3), at(5)}, {at(2), at(4)}})
+	if len(got) != 1 || got[0].Name != "first" {
+		t.Errorf("expected only the first blackout, got %+v", got)
+	}
+	if got := overlappingBlackouts(blackouts, []TimeInterval{{at(3), at(5)}}); len(got) != 0 {
+		t.Errorf("expected intervals touching blackouts not to overlap them, got %+v", got)
+	}
+}
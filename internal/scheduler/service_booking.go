@@ -0,0 +1,986 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/webhooks"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// defaultSlotHoldTTL is how long a student's hold keeps a slot from other
+// students before it has to be confirmed.
+const defaultSlotHoldTTL = 10 * time.Minute
+
+type SelfBookingService interface {
+	ListTutorSlots(*gin.Context, string, ListTutorSlotsParams)
+	PublishTutorSlots(*gin.Context, string)
+	ListBookableSlots(*gin.Context, ListBookableSlotsParams)
+	WithdrawSlot(*gin.Context, string)
+	HoldSlot(*gin.Context, string)
+	ReleaseSlotHold(*gin.Context, string)
+	ConfirmSlotHold(*gin.Context, string)
+	ListSelfBookingPolicies(*gin.Context)
+	SetSelfBookingPolicies(*gin.Context)
+}
+
+var _ SelfBookingService = (*Service)(nil)
+
+func (s *Service) ListTutorSlots(c *gin.Context, userID string, params ListTutorSlotsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	// Tutors of other organizations have no slots as far as the caller can
+	// tell.
+	slots := []BookableSlot{}
+	err = pgxscan.Select(c.Request.Context(), s.pgxPool, &slots, queryListTutorSlotsSQL, userID, currentUser.OrgID, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+func (s *Service) PublishTutorSlots(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only publish your own slots",
+		})
+		return
+	}
+
+	request := BookableSlotBatch{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	if len(request.Slots) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one slot is required"})
+		return
+	}
+	for _, slot := range request.Slots {
+		if err := validateBookableSlot(slot, now); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	tutor, err := getUser(ctx, s.pgxPool, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tutor.Role != "tutor" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_a_tutor",
+			"message": "Slots can only be published for tutors",
+		})
+		return
+	}
+
+	intervals := make([]TimeInterval, len(request.Slots))
+	for i, slot := range request.Slots {
+		intervals[i] = TimeInterval{slot.StartTime, classEndTime(slot.StartTime, slot.Duration)}
+	}
+	if !checkBlackouts(c, s.pgxPool, tutor.OrgId, intervals) {
+		return
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	status := Open
+	published := make([]BookableSlot, 0, len(request.Slots))
+	for _, slot := range request.Slots {
+		end := classEndTime(slot.StartTime, slot.Duration)
+
+		if slot.CourseId != nil {
+			var teaches bool
+			if err := tx.QueryRow(ctx, queryIsCourseTeacherSQL, userID, *slot.CourseId).Scan(&teaches); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if !teaches {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "not_course_teacher",
+					"message": fmt.Sprintf("Tutor does not teach course %s", *slot.CourseId),
+				})
+				return
+			}
+		}
+
+		availability, err := listOpenAvailability(ctx, tx, userID, slot.StartTime, end)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !intervalsCover(availability, slot.StartTime, end) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "slot_unavailable",
+				"message": fmt.Sprintf("Slot at %s is not covered by the tutor's open availability", slot.StartTime.Format(time.RFC3339)),
+			})
+			return
+		}
+
+		slotID := uuid.New().String()
+		if _, err := tx.Exec(ctx, createSlotSQL, slotID, tutor.OrgId, userID, slot.CourseId, slot.StartTime, end, now); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23P01" {
+				c.JSON(http.StatusConflict, gin.H{
+					"error":   "slot_overlap",
+					"message": fmt.Sprintf("Slot at %s overlaps another slot of the tutor", slot.StartTime.Format(time.RFC3339)),
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		slot.SlotId, slot.TutorId, slot.Status = &slotID, &userID, &status
+		published = append(published, slot)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, published)
+}
+
+func (s *Service) ListBookableSlots(c *gin.Context, params ListBookableSlotsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if !params.To.After(params.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	candidates := []BookableSlot{}
+	err = pgxscan.Select(ctx, s.pgxPool, &candidates, queryListOpenSlotsSQL, currentUser.OrgID, params.From, params.To, time.Now(),
+		params.TutorId, params.CourseId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Admins see every open slot; everyone else only what they may book.
+	if currentUser.Role == "admin" {
+		c.JSON(http.StatusOK, candidates)
+		return
+	}
+
+	slots := []BookableSlot{}
+	if currentUser.Role == "student" {
+		policies, err := listSelfBookingPolicies(ctx, s.pgxPool, currentUser.OrgID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		enrollments, err := getStudentEnrollments(ctx, s.pgxPool, currentUser.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		for _, slot := range candidates {
+			if canSelfBook(policies, enrollments, *slot.TutorId, slot.CourseId) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+func (s *Service) WithdrawSlot(c *gin.Context, slotID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	slot, err := getSlotForUpdate(ctx, tx, slotID)
+	if err != nil {
+		respondSlotLookupError(c, err)
+		return
+	}
+
+	if slot.TutorID != currentUser.UserID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only withdraw your own slots",
+		})
+		return
+	}
+
+	switch BookableSlotStatus(slot.Status) {
+	case Booked:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "slot_booked",
+			"message": "Booked slots must be cancelled through their class",
+		})
+		return
+	case Withdrawn:
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err := releaseActiveSlotHold(ctx, tx, slotID, SlotHoldStatusReleased, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, setSlotStatusSQL, slotID, Withdrawn, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) HoldSlot(c *gin.Context, slotID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.Role != "student" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only students can hold slots",
+		})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Locking the slot makes students racing for it take turns; the first
+	// one holds it and the others see it held.
+	slot, err := getSlotForUpdate(ctx, tx, slotID)
+	if err != nil {
+		respondSlotLookupError(c, err)
+		return
+	}
+
+	if slot.OrgID != currentUser.OrgID {
+		respondSlotNotFound(c)
+		return
+	}
+
+	if BookableSlotStatus(slot.Status) == Held {
+		// A hold past its deadline that the expiry job has not reached yet
+		// no longer blocks the slot.
+		hold, err := getActiveSlotHold(ctx, tx, slotID)
+		if err != nil && !pgxscan.NotFound(err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err == nil && hold.ExpiresAt.After(now) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "slot_held",
+				"message": "Slot is held by another student",
+			})
+			return
+		}
+		if err := releaseActiveSlotHold(ctx, tx, slotID, SlotHoldStatusExpired, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	} else if BookableSlotStatus(slot.Status) != Open {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "slot_unavailable",
+			"message": "Slot is " + slot.Status,
+		})
+		return
+	}
+
+	if !slot.StartTime.After(now) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "slot_started",
+			"message": "Slot has already started",
+		})
+		return
+	}
+
+	policies, err := listSelfBookingPolicies(ctx, tx, slot.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	enrollments, err := getStudentEnrollments(ctx, tx, currentUser.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !canSelfBook(policies, enrollments, slot.TutorID, slot.CourseID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "self_booking_not_allowed",
+			"message": "Self-booking policies do not allow you to book this slot",
+		})
+		return
+	}
+
+	hold := SlotHold{
+		HoldId:    uuid.New().String(),
+		SlotId:    slotID,
+		StudentId: currentUser.UserID,
+		Status:    SlotHoldStatusActive,
+		ExpiresAt: now.Add(s.slotHoldTTL),
+	}
+	if _, err := tx.Exec(ctx, createHoldSQL, hold.HoldId, slotID, slot.OrgID, hold.StudentId, hold.ExpiresAt, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, setSlotStatusSQL, slotID, Held, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, hold)
+}
+
+func (s *Service) ReleaseSlotHold(c *gin.Context, holdID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	hold, slot, ok := s.lockSlotHold(c, tx, holdID, currentUser, "release")
+	if !ok {
+		return
+	}
+
+	if _, err := tx.Exec(ctx, setHoldStatusSQL, holdID, SlotHoldStatusReleased, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if BookableSlotStatus(slot.Status) == Held {
+		if _, err := tx.Exec(ctx, setSlotStatusSQL, hold.SlotId, Open, nil, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) ConfirmSlotHold(c *gin.Context, holdID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var (
+		ctx     = c.Request.Context()
+		now     = time.Now()
+		classID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	hold, slot, ok := s.lockSlotHold(c, tx, holdID, currentUser, "confirm")
+	if !ok {
+		return
+	}
+
+	if !hold.ExpiresAt.After(now) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "hold_expired",
+			"message": "Hold has expired, place a new hold if the slot is still open",
+		})
+		return
+	}
+
+	// The tutor may have been booked into another class since the slot was
+	// published.
+	availability, err := listOpenAvailability(ctx, tx, slot.TutorID, slot.StartTime, slot.EndTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !intervalsCover(availability, slot.StartTime, slot.EndTime) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "slot_unavailable",
+			"message": "Tutor is no longer available for this slot",
+		})
+		return
+	}
+
+	duration := int(slot.EndTime.Sub(slot.StartTime) / time.Minute)
+	class := Class{
+		CourseId:  slot.CourseID,
+		StartTime: slot.StartTime,
+		Duration:  duration,
+		Students:  []string{hold.StudentId},
+		Teachers:  []string{slot.TutorID},
+	}
+
+	violations, err := checkTutorWorkload(ctx, tx, class.Teachers, nil, classSlot{class.StartTime, class.Duration})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(violations) > 0 {
+		respondWorkloadViolations(c, violations)
+		return
+	}
+
+	if !checkBlackouts(c, tx, slot.OrgID, []TimeInterval{{slot.StartTime, slot.EndTime}}) {
+		return
+	}
+
+	if err := checkOrgQuota(ctx, tx, slot.OrgID, quotaMonthlyClasses, 1, slot.StartTime); err != nil {
+		respondQuotaError(c, err)
+		return
+	}
+
+	if err := createClass(ctx, tx, class, classID, slot.OrgID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := createClassParticipants(ctx, tx, class, classID, slot.OrgID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := scheduleClassSlot(ctx, tx, classID, slot.StartTime, slot.EndTime, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	note := "self-booked from slot " + slot.SlotID
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionCreated,
+		StartTime: class.StartTime,
+		Duration:  class.Duration,
+		Note:      &note,
+		ActorId:   &currentUser.UserID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, slot.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, setHoldStatusSQL, holdID, SlotHoldStatusConfirmed, classID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, setSlotStatusSQL, slot.SlotID, Booked, classID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassCreated, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassCreated, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassCreated, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	classesCreated.Inc(sourceBooking)
+
+	s.respondWithClass(c, classID, http.StatusCreated)
+}
+
+func (s *Service) ListSelfBookingPolicies(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "view self-booking policies")
+	if !ok {
+		return
+	}
+
+	policies, err := listSelfBookingPolicies(c.Request.Context(), s.pgxPool, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+func (s *Service) SetSelfBookingPolicies(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "set self-booking policies")
+	if !ok {
+		return
+	}
+
+	request := SelfBookingPolicySet{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scopes := map[[2]string]bool{}
+	for _, policy := range request.Policies {
+		switch policy.Audience {
+		case AllStudents, EnrolledStudents, Nobody:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid audience " + string(policy.Audience)})
+			return
+		}
+
+		var scope [2]string
+		if policy.TutorId != nil {
+			scope[0] = *policy.TutorId
+		}
+		if policy.CourseId != nil {
+			scope[1] = *policy.CourseId
+		}
+		if scopes[scope] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "only one policy per tutor and course is allowed"})
+			return
+		}
+		scopes[scope] = true
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, deletePoliciesSQL, currentUser.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, policy := range request.Policies {
+		_, err := tx.Exec(ctx, createPolicySQL, uuid.New().String(), currentUser.OrgID, policy.TutorId, policy.CourseId, policy.Audience, now)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	policies, err := listSelfBookingPolicies(ctx, tx, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// lockSlotHold locks an active hold of the current user, or of anyone for
+// admins, and the slot it holds. It writes an error response and returns
+// false if the hold cannot be used. action completes the message "Can only
+// ... your own holds".
+func (s *Service) lockSlotHold(c *gin.Context, tx pgx.Tx, holdID string, currentUser *auth.User, action string) (SlotHold, slotRecord, bool) {
+	ctx := c.Request.Context()
+
+	hold := SlotHold{}
+	if err := pgxscan.Get(ctx, tx, &hold, queryGetHoldForUpdateSQL, holdID); err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "hold_not_found",
+				"message": "Hold not found",
+			})
+			return hold, slotRecord{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return hold, slotRecord{}, false
+	}
+
+	if hold.StudentId != currentUser.UserID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only " + action + " your own holds",
+		})
+		return hold, slotRecord{}, false
+	}
+
+	if hold.Status != SlotHoldStatusActive {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "hold_not_active",
+			"message": "Hold is " + string(hold.Status),
+		})
+		return hold, slotRecord{}, false
+	}
+
+	slot, err := getSlotForUpdate(ctx, tx, hold.SlotId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return hold, slot, false
+	}
+
+	return hold, slot, true
+}
+
+func respondSlotNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "slot_not_found",
+		"message": "Slot not found",
+	})
+}
+
+func respondSlotLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		respondSlotNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+//go:embed queries/booking/create_slot.sql
+var createSlotSQL string
+
+//go:embed queries/booking/list_tutor_slots.sql
+var queryListTutorSlotsSQL string
+
+//go:embed queries/booking/list_open_slots.sql
+var queryListOpenSlotsSQL string
+
+//go:embed queries/booking/get_slot_for_update.sql
+var queryGetSlotForUpdateSQL string
+
+//go:embed queries/booking/set_slot_status.sql
+var setSlotStatusSQL string
+
+//go:embed queries/booking/list_open_availability.sql
+var queryListOpenAvailabilitySQL string
+
+//go:embed queries/booking/is_course_teacher.sql
+var queryIsCourseTeacherSQL string
+
+//go:embed queries/booking/list_student_course_teachers.sql
+var queryListStudentCourseTeachersSQL string
+
+//go:embed queries/booking/create_hold.sql
+var createHoldSQL string
+
+//go:embed queries/booking/get_hold_for_update.sql
+var queryGetHoldForUpdateSQL string
+
+//go:embed queries/booking/get_active_slot_hold.sql
+var queryGetActiveSlotHoldSQL string
+
+//go:embed queries/booking/set_hold_status.sql
+var setHoldStatusSQL string
+
+//go:embed queries/booking/expire_slot_holds.sql
+var expireSlotHoldsSQL string
+
+//go:embed queries/booking/list_policies.sql
+var queryListPoliciesSQL string
+
+//go:embed queries/booking/delete_policies.sql
+var deletePoliciesSQL string
+
+//go:embed queries/booking/create_policy.sql
+var createPolicySQL string
+
+// slotRecord is a row of the bookable_slots table.
+type slotRecord struct {
+	SlotID    string
+	OrgID     string
+	TutorID   string
+	CourseID  *string
+	StartTime time.Time
+	EndTime   time.Time
+	Status    string
+}
+
+type studentCourseTeacherRecord struct {
+	CourseID string
+	TutorID  *string
+}
+
+// validateBookableSlot checks a slot to publish. Slots follow the 15-minute
+// grid of availability and must start in the future.
+func validateBookableSlot(slot BookableSlot, now time.Time) error {
+	if slot.Duration <= 0 || slot.Duration%15 != 0 {
+		return fmt.Errorf("duration must be a positive multiple of 15 minutes, got %d", slot.Duration)
+	}
+	if slot.StartTime.Truncate(15*time.Minute) != slot.StartTime {
+		return fmt.Errorf("start time must be on 00, 15, 30, or 45 minutes, got %s", slot.StartTime.Format(time.RFC3339))
+	}
+	if !slot.StartTime.After(now) {
+		return fmt.Errorf("slot at %s is in the past", slot.StartTime.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func getSlotForUpdate(ctx context.Context, tx pgx.Tx, slotID string) (slotRecord, error) {
+	slot := slotRecord{}
+	return slot, pgxscan.Get(ctx, tx, &slot, queryGetSlotForUpdateSQL, slotID)
+}
+
+func getActiveSlotHold(ctx context.Context, tx pgx.Tx, slotID string) (SlotHold, error) {
+	hold := SlotHold{}
+	return hold, pgxscan.Get(ctx, tx, &hold, queryGetActiveSlotHoldSQL, slotID)
+}
+
+// releaseActiveSlotHold ends the active hold on a slot, if any, with status.
+func releaseActiveSlotHold(ctx context.Context, tx pgx.Tx, slotID string, status SlotHoldStatus, now time.Time) error {
+	hold, err := getActiveSlotHold(ctx, tx, slotID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, setHoldStatusSQL, hold.HoldId, status, nil, now)
+	return err
+}
+
+func listOpenAvailability(ctx context.Context, db dbExecutor, userID string, start, end time.Time) ([]TimeInterval, error) {
+	chunks := []AvailabilityRecord{}
+	if err := pgxscan.Select(ctx, db, &chunks, queryListOpenAvailabilitySQL, userID, start, end); err != nil {
+		return nil, err
+	}
+
+	intervals := make([]TimeInterval, len(chunks))
+	for i, chunk := range chunks {
+		intervals[i] = TimeInterval{chunk.StartTime, chunk.EndTime}
+	}
+
+	return intervals, nil
+}
+
+func listSelfBookingPolicies(ctx context.Context, db dbExecutor, orgID string) ([]SelfBookingPolicy, error) {
+	policies := []SelfBookingPolicy{}
+	return policies, pgxscan.Select(ctx, db, &policies, queryListPoliciesSQL, orgID)
+}
+
+func getStudentEnrollments(ctx context.Context, db dbExecutor, userID string) (studentEnrollments, error) {
+	enrollments := studentEnrollments{Courses: map[string]bool{}, Tutors: map[string]bool{}}
+
+	records := []studentCourseTeacherRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListStudentCourseTeachersSQL, userID); err != nil {
+		return enrollments, err
+	}
+
+	for _, record := range records {
+		enrollments.Courses[record.CourseID] = true
+		if record.TutorID != nil {
+			enrollments.Tutors[*record.TutorID] = true
+		}
+	}
+
+	return enrollments, nil
+}
+
+// studentEnrollments is what the self-booking policies need to know about a
+// student: the courses they take and the tutors teaching those courses.
+type studentEnrollments struct {
+	Courses map[string]bool
+	Tutors  map[string]bool
+}
+
+// selfBookingAudience returns who may self-book a tutor's slot for courseID
+// under the most specific matching policy: one naming both the tutor and the
+// course, then the tutor, then the course, then an organization-wide one.
+// Without a matching policy nobody may self-book.
+func selfBookingAudience(policies []SelfBookingPolicy, tutorID string, courseID *string) SelfBookingAudience {
+	audience, best := Nobody, -1
+	for _, policy := range policies {
+		specificity := 0
+		if policy.TutorId != nil {
+			if *policy.TutorId != tutorID {
+				continue
+			}
+			specificity += 2
+		}
+		if policy.CourseId != nil {
+			if courseID == nil || *policy.CourseId != *courseID {
+				continue
+			}
+			specificity++
+		}
+
+		if specificity > best {
+			audience, best = policy.Audience, specificity
+		}
+	}
+
+	return audience
+}
+
+// canSelfBook reports whether a student with the given enrollments may book
+// a tutor's slot for courseID. Slots without a course count as enrolled when
+// the student takes any course the tutor teaches.
+func canSelfBook(policies []SelfBookingPolicy, enrollments studentEnrollments, tutorID string, courseID *string) bool {
+	switch selfBookingAudience(policies, tutorID, courseID) {
+	case AllStudents:
+		return true
+	case EnrolledStudents:
+		if courseID != nil {
+			return enrollments.Courses[*courseID]
+		}
+		return enrollments.Tutors[tutorID]
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func TestSelfBookingAudience(t *testing.T) {
+	id := func(s string) *string { return &s }
+
+	policies := []SelfBookingPolicy{
+		{Audience: EnrolledStudents},
+		{CourseId: id("math"), Audience: AllStudents},
+		{TutorId: id("alice"), Audience: Nobody},
+		{TutorId: id("alice"), CourseId: id("math"), Audience: AllStudents},
+	}
+
+	tests := []struct {
+		name     string
+		policies []SelfBookingPolicy
+		tutorID  string
+		courseID *string
+		want     SelfBookingAudience
+	}{
+		{"no policies", nil, "bob", nil, Nobody},
+		{"organization default", policies, "bob", nil, EnrolledStudents},
+		{"course beats organization", policies, "bob", id("math"), AllStudents},
+		{"tutor beats course", policies, "alice", id("art"), Nobody},
+		{"tutor and course beat tutor", policies, "alice", id("math"), AllStudents},
+		{"course policy needs a course", policies[1:2], "bob", nil, Nobody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selfBookingAudience(tt.policies, tt.tutorID, tt.courseID); got != tt.want {
+				t.Errorf("selfBookingAudience() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanSelfBook(t *testing.T) {
+	math := "math"
+	policies := []SelfBookingPolicy{{Audience: EnrolledStudents}}
+	enrollments := studentEnrollments{
+		Courses: map[string]bool{"math": true},
+		Tutors:  map[string]bool{"alice": true},
+	}
+
+	if !canSelfBook(policies, enrollments, "bob", &math) {
+		t.Error("expected a student of the course to book its slots")
+	}
+	if canSelfBook(policies, enrollments, "bob", nil) {
+		t.Error("expected office hours of a tutor the student has no course with to be refused")
+	}
+	if !canSelfBook(policies, enrollments, "alice", nil) {
+		t.Error("expected office hours of the student's tutor to be allowed")
+	}
+	if canSelfBook(policies, studentEnrollments{}, "alice", &math) {
+		t.Error("expected a student without enrollments to be refused")
+	}
+}
@@ -3,8 +3,13 @@ package scheduler
 import (
 	"context"
 	_ "embed"
+	"fmt"
 	"net/http"
 	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/webhooks"
+	"strings"
 	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -17,7 +22,15 @@ import (
 type ClassService interface {
 	CreateClass(*gin.Context)
 	ListUserClasses(*gin.Context, string)
-	ListCourseClasses(*gin.Context, string)
+	ListCourseClasses(*gin.Context, string, ListCourseClassesParams)
+	GetClass(*gin.Context, string)
+	UpdateClassParticipants(*gin.Context, string)
+	RescheduleClass(*gin.Context, string)
+	CancelClass(*gin.Context, string)
+	RestoreClass(*gin.Context, string)
+	ListClassHistory(*gin.Context, string)
+	ListClassAttendance(*gin.Context, string)
+	RecordClassAttendance(*gin.Context, string)
 }
 
 var _ ClassService = (*Service)(nil)
@@ -45,62 +58,1324 @@ func (s *Service) CreateClass(c *gin.Context) {
 		return
 	}
 
+	if createClassRequest.MaxStudents != nil && *createClassRequest.MaxStudents < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_students must be at least 1"})
+		return
+	}
+
+	// Students past the class capacity join its waitlist in the order given.
+	students, waitlisted := splitBySeats(createClassRequest.MaxStudents, createClassRequest.Students)
+	createClassRequest.Students = students
+
+	// The class belongs to the admin's organization, and so must its course.
+	var (
+		orgID   = currentUser.OrgID
+		classID = uuid.New().String()
+		now     = time.Now()
+		ctx     = c.Request.Context()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if createClassRequest.CourseId != nil {
+		courseOrgID, err := getCourseOrgID(ctx, tx, *createClassRequest.CourseId)
+		if err != nil || courseOrgID != orgID {
+			respondCourseLookupError(c, err)
+			return
+		}
+	}
+
+	slot := classSlot{createClassRequest.StartTime, createClassRequest.Duration}
+	violations, err := checkTutorWorkload(ctx, tx, createClassRequest.Teachers, nil, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+	if len(violations) > 0 {
+		respondWorkloadViolations(c, violations)
+		return
+	}
+
+	if !checkBlackouts(c, tx, orgID, []TimeInterval{{slot.StartTime, slot.endTime()}}) {
+		return
+	}
+
+	var resourceIDs []string
+	if createClassRequest.Resources != nil {
+		resourceIDs = uniqueIDs(*createClassRequest.Resources)
+	}
+	participants := len(createClassRequest.Students) + len(createClassRequest.Teachers)
+	conflicts, err := checkResourceReservations(ctx, tx, orgID, resourceIDs, nil, slot, participants)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+	if len(conflicts) > 0 {
+		respondResourceConflicts(c, conflicts)
+		return
+	}
+
+	if err := checkOrgQuota(ctx, tx, orgID, quotaMonthlyClasses, 1, createClassRequest.StartTime); err != nil {
+		respondQuotaError(c, err)
+		return
+	}
+
+	err = createClass(ctx, tx, createClassRequest, classID, orgID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	classParticipantsErr := createClassParticipants(ctx, tx, createClassRequest, classID, orgID, now)
+	if classParticipantsErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to add class participants": classParticipantsErr.Error()})
+		return
+	}
+
+	if err := addToWaitlist(ctx, tx, orgID, classWaitlist(classID), waitlisted, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to add class participants": err.Error()})
+		return
+	}
+
+	if err := reserveClassResources(ctx, tx, classID, orgID, resourceIDs, slot, now); err != nil {
+		respondReservationError(c, err)
+		return
+	}
+
+	end := classEndTime(createClassRequest.StartTime, createClassRequest.Duration)
+	if err := scheduleClassSlot(ctx, tx, classID, createClassRequest.StartTime, end, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionCreated,
+		StartTime: createClassRequest.StartTime,
+		Duration:  createClassRequest.Duration,
+		ActorId:   &currentUser.UserID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, orgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassCreated, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassCreated, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassCreated, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		return
+	}
+	classesCreated.Inc(sourceAPI)
+
+	s.respondWithClass(c, classID, http.StatusCreated)
+}
+
+func (s *Service) ListUserClasses(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own classes",
+		})
+		return
+	}
+
+	// Admins can only list the classes of users in their own organization.
+	if currentUser.UserID != userID {
+		user, err := getUser(c.Request.Context(), s.pgxPool, userID)
+		if err != nil && !pgxscan.NotFound(err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil || user.OrgId != currentUser.OrgID {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+	}
+
+	classes, err := listUserClasses(c.Request.Context(), s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list user classes": err.Error()})
+		return
+	}
+
+	if err := loadClassParticipants(c.Request.Context(), s.pgxPool, classes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list user classes": err.Error()})
+		return
+	}
+
+	if err := loadClassResources(c.Request.Context(), s.pgxPool, classes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list user classes": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, classes)
+}
+
+func (s *Service) ListCourseClasses(c *gin.Context, courseID string, params ListCourseClassesParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	orgID, err := getCourseOrgID(ctx, s.pgxPool, courseID)
+	if err != nil || orgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	classes, err := listCourseClasses(ctx, s.pgxPool, courseID, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list course classes": err.Error()})
+		return
+	}
+
+	if err := loadClassParticipants(ctx, s.pgxPool, classes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list course classes": err.Error()})
+		return
+	}
+
+	// Admins can see every class, everyone else only the classes they take part in.
+	if currentUser.Role != "admin" {
+		classes = participantClasses(classes, currentUser.UserID)
+	}
+
+	if err := loadClassResources(ctx, s.pgxPool, classes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list course classes": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, classes)
+}
+
+func (s *Service) GetClass(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	orgID, err := getClassOrgID(ctx, s.pgxPool, classID)
+	if err != nil || orgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	version, err := getClassVersion(ctx, s.pgxPool, classID)
+	if err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	class, err := getClassWithParticipants(ctx, s.pgxPool, classID)
+	if err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	// Admins can see every class, everyone else only the classes they take part in.
+	if currentUser.Role != "admin" && !hasClassParticipant(*class.Participants, currentUser.UserID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own classes",
+		})
+		return
+	}
+
+	if notModified(c, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, class)
+}
+
+func (s *Service) UpdateClassParticipants(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin can change class participants",
+		})
+		return
+	}
+
+	request := ClassParticipantUpdate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note := describeParticipantChanges(request)
+	if note == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "no_changes",
+			"message": "No participants provided to add or remove",
+		})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Cancelled classes must be restored before their participants can change",
+		})
+		return
+	}
+
+	if request.Teachers != nil {
+		teacherIDs := []string{}
+		if request.Teachers.Add != nil {
+			teacherIDs = append(teacherIDs, *request.Teachers.Add...)
+		}
+		if request.Teachers.Remove != nil {
+			teacherIDs = append(teacherIDs, *request.Teachers.Remove...)
+		}
+		if !checkPayrollLock(c, tx, classID, teacherIDs, class.StartTime) {
+			return
+		}
+	}
+
+	if request.Teachers != nil && request.Teachers.Add != nil {
+		violations, err := checkTutorWorkload(ctx, tx, *request.Teachers.Add, &classID, classSlot{class.StartTime, class.Duration})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(violations) > 0 {
+			respondWorkloadViolations(c, violations)
+			return
+		}
+	}
+
+	request, waitlisted, err := admitClassStudents(ctx, tx, classID, request, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(waitlisted) > 0 {
+		note = strings.TrimPrefix(describeParticipantChanges(request)+"; waitlisted students: "+strings.Join(waitlisted, ", "), "; ")
+	}
+
+	end := classEndTime(class.StartTime, class.Duration)
+	if err := updateClassParticipants(ctx, tx, classID, request, class.StartTime, end, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflicts, err := checkClassResourceCapacity(ctx, tx, classID, class.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(conflicts) > 0 {
+		respondResourceConflicts(c, conflicts)
+		return
+	}
+
+	target := classWaitlist(classID)
+	if request.Students != nil && request.Students.Add != nil {
+		if err := resolveWaitlistEntries(ctx, tx, target, *request.Students.Add, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := addToWaitlist(ctx, tx, class.OrgID, target, waitlisted, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Removed students free their seats for the waitlist.
+	if err := offerOpenSeats(ctx, tx, target, s.waitlistOfferTTL, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionParticipantsUpdated,
+		StartTime: class.StartTime,
+		Duration:  class.Duration,
+		Note:      &note,
+		ActorId:   &currentUser.UserID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassParticipantsUpdated, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassRemovals(ctx, tx, classID, removedParticipants(request), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassParticipantsUpdated, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassParticipantsUpdated, removedParticipants(request), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+func (s *Service) RescheduleClass(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin can reschedule classes",
+		})
+		return
+	}
+
+	request := ClassReschedule{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Duration != nil && *request.Duration <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "duration must be greater than zero"})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Cancelled classes must be restored before they can be rescheduled",
+		})
+		return
+	}
+
+	if !checkPayrollLock(c, tx, classID, nil, class.StartTime, request.StartTime) {
+		return
+	}
+
+	duration := class.Duration
+	if request.Duration != nil {
+		duration = *request.Duration
+	}
+
+	slot := classSlot{request.StartTime, duration}
+	violations, err := checkClassTeachersWorkload(ctx, tx, classID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(violations) > 0 {
+		respondWorkloadViolations(c, violations)
+		return
+	}
+
+	participantConflicts, err := checkParticipantConflicts(ctx, tx, classID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(participantConflicts) > 0 {
+		respondParticipantConflicts(c, participantConflicts)
+		return
+	}
+
+	if !checkBlackouts(c, tx, class.OrgID, []TimeInterval{{slot.StartTime, slot.endTime()}}) {
+		return
+	}
+
+	conflicts, err := checkClassResources(ctx, tx, classID, class.OrgID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(conflicts) > 0 {
+		respondResourceConflicts(c, conflicts)
+		return
+	}
+
+	// The class already counts toward the month it is in, so only a move
+	// into another month adds to that month's quota.
+	newMonth, _ := calendarMonth(slot.StartTime)
+	oldMonth, _ := calendarMonth(class.StartTime)
+	if !newMonth.Equal(oldMonth) {
+		if err := checkOrgQuota(ctx, tx, class.OrgID, quotaMonthlyClasses, 1, slot.StartTime); err != nil {
+			respondQuotaError(c, err)
+			return
+		}
+	}
+
+	// Release the old slot before the class moves so tracker counts and
+	// availability reflect only the new time.
+	if err := releaseClassSlot(ctx, tx, classID, class.StartTime, classEndTime(class.StartTime, class.Duration), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := updateClassSchedule(ctx, tx, classID, request.StartTime, duration, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := scheduleClassSlot(ctx, tx, classID, request.StartTime, classEndTime(request.StartTime, duration), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := updateClassReservations(ctx, tx, classID, slot, true); err != nil {
+		respondReservationError(c, err)
+		return
+	}
+
+	history := ClassHistoryEntry{
+		HistoryId:         uuid.New().String(),
+		ClassId:           classID,
+		Action:            ClassHistoryEntryActionRescheduled,
+		PreviousStartTime: &class.StartTime,
+		PreviousDuration:  &class.Duration,
+		StartTime:         request.StartTime,
+		Duration:          duration,
+		Note:              request.Note,
+		ActorId:           &currentUser.UserID,
+		CreatedAt:         now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	previousSchedule := map[string]any{
+		"previous_start_time": class.StartTime,
+		"previous_duration":   class.Duration,
+	}
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassRescheduled, previousSchedule, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassRescheduled, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassRescheduled, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+func (s *Service) CancelClass(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin can cancel classes",
+		})
+		return
+	}
+
+	request := ClassCancellation{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isValidCancellationReason(request.ReasonCode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reason_code"})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Class is already cancelled",
+		})
+		return
+	}
+
+	if !checkPayrollLock(c, tx, classID, nil, class.StartTime) {
+		return
+	}
+
+	if err := releaseClassSlot(ctx, tx, classID, class.StartTime, classEndTime(class.StartTime, class.Duration), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, cancelClassSQL, classID, request.ReasonCode, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Cancelled classes give up their resources but remember them in case
+	// the class is restored.
+	if err := updateClassReservations(ctx, tx, classID, classSlot{class.StartTime, class.Duration}, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	history := ClassHistoryEntry{
+		HistoryId:  uuid.New().String(),
+		ClassId:    classID,
+		Action:     ClassHistoryEntryActionCancelled,
+		StartTime:  class.StartTime,
+		Duration:   class.Duration,
+		ReasonCode: &request.ReasonCode,
+		Note:       request.Note,
+		ActorId:    &currentUser.UserID,
+		CreatedAt:  now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassCancelled, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassCancelled, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassCancelled, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+func (s *Service) RestoreClass(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin can restore classes",
+		})
+		return
+	}
+
+	// The request body is optional and only carries a note.
+	request := ClassRestore{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status != string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_not_cancelled",
+			"message": "Only cancelled classes can be restored",
+		})
+		return
+	}
+
+	if !checkPayrollLock(c, tx, classID, nil, class.StartTime) {
+		return
+	}
+
+	slot := classSlot{class.StartTime, class.Duration}
+	violations, err := checkClassTeachersWorkload(ctx, tx, classID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(violations) > 0 {
+		respondWorkloadViolations(c, violations)
+		return
+	}
+
+	participantConflicts, err := checkParticipantConflicts(ctx, tx, classID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(participantConflicts) > 0 {
+		respondParticipantConflicts(c, participantConflicts)
+		return
+	}
+
+	if !checkBlackouts(c, tx, class.OrgID, []TimeInterval{{slot.StartTime, slot.endTime()}}) {
+		return
+	}
+
+	conflicts, err := checkClassResources(ctx, tx, classID, class.OrgID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(conflicts) > 0 {
+		respondResourceConflicts(c, conflicts)
+		return
+	}
+
+	// Cancelled classes do not count toward the monthly quota, so restoring
+	// one adds to its month again.
+	if err := checkOrgQuota(ctx, tx, class.OrgID, quotaMonthlyClasses, 1, class.StartTime); err != nil {
+		respondQuotaError(c, err)
+		return
+	}
+
+	if _, err := tx.Exec(ctx, restoreClassSQL, classID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := updateClassReservations(ctx, tx, classID, slot, true); err != nil {
+		respondReservationError(c, err)
+		return
+	}
+
+	if err := scheduleClassSlot(ctx, tx, classID, class.StartTime, classEndTime(class.StartTime, class.Duration), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionRestored,
+		StartTime: class.StartTime,
+		Duration:  class.Duration,
+		Note:      request.Note,
+		ActorId:   &currentUser.UserID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassRestored, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassRestored, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassRestored, nil, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+func (s *Service) ListClassHistory(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	orgID, err := getClassOrgID(ctx, s.pgxPool, classID)
+	if err != nil || orgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	participants, err := getClassParticipants(ctx, s.pgxPool, []string{classID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Admins can see every class, everyone else only the classes they take part in.
+	if currentUser.Role != "admin" && !hasClassParticipant(classParticipants(participants), currentUser.UserID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view the history of your own classes",
+		})
+		return
+	}
+
+	history, err := listClassHistory(ctx, s.pgxPool, classID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (s *Service) ListClassAttendance(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	orgID, err := getClassOrgID(ctx, s.pgxPool, classID)
+	if err != nil || orgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	attendance, err := listClassAttendance(ctx, s.pgxPool, classID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Admins can see every class, everyone else only the classes they take part in.
+	if currentUser.Role != "admin" && !hasAttendanceParticipant(attendance, currentUser.UserID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view attendance of your own classes",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+func (s *Service) RecordClassAttendance(c *gin.Context, classID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	request := ClassAttendanceUpdate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var (
-		// Hard coded org id here for now.
-		orgID   = "00000000-0000-0000-0000-000000000001"
-		classID = uuid.New().String()
-		now     = time.Now()
+		ctx = c.Request.Context()
+		now = time.Now()
 	)
 
-	err = createClass(c.Request.Context(), s.pgxPool, createClassRequest, classID, orgID, now)
+	tx, err := s.pgxPool.Begin(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Class": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
 
-	classParticipantsErr := createClassParticipants(c.Request.Context(), s.pgxPool, createClassRequest, classID, orgID, now)
-	if classParticipantsErr != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"Failed to add class participants": classParticipantsErr.Error()})
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	participantRecords, err := getClassParticipants(ctx, tx, []string{classID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	participants := classParticipants(participantRecords)
+
+	// Attendance is taken by admins or by the teachers of the class.
+	if role, ok := classParticipantRole(participants, currentUser.UserID); currentUser.Role != "admin" && (!ok || role != ClassParticipantRoleTeacher) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin or the class teachers can record attendance",
+		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Class created successfully"})
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Attendance cannot be recorded for a cancelled class",
+		})
+		return
+	}
+
+	// Teacher attendance decides tutor pay, so it is locked with payroll.
+	teacherRecord := false
+	for _, record := range request.Records {
+		if !hasClassParticipant(participants, record.UserId) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "not_a_participant",
+				"message": "User " + record.UserId + " is not a participant of the class",
+			})
+			return
+		}
+
+		if role, _ := classParticipantRole(participants, record.UserId); role == ClassParticipantRoleTeacher {
+			teacherRecord = true
+		}
+	}
+
+	if teacherRecord && !checkPayrollLock(c, tx, classID, nil, class.StartTime) {
+		return
+	}
+
+	for _, record := range request.Records {
+		if _, err := tx.Exec(ctx, recordClassAttendanceSQL, classID, record.UserId, record.Attended, record.Notes, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	event := map[string]any{
+		"class_id": classID,
+		"records":  request.Records,
+	}
+	if err := webhooks.Enqueue(ctx, tx, class.OrgID, webhooks.EventAttendanceRecorded, event, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.Publish(ctx, tx, class.OrgID, live.EventAttendanceRecorded, participantIDs(participants), event, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := listClassAttendance(ctx, s.pgxPool, classID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
 }
 
-func (s *Service) ListUserClasses(c *gin.Context, userID string) {
-	classes, err := listUserClasses(c.Request.Context(), s.pgxPool, userID)
+// respondWithClass writes the current state of a class, including its
+// participants, as the response body, with its ETag.
+func (s *Service) respondWithClass(c *gin.Context, classID string, status int) {
+	version, err := getClassVersion(c.Request.Context(), s.pgxPool, classID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"Failed to list user classes": err.Error()})
+		s.respondClassLookupError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, classes)
+	class, err := getClassWithParticipants(c.Request.Context(), s.pgxPool, classID)
+	if err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	c.Header("ETag", versionETag(version))
+	c.JSON(status, class)
 }
 
-func (s *Service) ListCourseClasses(c *gin.Context, courseID string) {
+func (s *Service) respondClassLookupError(c *gin.Context, err error) {
+	if err == nil || pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "class_not_found",
+			"message": "Class not found",
+		})
+		return
+	}
 
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
 
+//go:embed queries/attendance/list_class_attendance.sql
+var queryListClassAttendanceSQL string
+
+//go:embed queries/attendance/record_class_attendance.sql
+var recordClassAttendanceSQL string
+
 //go:embed queries/class/list_user_classes.sql
 var queryListUserClassesSQL string
 
+//go:embed queries/class/get_class_org.sql
+var queryGetClassOrgSQL string
+
+//go:embed queries/class/get_class_version.sql
+var queryGetClassVersionSQL string
+
 //go:embed queries/class/create_class.sql
 var createClassSQL string
 
 //go:embed queries/class/create_class_participants.sql
 var createClassParticipantsSQL string
 
+//go:embed queries/class/get_class.sql
+var queryGetClassSQL string
+
+//go:embed queries/class/get_class_for_update.sql
+var queryGetClassForUpdateSQL string
+
+//go:embed queries/class/get_class_participants.sql
+var queryGetClassParticipantsSQL string
+
+//go:embed queries/class/list_course_classes.sql
+var queryListCourseClassesSQL string
+
+//go:embed queries/class/add_class_participant.sql
+var addClassParticipantSQL string
+
+//go:embed queries/class/remove_class_participant.sql
+var removeClassParticipantSQL string
+
+//go:embed queries/class/set_user_availability_matched.sql
+var setUserAvailabilityMatchedSQL string
+
+//go:embed queries/class/update_class_schedule.sql
+var updateClassScheduleSQL string
+
+//go:embed queries/class/cancel_class.sql
+var cancelClassSQL string
+
+//go:embed queries/class/restore_class.sql
+var restoreClassSQL string
+
+//go:embed queries/class/create_class_history.sql
+var createClassHistorySQL string
+
+//go:embed queries/class/list_class_history.sql
+var queryListClassHistorySQL string
+
+//go:embed queries/class/link_class_trackers.sql
+var linkClassTrackersSQL string
+
+//go:embed queries/class/unlink_class_trackers.sql
+var unlinkClassTrackersSQL string
+
+//go:embed queries/class/set_class_availability_matched.sql
+var setClassAvailabilityMatchedSQL string
+
+//go:embed queries/class/release_class_availability.sql
+var releaseClassAvailabilitySQL string
+
+//go:embed queries/class/release_user_availability.sql
+var releaseUserAvailabilitySQL string
+
+//go:embed queries/class/list_participant_conflicts.sql
+var queryListParticipantConflictsSQL string
+
+// classRecord is a row of the classes table as needed for lifecycle changes.
+type classRecord struct {
+	ClassID      string
+	CourseID     *string
+	OrgID        string
+	StartTime    time.Time
+	Duration     int
+	Status       string
+	CancelReason *string
+	Version      int64
+}
+
+// classParticipantRecord is a participant row tagged with the class it
+// belongs to, so participants of many classes can be loaded at once.
+type classParticipantRecord struct {
+	ClassID string
+	ClassParticipant
+}
+
 func listUserClasses(ctx context.Context, pgxPool *pgxpool.Pool, userID string) ([]Class, error) {
 	classes := []Class{}
 	return classes, pgxscan.Select(ctx, pgxPool, &classes, queryListUserClassesSQL, userID)
 }
 
-func createClass(ctx context.Context, pgxPool *pgxpool.Pool, class Class, classID, orgID string, now time.Time) error {
-	_, err := pgxPool.Exec(ctx, createClassSQL, classID, class.CourseId, orgID, class.StartTime, class.Duration, now, now)
+func getClass(ctx context.Context, db dbExecutor, classID string) (Class, error) {
+	class := Class{}
+	return class, pgxscan.Get(ctx, db, &class, queryGetClassSQL, classID)
+}
+
+func listClassAttendance(ctx context.Context, db dbExecutor, classID string) ([]ClassAttendance, error) {
+	attendance := []ClassAttendance{}
+	return attendance, pgxscan.Select(ctx, db, &attendance, queryListClassAttendanceSQL, classID)
+}
+
+func listCourseClasses(ctx context.Context, pgxPool *pgxpool.Pool, courseID string, from, to *time.Time) ([]Class, error) {
+	classes := []Class{}
+	return classes, pgxscan.Select(ctx, pgxPool, &classes, queryListCourseClassesSQL, courseID, from, to)
+}
+
+func getClassWithParticipants(ctx context.Context, db dbExecutor, classID string) (Class, error) {
+	class, err := getClass(ctx, db, classID)
+	if err != nil {
+		return class, err
+	}
+
+	classes := []Class{class}
+	if err := loadClassParticipants(ctx, db, classes); err != nil {
+		return class, err
+	}
+
+	if err := loadClassResources(ctx, db, classes); err != nil {
+		return class, err
+	}
+
+	return classes[0], nil
+}
+
+// loadClassParticipants fills in the participants of every class with a
+// single query.
+func loadClassParticipants(ctx context.Context, db dbExecutor, classes []Class) error {
+	classIDs := make([]string, 0, len(classes))
+	for _, class := range classes {
+		if class.ClassId != nil {
+			classIDs = append(classIDs, *class.ClassId)
+		}
+	}
+
+	participants, err := getClassParticipants(ctx, db, classIDs)
+	if err != nil {
+		return err
+	}
+
+	attachClassParticipants(classes, participants)
+	return nil
+}
+
+// getClassOrgID returns the organization a class belongs to.
+func getClassOrgID(ctx context.Context, db dbExecutor, classID string) (string, error) {
+	var orgID string
+	return orgID, db.QueryRow(ctx, queryGetClassOrgSQL, classID).Scan(&orgID)
+}
+
+// getClassVersion returns the row version behind the ETag of a class.
+func getClassVersion(ctx context.Context, db dbExecutor, classID string) (int64, error) {
+	var version int64
+	return version, pgxscan.Get(ctx, db, &version, queryGetClassVersionSQL, classID)
+}
+
+func getClassForUpdate(ctx context.Context, tx pgx.Tx, classID string) (classRecord, error) {
+	class := classRecord{}
+	return class, pgxscan.Get(ctx, tx, &class, queryGetClassForUpdateSQL, classID)
+}
+
+func getClassParticipants(ctx context.Context, db dbExecutor, classIDs []string) ([]classParticipantRecord, error) {
+	participants := []classParticipantRecord{}
+	return participants, pgxscan.Select(ctx, db, &participants, queryGetClassParticipantsSQL, classIDs)
+}
+
+func createClass(ctx context.Context, db dbExecutor, class Class, classID, orgID string, now time.Time) error {
+	_, err := db.Exec(ctx, createClassSQL, classID, class.CourseId, orgID, class.StartTime, class.Duration, class.MaxStudents, now, now)
 	return err
 }
 
-func createClassParticipants(ctx context.Context, pgxPool *pgxpool.Pool, class Class, classID string, orgID string, now time.Time) error {
+func createClassParticipants(ctx context.Context, db dbExecutor, class Class, classID string, orgID string, now time.Time) error {
 	batch := &pgx.Batch{}
 
 	for _, student := range class.Students {
@@ -111,7 +1386,7 @@ func createClassParticipants(ctx context.Context, pgxPool *pgxpool.Pool, class C
 		batch.Queue(createClassParticipantsSQL, classID, teacher, "teacher", now)
 	}
 
-	batchResult := pgxPool.SendBatch(ctx, batch)
+	batchResult := db.SendBatch(ctx, batch)
 	defer func() {
 		_ = batchResult.Close()
 	}()
@@ -125,3 +1400,320 @@ func createClassParticipants(ctx context.Context, pgxPool *pgxpool.Pool, class C
 
 	return nil
 }
+
+// updateClassParticipants applies participant changes to a class and keeps
+// the matched flag of the affected users' availability in sync.
+func updateClassParticipants(ctx context.Context, db dbExecutor, classID string, update ClassParticipantUpdate, start, end, now time.Time) error {
+	changes := []struct {
+		role    ClassParticipantRole
+		changes *CourseParticipantChanges
+	}{
+		{ClassParticipantRoleStudent, update.Students},
+		{ClassParticipantRoleTeacher, update.Teachers},
+	}
+
+	var added, removed []string
+	batch := &pgx.Batch{}
+	for _, change := range changes {
+		if change.changes == nil {
+			continue
+		}
+
+		if change.changes.Remove != nil {
+			for _, userID := range *change.changes.Remove {
+				batch.Queue(removeClassParticipantSQL, classID, userID, change.role)
+				removed = append(removed, userID)
+			}
+		}
+
+		if change.changes.Add != nil {
+			for _, userID := range *change.changes.Add {
+				batch.Queue(addClassParticipantSQL, classID, userID, change.role, now)
+				added = append(added, userID)
+			}
+		}
+	}
+
+	batchResult := db.SendBatch(ctx, batch)
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := batchResult.Exec(); err != nil {
+			_ = batchResult.Close()
+			return err
+		}
+	}
+	if err := batchResult.Close(); err != nil {
+		return err
+	}
+
+	if len(removed) > 0 {
+		if _, err := db.Exec(ctx, releaseUserAvailabilitySQL, removed, classID, start, end, now); err != nil {
+			return err
+		}
+	}
+
+	if len(added) > 0 {
+		if _, err := db.Exec(ctx, setUserAvailabilityMatchedSQL, added, start, end, true, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateClassSchedule(ctx context.Context, db dbExecutor, classID string, startTime time.Time, duration int, now time.Time) error {
+	_, err := db.Exec(ctx, updateClassScheduleSQL, classID, startTime, duration, now)
+	return err
+}
+
+func createClassHistory(ctx context.Context, db dbExecutor, entry ClassHistoryEntry, orgID string) error {
+	_, err := db.Exec(ctx, createClassHistorySQL,
+		entry.HistoryId,
+		entry.ClassId,
+		orgID,
+		entry.Action,
+		entry.PreviousStartTime,
+		entry.PreviousDuration,
+		entry.StartTime,
+		entry.Duration,
+		entry.ReasonCode,
+		entry.Note,
+		entry.ActorId,
+		entry.CreatedAt,
+	)
+	return err
+}
+
+func listClassHistory(ctx context.Context, db dbExecutor, classID string) ([]ClassHistoryEntry, error) {
+	history := []ClassHistoryEntry{}
+	return history, pgxscan.Select(ctx, db, &history, queryListClassHistorySQL, classID)
+}
+
+// scheduleClassSlot counts a class towards the trackers of its course and
+// marks the participants' availability in the slot as matched.
+func scheduleClassSlot(ctx context.Context, db dbExecutor, classID string, start, end, now time.Time) error {
+	if err := updateClassTrackers(ctx, db, linkClassTrackersSQL, classID, now); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(ctx, setClassAvailabilityMatchedSQL, classID, start, end, true, now)
+	return err
+}
+
+// releaseClassSlot undoes scheduleClassSlot for the slot a class is leaving.
+// Availability another scheduled class of a participant still covers stays
+// matched.
+func releaseClassSlot(ctx context.Context, db dbExecutor, classID string, start, end, now time.Time) error {
+	if err := updateClassTrackers(ctx, db, unlinkClassTrackersSQL, classID, now); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(ctx, releaseClassAvailabilitySQL, classID, start, end, now)
+	return err
+}
+
+// participantConflict is another scheduled class a participant of a class
+// takes part in during the slot the class would take.
+type participantConflict struct {
+	UserID    string    `json:"user_id"`
+	ClassID   string    `json:"class_id"`
+	StartTime time.Time `json:"start_time"`
+	Duration  int       `json:"duration"`
+}
+
+// checkParticipantConflicts lists the classes that would double-book a
+// participant of a class if it took place at slot.
+func checkParticipantConflicts(ctx context.Context, db dbExecutor, classID string, slot classSlot) ([]participantConflict, error) {
+	conflicts := []participantConflict{}
+	return conflicts, pgxscan.Select(ctx, db, &conflicts, queryListParticipantConflictsSQL, classID, slot.StartTime, slot.endTime())
+}
+
+func respondParticipantConflicts(c *gin.Context, conflicts []participantConflict) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error":     "participant_conflict",
+		"message":   "Participants of the class have another class at that time",
+		"conflicts": conflicts,
+	})
+}
+
+type trackerStatusChange struct {
+	TrackingID     string  `json:"tracking_id"`
+	CourseID       string  `json:"course_id"`
+	OrgID          string  `json:"-"`
+	PreviousStatus *string `json:"previous_status"`
+	Status         *string `json:"status"`
+}
+
+// updateClassTrackers runs a query that links or unlinks a class from its
+// trackers and sends a webhook for every tracker whose status changed.
+func updateClassTrackers(ctx context.Context, db dbExecutor, query, classID string, now time.Time) error {
+	changes := []trackerStatusChange{}
+	if err := pgxscan.Select(ctx, db, &changes, query, classID, now); err != nil {
+		return err
+	}
+
+	return enqueueTrackerStatusChanges(ctx, db, changes, now)
+}
+
+// enqueueTrackerStatusChanges sends a webhook for every tracker whose status
+// actually changed.
+func enqueueTrackerStatusChanges(ctx context.Context, db dbExecutor, changes []trackerStatusChange, now time.Time) error {
+	for _, change := range changes {
+		if trackerStatusEqual(change.PreviousStatus, change.Status) {
+			continue
+		}
+		if err := webhooks.Enqueue(ctx, db, change.OrgID, webhooks.EventTrackerStatusChanged, change, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasClassParticipant(participants []ClassParticipant, userID string) bool {
+	for _, participant := range participants {
+		if participant.UserId == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValidCancellationReason(reason ClassCancellationReason) bool {
+	switch reason {
+	case TutorUnavailable, StudentUnavailable, SchedulingConflict, Weather, Holiday, Other:
+		return true
+	}
+
+	return false
+}
+
+// attachClassParticipants sets the participant lists of each class from
+// participant rows that may belong to any of the classes.
+func attachClassParticipants(classes []Class, records []classParticipantRecord) {
+	byClass := map[string][]ClassParticipant{}
+	for _, record := range records {
+		byClass[record.ClassID] = append(byClass[record.ClassID], record.ClassParticipant)
+	}
+
+	for i := range classes {
+		if classes[i].ClassId == nil {
+			continue
+		}
+
+		participants := byClass[*classes[i].ClassId]
+		if participants == nil {
+			participants = []ClassParticipant{}
+		}
+
+		classes[i].Students = []string{}
+		classes[i].Teachers = []string{}
+		for _, participant := range participants {
+			switch participant.Role {
+			case ClassParticipantRoleStudent:
+				classes[i].Students = append(classes[i].Students, participant.UserId)
+			case ClassParticipantRoleTeacher:
+				classes[i].Teachers = append(classes[i].Teachers, participant.UserId)
+			}
+		}
+		classes[i].Participants = &participants
+	}
+}
+
+func classParticipants(records []classParticipantRecord) []ClassParticipant {
+	participants := make([]ClassParticipant, len(records))
+	for i, record := range records {
+		participants[i] = record.ClassParticipant
+	}
+
+	return participants
+}
+
+// participantClasses keeps the classes the user takes part in.
+func participantClasses(classes []Class, userID string) []Class {
+	kept := []Class{}
+	for _, class := range classes {
+		if class.Participants != nil && hasClassParticipant(*class.Participants, userID) {
+			kept = append(kept, class)
+		}
+	}
+
+	return kept
+}
+
+// describeParticipantChanges summarizes a participant update for the class
+// history, e.g. "added students: a, b; removed teachers: c".
+func describeParticipantChanges(update ClassParticipantUpdate) string {
+	var parts []string
+
+	describe := func(verb, role string, userIDs *[]string) {
+		if userIDs != nil && len(*userIDs) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s: %s", verb, role, strings.Join(*userIDs, ", ")))
+		}
+	}
+
+	if update.Students != nil {
+		describe("added", "students", update.Students.Add)
+		describe("removed", "students", update.Students.Remove)
+	}
+	if update.Teachers != nil {
+		describe("added", "teachers", update.Teachers.Add)
+		describe("removed", "teachers", update.Teachers.Remove)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// classParticipantRole returns the role a user has in a class, if any.
+func classParticipantRole(participants []ClassParticipant, userID string) (ClassParticipantRole, bool) {
+	for _, participant := range participants {
+		if participant.UserId == userID {
+			return participant.Role, true
+		}
+	}
+
+	return "", false
+}
+
+// hasAttendanceParticipant reports whether the user is one of the class
+// participants listed in its attendance.
+func hasAttendanceParticipant(attendance []ClassAttendance, userID string) bool {
+	for _, entry := range attendance {
+		if entry.UserId == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackerStatusEqual compares two nullable tracker statuses.
+func trackerStatusEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func participantIDs(participants []ClassParticipant) []string {
+	userIDs := make([]string, len(participants))
+	for i, participant := range participants {
+		userIDs[i] = participant.UserId
+	}
+
+	return userIDs
+}
+
+// removedParticipants lists the users a participant update removes from a
+// class, students first.
+func removedParticipants(update ClassParticipantUpdate) []string {
+	var removed []string
+	for _, changes := range []*CourseParticipantChanges{update.Students, update.Teachers} {
+		if changes != nil && changes.Remove != nil {
+			removed = append(removed, *changes.Remove...)
+		}
+	}
+
+	return removed
+}
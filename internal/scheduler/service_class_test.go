@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAttachClassParticipants(t *testing.T) {
+	classA, classB := "class-a", "class-b"
+	classes := []Class{{ClassId: &classA}, {ClassId: &classB}}
+	records := []classParticipantRecord{
+		{ClassID: classA, ClassParticipant: ClassParticipant{UserId: "student-1", Role: ClassParticipantRoleStudent, FirstName: "Emma", LastName: "Brown"}},
+		{ClassID: classA, ClassParticipant: ClassParticipant{UserId: "teacher-1", Role: ClassParticipantRoleTeacher, FirstName: "Sarah", LastName: "Williams"}},
+		{ClassID: classA, ClassParticipant: ClassParticipant{UserId: "student-2", Role: ClassParticipantRoleStudent, FirstName: "James", LastName: "Wilson"}},
+	}
+
+	attachClassParticipants(classes, records)
+
+	tests := []struct {
+		name             string
+		class            Class
+		expectedStudents []string
+		expectedTeachers []string
+	}{
+		{
+			name:             "class with students and teachers",
+			class:            classes[0],
+			expectedStudents: []string{"student-1", "student-2"},
+			expectedTeachers: []string{"teacher-1"},
+		},
+		{
+			name:             "class without participants",
+			class:            classes[1],
+			expectedStudents: []string{},
+			expectedTeachers: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.class.Students) != len(tt.expectedStudents) {
+				t.Fatalf("expected %d students, got %d", len(tt.expectedStudents), len(tt.class.Students))
+			}
+			for i := range tt.class.Students {
+				if tt.class.Students[i] != tt.expectedStudents[i] {
+					t.Errorf("student %d: expected %s, got %s", i, tt.expectedStudents[i], tt.class.Students[i])
+				}
+			}
+
+			if len(tt.class.Teachers) != len(tt.expectedTeachers) {
+				t.Fatalf("expected %d teachers, got %d", len(tt.expectedTeachers), len(tt.class.Teachers))
+			}
+			for i := range tt.class.Teachers {
+				if tt.class.Teachers[i] != tt.expectedTeachers[i] {
+					t.Errorf("teacher %d: expected %s, got %s", i, tt.expectedTeachers[i], tt.class.Teachers[i])
+				}
+			}
+
+			if tt.class.Participants == nil {
+				t.Fatal("expected participants to be set")
+			}
+			if len(*tt.class.Participants) != len(tt.expectedStudents)+len(tt.expectedTeachers) {
+				t.Errorf("expected %d participants, got %d",
+					len(tt.expectedStudents)+len(tt.expectedTeachers), len(*tt.class.Participants))
+			}
+		})
+	}
+}
+
+func TestParticipantClasses(t *testing.T) {
+	classA, classB, classC := "class-a", "class-b", "class-c"
+	classes := []Class{{ClassId: &classA}, {ClassId: &classB}, {ClassId: &classC}}
+	attachClassParticipants(classes[:2], []classParticipantRecord{
+		{ClassID: classA, ClassParticipant: ClassParticipant{UserId: "student-1", Role: ClassParticipantRoleStudent}},
+		{ClassID: classB, ClassParticipant: ClassParticipant{UserId: "student-2", Role: ClassParticipantRoleStudent}},
+	})
+
+	kept := participantClasses(classes, "student-1")
+	if len(kept) != 1 || *kept[0].ClassId != classA {
+		t.Fatalf("expected only %s, got %d classes", classA, len(kept))
+	}
+
+	if kept := participantClasses(classes, "student-3"); len(kept) != 0 {
+		t.Errorf("expected no classes, got %d", len(kept))
+	}
+}
+
+func TestDescribeParticipantChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   ClassParticipantUpdate
+		expected string
+	}{
+		{
+			name:     "no changes",
+			update:   ClassParticipantUpdate{},
+			expected: "",
+		},
+		{
+			name: "students and teachers",
+			update: ClassParticipantUpdate{
+				Students: &CourseParticipantChanges{
+					Add:    &[]string{"student-1", "student-2"},
+					Remove: &[]string{"student-3"},
+				},
+				Teachers: &CourseParticipantChanges{
+					Remove: &[]string{"teacher-1"},
+				},
+			},
+			expected: "added students: student-1, student-2; removed students: student-3; removed teachers: teacher-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := describeParticipantChanges(tt.update); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParticipantIDs(t *testing.T) {
+	participants := []ClassParticipant{
+		{UserId: "student-1", Role: ClassParticipantRoleStudent},
+		{UserId: "teacher-1", Role: ClassParticipantRoleTeacher},
+	}
+	expected := []string{"student-1", "teacher-1"}
+	if userIDs := participantIDs(participants); !slices.Equal(userIDs, expected) {
+		t.Errorf("expected %v, got %v", expected, userIDs)
+	}
+}
+
+func TestRemovedParticipants(t *testing.T) {
+	if removed := removedParticipants(ClassParticipantUpdate{}); removed != nil {
+		t.Errorf("expected no removed participants, got %v", removed)
+	}
+
+	update := ClassParticipantUpdate{
+		Students: &CourseParticipantChanges{
+			Add:    &[]string{"student-1"},
+			Remove: &[]string{"student-2", "student-3"},
+		},
+		Teachers: &CourseParticipantChanges{
+			Remove: &[]string{"teacher-1"},
+		},
+	}
+	expected := []string{"student-2", "student-3", "teacher-1"}
+	if removed := removedParticipants(update); !slices.Equal(removed, expected) {
+		t.Errorf("expected %v, got %v", expected, removed)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"net/http"
 	"scheduler-api/internal/auth"
+	"scheduler-api/internal/webhooks"
 	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -46,61 +47,125 @@ func (s *Service) CreateCourse(c *gin.Context) {
 		return
 	}
 
+	if createCourseRequest.MaxStudents != nil && *createCourseRequest.MaxStudents < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_students must be at least 1"})
+		return
+	}
+
 	var (
-		// Hard coded org id here for now.
-		orgID = "00000000-0000-0000-0000-000000000001"
+		orgID = currentUser.OrgID
 		now   = time.Now()
+		ctx   = c.Request.Context()
 	)
 
-	err = createCourse(c.Request.Context(), s.pgxPool, createCourseRequest, orgID, now)
+	// Students past the course capacity join its waitlist in the order given.
+	students, waitlisted := splitBySeats(createCourseRequest.MaxStudents, createCourseRequest.Students)
+	createCourseRequest.Students = students
+
+	tx, err := s.pgxPool.Begin(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Course": err.Error()})
 		return
 	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
 
-	courseParticipantsError := addCourseParticipants(c.Request.Context(), s.pgxPool, createCourseRequest, now)
+	if err := checkOrgQuota(ctx, tx, orgID, quotaCourses, 1, now); err != nil {
+		respondQuotaError(c, err)
+		return
+	}
+
+	err = createCourse(ctx, tx, createCourseRequest, orgID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Course": err.Error()})
+		return
+	}
+
+	courseParticipantsError := addCourseParticipants(ctx, tx, createCourseRequest, now)
 
 	if courseParticipantsError != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"Failed to add course participants": courseParticipantsError.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Course created successfully"})
+	if err := addToWaitlist(ctx, tx, orgID, courseWaitlist(createCourseRequest.CourseId), waitlisted, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to add course participants": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"Failed to create Course": err.Error()})
+		return
+	}
+
+	if waitlisted == nil {
+		waitlisted = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Course created successfully", "waitlisted": waitlisted})
 }
 
 func (s *Service) GetCourse(c *gin.Context, courseID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	orgID, err := getCourseOrgID(c.Request.Context(), s.pgxPool, courseID)
+	if err != nil || orgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	var version int64
+	if err := pgxscan.Get(c.Request.Context(), s.pgxPool, &version, queryGetCourseVersionSQL, courseID); err != nil {
+		respondCourseLookupError(c, err)
+		return
+	}
+
 	course, err := getCourse(c.Request.Context(), s.pgxPool, courseID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	users, err := getCourseUsers(c.Request.Context(), s.pgxPool, courseID)
-	if err != nil {
+	if err := setCourseUsers(c.Request.Context(), s.pgxPool, &course); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	course.Students = users
+	if notModified(c, version) {
+		return
+	}
+
 	c.JSON(http.StatusOK, course)
 }
 
 func (s *Service) ListCourses(c *gin.Context) {
-	organizationID := "00000000-0000-0000-0000-000000000001"
-	courses, err := listCourses(c.Request.Context(), s.pgxPool, organizationID)
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	courses, err := listCourses(c.Request.Context(), s.pgxPool, currentUser.OrgID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	for i := range courses {
-		users, err := getCourseUsers(c.Request.Context(), s.pgxPool, courses[i].CourseId)
-		if err != nil {
+		if err := setCourseUsers(c.Request.Context(), s.pgxPool, &courses[i]); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-
-		courses[i].Students = users
 	}
 
 	c.JSON(http.StatusOK, courses)
@@ -119,7 +184,7 @@ func (s *Service) UpdateCourse(c *gin.Context, courseID string) {
 	if currentUser.Role != "admin" {
 		c.JSON(http.StatusForbidden, gin.H{
 			"error":   "forbidden",
-			"message": "Only admin can create courses",
+			"message": "Only admin can update courses",
 		})
 		return
 	}
@@ -130,31 +195,126 @@ func (s *Service) UpdateCourse(c *gin.Context, courseID string) {
 		return
 	}
 
+	if updateRequest.MaxStudents != nil && *updateRequest.MaxStudents < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_students cannot be negative"})
+		return
+	}
+
 	// Validate course ID format
 	if courseID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Course ID is required"})
 		return
 	}
 
-	now := time.Now()
-	err = updateCourse(c.Request.Context(), s.pgxPool, courseID, updateRequest, now)
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var version int64
+	if err := pgxscan.Get(ctx, tx, &version, lockCourseVersionSQL, courseID); err != nil {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	courseOrgID, err := getCourseOrgID(ctx, tx, courseID)
+	if err != nil || courseOrgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, version, "The course") {
+		return
+	}
+
+	orgID, err := updateCourse(ctx, tx, courseID, updateRequest, now)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Course updated successfully"})
+	// Drop first so the seats removed students free can go to the ones added,
+	// and offer what is left to the waitlist once both are done.
+	participantChanges := []struct {
+		role    EnrollmentRole
+		changes *CourseParticipantChanges
+	}{
+		{EnrollmentRoleStudent, updateRequest.Students},
+		{EnrollmentRoleTeacher, updateRequest.Teachers},
+	}
+	for _, change := range participantChanges {
+		if change.changes == nil || change.changes.Remove == nil {
+			continue
+		}
+		if err := dropUsers(ctx, tx, courseID, *change.changes.Remove, change.role, currentUser.UserID, s.waitlistOfferTTL, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	waitlisted := []string{}
+	for _, change := range participantChanges {
+		if change.changes == nil || change.changes.Add == nil {
+			continue
+		}
+		result, err := enrollUsers(ctx, tx, courseID, *change.changes.Add, &change.role, currentUser.UserID, now)
+		if err != nil {
+			respondEnrollmentError(c, err)
+			return
+		}
+		waitlisted = append(waitlisted, result.Waitlisted...)
+	}
+
+	// Seats freed by drops or a raised capacity go to the waitlist.
+	if err := offerOpenSeats(ctx, tx, courseWaitlist(courseID), s.waitlistOfferTTL, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	event := map[string]any{
+		"course_id": courseID,
+		"changes":   updateRequest,
+	}
+	if err := webhooks.Enqueue(ctx, tx, orgID, webhooks.EventCourseUpdated, event, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Course updated successfully", "waitlisted": waitlisted})
 }
 
 //go:embed queries/course/get_course.sql
 var queryGetCourseSQL string
 
+//go:embed queries/course/get_course_org.sql
+var queryGetCourseOrgSQL string
+
 //go:embed queries/course/list_courses.sql
 var queryListCoursesSQL string
 
 //go:embed queries/course/get_course_users.sql
 var queryGetCourseUsersSQL string
 
+//go:embed queries/course/get_course_version.sql
+var queryGetCourseVersionSQL string
+
+//go:embed queries/course/lock_course_version.sql
+var lockCourseVersionSQL string
+
 //go:embed queries/course/create_course.sql
 var createCourseSql string
 
@@ -169,9 +329,24 @@ func listCourses(ctx context.Context, pgxPool *pgxpool.Pool, organizationID stri
 	return courses, pgxscan.Select(ctx, pgxPool, &courses, queryListCoursesSQL, organizationID)
 }
 
-func getCourseUsers(ctx context.Context, pgxPool *pgxpool.Pool, courseID string) ([]string, error) {
+func getCourseUsers(ctx context.Context, pgxPool *pgxpool.Pool, courseID string, role EnrollmentRole) ([]string, error) {
 	users := []string{}
-	return users, pgxscan.Select(ctx, pgxPool, &users, queryGetCourseUsersSQL, courseID)
+	return users, pgxscan.Select(ctx, pgxPool, &users, queryGetCourseUsersSQL, courseID, role)
+}
+
+// setCourseUsers fills in the active students and tutors of a course.
+func setCourseUsers(ctx context.Context, pgxPool *pgxpool.Pool, course *Course) error {
+	students, err := getCourseUsers(ctx, pgxPool, course.CourseId, EnrollmentRoleStudent)
+	if err != nil {
+		return err
+	}
+	tutors, err := getCourseUsers(ctx, pgxPool, course.CourseId, EnrollmentRoleTeacher)
+	if err != nil {
+		return err
+	}
+	course.Students = students
+	course.Tutors = tutors
+	return nil
 }
 
 func getCourse(ctx context.Context, pgxPool *pgxpool.Pool, courseID string) (Course, error) {
@@ -179,17 +354,23 @@ func getCourse(ctx context.Context, pgxPool *pgxpool.Pool, courseID string) (Cou
 	return course, pgxscan.Get(ctx, pgxPool, &course, queryGetCourseSQL, courseID)
 }
 
-func createCourse(ctx context.Context, pgxPool *pgxpool.Pool, course Course, orgId string, now time.Time) error {
-	_, err := pgxPool.Exec(ctx, createCourseSql, course.CourseId, orgId, course.CourseName, course.CourseDescription, course.StartAt, course.EndAt, course.Interval, course.Frequency, now)
-	return err
+func getCourseOrgID(ctx context.Context, db dbExecutor, courseID string) (string, error) {
+	var orgID string
+	return orgID, db.QueryRow(ctx, queryGetCourseOrgSQL, courseID).Scan(&orgID)
 }
 
-func updateCourse(ctx context.Context, pgxPool *pgxpool.Pool, courseID string, update CourseUpdate, now time.Time) error {
-	_, err := pgxPool.Exec(ctx, updateCourseSQL, courseID, update.CourseName, nil, update.StartAt, update.EndAt, update.Interval, update.Frequency, now)
+func createCourse(ctx context.Context, db dbExecutor, course Course, orgId string, now time.Time) error {
+	_, err := db.Exec(ctx, createCourseSql, course.CourseId, orgId, course.CourseName, course.CourseDescription, course.StartAt, course.EndAt, course.Interval, course.Frequency, course.MaxStudents, now)
 	return err
 }
 
-func addCourseParticipants(ctx context.Context, pgxPool *pgxpool.Pool, course Course, now time.Time) error {
+func updateCourse(ctx context.Context, db dbExecutor, courseID string, update CourseUpdate, now time.Time) (string, error) {
+	var orgID string
+	err := db.QueryRow(ctx, updateCourseSQL, courseID, update.CourseName, update.CourseDescription, update.StartAt, update.EndAt, update.Interval, update.Frequency, now, update.MaxStudents).Scan(&orgID)
+	return orgID, err
+}
+
+func addCourseParticipants(ctx context.Context, db dbExecutor, course Course, now time.Time) error {
 	batch := &pgx.Batch{}
 
 	for _, student := range course.Students {
@@ -200,7 +381,7 @@ func addCourseParticipants(ctx context.Context, pgxPool *pgxpool.Pool, course Co
 		batch.Queue(addCourseParticipantSQL, tutor, course.CourseId, "teacher", now)
 	}
 
-	batchResult := pgxPool.SendBatch(ctx, batch)
+	batchResult := db.SendBatch(ctx, batch)
 	defer func() {
 		_ = batchResult.Close()
 	}()
@@ -0,0 +1,654 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/webhooks"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type EnrollmentService interface {
+	ListCourseEnrollments(*gin.Context, string, ListCourseEnrollmentsParams)
+	EnrollCourseUser(*gin.Context, string)
+	BulkEnrollCourseUsers(*gin.Context, string)
+	DropCourseEnrollment(*gin.Context, string, string)
+	CompleteCourseEnrollment(*gin.Context, string, string)
+	ReactivateCourseEnrollment(*gin.Context, string, string)
+	ListEnrollmentHistory(*gin.Context, string, string)
+}
+
+var _ EnrollmentService = (*Service)(nil)
+
+// errNotEnrollable is returned for users that cannot be enrolled in a course:
+// unknown users, users of other organizations, admins, and users listed
+// under the wrong role.
+var errNotEnrollable = errors.New("user cannot be enrolled in this course")
+
+func (s *Service) ListCourseEnrollments(c *gin.Context, courseID string, params ListCourseEnrollmentsParams) {
+	currentUser, ok := s.requireAdmin(c, "view enrollments")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	orgID, err := getCourseOrgID(ctx, s.pgxPool, courseID)
+	if err != nil || orgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	enrollments := []Enrollment{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &enrollments, queryListEnrollmentsSQL, courseID, params.Status); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollments)
+}
+
+func (s *Service) EnrollCourseUser(c *gin.Context, courseID string) {
+	request := EnrollmentCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, ok := s.enroll(c, courseID, []string{request.UserId})
+	if !ok {
+		return
+	}
+
+	if len(result.Enrolled) == 0 && len(result.Waitlisted) == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "already_enrolled",
+			"message": "User is already enrolled in this course",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (s *Service) BulkEnrollCourseUsers(c *gin.Context, courseID string) {
+	request := EnrollmentBatch{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(request.UserIds) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one user_id is required"})
+		return
+	}
+
+	result, ok := s.enroll(c, courseID, request.UserIds)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// enroll enrolls users in a course in their own transaction and writes the
+// error response if that fails.
+func (s *Service) enroll(c *gin.Context, courseID string, userIDs []string) (EnrollmentResult, bool) {
+	currentUser, ok := s.requireAdmin(c, "enroll users")
+	if !ok {
+		return EnrollmentResult{}, false
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return EnrollmentResult{}, false
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	orgID, err := getCourseOrgID(ctx, tx, courseID)
+	if err != nil || orgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return EnrollmentResult{}, false
+	}
+
+	result, err := enrollUsers(ctx, tx, courseID, userIDs, nil, currentUser.UserID, now)
+	if err != nil {
+		respondEnrollmentError(c, err)
+		return EnrollmentResult{}, false
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return EnrollmentResult{}, false
+	}
+
+	return result, true
+}
+
+func (s *Service) DropCourseEnrollment(c *gin.Context, courseID, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only drop your own enrollment",
+		})
+		return
+	}
+
+	request := EnrollmentDrop{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	s.changeEnrollment(c, currentUser.OrgID, courseID, userID, func(ctx context.Context, tx pgx.Tx, orgID string, enrollment Enrollment, now time.Time) (Enrollment, error) {
+		dropped, err := dropEnrollment(ctx, tx, orgID, enrollment, request.Reason, currentUser.UserID, s.waitlistOfferTTL, now)
+		if err != nil {
+			return Enrollment{}, err
+		}
+
+		if dropped.Role == EnrollmentRoleStudent {
+			if err := offerOpenSeats(ctx, tx, courseWaitlist(enrollment.CourseId), s.waitlistOfferTTL, now); err != nil {
+				return Enrollment{}, err
+			}
+		}
+
+		return dropped, nil
+	})
+}
+
+func (s *Service) CompleteCourseEnrollment(c *gin.Context, courseID, userID string) {
+	currentUser, ok := s.requireAdmin(c, "complete enrollments")
+	if !ok {
+		return
+	}
+
+	s.changeEnrollment(c, currentUser.OrgID, courseID, userID, func(ctx context.Context, tx pgx.Tx, orgID string, enrollment Enrollment, now time.Time) (Enrollment, error) {
+		completed, err := setEnrollmentStatus(ctx, tx, orgID, enrollment, EnrollmentStatusCompleted, nil, currentUser.UserID, now)
+		if err != nil {
+			return Enrollment{}, err
+		}
+
+		// A student who completed the course no longer takes a seat.
+		if completed.Role == EnrollmentRoleStudent {
+			if err := offerOpenSeats(ctx, tx, courseWaitlist(enrollment.CourseId), s.waitlistOfferTTL, now); err != nil {
+				return Enrollment{}, err
+			}
+		}
+
+		return completed, nil
+	})
+}
+
+// changeEnrollment locks an active enrollment in a course of the
+// organization, applies change to it and responds with the result.
+func (s *Service) changeEnrollment(c *gin.Context, orgID, courseID, userID string, change func(context.Context, pgx.Tx, string, Enrollment, time.Time) (Enrollment, error)) {
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	capacity, enrollment, ok := lockEnrollment(c, tx, orgID, courseID, userID, now)
+	if !ok {
+		return
+	}
+
+	if enrollment.Status != EnrollmentStatusActive {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "enrollment_not_active",
+			"message": "Enrollment is already " + string(enrollment.Status),
+		})
+		return
+	}
+
+	enrollment, err = change(ctx, tx, capacity.OrgID, enrollment, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func (s *Service) ReactivateCourseEnrollment(c *gin.Context, courseID, userID string) {
+	currentUser, ok := s.requireAdmin(c, "re-activate enrollments")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	capacity, enrollment, ok := lockEnrollment(c, tx, currentUser.OrgID, courseID, userID, now)
+	if !ok {
+		return
+	}
+
+	if enrollment.Status == EnrollmentStatusActive {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "enrollment_active",
+			"message": "Enrollment is already active",
+		})
+		return
+	}
+
+	if free := capacity.freeSeats(); enrollment.Role == EnrollmentRoleStudent && free != nil && *free == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "course_full",
+			"message": "Course is full",
+		})
+		return
+	}
+
+	enrollment, err = activateEnrollment(ctx, tx, capacity.OrgID, userID, courseID, enrollment.Role, &enrollment.Status, currentUser.UserID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := resolveWaitlistEntries(ctx, tx, courseWaitlist(courseID), []string{userID}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func (s *Service) ListEnrollmentHistory(c *gin.Context, courseID, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own enrollments",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	orgID, err := getCourseOrgID(ctx, s.pgxPool, courseID)
+	if err != nil || orgID != currentUser.OrgID {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	history := []EnrollmentHistoryEntry{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &history, queryListEnrollmentHistorySQL, courseID, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func respondEnrollmentError(c *gin.Context, err error) {
+	if errors.Is(err, errNotEnrollable) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_enrollable",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	respondCourseLookupError(c, err)
+}
+
+// lockEnrollment locks a course of the organization and one of its
+// enrollments, writing a 404 if either is missing.
+func lockEnrollment(c *gin.Context, tx pgx.Tx, orgID, courseID, userID string, now time.Time) (seatCapacity, Enrollment, bool) {
+	ctx := c.Request.Context()
+
+	capacity, err := courseWaitlist(courseID).capacity(ctx, tx, now)
+	if err != nil || capacity.OrgID != orgID {
+		respondCourseLookupError(c, err)
+		return seatCapacity{}, Enrollment{}, false
+	}
+
+	enrollment, err := getEnrollmentForUpdate(ctx, tx, courseID, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "enrollment_not_found",
+				"message": "Enrollment not found",
+			})
+			return seatCapacity{}, Enrollment{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return seatCapacity{}, Enrollment{}, false
+	}
+
+	return capacity, enrollment, true
+}
+
+//go:embed queries/enrollment/list_enrollments.sql
+var queryListEnrollmentsSQL string
+
+//go:embed queries/enrollment/get_enrollment_for_update.sql
+var queryGetEnrollmentForUpdateSQL string
+
+//go:embed queries/enrollment/activate_enrollment.sql
+var activateEnrollmentSQL string
+
+//go:embed queries/enrollment/set_enrollment_status.sql
+var setEnrollmentStatusSQL string
+
+//go:embed queries/enrollment/create_enrollment_history.sql
+var createEnrollmentHistorySQL string
+
+//go:embed queries/enrollment/list_enrollment_history.sql
+var queryListEnrollmentHistorySQL string
+
+//go:embed queries/enrollment/list_future_enrolled_classes.sql
+var queryListFutureEnrolledClassesSQL string
+
+// dropReasonRemoved is recorded when an admin removes a user through a
+// course update.
+const dropReasonRemoved = "removed from course"
+
+func getEnrollmentForUpdate(ctx context.Context, db dbExecutor, courseID, userID string) (Enrollment, error) {
+	enrollment := Enrollment{}
+	return enrollment, pgxscan.Get(ctx, db, &enrollment, queryGetEnrollmentForUpdateSQL, courseID, userID)
+}
+
+// enrollUsers enrolls users in a course, re-activating earlier enrollments
+// and leaving active ones alone. Students are enrolled as students and
+// tutors as teachers; role, if set, is the only role accepted. Students past
+// the course capacity join its waitlist in the order given. The course row
+// stays locked until the transaction ends.
+func enrollUsers(ctx context.Context, tx pgx.Tx, courseID string, userIDs []string, role *EnrollmentRole, actorID string, now time.Time) (EnrollmentResult, error) {
+	result := EnrollmentResult{Enrolled: []Enrollment{}, Waitlisted: []string{}}
+
+	capacity, err := courseWaitlist(courseID).capacity(ctx, tx, now)
+	if err != nil {
+		return result, err
+	}
+	free := capacity.freeSeats()
+
+	var enrolledStudents []string
+	for _, userID := range uniqueIDs(userIDs) {
+		user, err := getUser(ctx, tx, userID)
+		if err != nil && !pgxscan.NotFound(err) {
+			return result, err
+		}
+
+		userRole, ok := enrollmentRole(user.Role)
+		if err != nil || !ok || user.OrgId != capacity.OrgID || (role != nil && userRole != *role) {
+			return result, fmt.Errorf("%w: %s", errNotEnrollable, userID)
+		}
+
+		var previous *EnrollmentStatus
+		existing, err := getEnrollmentForUpdate(ctx, tx, courseID, userID)
+		switch {
+		case err == nil && existing.Status == EnrollmentStatusActive:
+			continue
+		case err == nil:
+			previous = &existing.Status
+		case !pgxscan.NotFound(err):
+			return result, err
+		}
+
+		if userRole == EnrollmentRoleStudent && free != nil {
+			if *free == 0 {
+				result.Waitlisted = append(result.Waitlisted, userID)
+				continue
+			}
+			*free--
+		}
+
+		enrollment, err := activateEnrollment(ctx, tx, capacity.OrgID, userID, courseID, userRole, previous, actorID, now)
+		if err != nil {
+			return result, err
+		}
+		result.Enrolled = append(result.Enrolled, enrollment)
+
+		if userRole == EnrollmentRoleStudent {
+			enrolledStudents = append(enrolledStudents, userID)
+		}
+	}
+
+	target := courseWaitlist(courseID)
+	if err := resolveWaitlistEntries(ctx, tx, target, enrolledStudents, now); err != nil {
+		return result, err
+	}
+
+	return result, addToWaitlist(ctx, tx, capacity.OrgID, target, result.Waitlisted, now)
+}
+
+// activateEnrollment makes a user's enrollment active, creating it if there
+// was none, i.e. previous is nil.
+func activateEnrollment(ctx context.Context, db dbExecutor, orgID, userID, courseID string, role EnrollmentRole, previous *EnrollmentStatus, actorID string, now time.Time) (Enrollment, error) {
+	enrollment := Enrollment{}
+	if err := pgxscan.Get(ctx, db, &enrollment, activateEnrollmentSQL, userID, courseID, role, now, actorID); err != nil {
+		return Enrollment{}, err
+	}
+
+	action := EnrollmentHistoryEntryActionEnrolled
+	if previous != nil {
+		action = EnrollmentHistoryEntryActionReactivated
+	}
+
+	return enrollment, recordEnrollmentChange(ctx, db, orgID, enrollment, action, previous, nil, actorID, now)
+}
+
+// setEnrollmentStatus ends an active enrollment as completed or dropped.
+func setEnrollmentStatus(ctx context.Context, db dbExecutor, orgID string, enrollment Enrollment, status EnrollmentStatus, reason *string, actorID string, now time.Time) (Enrollment, error) {
+	previous := enrollment.Status
+
+	updated := Enrollment{}
+	err := pgxscan.Get(ctx, db, &updated, setEnrollmentStatusSQL, enrollment.CourseId, enrollment.UserId, status, reason, actorID, now)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	return updated, recordEnrollmentChange(ctx, db, orgID, updated, EnrollmentHistoryEntryAction(status), &previous, reason, actorID, now)
+}
+
+// enrollmentChange is the payload of the enrollment.changed webhook.
+type enrollmentChange struct {
+	CourseID       string            `json:"course_id"`
+	UserID         string            `json:"user_id"`
+	Role           EnrollmentRole    `json:"role"`
+	PreviousStatus *EnrollmentStatus `json:"previous_status"`
+	Status         EnrollmentStatus  `json:"status"`
+	Reason         *string           `json:"reason,omitempty"`
+	ActorID        string            `json:"actor_id"`
+}
+
+// recordEnrollmentChange adds a status change to the enrollment history and
+// sends the enrollment.changed webhook.
+func recordEnrollmentChange(ctx context.Context, db dbExecutor, orgID string, enrollment Enrollment, action EnrollmentHistoryEntryAction, previous *EnrollmentStatus, reason *string, actorID string, now time.Time) error {
+	_, err := db.Exec(ctx, createEnrollmentHistorySQL, orgID, enrollment.UserId, enrollment.CourseId, action, previous, enrollment.Status, reason, actorID, now)
+	if err != nil {
+		return err
+	}
+
+	change := enrollmentChange{
+		CourseID:       enrollment.CourseId,
+		UserID:         enrollment.UserId,
+		Role:           enrollment.Role,
+		PreviousStatus: previous,
+		Status:         enrollment.Status,
+		Reason:         reason,
+		ActorID:        actorID,
+	}
+	return webhooks.Enqueue(ctx, db, orgID, webhooks.EventEnrollmentChanged, change, now)
+}
+
+type enrolledClassRecord struct {
+	ClassID   string
+	OrgID     string
+	StartTime time.Time
+	Duration  int
+	Role      ClassParticipantRole
+}
+
+// dropEnrollment drops an active enrollment and removes the user from the
+// course's classes that have not started yet. The seats they free in those
+// classes are offered to the waitlists. The seat a student frees in the
+// course is left to the caller to offer, once it has made all its changes.
+func dropEnrollment(ctx context.Context, tx pgx.Tx, orgID string, enrollment Enrollment, reason, actorID string, ttl time.Duration, now time.Time) (Enrollment, error) {
+	dropped, err := setEnrollmentStatus(ctx, tx, orgID, enrollment, EnrollmentStatusDropped, &reason, actorID, now)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	classes := []enrolledClassRecord{}
+	if err := pgxscan.Select(ctx, tx, &classes, queryListFutureEnrolledClassesSQL, enrollment.CourseId, enrollment.UserId, now); err != nil {
+		return Enrollment{}, err
+	}
+
+	for _, class := range classes {
+		changes := &CourseParticipantChanges{Remove: &[]string{enrollment.UserId}}
+		update := ClassParticipantUpdate{Students: changes}
+		if class.Role == ClassParticipantRoleTeacher {
+			update = ClassParticipantUpdate{Teachers: changes}
+		}
+
+		end := classEndTime(class.StartTime, class.Duration)
+		if err := updateClassParticipants(ctx, tx, class.ClassID, update, class.StartTime, end, now); err != nil {
+			return Enrollment{}, err
+		}
+
+		note := describeParticipantChanges(update) + "; dropped from course: " + reason
+		history := ClassHistoryEntry{
+			HistoryId: uuid.New().String(),
+			ClassId:   class.ClassID,
+			Action:    ClassHistoryEntryActionParticipantsUpdated,
+			StartTime: class.StartTime,
+			Duration:  class.Duration,
+			Note:      &note,
+			ActorId:   &actorID,
+			CreatedAt: now,
+		}
+		if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+			return Enrollment{}, err
+		}
+
+		if err := webhooks.EnqueueClassEvent(ctx, tx, class.ClassID, webhooks.EventClassParticipantsUpdated, now); err != nil {
+			return Enrollment{}, err
+		}
+
+		if err := live.PublishClassEvent(ctx, tx, class.ClassID, live.EventClassParticipantsUpdated, []string{enrollment.UserId}, now); err != nil {
+			return Enrollment{}, err
+		}
+
+		if class.Role == ClassParticipantRoleStudent {
+			if err := offerOpenSeats(ctx, tx, classWaitlist(class.ClassID), ttl, now); err != nil {
+				return Enrollment{}, err
+			}
+		}
+	}
+
+	return dropped, nil
+}
+
+// dropUsers drops the active enrollments of users in role, as when an admin
+// removes them in a course update. Users without one are skipped. The course
+// seats freed are not offered to the waitlist.
+func dropUsers(ctx context.Context, tx pgx.Tx, courseID string, userIDs []string, role EnrollmentRole, actorID string, ttl time.Duration, now time.Time) error {
+	capacity, err := courseWaitlist(courseID).capacity(ctx, tx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range uniqueIDs(userIDs) {
+		enrollment, err := getEnrollmentForUpdate(ctx, tx, courseID, userID)
+		if err != nil {
+			if pgxscan.NotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if enrollment.Status != EnrollmentStatusActive || enrollment.Role != role {
+			continue
+		}
+
+		if _, err := dropEnrollment(ctx, tx, capacity.OrgID, enrollment, dropReasonRemoved, actorID, ttl, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enrollmentRole returns the role a user takes in the courses they are
+// enrolled in: students take them and tutors teach them. Admins cannot be
+// enrolled.
+func enrollmentRole(role UserRole) (EnrollmentRole, bool) {
+	switch role {
+	case UserRoleStudent:
+		return EnrollmentRoleStudent, true
+	case UserRoleTutor:
+		return EnrollmentRoleTeacher, true
+	default:
+		return "", false
+	}
+}
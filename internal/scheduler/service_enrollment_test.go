@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func TestEnrollmentRole(t *testing.T) {
+	tests := []struct {
+		role   UserRole
+		want   EnrollmentRole
+		wantOK bool
+	}{
+		{UserRoleStudent, EnrollmentRoleStudent, true},
+		{UserRoleTutor, EnrollmentRoleTeacher, true},
+		{UserRoleAdmin, "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := enrollmentRole(tt.role)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("enrollmentRole(%q) = %q, %v, want %q, %v", tt.role, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
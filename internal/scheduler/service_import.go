@@ -0,0 +1,793 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// errAccountProvisioning is returned when sign-in accounts cannot be created
+// for imported users.
+var errAccountProvisioning = errors.New("failed to create sign-in accounts")
+
+// AccountProvisioner creates sign-in accounts for imported users and the
+// links that invite them to set a password.
+type AccountProvisioner interface {
+	CreateInvitedUser(ctx context.Context, email, displayName string) (string, bool, error)
+	SetCustomClaims(ctx context.Context, uid string, claims map[string]interface{}) error
+	PasswordResetLink(ctx context.Context, email string) (string, error)
+	DeleteUser(ctx context.Context, uid string) error
+}
+
+var _ AccountProvisioner = (*auth.FirebaseService)(nil)
+
+type ImportService interface {
+	RunImport(*gin.Context)
+}
+
+var _ ImportService = (*Service)(nil)
+
+func (s *Service) RunImport(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "import data")
+	if !ok {
+		return
+	}
+
+	request := ImportRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.UsersCsv == nil && request.CoursesCsv == nil && request.EnrollmentsCsv == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one of users_csv, courses_csv or enrollments_csv is required"})
+		return
+	}
+
+	var accounts AccountProvisioner
+	if request.CreateAccounts != nil && *request.CreateAccounts {
+		accounts = s.firebaseService
+	}
+
+	report, err := ImportCSV(c.Request.Context(), s.pgxPool, accounts, currentUser.OrgID, currentUser.UserID, request, time.Now())
+	switch {
+	case errors.Is(err, errInvitationsWithoutAccounts):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, new(*quotaExceededError)):
+		respondQuotaError(c, err)
+	case errors.Is(err, errAccountProvisioning):
+		s.log(c.Request.Context()).Error("Imported users left without sign-in accounts", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "account_provisioning_failed",
+			"message": err.Error(),
+		})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	case report.Committed:
+		c.JSON(http.StatusCreated, report)
+	case !report.DryRun:
+		c.JSON(http.StatusUnprocessableEntity, report)
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// errInvitationsWithoutAccounts is returned when invitations are asked for
+// without creating the accounts they invite to.
+var errInvitationsWithoutAccounts = errors.New("send_invitations needs create_accounts")
+
+// ImportCSV validates the CSV files of an import into organization orgID
+// and, unless it is a dry run or a row has an error, writes every user,
+// course and enrollment in one transaction. With accounts set, each
+// imported user then gets a sign-in account. Accounts are created once the
+// import is committed, so no transaction is held open while the identity
+// provider is called, and an import that fails leaves no accounts behind.
+func ImportCSV(ctx context.Context, pool *pgxpool.Pool, accounts AccountProvisioner, orgID, actorID string, request ImportRequest, now time.Time) (ImportReport, error) {
+	dryRun := request.DryRun == nil || *request.DryRun
+	invite := request.SendInvitations != nil && *request.SendInvitations
+	if invite && accounts == nil {
+		return ImportReport{}, errInvitationsWithoutAccounts
+	}
+
+	data, errs := parseImport(request)
+
+	lookup, err := loadImportLookup(ctx, pool, orgID, data)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	errs = append(errs, checkImportReferences(data, orgID, lookup)...)
+	sortImportErrors(errs)
+	if errs == nil {
+		errs = []ImportRowError{}
+	}
+
+	report := ImportReport{
+		DryRun:      dryRun,
+		Users:       len(data.Users),
+		Courses:     len(data.Courses),
+		Enrollments: len(data.Enrollments),
+		Errors:      errs,
+	}
+	if dryRun || len(errs) > 0 {
+		return report, nil
+	}
+
+	userIDs := map[string]string{}
+	err = pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		return writeImport(ctx, tx, orgID, actorID, data, lookup, &report, userIDs, now)
+	})
+	if err != nil {
+		return report, err
+	}
+	report.Committed = true
+
+	if accounts == nil {
+		return report, nil
+	}
+	return report, provisionImportAccounts(ctx, pool, accounts, invite, orgID, data.Users, userIDs, &report, now)
+}
+
+// writeImport writes a validated import, counting waitlisted students into
+// report and the IDs of new users into userIDs by lowercase email.
+func writeImport(ctx context.Context, tx pgx.Tx, orgID, actorID string, data importData, lookup importLookup, report *ImportReport, userIDs map[string]string, now time.Time) error {
+	// The quotas stay locked until the import commits, which it does before
+	// any sign-in account is created.
+	if err := checkOrgQuota(ctx, tx, orgID, quotaUsers, len(data.Users), now); err != nil {
+		return err
+	}
+	if err := checkOrgQuota(ctx, tx, orgID, quotaCourses, len(data.Courses), now); err != nil {
+		return err
+	}
+
+	for _, user := range data.Users {
+		userID := uuid.New().String()
+		_, err := tx.Exec(ctx, createImportUserSQL, userID, orgID, user.Role, user.FirstName, user.LastName, user.PhoneNumber, user.Email, now)
+		if err != nil {
+			return err
+		}
+		userIDs[strings.ToLower(user.Email)] = userID
+
+		event := gin.H{
+			"user_id":    userID,
+			"org_id":     orgID,
+			"role":       user.Role,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"email":      user.Email,
+			"created_at": now,
+		}
+		if err := webhooks.Enqueue(ctx, tx, orgID, webhooks.EventUserCreated, event, now); err != nil {
+			return err
+		}
+	}
+
+	for _, course := range data.Courses {
+		_, err := tx.Exec(ctx, createCourseSql, course.CourseID, orgID, course.CourseName, course.Description, course.StartAt, course.EndAt,
+			course.Interval, course.Frequency, course.MaxStudents, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Enroll course by course, in the order of the file, so waitlists fill
+	// up in that order too.
+	var courseIDs []string
+	enrollees := map[string][]string{}
+	for _, enrollment := range data.Enrollments {
+		userID, ok := userIDs[enrollment.Email]
+		if !ok {
+			userID = lookup.Users[enrollment.Email].UserID
+		}
+		if _, ok := enrollees[enrollment.CourseID]; !ok {
+			courseIDs = append(courseIDs, enrollment.CourseID)
+		}
+		enrollees[enrollment.CourseID] = append(enrollees[enrollment.CourseID], userID)
+	}
+
+	waitlisted := 0
+	for _, courseID := range courseIDs {
+		result, err := enrollUsers(ctx, tx, courseID, enrollees[courseID], nil, actorID, now)
+		if err != nil {
+			return err
+		}
+		waitlisted += len(result.Waitlisted)
+	}
+	report.Waitlisted = &waitlisted
+	return nil
+}
+
+// provisionImportAccounts gives the users of a committed import sign-in
+// accounts, counting accounts and invitations into report. Accounts that
+// already exist for an email are linked to its user, but their claims are
+// left alone, since they were not made for this import. Users whose account
+// cannot be created or linked are left without one and their errors are
+// returned together.
+func provisionImportAccounts(ctx context.Context, pool *pgxpool.Pool, accounts AccountProvisioner, invite bool, orgID string, users []importUser, userIDs map[string]string, report *ImportReport, now time.Time) error {
+	var errs []error
+	accountsCreated, invitations := 0, 0
+	for _, user := range users {
+		userID := userIDs[strings.ToLower(user.Email)]
+
+		uid, isNew, err := accounts.CreateInvitedUser(ctx, user.Email, user.FirstName+" "+user.LastName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", user.Email, err))
+			continue
+		}
+		if isNew {
+			accountsCreated++
+
+			claims := map[string]interface{}{
+				"role":   user.Role,
+				"org_id": orgID,
+			}
+			if err := accounts.SetCustomClaims(ctx, uid, claims); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", user.Email, err))
+				continue
+			}
+		}
+
+		var link string
+		if invite {
+			link, err = accounts.PasswordResetLink(ctx, user.Email)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", user.Email, err))
+				continue
+			}
+		}
+
+		err = pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, setUserFirebaseUIDSQL, userID, uid, now); err != nil {
+				return err
+			}
+			if !invite {
+				return nil
+			}
+			return notifications.EnqueueUserInvitation(ctx, tx, userID, link, now)
+		})
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			err = errors.New("the sign-in account belongs to another user")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", user.Email, err))
+			continue
+		}
+		if invite {
+			invitations++
+		}
+	}
+
+	report.AccountsCreated = &accountsCreated
+	if invite {
+		report.InvitationsSent = &invitations
+	}
+
+	if errs != nil {
+		return fmt.Errorf("%w: %w", errAccountProvisioning, errors.Join(errs...))
+	}
+	return nil
+}
+
+type importCourseRecord struct {
+	CourseID string
+	OrgID    string
+}
+
+type importEnrollmentRecord struct {
+	UserID   string
+	CourseID string
+}
+
+// loadImportLookup loads the existing users, courses and enrollments an
+// import names.
+func loadImportLookup(ctx context.Context, db dbExecutor, orgID string, data importData) (importLookup, error) {
+	lookup := importLookup{
+		Users:      map[string]importExistingUser{},
+		CourseOrgs: map[string]string{},
+		Enrolled:   map[[2]string]bool{},
+	}
+
+	emails, courseIDs := []string{}, []string{}
+	for _, user := range data.Users {
+		emails = append(emails, strings.ToLower(user.Email))
+	}
+	for _, course := range data.Courses {
+		if course.CourseID != "" {
+			courseIDs = append(courseIDs, course.CourseID)
+		}
+	}
+	for _, enrollment := range data.Enrollments {
+		emails = append(emails, enrollment.Email)
+		if enrollment.CourseID != "" {
+			courseIDs = append(courseIDs, enrollment.CourseID)
+		}
+	}
+
+	users := []importExistingUser{}
+	if err := pgxscan.Select(ctx, db, &users, queryListImportUsersSQL, uniqueIDs(emails), orgID); err != nil {
+		return lookup, err
+	}
+	userIDs := []string{}
+	for _, user := range users {
+		lookup.Users[user.Email] = user
+		userIDs = append(userIDs, user.UserID)
+	}
+
+	courses := []importCourseRecord{}
+	if err := pgxscan.Select(ctx, db, &courses, queryListImportCoursesSQL, uniqueIDs(courseIDs)); err != nil {
+		return lookup, err
+	}
+	for _, course := range courses {
+		lookup.CourseOrgs[course.CourseID] = course.OrgID
+	}
+
+	enrollments := []importEnrollmentRecord{}
+	if err := pgxscan.Select(ctx, db, &enrollments, queryListImportEnrollmentsSQL, userIDs, uniqueIDs(courseIDs)); err != nil {
+		return lookup, err
+	}
+	for _, enrollment := range enrollments {
+		lookup.Enrolled[[2]string{enrollment.UserID, enrollment.CourseID}] = true
+	}
+
+	return lookup, nil
+}
+
+//go:embed queries/import/list_import_users.sql
+var queryListImportUsersSQL string
+
+//go:embed queries/import/list_import_courses.sql
+var queryListImportCoursesSQL string
+
+//go:embed queries/import/list_import_enrollments.sql
+var queryListImportEnrollmentsSQL string
+
+//go:embed queries/import/create_import_user.sql
+var createImportUserSQL string
+
+//go:embed queries/import/set_user_firebase_uid.sql
+var setUserFirebaseUIDSQL string
+
+// csvRecord is a row of an import file, keyed by lowercased column name.
+type csvRecord struct {
+	Line   int
+	Values map[string]string
+}
+
+func importError(file ImportFile, line int, column, message string) ImportRowError {
+	rowErr := ImportRowError{File: file, Row: line, Message: message}
+	if column != "" {
+		rowErr.Column = &column
+	}
+	return rowErr
+}
+
+// readImportCSV reads an import file with a header row. Column names are
+// matched case-insensitively; required columns must be there and columns
+// that are neither required nor optional are reported, as are malformed
+// rows.
+func readImportCSV(file ImportFile, data string, required, optional []string) ([]csvRecord, []ImportRowError) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(data, "\ufeff")))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, []ImportRowError{importError(file, 1, "", "file is empty")}
+	}
+	if err != nil {
+		return nil, []ImportRowError{csvReadError(file, err)}
+	}
+
+	var errs []ImportRowError
+	columns := make([]string, len(header))
+	seen := map[string]bool{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[i] = name
+		switch {
+		case seen[name]:
+			errs = append(errs, importError(file, 1, name, "duplicate column"))
+		case !slices.Contains(required, name) && !slices.Contains(optional, name):
+			errs = append(errs, importError(file, 1, name, "unknown column"))
+		}
+		seen[name] = true
+	}
+	for _, name := range required {
+		if !seen[name] {
+			errs = append(errs, importError(file, 1, name, "missing column"))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	var records []csvRecord
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, csvReadError(file, err))
+			// Rows with the wrong number of fields are skipped; anything
+			// else leaves the rest of the file unreadable.
+			if !errors.Is(err, csv.ErrFieldCount) {
+				break
+			}
+			continue
+		}
+
+		line, _ := r.FieldPos(0)
+		values := make(map[string]string, len(fields))
+		for i, value := range fields {
+			values[columns[i]] = strings.TrimSpace(value)
+		}
+		records = append(records, csvRecord{Line: line, Values: values})
+	}
+
+	return records, errs
+}
+
+func csvReadError(file ImportFile, err error) ImportRowError {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return importError(file, parseErr.StartLine, "", parseErr.Err.Error())
+	}
+	return importError(file, 1, "", err.Error())
+}
+
+// requireImportValues reports the columns of a row that are empty.
+func requireImportValues(file ImportFile, record csvRecord, columns ...string) []ImportRowError {
+	var errs []ImportRowError
+	for _, column := range columns {
+		if record.Values[column] == "" {
+			errs = append(errs, importError(file, record.Line, column, "is required"))
+		}
+	}
+	return errs
+}
+
+// importUser is a row of the users file.
+type importUser struct {
+	Line        int
+	FirstName   string
+	LastName    string
+	Email       string
+	Role        UserRole
+	PhoneNumber *string
+}
+
+// importCourse is a row of the courses file. Courses without a course_id
+// are given a new one.
+type importCourse struct {
+	Line        int
+	CourseID    string
+	CourseName  string
+	Description *string
+	StartAt     *time.Time
+	EndAt       *time.Time
+	Interval    *string
+	Frequency   *int
+	MaxStudents *int
+}
+
+// importEnrollment is a row of the enrollments file.
+type importEnrollment struct {
+	Line     int
+	Email    string
+	CourseID string
+}
+
+// importData is what an import would write.
+type importData struct {
+	Users       []importUser
+	Courses     []importCourse
+	Enrollments []importEnrollment
+}
+
+// parseImport reads the files of an import and checks each row on its own
+// and against the other rows of its file. Checks against the database are
+// left to checkImportReferences.
+func parseImport(request ImportRequest) (importData, []ImportRowError) {
+	var (
+		data importData
+		errs []ImportRowError
+	)
+
+	if request.UsersCsv != nil {
+		users, userErrs := parseImportUsers(*request.UsersCsv)
+		data.Users, errs = users, append(errs, userErrs...)
+	}
+	if request.CoursesCsv != nil {
+		courses, courseErrs := parseImportCourses(*request.CoursesCsv)
+		data.Courses, errs = courses, append(errs, courseErrs...)
+	}
+	if request.EnrollmentsCsv != nil {
+		enrollments, enrollmentErrs := parseImportEnrollments(*request.EnrollmentsCsv)
+		data.Enrollments, errs = enrollments, append(errs, enrollmentErrs...)
+	}
+
+	return data, errs
+}
+
+func parseImportUsers(file string) ([]importUser, []ImportRowError) {
+	records, errs := readImportCSV(Users, file, []string{"first_name", "last_name", "email", "role"}, []string{"phone_number"})
+
+	users := []importUser{}
+	emails := map[string]int{}
+	for _, record := range records {
+		errs = append(errs, requireImportValues(Users, record, "first_name", "last_name", "email", "role")...)
+
+		user := importUser{
+			Line:      record.Line,
+			FirstName: record.Values["first_name"],
+			LastName:  record.Values["last_name"],
+			Email:     record.Values["email"],
+			Role:      UserRole(strings.ToLower(record.Values["role"])),
+		}
+		if phone := record.Values["phone_number"]; phone != "" {
+			user.PhoneNumber = &phone
+		}
+
+		if user.Email != "" {
+			key := strings.ToLower(user.Email)
+			if !isValidEmail(user.Email) {
+				errs = append(errs, importError(Users, record.Line, "email", "is not a valid email address"))
+			} else if line, ok := emails[key]; ok {
+				errs = append(errs, importError(Users, record.Line, "email", fmt.Sprintf("is also on line %d", line)))
+			} else {
+				emails[key] = record.Line
+			}
+		}
+
+		if user.Role != "" && !slices.Contains([]UserRole{UserRoleAdmin, UserRoleStudent, UserRoleTutor}, user.Role) {
+			errs = append(errs, importError(Users, record.Line, "role", "must be admin, student or tutor"))
+		}
+
+		users = append(users, user)
+	}
+
+	return users, errs
+}
+
+func parseImportCourses(file string) ([]importCourse, []ImportRowError) {
+	optional := []string{"course_id", "course_description", "start_at", "end_at", "interval", "frequency", "max_students"}
+	records, errs := readImportCSV(Courses, file, []string{"course_name"}, optional)
+
+	courses := []importCourse{}
+	courseIDs := map[string]int{}
+	for _, record := range records {
+		errs = append(errs, requireImportValues(Courses, record, "course_name")...)
+
+		course := importCourse{Line: record.Line, CourseName: record.Values["course_name"]}
+		fail := func(column, message string) {
+			errs = append(errs, importError(Courses, record.Line, column, message))
+		}
+
+		if id := record.Values["course_id"]; id == "" {
+			course.CourseID = uuid.New().String()
+		} else if parsed, err := uuid.Parse(id); err != nil {
+			fail("course_id", "must be a UUID")
+		} else if line, ok := courseIDs[parsed.String()]; ok {
+			fail("course_id", fmt.Sprintf("is also on line %d", line))
+		} else {
+			course.CourseID = parsed.String()
+			courseIDs[course.CourseID] = record.Line
+		}
+
+		if description := record.Values["course_description"]; description != "" {
+			course.Description = &description
+		}
+
+		times := []struct {
+			column string
+			target **time.Time
+		}{{"start_at", &course.StartAt}, {"end_at", &course.EndAt}}
+		for _, field := range times {
+			if value := record.Values[field.column]; value != "" {
+				t, err := parseImportTime(value)
+				if err != nil {
+					fail(field.column, "must be a date (2006-01-02) or an RFC 3339 time")
+					continue
+				}
+				*field.target = &t
+			}
+		}
+		if course.StartAt != nil && course.EndAt != nil && !course.EndAt.After(*course.StartAt) {
+			fail("end_at", "must be after start_at")
+		}
+
+		if value := record.Values["interval"]; value != "" {
+			interval, ok := courseIntervalValue(value)
+			if !ok {
+				fail("interval", "must be week, bi-weekly or month")
+			}
+			course.Interval = &interval
+		}
+
+		counts := []struct {
+			column string
+			target **int
+		}{{"frequency", &course.Frequency}, {"max_students", &course.MaxStudents}}
+		for _, field := range counts {
+			if value := record.Values[field.column]; value != "" {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					fail(field.column, "must be a whole number of at least 1")
+					continue
+				}
+				*field.target = &n
+			}
+		}
+		if (record.Values["interval"] == "") != (record.Values["frequency"] == "") {
+			fail("frequency", "interval and frequency must be given together")
+		}
+
+		courses = append(courses, course)
+	}
+
+	return courses, errs
+}
+
+func parseImportEnrollments(file string) ([]importEnrollment, []ImportRowError) {
+	records, errs := readImportCSV(Enrollments, file, []string{"email", "course_id"}, nil)
+
+	enrollments := []importEnrollment{}
+	seen := map[importEnrollment]int{}
+	for _, record := range records {
+		errs = append(errs, requireImportValues(Enrollments, record, "email", "course_id")...)
+
+		enrollment := importEnrollment{Email: strings.ToLower(record.Values["email"])}
+		if id := record.Values["course_id"]; id != "" {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				errs = append(errs, importError(Enrollments, record.Line, "course_id", "must be a UUID"))
+			} else {
+				enrollment.CourseID = parsed.String()
+			}
+		}
+
+		if enrollment.Email != "" && enrollment.CourseID != "" {
+			if line, ok := seen[enrollment]; ok {
+				errs = append(errs, importError(Enrollments, record.Line, "", fmt.Sprintf("enrollment is also on line %d", line)))
+			}
+			seen[enrollment] = record.Line
+		}
+
+		enrollment.Line = record.Line
+		enrollments = append(enrollments, enrollment)
+	}
+
+	return enrollments, errs
+}
+
+// importExistingUser is a user already in the database with an email named
+// by an import.
+type importExistingUser struct {
+	UserID string
+	OrgID  string
+	Email  string
+	Role   UserRole
+}
+
+// importLookup is what the database already holds of the users, courses
+// and enrollments an import names.
+type importLookup struct {
+	// Users by lowercased email, in any organization.
+	Users map[string]importExistingUser
+	// CourseOrgs maps course IDs, in any organization, to their organization.
+	CourseOrgs map[string]string
+	// Enrolled holds the active enrollments, keyed by user ID and course ID.
+	Enrolled map[[2]string]bool
+}
+
+// checkImportReferences checks an import against the database: new users
+// and courses must not exist yet, and enrollments must name students or
+// tutors and courses of the organization or of the import, who are not
+// enrolled already.
+func checkImportReferences(data importData, orgID string, lookup importLookup) []ImportRowError {
+	var errs []ImportRowError
+
+	roles := map[string]UserRole{}
+	for _, user := range data.Users {
+		key := strings.ToLower(user.Email)
+		if _, ok := lookup.Users[key]; ok {
+			errs = append(errs, importError(Users, user.Line, "email", "belongs to an existing user"))
+		}
+		roles[key] = user.Role
+	}
+
+	courses := map[string]bool{}
+	for _, course := range data.Courses {
+		if _, ok := lookup.CourseOrgs[course.CourseID]; ok {
+			errs = append(errs, importError(Courses, course.Line, "course_id", "belongs to an existing course"))
+		}
+		courses[course.CourseID] = true
+	}
+
+	for _, enrollment := range data.Enrollments {
+		if enrollment.Email == "" || enrollment.CourseID == "" {
+			continue
+		}
+
+		role, imported := roles[enrollment.Email]
+		existing, ok := lookup.Users[enrollment.Email]
+		switch {
+		case imported:
+		case ok && existing.OrgID == orgID:
+			role = existing.Role
+		default:
+			errs = append(errs, importError(Enrollments, enrollment.Line, "email", "is not a user of the organization or the import"))
+			continue
+		}
+
+		if _, ok := enrollmentRole(role); !ok && role != "" {
+			errs = append(errs, importError(Enrollments, enrollment.Line, "email", "belongs to an admin, who cannot be enrolled"))
+		}
+
+		if !courses[enrollment.CourseID] && lookup.CourseOrgs[enrollment.CourseID] != orgID {
+			errs = append(errs, importError(Enrollments, enrollment.Line, "course_id", "is not a course of the organization or the import"))
+			continue
+		}
+
+		if !imported && lookup.Enrolled[[2]string{existing.UserID, enrollment.CourseID}] {
+			errs = append(errs, importError(Enrollments, enrollment.Line, "", "user is already enrolled in the course"))
+		}
+	}
+
+	return errs
+}
+
+// sortImportErrors orders errors by file, in the order users, courses and
+// enrollments, and then by row.
+func sortImportErrors(errs []ImportRowError) {
+	rank := map[ImportFile]int{Users: 0, Courses: 1, Enrollments: 2}
+	slices.SortStableFunc(errs, func(a, b ImportRowError) int {
+		if rank[a.File] != rank[b.File] {
+			return rank[a.File] - rank[b.File]
+		}
+		return a.Row - b.Row
+	})
+}
+
+// parseImportTime parses a date, taken as midnight UTC, or an RFC 3339 time.
+func parseImportTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// courseIntervalValue returns the stored form of a course interval, which
+// may also be given as weekly or monthly.
+func courseIntervalValue(interval string) (string, bool) {
+	switch strings.ToLower(interval) {
+	case "week", "weekly":
+		return "week", true
+	case "bi-weekly":
+		return "bi-weekly", true
+	case "month", "monthly":
+		return "month", true
+	default:
+		return "", false
+	}
+}
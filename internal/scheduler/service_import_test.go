@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadImportCSV(t *testing.T) {
+	data := "\ufeffEmail, Course_ID\na@example.com,c1\nb@example.com\n c@example.com , c3 \n"
+	records, errs := readImportCSV(Enrollments, data, []string{"email", "course_id"}, nil)
+
+	if len(records) != 2 || records[0].Line != 2 || records[1].Line != 4 {
+		t.Fatalf("expected rows 2 and 4, got %+v", records)
+	}
+	if records[1].Values["email"] != "c@example.com" || records[1].Values["course_id"] != "c3" {
+		t.Errorf("expected trimmed values, got %+v", records[1].Values)
+	}
+	if len(errs) != 1 || errs[0].Row != 3 {
+		t.Errorf("expected an error for the short row 3, got %+v", errs)
+	}
+
+	_, errs = readImportCSV(Users, "email,email,nickname\n", []string{"email", "role"}, nil)
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, *err.Column+" "+err.Message)
+	}
+	expected := []string{"email duplicate column", "nickname unknown column", "role missing column"}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("expected %v, got %v", expected, messages)
+	}
+
+	if _, errs := readImportCSV(Users, "", []string{"email"}, nil); len(errs) != 1 || errs[0].Message != "file is empty" {
+		t.Errorf("expected an empty file error, got %+v", errs)
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	users := "first_name,last_name,email,role\n" +
+		"Ada,Lovelace,ada@example.com,student\n" +
+		"Alan,Turing,ADA@example.com,tutor\n" +
+		",Hopper,grace@,teacher\n"
+	courses := "course_id,course_name,start_at,end_at,interval,frequency\n" +
+		",Algebra,2025-01-06,2025-06-30,weekly,2\n" +
+		"not-a-uuid,Geometry,2025-06-30,2025-01-06,yearly,\n"
+	enrollments := "email,course_id\n" +
+		"ada@example.com,0b6b1c9e-4d1e-4a55-9a8e-8f4c0c2b6d11\n" +
+		"Ada@Example.com,0B6B1C9E-4D1E-4A55-9A8E-8F4C0C2B6D11\n"
+
+	data, errs := parseImport(ImportRequest{UsersCsv: &users, CoursesCsv: &courses, EnrollmentsCsv: &enrollments})
+	sortImportErrors(errs)
+
+	if len(data.Users) != 3 || len(data.Courses) != 2 || len(data.Enrollments) != 2 {
+		t.Fatalf("expected every row to be read, got %+v", data)
+	}
+
+	algebra := data.Courses[0]
+	if algebra.CourseID == "" || algebra.Interval == nil || *algebra.Interval != "week" || *algebra.Frequency != 2 {
+		t.Errorf("unexpected course %+v", algebra)
+	}
+
+	got := []string{}
+	for _, err := range errs {
+		column := ""
+		if err.Column != nil {
+			column = *err.Column
+		}
+		got = append(got, fmt.Sprintf("%s:%d:%s %s", err.File, err.Row, column, err.Message))
+	}
+	expected := []string{
+		"users:3:email is also on line 2",
+		"users:4:first_name is required",
+		"users:4:email is not a valid email address",
+		"users:4:role must be admin, student or tutor",
+		"courses:3:course_id must be a UUID",
+		"courses:3:end_at must be after start_at",
+		"courses:3:interval must be week, bi-weekly or month",
+		"courses:3:frequency interval and frequency must be given together",
+		"enrollments:3: enrollment is also on line 2",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected errors\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestCheckImportReferences(t *testing.T) {
+	const (
+		org      = "org-1"
+		course   = "course-1"
+		imported = "course-2"
+	)
+	data := importData{
+		Users: []importUser{
+			{Line: 2, Email: "New@example.com", Role: UserRoleStudent},
+			{Line: 3, Email: "taken@example.com", Role: UserRoleTutor},
+		},
+		Courses: []importCourse{{Line: 2, CourseID: imported}, {Line: 3, CourseID: course}},
+		Enrollments: []importEnrollment{
+			{Line: 2, Email: "new@example.com", CourseID: imported},
+			{Line: 3, Email: "student@example.com", CourseID: course},
+			{Line: 4, Email: "admin@example.com", CourseID: course},
+			{Line: 5, Email: "other@example.com", CourseID: course},
+			{Line: 6, Email: "new@example.com", CourseID: "course-3"},
+		},
+	}
+	lookup := importLookup{
+		Users: map[string]importExistingUser{
+			"taken@example.com":   {UserID: "u1", OrgID: "org-2", Role: UserRoleTutor},
+			"student@example.com": {UserID: "u2", OrgID: org, Role: UserRoleStudent},
+			"admin@example.com":   {UserID: "u3", OrgID: org, Role: UserRoleAdmin},
+			"other@example.com":   {UserID: "u4", OrgID: "org-2", Role: UserRoleStudent},
+		},
+		CourseOrgs: map[string]string{course: org, "course-3": "org-2"},
+		Enrolled:   map[[2]string]bool{{"u2", course}: true},
+	}
+
+	got := []string{}
+	for _, err := range checkImportReferences(data, org, lookup) {
+		got = append(got, fmt.Sprintf("%s:%d %s", err.File, err.Row, err.Message))
+	}
+	expected := []string{
+		"users:3 belongs to an existing user",
+		"courses:3 belongs to an existing course",
+		"enrollments:3 user is already enrolled in the course",
+		"enrollments:4 belongs to an admin, who cannot be enrolled",
+		"enrollments:5 is not a user of the organization or the import",
+		"enrollments:6 is not a course of the organization or the import",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected errors\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestCourseIntervalValue(t *testing.T) {
+	for input, expected := range map[string]string{"Weekly": "week", "week": "week", "bi-weekly": "bi-weekly", "monthly": "month"} {
+		if got, ok := courseIntervalValue(input); !ok || got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, input, got)
+		}
+	}
+	if _, ok := courseIntervalValue("yearly"); ok {
+		t.Error("expected yearly to be rejected")
+	}
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"scheduler-api/internal/jobs"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+type JobService interface {
+	ListJobs(*gin.Context, ListJobsParams)
+	ListJobSchedules(*gin.Context)
+	GetJob(*gin.Context, string)
+	RetryJob(*gin.Context, string)
+	CancelJob(*gin.Context, string)
+}
+
+var _ JobService = (*Service)(nil)
+
+func (s *Service) ListJobs(c *gin.Context, params ListJobsParams) {
+	currentUser, ok := s.requireAdmin(c, "manage jobs")
+	if !ok {
+		return
+	}
+
+	limit := 100
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = min(*params.Limit, 1000)
+	}
+
+	result := []Job{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &result, listJobsSQL, currentUser.OrgID, params.Status, params.Kind, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Service) ListJobSchedules(c *gin.Context) {
+	if _, ok := s.requireAdmin(c, "manage jobs"); !ok {
+		return
+	}
+
+	schedules := []JobSchedule{}
+	if err := pgxscan.Select(c.Request.Context(), s.pgxPool, &schedules, listJobSchedulesSQL); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+func (s *Service) GetJob(c *gin.Context, jobID string) {
+	currentUser, ok := s.requireAdmin(c, "manage jobs")
+	if !ok {
+		return
+	}
+
+	s.respondWithJob(c, jobID, currentUser.OrgID)
+}
+
+func (s *Service) RetryJob(c *gin.Context, jobID string) {
+	currentUser, ok := s.requireAdmin(c, "manage jobs")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getJob(ctx, s.pgxPool, jobID, currentUser.OrgID); err != nil {
+		respondJobLookupError(c, err)
+		return
+	}
+
+	retried, err := jobs.Retry(ctx, s.pgxPool, jobID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !retried {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "job_not_retryable",
+			"message": "Only failed or cancelled jobs can be retried",
+		})
+		return
+	}
+
+	s.respondWithJob(c, jobID, currentUser.OrgID)
+}
+
+func (s *Service) CancelJob(c *gin.Context, jobID string) {
+	currentUser, ok := s.requireAdmin(c, "manage jobs")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getJob(ctx, s.pgxPool, jobID, currentUser.OrgID); err != nil {
+		respondJobLookupError(c, err)
+		return
+	}
+
+	cancelled, err := jobs.Cancel(ctx, s.pgxPool, jobID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !cancelled {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "job_not_cancellable",
+			"message": "Only queued jobs can be cancelled",
+		})
+		return
+	}
+
+	s.respondWithJob(c, jobID, currentUser.OrgID)
+}
+
+func (s *Service) respondWithJob(c *gin.Context, jobID, orgID string) {
+	job, err := getJob(c.Request.Context(), s.pgxPool, jobID, orgID)
+	if err != nil {
+		respondJobLookupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func respondJobLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "job_not_found",
+			"message": "Job not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+//go:embed queries/job/list_jobs.sql
+var listJobsSQL string
+
+//go:embed queries/job/get_job.sql
+var getJobSQL string
+
+//go:embed queries/job/list_job_schedules.sql
+var listJobSchedulesSQL string
+
+// getJob returns a job the organization queued. Jobs run for every
+// organization are not returned.
+func getJob(ctx context.Context, db dbExecutor, jobID, orgID string) (Job, error) {
+	job := Job{}
+	return job, pgxscan.Get(ctx, db, &job, getJobSQL, jobID, orgID)
+}
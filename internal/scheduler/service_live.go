@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// liveKeepAliveInterval is how often an idle stream writes a comment, so
+	// proxies do not close it.
+	liveKeepAliveInterval = 25 * time.Second
+	// liveRetryInterval is how long clients wait before reconnecting.
+	liveRetryInterval = 5 * time.Second
+	// liveReplayPageSize is how many missed events are read at a time.
+	liveReplayPageSize = 500
+)
+
+type EventsService interface {
+	StreamEvents(*gin.Context, StreamEventsParams)
+}
+
+var _ EventsService = (*Service)(nil)
+
+func (s *Service) StreamEvents(c *gin.Context, params StreamEventsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	// EventSource sends the ID of the last event it got when it reconnects.
+	lastEventID := params.LastEventId
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_last_event_id",
+				"message": "Last-Event-ID must be the ID of an event",
+			})
+			return
+		}
+		lastEventID = &id
+	}
+
+	subscriber := live.Subscriber{
+		OrgID:  currentUser.OrgID,
+		UserID: currentUser.UserID,
+		Admin:  currentUser.Role == "admin",
+	}
+
+	// Subscribe before replaying, so events published in between are not
+	// missed. Those also replayed are skipped below, by ID since events can
+	// commit out of ID order.
+	subscription := s.liveBroker.Subscribe(subscriber)
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	var (
+		ctx = c.Request.Context()
+		w   = c.Writer
+	)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", liveRetryInterval.Milliseconds()); err != nil {
+		return
+	}
+	w.Flush()
+
+	replayed := map[int64]bool{}
+	if lastEventID != nil {
+		replayedID, err := s.liveBroker.ResumeAfter(ctx, *lastEventID)
+		if err != nil {
+			s.log(c.Request.Context()).Error("Failed to replay live events", zap.String("user_id", currentUser.UserID), zap.Error(err))
+			return
+		}
+
+		for {
+			events, err := s.liveBroker.Replay(ctx, subscriber, replayedID, liveReplayPageSize)
+			if err != nil {
+				// The stream has started, so the client just reconnects.
+				s.log(c.Request.Context()).Error("Failed to replay live events", zap.String("user_id", currentUser.UserID), zap.Error(err))
+				return
+			}
+
+			for _, event := range events {
+				if err := live.WriteEvent(w, event); err != nil {
+					return
+				}
+				replayed[event.ID] = true
+				replayedID = event.ID
+			}
+			w.Flush()
+
+			if len(events) < liveReplayPageSize {
+				break
+			}
+		}
+	}
+
+	keepAlive := time.NewTicker(liveKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-subscription.Events():
+			// The subscription fell too far behind; the client resumes from
+			// the last event it got.
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if err := live.WriteEvent(w, event); err != nil {
+				return
+			}
+			w.Flush()
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
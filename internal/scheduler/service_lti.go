@@ -0,0 +1,667 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"scheduler-api/internal/lti"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// ltiLaunchStateTTL bounds the time between a login and its launch.
+const ltiLaunchStateTTL = 10 * time.Minute
+
+var (
+	// errLTIRole is returned for launches that would create a user with
+	// none of the roles the tool maps.
+	errLTIRole = errors.New("the launch has no learner or instructor role")
+	// errLTIUserInactive is returned for launches of inactive users.
+	errLTIUserInactive = errors.New("the user is not active")
+	// errLTIAdmin is returned for launches of admins, who only sign in
+	// with their own account.
+	errLTIAdmin = errors.New("admins cannot sign in through LTI")
+	// errLTIAccountExists is returned for launches of users without a
+	// sign-in account whose email already has one, which may belong to
+	// another organization.
+	errLTIAccountExists = errors.New("the email already has a sign-in account that is not linked to the user")
+)
+
+type LtiService interface {
+	ListLtiPlatforms(*gin.Context)
+	CreateLtiPlatform(*gin.Context)
+	DeleteLtiPlatform(*gin.Context, string)
+}
+
+var _ LtiService = (*Service)(nil)
+
+func (s *Service) ListLtiPlatforms(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage LTI platforms")
+	if !ok {
+		return
+	}
+
+	platforms := []LtiPlatform{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &platforms, listLTIPlatformsSQL, currentUser.OrgID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range platforms {
+		setLTIToolURLs(c, &platforms[i])
+	}
+	c.JSON(http.StatusOK, platforms)
+}
+
+func (s *Service) CreateLtiPlatform(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage LTI platforms")
+	if !ok {
+		return
+	}
+
+	request := LtiPlatformCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateLTIPlatform(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx        = c.Request.Context()
+		platformID = uuid.New().String()
+	)
+
+	_, err := s.pgxPool.Exec(ctx, createLTIPlatformSQL, platformID, currentUser.OrgID, request.Name, request.Issuer, request.ClientId,
+		uniqueIDs(request.DeploymentIds), request.AuthLoginUrl, request.JwksUrl, currentUser.UserID, time.Now())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   "lti_platform_exists",
+				"message": "The issuer and client ID are already registered",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	platform := LtiPlatform{}
+	if err := pgxscan.Get(ctx, s.pgxPool, &platform, listLTIPlatformsSQL, currentUser.OrgID, platformID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	setLTIToolURLs(c, &platform)
+	c.JSON(http.StatusCreated, platform)
+}
+
+func (s *Service) DeleteLtiPlatform(c *gin.Context, platformID string) {
+	currentUser, ok := s.requireAdmin(c, "manage LTI platforms")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deleteLTIPlatformSQL, platformID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "lti_platform_not_found",
+			"message": "LTI platform not found",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func validateLTIPlatform(request LtiPlatformCreate) error {
+	if request.Name == "" || request.Issuer == "" || request.ClientId == "" {
+		return fmt.Errorf("name, issuer and client_id are required")
+	}
+
+	if len(request.DeploymentIds) == 0 || slices.Contains(request.DeploymentIds, "") {
+		return fmt.Errorf("deployment_ids must list at least one deployment")
+	}
+
+	urls := map[string]string{"auth_login_url": request.AuthLoginUrl, "jwks_url": request.JwksUrl}
+	for name, value := range urls {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an absolute http or https URL", name)
+		}
+	}
+
+	return nil
+}
+
+// setLTIToolURLs sets the URLs the platform is configured to launch the
+// tool with.
+func setLTIToolURLs(c *gin.Context, platform *LtiPlatform) {
+	platform.ToolLoginUrl = ltiLocation(c, "login")
+	platform.ToolLaunchUrl = ltiLocation(c, "launch")
+}
+
+// RegisterLTIHandlers registers the LTI 1.3 login initiation and launch
+// endpoints at /lti. Platforms reach them through the user's browser, which
+// has no API session yet, so they are public; launches are authenticated by
+// the platform's signed id_token instead.
+func RegisterLTIHandlers(router gin.IRouter, s *Service) {
+	group := router.Group("/lti")
+	group.GET("/login", s.ltiLogin)
+	group.POST("/login", s.ltiLogin)
+	group.POST("/launch", s.ltiLaunch)
+}
+
+// ltiPlatform is a platform registration as launches use it.
+type ltiPlatform struct {
+	PlatformID    string
+	OrgID         string
+	Issuer        string
+	ClientID      string
+	DeploymentIds []string
+	AuthLoginURL  string
+	JwksURL       string
+}
+
+func (p ltiPlatform) registration() lti.Platform {
+	return lti.Platform{
+		Issuer:        p.Issuer,
+		ClientID:      p.ClientID,
+		DeploymentIDs: p.DeploymentIds,
+		AuthLoginURL:  p.AuthLoginURL,
+		JWKSURL:       p.JwksURL,
+	}
+}
+
+// ltiLogin answers a platform's login initiation by sending the browser to
+// the platform to authenticate, with a fresh state and nonce that the
+// launch is checked against.
+func (s *Service) ltiLogin(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	login, err := lti.ParseLoginRequest(c.Request.Form)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	platforms := []ltiPlatform{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &platforms, findLTIPlatformsSQL, login.Issuer, login.ClientID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(platforms) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "lti_platform_not_found",
+			"message": "The platform is not registered",
+		})
+		return
+	}
+	if len(platforms) > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "lti_client_id_required",
+			"message": "The platform is registered more than once; the login must name its client_id",
+		})
+		return
+	}
+	platform := platforms[0]
+
+	state, nonce, err := newLTIState()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	if _, err := s.pgxPool.Exec(ctx, createLTILaunchStateSQL, state, nonce, platform.PlatformID, now.Add(ltiLaunchStateTTL), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	authURL, err := lti.AuthURL(platform.registration(), login, ltiLocation(c, "launch"), state, nonce)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// ltiSession is the API session a launch starts. The client signs in to
+// Firebase with the custom token.
+type ltiSession struct {
+	CustomToken string  `json:"custom_token"`
+	UserID      string  `json:"user_id"`
+	Role        string  `json:"role"`
+	CourseID    *string `json:"course_id,omitempty"`
+	// Waitlisted is set when the launch put the student on the course
+	// waitlist because the course is full.
+	Waitlisted bool `json:"waitlisted,omitempty"`
+}
+
+// ltiLaunch validates the launch the platform posts and starts a session
+// for its user. With LTI_APP_URL set, the browser is sent to the app with
+// the session in the URL fragment; otherwise the session is the response.
+func (s *Service) ltiLaunch(c *gin.Context) {
+	if errorCode := c.PostForm("error"); errorCode != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "lti_login_failed",
+			"message": errorCode + ": " + c.PostForm("error_description"),
+		})
+		return
+	}
+
+	var (
+		ctx      = c.Request.Context()
+		now      = time.Now()
+		platform struct {
+			ltiPlatform
+			Nonce string
+		}
+	)
+
+	err := pgxscan.Get(ctx, s.pgxPool, &platform, takeLTILaunchStateSQL, c.PostForm("state"), now)
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "lti_state_invalid",
+			"message": "The launch has expired or was already used",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	launch, err := s.ltiVerifier.Verify(platform.registration(), c.PostForm("id_token"), platform.Nonce, now)
+	switch {
+	case errors.Is(err, lti.ErrInvalidLaunch):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "lti_launch_invalid", "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := s.startLTISession(ctx, platform.ltiPlatform, launch, now)
+	switch {
+	case errors.Is(err, errLTIRole), errors.Is(err, errLTIUserInactive), errors.Is(err, errLTIAdmin):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+		return
+	case errors.Is(err, errLTIAccountExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "lti_account_exists", "message": err.Error()})
+		return
+	case errors.As(err, new(*quotaExceededError)):
+		respondQuotaError(c, err)
+		return
+	case errors.Is(err, errAccountProvisioning):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		s.log(c.Request.Context()).Error("Failed to start LTI session", zap.String("platform_id", platform.PlatformID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if s.ltiAppURL == "" {
+		c.JSON(http.StatusOK, session)
+		return
+	}
+
+	fragment := url.Values{"custom_token": {session.CustomToken}, "user_id": {session.UserID}}
+	if session.CourseID != nil {
+		fragment.Set("course_id", *session.CourseID)
+	}
+	if session.Waitlisted {
+		fragment.Set("waitlisted", "true")
+	}
+	c.Redirect(http.StatusSeeOther, s.ltiAppURL+"#"+fragment.Encode())
+}
+
+// ltiUserRecord is a user a launch signs in.
+type ltiUserRecord struct {
+	UserID      string
+	Role        UserRole
+	FirstName   string
+	LastName    string
+	Email       string
+	Status      string
+	FirebaseUID *string
+}
+
+// startLTISession maps the launch's user and context to a user and course,
+// creating them on their first launch, enrolls the user in the course and
+// mints the custom token of the session.
+//
+// Platform users are matched to existing users of the organization by
+// email. Existing users keep their role, while new ones get theirs from
+// their context roles; admins cannot launch. Users are enrolled on their first launch from a
+// context only, so dropping them from the course sticks.
+func (s *Service) startLTISession(ctx context.Context, platform ltiPlatform, launch lti.Launch, now time.Time) (ltiSession, error) {
+	var (
+		session ltiSession
+		user    ltiUserRecord
+		created string
+	)
+
+	err := pgx.BeginFunc(ctx, s.pgxPool, func(tx pgx.Tx) error {
+		userID, err := s.ltiUser(ctx, tx, platform, launch, now)
+		if err != nil {
+			return err
+		}
+
+		if err := pgxscan.Get(ctx, tx, &user, getLTISessionUserSQL, userID); err != nil {
+			return err
+		}
+		if user.Status != "active" {
+			return errLTIUserInactive
+		}
+		if user.Role == UserRoleAdmin {
+			return errLTIAdmin
+		}
+		session.UserID, session.Role = user.UserID, string(user.Role)
+
+		if launch.Context != nil {
+			courseID, err := ltiCourse(ctx, tx, platform, *launch.Context, now)
+			if err != nil {
+				return err
+			}
+			session.CourseID = &courseID
+
+			session.Waitlisted, err = enrollLTIUser(ctx, tx, courseID, user, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		if user.FirebaseUID != nil {
+			return nil
+		}
+
+		// Users without an email get an account of their own on their
+		// first sign-in with the custom token.
+		uid := "lti-" + user.UserID
+		if user.Email != "" {
+			var isNew bool
+			uid, isNew, err = s.firebaseService.CreateInvitedUser(ctx, user.Email, user.FirstName+" "+user.LastName)
+			if err != nil {
+				return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+			}
+			// The launch would mint a token for an account that was not
+			// made for this organization, so it has to be linked with an
+			// import or SCIM first.
+			if !isNew {
+				return errLTIAccountExists
+			}
+			created = uid
+		}
+		user.FirebaseUID = &uid
+
+		if _, err := tx.Exec(ctx, setUserFirebaseUIDSQL, user.UserID, uid, now); err != nil {
+			return err
+		}
+		if created == "" {
+			return nil
+		}
+
+		claims := map[string]interface{}{"role": user.Role, "org_id": platform.OrgID}
+		if err := s.firebaseService.SetCustomClaims(ctx, created, claims); err != nil {
+			return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if created != "" {
+			if deleteErr := s.firebaseService.DeleteUser(context.WithoutCancel(ctx), created); deleteErr != nil {
+				s.log(ctx).Error("Failed to delete account of rolled back LTI user", zap.String("firebase_uid", created), zap.Error(deleteErr))
+			}
+		}
+		return ltiSession{}, err
+	}
+
+	claims := map[string]interface{}{"role": user.Role, "org_id": platform.OrgID}
+	session.CustomToken, err = s.firebaseService.CustomToken(ctx, *user.FirebaseUID, claims)
+	if err != nil {
+		return ltiSession{}, fmt.Errorf("%w: %w", errAccountProvisioning, err)
+	}
+	return session, nil
+}
+
+// ltiUser finds the user a launch signs in, linking the platform user to an
+// existing user with its email or creating a user for it.
+func (s *Service) ltiUser(ctx context.Context, tx pgx.Tx, platform ltiPlatform, launch lti.Launch, now time.Time) (string, error) {
+	var userID string
+	err := tx.QueryRow(ctx, getLTIUserSQL, platform.PlatformID, launch.Subject).Scan(&userID)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	if launch.Email != "" {
+		var role UserRole
+		err := tx.QueryRow(ctx, findLTIEmailUserSQL, platform.OrgID, launch.Email).Scan(&userID, &role)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", err
+		}
+		// Platforms vouch for emails, so they cannot sign in as admins.
+		if role == UserRoleAdmin {
+			return "", errLTIAdmin
+		}
+	}
+
+	if userID == "" {
+		role, ok := ltiUserRole(launch)
+		if !ok {
+			return "", errLTIRole
+		}
+
+		var (
+			firstName, lastName = ltiUserName(launch)
+			email               *string
+		)
+		if launch.Email != "" && isValidEmail(launch.Email) {
+			email = &launch.Email
+		}
+
+		if err := checkOrgQuota(ctx, tx, platform.OrgID, quotaUsers, 1, now); err != nil {
+			return "", err
+		}
+
+		userID = uuid.New().String()
+		if _, err := tx.Exec(ctx, createImportUserSQL, userID, platform.OrgID, role, firstName, lastName, nil, email, now); err != nil {
+			return "", err
+		}
+
+		event := gin.H{
+			"user_id":    userID,
+			"org_id":     platform.OrgID,
+			"role":       role,
+			"first_name": firstName,
+			"last_name":  lastName,
+			"email":      email,
+			"created_at": now,
+		}
+		if err := webhooks.Enqueue(ctx, tx, platform.OrgID, webhooks.EventUserCreated, event, now); err != nil {
+			return "", err
+		}
+	}
+
+	_, err = tx.Exec(ctx, createLTIUserSQL, platform.PlatformID, launch.Subject, userID, now)
+	return userID, err
+}
+
+// ltiCourse finds the course of a launch context, creating it on the first
+// launch from the context.
+func ltiCourse(ctx context.Context, tx pgx.Tx, platform ltiPlatform, context lti.Context, now time.Time) (string, error) {
+	var courseID string
+	err := tx.QueryRow(ctx, getLTIContextSQL, platform.PlatformID, context.ID).Scan(&courseID)
+	if err == nil || !errors.Is(err, pgx.ErrNoRows) {
+		return courseID, err
+	}
+
+	if err := checkOrgQuota(ctx, tx, platform.OrgID, quotaCourses, 1, now); err != nil {
+		return "", err
+	}
+
+	courseID = uuid.New().String()
+	name, description := ltiCourseName(context)
+	if _, err := tx.Exec(ctx, createCourseSql, courseID, platform.OrgID, name, description, nil, nil, nil, nil, nil, now); err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx, createLTIContextSQL, platform.PlatformID, context.ID, courseID, now)
+	return courseID, err
+}
+
+// enrollLTIUser enrolls a user in the course of a launch unless the user
+// was ever enrolled in it, reporting whether the course was full and the
+// user joined its waitlist.
+func enrollLTIUser(ctx context.Context, tx pgx.Tx, courseID string, user ltiUserRecord, now time.Time) (bool, error) {
+	_, err := getEnrollmentForUpdate(ctx, tx, courseID, user.UserID)
+	if err == nil || !pgxscan.NotFound(err) {
+		return false, err
+	}
+
+	result, err := enrollUsers(ctx, tx, courseID, []string{user.UserID}, nil, user.UserID, now)
+	if err != nil {
+		return false, err
+	}
+	return len(result.Waitlisted) > 0, nil
+}
+
+// ltiLocation is the absolute URL of an LTI endpoint of this server.
+func ltiLocation(c *gin.Context, path string) string {
+	scheme := "https"
+	if c.Request.TLS == nil && c.GetHeader("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return scheme + "://" + c.Request.Host + "/lti/" + path
+}
+
+func newLTIState() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(b[:16]), hex.EncodeToString(b[16:]), nil
+}
+
+//go:embed queries/lti/create_lti_platform.sql
+var createLTIPlatformSQL string
+
+//go:embed queries/lti/list_lti_platforms.sql
+var listLTIPlatformsSQL string
+
+//go:embed queries/lti/delete_lti_platform.sql
+var deleteLTIPlatformSQL string
+
+//go:embed queries/lti/find_lti_platforms.sql
+var findLTIPlatformsSQL string
+
+//go:embed queries/lti/create_lti_launch_state.sql
+var createLTILaunchStateSQL string
+
+//go:embed queries/lti/take_lti_launch_state.sql
+var takeLTILaunchStateSQL string
+
+//go:embed queries/lti/get_lti_user.sql
+var getLTIUserSQL string
+
+//go:embed queries/lti/create_lti_user.sql
+var createLTIUserSQL string
+
+//go:embed queries/lti/find_lti_email_user.sql
+var findLTIEmailUserSQL string
+
+//go:embed queries/lti/get_lti_session_user.sql
+var getLTISessionUserSQL string
+
+//go:embed queries/lti/get_lti_context.sql
+var getLTIContextSQL string
+
+//go:embed queries/lti/create_lti_context.sql
+var createLTIContextSQL string
+
+// ltiTutorRoles are the context roles of launches that create tutors.
+var ltiTutorRoles = []string{lti.RoleInstructor, lti.RoleTeachingAssistant, lti.RoleContentDeveloper, lti.RoleMentor, lti.RoleAdministrator}
+
+// ltiUserRole is the role of a user created by a launch: instructors and
+// the other teaching roles of the context make tutors and learners make
+// students. Launches never create admins.
+func ltiUserRole(launch lti.Launch) (UserRole, bool) {
+	switch {
+	case slices.ContainsFunc(ltiTutorRoles, launch.HasRole):
+		return UserRoleTutor, true
+	case launch.HasRole(lti.RoleLearner):
+		return UserRoleStudent, true
+	}
+	return "", false
+}
+
+// ltiUserName is the first and last name of a user created by a launch.
+// Platforms may withhold names, and users without one get a placeholder.
+func ltiUserName(launch lti.Launch) (string, string) {
+	first, last := strings.TrimSpace(launch.GivenName), strings.TrimSpace(launch.FamilyName)
+	if first == "" && last == "" {
+		name := strings.TrimSpace(launch.Name)
+		if i := strings.LastIndexByte(name, ' '); i > 0 {
+			first, last = strings.TrimSpace(name[:i]), name[i+1:]
+		} else {
+			first = name
+		}
+	}
+
+	switch {
+	case first == "" && last == "":
+		return "LTI", "User"
+	case first == "":
+		return last, ""
+	}
+	return first, last
+}
+
+// ltiCourseName is the name and description of the course created for a
+// launch context: its title, with its label as the description, or
+// whichever of them it has.
+func ltiCourseName(context lti.Context) (string, *string) {
+	switch {
+	case context.Title != "" && context.Label != "" && context.Label != context.Title:
+		return context.Title, &context.Label
+	case context.Title != "":
+		return context.Title, nil
+	case context.Label != "":
+		return context.Label, nil
+	}
+	return context.ID, nil
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"scheduler-api/internal/lti"
+	"testing"
+)
+
+func TestLTIUserRole(t *testing.T) {
+	const membership = "http://purl.imsglobal.org/vocab/lis/v2/membership"
+
+	tests := []struct {
+		roles []string
+		want  UserRole
+		ok    bool
+	}{
+		{[]string{membership + "#Learner"}, UserRoleStudent, true},
+		{[]string{"Learner"}, UserRoleStudent, true},
+		{[]string{membership + "#Instructor"}, UserRoleTutor, true},
+		{[]string{membership + "/Instructor#TeachingAssistant"}, UserRoleTutor, true},
+		{[]string{membership + "#Learner", membership + "#Mentor"}, UserRoleTutor, true},
+		{[]string{membership + "#Administrator"}, UserRoleTutor, true},
+		{[]string{"http://purl.imsglobal.org/vocab/lis/v2/institution/person#Administrator"}, "", false},
+		{[]string{"http://purl.imsglobal.org/vocab/lis/v2/system/person#Administrator"}, "", false},
+		{nil, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ltiUserRole(lti.Launch{Roles: tt.roles})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ltiUserRole(%v) = %q, %v, want %q, %v", tt.roles, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLTIUserNameAndCourseName(t *testing.T) {
+	names := []struct {
+		launch      lti.Launch
+		first, last string
+	}{
+		{lti.Launch{GivenName: "Ada", FamilyName: "Lovelace", Name: "Countess"}, "Ada", "Lovelace"},
+		{lti.Launch{Name: "Ada King Lovelace"}, "Ada King", "Lovelace"},
+		{lti.Launch{Name: "Ada"}, "Ada", ""},
+		{lti.Launch{FamilyName: "Lovelace"}, "Lovelace", ""},
+		{lti.Launch{}, "LTI", "User"},
+	}
+	for _, tt := range names {
+		if first, last := ltiUserName(tt.launch); first != tt.first || last != tt.last {
+			t.Errorf("ltiUserName(%+v) = %q %q, want %q %q", tt.launch, first, last, tt.first, tt.last)
+		}
+	}
+
+	courses := []struct {
+		context     lti.Context
+		name        string
+		description string
+	}{
+		{lti.Context{ID: "c1", Title: "Calculus", Label: "MATH 101"}, "Calculus", "MATH 101"},
+		{lti.Context{ID: "c1", Title: "Calculus", Label: "Calculus"}, "Calculus", ""},
+		{lti.Context{ID: "c1", Label: "MATH 101"}, "MATH 101", ""},
+		{lti.Context{ID: "c1"}, "c1", ""},
+	}
+	for _, tt := range courses {
+		name, description := ltiCourseName(tt.context)
+		if name != tt.name || (description == nil) != (tt.description == "") || (description != nil && *description != tt.description) {
+			t.Errorf("ltiCourseName(%+v) = %q, %v, want %q, %q", tt.context, name, description, tt.name, tt.description)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationService interface {
+	GetNotificationPreferences(*gin.Context, string)
+	UpdateNotificationPreferences(*gin.Context, string)
+	ListUserNotifications(*gin.Context, string, ListUserNotificationsParams)
+	GetNotification(*gin.Context, string)
+}
+
+var _ NotificationService = (*Service)(nil)
+
+func (s *Service) GetNotificationPreferences(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own notification preferences",
+		})
+		return
+	}
+
+	preferences, err := getNotificationPreferences(c.Request.Context(), s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+func (s *Service) UpdateNotificationPreferences(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only update your own notification preferences",
+		})
+		return
+	}
+
+	request := NotificationPreferencesUpdate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	changes := map[NotificationChannel]*bool{
+		Email: request.Email,
+		Sms:   request.Sms,
+	}
+	for channel, enabled := range changes {
+		if enabled == nil {
+			continue
+		}
+		if _, err := tx.Exec(ctx, upsertNotificationPreferenceSQL, userID, channel, *enabled, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := getNotificationPreferences(ctx, s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+func (s *Service) ListUserNotifications(c *gin.Context, userID string, params ListUserNotificationsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own notifications",
+		})
+		return
+	}
+
+	notifications := []Notification{}
+	err = pgxscan.Select(c.Request.Context(), s.pgxPool, &notifications, listUserNotificationsSQL, userID, params.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (s *Service) GetNotification(c *gin.Context, notificationID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	notification := Notification{}
+	err = pgxscan.Get(c.Request.Context(), s.pgxPool, &notification, getNotificationSQL, notificationID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "notification_not_found",
+				"message": "Notification not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Hide other users' notifications behind a 404 rather than revealing
+	// that the ID exists.
+	if currentUser.UserID != notification.UserId && currentUser.Role != "admin" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "notification_not_found",
+			"message": "Notification not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+//go:embed queries/notification/get_notification_preferences.sql
+var getNotificationPreferencesSQL string
+
+//go:embed queries/notification/upsert_notification_preference.sql
+var upsertNotificationPreferenceSQL string
+
+//go:embed queries/notification/list_user_notifications.sql
+var listUserNotificationsSQL string
+
+//go:embed queries/notification/get_notification.sql
+var getNotificationSQL string
+
+func getNotificationPreferences(ctx context.Context, db dbExecutor, userID string) (NotificationPreferences, error) {
+	preferences := NotificationPreferences{}
+	return preferences, pgxscan.Get(ctx, db, &preferences, getNotificationPreferencesSQL, userID)
+}
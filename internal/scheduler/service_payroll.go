@@ -0,0 +1,1013 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// errTimesheetOverlap is returned when a pay period overlaps another
+	// timesheet of the tutor.
+	errTimesheetOverlap = errors.New("pay period overlaps another timesheet of the tutor")
+
+	// errMixedPayCurrencies is returned when a tutor's classes are paid at
+	// rates in different currencies.
+	errMixedPayCurrencies = errors.New("classes are paid in more than one currency")
+)
+
+// missingPayRateError is returned when no pay rate matches some of a
+// tutor's classes.
+type missingPayRateError struct {
+	TutorID  string
+	ClassIDs []string
+}
+
+func (e *missingPayRateError) Error() string {
+	return "no pay rate matches some of the classes of tutor " + e.TutorID
+}
+
+type PayrollService interface {
+	ListPayRates(*gin.Context)
+	CreatePayRate(*gin.Context)
+	UpdatePayRate(*gin.Context, string)
+	DeletePayRate(*gin.Context, string)
+	GetPayrollPolicy(*gin.Context)
+	SetPayrollPolicy(*gin.Context)
+	ListTimesheets(*gin.Context, ListTimesheetsParams)
+	GenerateTimesheets(*gin.Context)
+	ExportTimesheets(*gin.Context, ExportTimesheetsParams)
+	GetTimesheet(*gin.Context, string)
+	AddTimesheetAdjustment(*gin.Context, string)
+	ApproveTimesheet(*gin.Context, string)
+}
+
+var _ PayrollService = (*Service)(nil)
+
+func (s *Service) ListPayRates(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	rates, err := listPayRates(c.Request.Context(), s.pgxPool, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (s *Service) CreatePayRate(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	request := PayRate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	currency, ok := s.validatePayRate(c, currentUser.OrgID, request)
+	if !ok {
+		return
+	}
+
+	payRateID := uuid.New().String()
+	_, err := s.pgxPool.Exec(c.Request.Context(), createPayRateSQL, payRateID, currentUser.OrgID, request.TutorId, request.CourseId,
+		request.HourlyCents, currency, time.Now())
+	if err != nil {
+		respondPayRateWriteError(c, err)
+		return
+	}
+
+	s.respondWithPayRate(c, payRateID, currentUser.OrgID, http.StatusCreated)
+}
+
+func (s *Service) UpdatePayRate(c *gin.Context, payRateID string) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	request := PayRate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	currency, ok := s.validatePayRate(c, currentUser.OrgID, request)
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), updatePayRateSQL, payRateID, currentUser.OrgID, request.TutorId, request.CourseId,
+		request.HourlyCents, currency, time.Now())
+	if err != nil {
+		respondPayRateWriteError(c, err)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondPayRateNotFound(c)
+		return
+	}
+
+	s.respondWithPayRate(c, payRateID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) DeletePayRate(c *gin.Context, payRateID string) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deletePayRateSQL, payRateID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondPayRateNotFound(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// validatePayRate checks a pay rate and returns its currency, USD unless
+// given. It writes an error response and returns false when the rate is
+// invalid.
+func (s *Service) validatePayRate(c *gin.Context, orgID string, rate PayRate) (string, bool) {
+	currency := "USD"
+	if rate.Currency != nil {
+		currency = *rate.Currency
+	}
+
+	if rate.HourlyCents < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "hourly_cents must not be negative"})
+		return "", false
+	}
+
+	if !currencyPattern.MatchString(currency) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "currency must be a three letter ISO 4217 code, e.g. USD"})
+		return "", false
+	}
+
+	found, err := courseAndTutorInOrg(c.Request.Context(), s.pgxPool, orgID, rate.CourseId, rate.TutorId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return "", false
+	}
+	if !found {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "course_id and tutor_id must be a course and a tutor of the organization"})
+		return "", false
+	}
+
+	return currency, true
+}
+
+func (s *Service) respondWithPayRate(c *gin.Context, payRateID, orgID string, status int) {
+	rate := PayRate{}
+	if err := pgxscan.Get(c.Request.Context(), s.pgxPool, &rate, queryGetPayRateSQL, payRateID, orgID); err != nil {
+		if pgxscan.NotFound(err) {
+			respondPayRateNotFound(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, rate)
+}
+
+func (s *Service) GetPayrollPolicy(c *gin.Context) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	policy, err := getPayrollPolicy(c.Request.Context(), s.pgxPool, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+func (s *Service) SetPayrollPolicy(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	request := PayrollPolicy{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.LateCancellationHours < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "late_cancellation_hours must not be negative"})
+		return
+	}
+
+	if request.LateCancellationPayPercent < 0 || request.LateCancellationPayPercent > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "late_cancellation_pay_percent must be between 0 and 100"})
+		return
+	}
+
+	_, err := s.pgxPool.Exec(c.Request.Context(), setPayrollPolicySQL, currentUser.OrgID, request.LateCancellationHours,
+		request.LateCancellationPayPercent, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (s *Service) ListTimesheets(c *gin.Context, params ListTimesheetsParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	// Tutors only see their own timesheets.
+	tutorID := params.TutorId
+	switch currentUser.Role {
+	case "admin":
+	case "tutor":
+		tutorID = &currentUser.UserID
+	default:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Only admin and tutors can view timesheets",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	timesheets := []Timesheet{}
+	err = pgxscan.Select(ctx, s.pgxPool, &timesheets, queryListTimesheetsSQL, currentUser.OrgID, tutorID, params.Status, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := loadTimesheetEntries(ctx, s.pgxPool, timesheets); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheets)
+}
+
+func (s *Service) GenerateTimesheets(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	request := TimesheetGenerate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !request.PeriodEnd.After(request.PeriodStart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period_end must be after period_start"})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tutorIDs := []string{}
+	if request.TutorId != nil {
+		found, err := courseAndTutorInOrg(ctx, tx, currentUser.OrgID, nil, request.TutorId)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !found {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tutor_id must be a tutor of the organization"})
+			return
+		}
+		tutorIDs = append(tutorIDs, *request.TutorId)
+	} else {
+		err := pgxscan.Select(ctx, tx, &tutorIDs, queryListPeriodTutorsSQL, currentUser.OrgID, request.PeriodStart, request.PeriodEnd)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	policy, err := getPayrollPolicy(ctx, tx, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rates, err := listPayRates(ctx, tx, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	timesheetIDs := []string{}
+	for _, tutorID := range tutorIDs {
+		timesheetID, err := computeTimesheet(ctx, tx, currentUser.OrgID, tutorID, request.PeriodStart, request.PeriodEnd, policy, rates, now)
+		if err != nil {
+			respondTimesheetError(c, err)
+			return
+		}
+		timesheetIDs = append(timesheetIDs, timesheetID)
+	}
+
+	timesheets := []Timesheet{}
+	for _, timesheetID := range timesheetIDs {
+		timesheet, err := getTimesheet(ctx, tx, timesheetID, currentUser.OrgID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		timesheets = append(timesheets, timesheet)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		respondTimesheetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheets)
+}
+
+func (s *Service) ExportTimesheets(c *gin.Context, params ExportTimesheetsParams) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	if !params.To.After(params.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	rows := []timesheetExportRecord{}
+	if err := pgxscan.Select(c.Request.Context(), s.pgxPool, &rows, queryListExportTimesheetsSQL, currentUser.OrgID, params.From, params.To); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := timesheetsCSV(rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("timesheets-%s-%s.csv", params.From.UTC().Format("2006-01-02"), params.To.UTC().Format("2006-01-02"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", file)
+}
+
+func (s *Service) GetTimesheet(c *gin.Context, timesheetID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	timesheet, err := getTimesheet(c.Request.Context(), s.pgxPool, timesheetID, currentUser.OrgID)
+	if err != nil {
+		respondTimesheetLookupError(c, err)
+		return
+	}
+
+	if currentUser.UserID != timesheet.TutorId && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own timesheets",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
+
+func (s *Service) AddTimesheetAdjustment(c *gin.Context, timesheetID string) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	request := TimesheetAdjustment{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(request.Note) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "note is required"})
+		return
+	}
+
+	paidMinutes := 0
+	if request.PaidMinutes != nil {
+		paidMinutes = *request.PaidMinutes
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	locked, ok := s.lockDraftTimesheet(c, tx, timesheetID, currentUser.OrgID)
+	if !ok {
+		return
+	}
+
+	entry := TimesheetEntry{
+		EntryId:     uuid.New().String(),
+		Kind:        Adjustment,
+		PaidMinutes: paidMinutes,
+		AmountCents: request.AmountCents,
+		Note:        &request.Note,
+	}
+	if err := createTimesheetEntry(ctx, tx, timesheetID, entry, &currentUser.UserID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, refreshTimesheetTotalsSQL, timesheetID, locked.Currency, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	timesheet, err := getTimesheet(ctx, tx, timesheetID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, timesheet)
+}
+
+func (s *Service) ApproveTimesheet(c *gin.Context, timesheetID string) {
+	currentUser, ok := s.requireAdmin(c, "manage payroll")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, ok := s.lockDraftTimesheet(c, tx, timesheetID, currentUser.OrgID); !ok {
+		return
+	}
+
+	if _, err := tx.Exec(ctx, approveTimesheetSQL, timesheetID, currentUser.UserID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	timesheet, err := getTimesheet(ctx, tx, timesheetID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
+
+type lockedTimesheet struct {
+	TimesheetID string
+	TutorID     string
+	Status      TimesheetStatus
+	Currency    string
+}
+
+// lockDraftTimesheet locks a timesheet for the rest of the transaction. It
+// writes an error response and returns false when the timesheet does not
+// exist or is already approved.
+func (s *Service) lockDraftTimesheet(c *gin.Context, tx pgx.Tx, timesheetID, orgID string) (lockedTimesheet, bool) {
+	locked := lockedTimesheet{}
+	if err := pgxscan.Get(c.Request.Context(), tx, &locked, queryGetTimesheetForUpdateSQL, timesheetID, orgID); err != nil {
+		respondTimesheetLookupError(c, err)
+		return lockedTimesheet{}, false
+	}
+
+	if locked.Status == TimesheetStatusApproved {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "timesheet_approved",
+			"message": "Approved timesheets cannot change",
+		})
+		return lockedTimesheet{}, false
+	}
+
+	return locked, true
+}
+
+// computeTimesheet fills in the tutor's timesheet for a pay period from
+// the classes they taught, creating it if needed, and returns its ID. A
+// draft timesheet of the period is recomputed and keeps its adjustments; an
+// approved one is left as it is.
+func computeTimesheet(ctx context.Context, tx pgx.Tx, orgID, tutorID string, start, end time.Time, policy PayrollPolicy, rates []PayRate, now time.Time) (string, error) {
+	existing := lockedTimesheet{}
+	err := pgxscan.Get(ctx, tx, &existing, queryGetPeriodTimesheetForUpdateSQL, tutorID, start, end)
+	switch {
+	case err == nil && existing.Status == TimesheetStatusApproved:
+		return existing.TimesheetID, nil
+	case err == nil:
+		if _, err := tx.Exec(ctx, deleteClassEntriesSQL, existing.TimesheetID); err != nil {
+			return "", err
+		}
+	case pgxscan.NotFound(err):
+		existing.TimesheetID = uuid.New().String()
+		existing.Currency = "USD"
+		if _, err := tx.Exec(ctx, createTimesheetSQL, existing.TimesheetID, orgID, tutorID, start, end, existing.Currency, now); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23P01" {
+				return "", errTimesheetOverlap
+			}
+			return "", err
+		}
+	default:
+		return "", err
+	}
+
+	classes := []payrollClass{}
+	if err := pgxscan.Select(ctx, tx, &classes, queryListTutorPeriodClassesSQL, tutorID, start, end); err != nil {
+		return "", err
+	}
+
+	entries, unpaid := []TimesheetEntry{}, []string{}
+	currency := ""
+	for _, class := range classes {
+		kind, minutes, paid := payrollEntry(class, policy, now)
+		if !paid {
+			continue
+		}
+
+		rate, ok := matchPayRate(rates, tutorID, class.CourseID)
+		if !ok {
+			unpaid = append(unpaid, class.ClassID)
+			continue
+		}
+		if currency != "" && *rate.Currency != currency {
+			return "", errMixedPayCurrencies
+		}
+		currency = *rate.Currency
+
+		entries = append(entries, TimesheetEntry{
+			EntryId:     uuid.New().String(),
+			Kind:        kind,
+			ClassId:     &class.ClassID,
+			CourseName:  class.CourseName,
+			StartTime:   &class.StartTime,
+			Duration:    &class.Duration,
+			PaidMinutes: minutes,
+			PayRateId:   rate.PayRateId,
+			HourlyCents: &rate.HourlyCents,
+			AmountCents: payForMinutes(rate.HourlyCents, minutes),
+		})
+	}
+	if len(unpaid) > 0 {
+		return "", &missingPayRateError{TutorID: tutorID, ClassIDs: unpaid}
+	}
+
+	for _, entry := range entries {
+		if err := createTimesheetEntry(ctx, tx, existing.TimesheetID, entry, nil, now); err != nil {
+			return "", err
+		}
+	}
+
+	if currency == "" {
+		currency = existing.Currency
+	}
+	if _, err := tx.Exec(ctx, refreshTimesheetTotalsSQL, existing.TimesheetID, currency, now); err != nil {
+		return "", err
+	}
+
+	return existing.TimesheetID, nil
+}
+
+// checkPayrollLock responds with a conflict and returns false when an
+// approved timesheet of one of the class's teachers, or of tutorIDs, covers
+// any of the times. Approved pay periods are locked against changes to the
+// classes they paid for.
+func checkPayrollLock(c *gin.Context, db dbExecutor, classID string, tutorIDs []string, times ...time.Time) bool {
+	if tutorIDs == nil {
+		tutorIDs = []string{}
+	}
+
+	var locked bool
+	if err := db.QueryRow(c.Request.Context(), queryIsPayrollLockedSQL, classID, times, tutorIDs).Scan(&locked); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if locked {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "payroll_locked",
+			"message": "The class falls in a pay period whose timesheet is approved",
+		})
+		return false
+	}
+
+	return true
+}
+
+func getPayrollPolicy(ctx context.Context, db dbExecutor, orgID string) (PayrollPolicy, error) {
+	policy := PayrollPolicy{}
+	return policy, pgxscan.Get(ctx, db, &policy, queryGetPayrollPolicySQL, orgID)
+}
+
+func listPayRates(ctx context.Context, db dbExecutor, orgID string) ([]PayRate, error) {
+	rates := []PayRate{}
+	return rates, pgxscan.Select(ctx, db, &rates, queryListPayRatesSQL, orgID)
+}
+
+func getTimesheet(ctx context.Context, db dbExecutor, timesheetID, orgID string) (Timesheet, error) {
+	timesheet := Timesheet{}
+	if err := pgxscan.Get(ctx, db, &timesheet, queryGetTimesheetSQL, timesheetID, orgID); err != nil {
+		return Timesheet{}, err
+	}
+
+	timesheets := []Timesheet{timesheet}
+	return timesheets[0], loadTimesheetEntries(ctx, db, timesheets)
+}
+
+type timesheetEntryRecord struct {
+	TimesheetID string
+	TimesheetEntry
+}
+
+// loadTimesheetEntries fills in the entries of each timesheet.
+func loadTimesheetEntries(ctx context.Context, db dbExecutor, timesheets []Timesheet) error {
+	timesheetIDs := make([]string, len(timesheets))
+	for i := range timesheets {
+		timesheetIDs[i] = timesheets[i].TimesheetId
+		timesheets[i].Entries = []TimesheetEntry{}
+	}
+
+	records := []timesheetEntryRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListTimesheetEntriesSQL, timesheetIDs); err != nil {
+		return err
+	}
+
+	byTimesheet := map[string][]TimesheetEntry{}
+	for _, record := range records {
+		byTimesheet[record.TimesheetID] = append(byTimesheet[record.TimesheetID], record.TimesheetEntry)
+	}
+	for i := range timesheets {
+		if entries, ok := byTimesheet[timesheets[i].TimesheetId]; ok {
+			timesheets[i].Entries = entries
+		}
+	}
+
+	return nil
+}
+
+func createTimesheetEntry(ctx context.Context, db dbExecutor, timesheetID string, entry TimesheetEntry, actorID *string, now time.Time) error {
+	_, err := db.Exec(ctx, createTimesheetEntrySQL, entry.EntryId, timesheetID, entry.Kind, entry.ClassId, entry.CourseName, entry.StartTime,
+		entry.Duration, entry.PaidMinutes, entry.PayRateId, entry.HourlyCents, entry.AmountCents, entry.Note, actorID, now)
+	return err
+}
+
+type timesheetExportRecord struct {
+	TimesheetID             string
+	TutorID                 string
+	TutorName               string
+	TutorEmail              *string
+	PeriodStart             time.Time
+	PeriodEnd               time.Time
+	Currency                string
+	TaughtMinutes           int
+	LateCancellationMinutes int
+	PaidMinutes             int
+	TotalCents              int
+	ApprovedAt              *time.Time
+}
+
+// timesheetsCSV writes approved timesheets as the CSV file handed to the
+// payroll provider, one row per timesheet.
+func timesheetsCSV(rows []timesheetExportRecord) ([]byte, error) {
+	var file bytes.Buffer
+	w := csv.NewWriter(&file)
+
+	header := []string{
+		"timesheet_id", "tutor_id", "tutor_name", "tutor_email", "period_start", "period_end",
+		"taught_hours", "late_cancellation_hours", "paid_hours", "total", "currency", "approved_at",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		email, approvedAt := "", ""
+		if row.TutorEmail != nil {
+			email = *row.TutorEmail
+		}
+		if row.ApprovedAt != nil {
+			approvedAt = row.ApprovedAt.UTC().Format(time.RFC3339)
+		}
+
+		record := []string{
+			row.TimesheetID,
+			row.TutorID,
+			row.TutorName,
+			email,
+			row.PeriodStart.UTC().Format(time.RFC3339),
+			row.PeriodEnd.UTC().Format(time.RFC3339),
+			formatHours(row.TaughtMinutes),
+			formatHours(row.LateCancellationMinutes),
+			formatHours(row.PaidMinutes),
+			formatAmount(row.TotalCents),
+			row.Currency,
+			approvedAt,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return file.Bytes(), w.Error()
+}
+
+func respondPayRateNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "pay_rate_not_found",
+		"message": "Pay rate not found",
+	})
+}
+
+// respondPayRateWriteError writes the error of a failed pay rate write,
+// turning a second rate for the same tutor and course into a conflict.
+func respondPayRateWriteError(c *gin.Context, err error) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "pay_rate_exists",
+			"message": "A pay rate for the same tutor and course exists",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func respondTimesheetLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "timesheet_not_found",
+			"message": "Timesheet not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// respondTimesheetError writes the error of a failed timesheet computation.
+func respondTimesheetError(c *gin.Context, err error) {
+	var missing *missingPayRateError
+	switch {
+	case errors.As(err, &missing):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "no_pay_rate",
+			"message":   missing.Error(),
+			"tutor_id":  missing.TutorID,
+			"class_ids": missing.ClassIDs,
+		})
+	case errors.Is(err, errMixedPayCurrencies):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "mixed_currencies",
+			"message": err.Error(),
+		})
+	case errors.Is(err, errTimesheetOverlap):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "timesheet_overlap",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+//go:embed queries/payroll/list_pay_rates.sql
+var queryListPayRatesSQL string
+
+//go:embed queries/payroll/get_pay_rate.sql
+var queryGetPayRateSQL string
+
+//go:embed queries/payroll/create_pay_rate.sql
+var createPayRateSQL string
+
+//go:embed queries/payroll/update_pay_rate.sql
+var updatePayRateSQL string
+
+//go:embed queries/payroll/delete_pay_rate.sql
+var deletePayRateSQL string
+
+//go:embed queries/payroll/get_payroll_policy.sql
+var queryGetPayrollPolicySQL string
+
+//go:embed queries/payroll/set_payroll_policy.sql
+var setPayrollPolicySQL string
+
+//go:embed queries/payroll/list_period_tutors.sql
+var queryListPeriodTutorsSQL string
+
+//go:embed queries/payroll/list_tutor_period_classes.sql
+var queryListTutorPeriodClassesSQL string
+
+//go:embed queries/payroll/get_period_timesheet_for_update.sql
+var queryGetPeriodTimesheetForUpdateSQL string
+
+//go:embed queries/payroll/create_timesheet.sql
+var createTimesheetSQL string
+
+//go:embed queries/payroll/delete_class_entries.sql
+var deleteClassEntriesSQL string
+
+//go:embed queries/payroll/create_timesheet_entry.sql
+var createTimesheetEntrySQL string
+
+//go:embed queries/payroll/refresh_timesheet_totals.sql
+var refreshTimesheetTotalsSQL string
+
+//go:embed queries/payroll/list_timesheets.sql
+var queryListTimesheetsSQL string
+
+//go:embed queries/payroll/get_timesheet.sql
+var queryGetTimesheetSQL string
+
+//go:embed queries/payroll/get_timesheet_for_update.sql
+var queryGetTimesheetForUpdateSQL string
+
+//go:embed queries/payroll/list_timesheet_entries.sql
+var queryListTimesheetEntriesSQL string
+
+//go:embed queries/payroll/approve_timesheet.sql
+var approveTimesheetSQL string
+
+//go:embed queries/payroll/list_export_timesheets.sql
+var queryListExportTimesheetsSQL string
+
+//go:embed queries/payroll/is_payroll_locked.sql
+var queryIsPayrollLockedSQL string
+
+// payrollClass is a class a tutor teaches in a pay period, with the tutor's
+// attendance.
+type payrollClass struct {
+	ClassID      string
+	CourseID     *string
+	CourseName   *string
+	StartTime    time.Time
+	Duration     int
+	Status       string
+	CancelReason *string
+	CancelledAt  *time.Time
+	Attended     *bool
+}
+
+// payrollEntry returns how a class is paid and for how many minutes. Classes
+// that have ended are paid in full unless the tutor was recorded absent.
+// Cancelled classes are paid under the late cancellation policy when they
+// were cancelled late for a reason other than the tutor being unavailable.
+func payrollEntry(class payrollClass, policy PayrollPolicy, now time.Time) (TimesheetEntryKind, int, bool) {
+	if class.Status != string(ClassStatusCancelled) {
+		if class.Attended != nil && !*class.Attended {
+			return "", 0, false
+		}
+		if classEndTime(class.StartTime, class.Duration).After(now) {
+			return "", 0, false
+		}
+		return Taught, class.Duration, true
+	}
+
+	if class.CancelledAt == nil || (class.CancelReason != nil && *class.CancelReason == string(TutorUnavailable)) {
+		return "", 0, false
+	}
+
+	notice := class.StartTime.Sub(*class.CancelledAt)
+	if notice >= time.Duration(policy.LateCancellationHours)*time.Hour {
+		return "", 0, false
+	}
+
+	minutes := (class.Duration*policy.LateCancellationPayPercent + 50) / 100
+	if minutes == 0 {
+		return "", 0, false
+	}
+	return LateCancellation, minutes, true
+}
+
+// matchPayRate returns the pay rate of a tutor for a class of courseID: a
+// rate naming both the tutor and the course, then the tutor, then the
+// course, then one for the whole organization.
+func matchPayRate(rates []PayRate, tutorID string, courseID *string) (PayRate, bool) {
+	match, best := PayRate{}, -1
+	for _, rate := range rates {
+		specificity := 0
+		if rate.TutorId != nil {
+			if *rate.TutorId != tutorID {
+				continue
+			}
+			specificity += 2
+		}
+		if rate.CourseId != nil {
+			if courseID == nil || *rate.CourseId != *courseID {
+				continue
+			}
+			specificity++
+		}
+
+		if specificity > best {
+			match, best = rate, specificity
+		}
+	}
+
+	return match, best >= 0
+}
+
+// payForMinutes returns the pay in cents for minutes at an hourly rate,
+// rounded to the nearest cent.
+func payForMinutes(hourlyCents, minutes int) int {
+	return (hourlyCents*minutes + 30) / 60
+}
+
+// formatHours formats minutes as decimal hours, e.g. 90 as 1.50.
+func formatHours(minutes int) string {
+	return formatAmount((minutes*100 + 30) / 60)
+}
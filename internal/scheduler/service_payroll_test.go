@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPayrollEntry(t *testing.T) {
+	var (
+		start    = time.Date(2025, 3, 3, 15, 0, 0, 0, time.UTC)
+		now      = start.Add(24 * time.Hour)
+		policy   = PayrollPolicy{LateCancellationHours: 24, LateCancellationPayPercent: 50}
+		absent   = false
+		present  = true
+		early    = start.Add(-48 * time.Hour)
+		late     = start.Add(-2 * time.Hour)
+		weather  = string(Weather)
+		tutorOut = string(TutorUnavailable)
+		held     = string(ClassStatusScheduled)
+		off      = string(ClassStatusCancelled)
+	)
+
+	tests := []struct {
+		name        string
+		class       payrollClass
+		wantKind    TimesheetEntryKind
+		wantMinutes int
+		wantOK      bool
+	}{
+		{"taught without attendance", payrollClass{StartTime: start, Duration: 60, Status: held}, Taught, 60, true},
+		{"taught and present", payrollClass{StartTime: start, Duration: 60, Status: held, Attended: &present}, Taught, 60, true},
+		{"tutor absent", payrollClass{StartTime: start, Duration: 60, Status: held, Attended: &absent}, "", 0, false},
+		{"not over yet", payrollClass{StartTime: now.Add(-30 * time.Minute), Duration: 60, Status: held}, "", 0, false},
+		{"late cancellation", payrollClass{StartTime: start, Duration: 45, Status: off, CancelReason: &weather, CancelledAt: &late}, LateCancellation, 23, true},
+		{"early cancellation", payrollClass{StartTime: start, Duration: 60, Status: off, CancelReason: &weather, CancelledAt: &early}, "", 0, false},
+		{"tutor cancelled late", payrollClass{StartTime: start, Duration: 60, Status: off, CancelReason: &tutorOut, CancelledAt: &late}, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, minutes, ok := payrollEntry(tt.class, policy, now)
+			if kind != tt.wantKind || minutes != tt.wantMinutes || ok != tt.wantOK {
+				t.Errorf("got %q, %d, %v, want %q, %d, %v", kind, minutes, ok, tt.wantKind, tt.wantMinutes, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMatchPayRate(t *testing.T) {
+	var (
+		tutor  = "tutor-1"
+		course = "course-1"
+	)
+
+	rates := []PayRate{
+		{HourlyCents: 2000},
+		{CourseId: &course, HourlyCents: 2500},
+		{TutorId: &tutor, HourlyCents: 3000},
+		{TutorId: &tutor, CourseId: &course, HourlyCents: 3500},
+	}
+
+	tests := []struct {
+		name     string
+		tutorID  string
+		courseID *string
+		want     int
+	}{
+		{"organization rate", "tutor-2", nil, 2000},
+		{"course rate", "tutor-2", &course, 2500},
+		{"tutor rate", tutor, nil, 3000},
+		{"tutor and course rate", tutor, &course, 3500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchPayRate(rates, tt.tutorID, tt.courseID)
+			if !ok || got.HourlyCents != tt.want {
+				t.Errorf("expected %d, got %d (matched %v)", tt.want, got.HourlyCents, ok)
+			}
+		})
+	}
+
+	if _, ok := matchPayRate(rates[2:3], "tutor-2", nil); ok {
+		t.Error("expected another tutor's rate not to match")
+	}
+}
+
+func TestPayForMinutes(t *testing.T) {
+	if got := payForMinutes(3000, 45); got != 2250 {
+		t.Errorf("expected 2250, got %d", got)
+	}
+	if got := payForMinutes(1999, 1); got != 33 {
+		t.Errorf("expected rounding to the nearest cent, got %d", got)
+	}
+	if got := formatHours(90); got != "1.50" {
+		t.Errorf("expected 1.50, got %q", got)
+	}
+	if got := formatHours(50); got != "0.83" {
+		t.Errorf("expected 0.83, got %q", got)
+	}
+}
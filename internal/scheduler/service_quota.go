@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+// Quotas of an organization's plan, enforced when users, courses and
+// classes are created.
+const (
+	quotaUsers          = "users"
+	quotaCourses        = "courses"
+	quotaMonthlyClasses = "monthly_classes"
+)
+
+// quotaExceededError is returned when creating something would take an
+// organization past a quota of its plan.
+type quotaExceededError struct {
+	Plan  string
+	Quota string
+	Limit int
+}
+
+func (e *quotaExceededError) Error() string {
+	return fmt.Sprintf("the %s plan allows at most %d %s", e.Plan, e.Limit, quotaDescription(e.Quota))
+}
+
+type orgQuotaRecord struct {
+	Plan              string
+	MaxUsers          *int
+	MaxCourses        *int
+	MaxMonthlyClasses *int
+}
+
+type orgUsageRecord struct {
+	Users          int
+	Courses        int
+	MonthlyClasses int
+}
+
+//go:embed queries/quota/lock_org_quota.sql
+var queryLockOrgQuotaSQL string
+
+//go:embed queries/quota/get_org_usage.sql
+var queryGetOrgUsageSQL string
+
+// checkOrgQuota returns a *quotaExceededError when creating adding more of
+// what quota counts would take the organization past it. Callers that
+// cannot tell how many rows they create check after creating them, with
+// adding 0. The organization's quotas are locked until the transaction
+// ends, so concurrent creates are counted one after the other. at is the
+// start of the class being created, which picks the month monthly quotas
+// count.
+func checkOrgQuota(ctx context.Context, db dbExecutor, orgID, quota string, adding int, at time.Time) error {
+	quotas := []orgQuotaRecord{}
+	if err := pgxscan.Select(ctx, db, &quotas, queryLockOrgQuotaSQL, orgID); err != nil {
+		return err
+	}
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	from, to := calendarMonth(at)
+	usage := orgUsageRecord{}
+	if err := pgxscan.Get(ctx, db, &usage, queryGetOrgUsageSQL, orgID, from, to); err != nil {
+		return err
+	}
+
+	if exceeded := orgQuotaExceeded(quotas[0], usage, quota, adding); exceeded != nil {
+		return exceeded
+	}
+	return nil
+}
+
+// checkOrgQuotaSQL is checkOrgQuota for database/sql transactions.
+func checkOrgQuotaSQL(ctx context.Context, tx *sql.Tx, orgID, quota string, adding int, at time.Time) error {
+	quotas := orgQuotaRecord{}
+	err := tx.QueryRowContext(ctx, queryLockOrgQuotaSQL, orgID).Scan(&quotas.Plan, &quotas.MaxUsers, &quotas.MaxCourses, &quotas.MaxMonthlyClasses)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	from, to := calendarMonth(at)
+	usage := orgUsageRecord{}
+	err = tx.QueryRowContext(ctx, queryGetOrgUsageSQL, orgID, from, to).Scan(&usage.Users, &usage.Courses, &usage.MonthlyClasses)
+	if err != nil {
+		return err
+	}
+
+	if exceeded := orgQuotaExceeded(quotas, usage, quota, adding); exceeded != nil {
+		return exceeded
+	}
+	return nil
+}
+
+// respondQuotaError writes the response for an error of checkOrgQuota.
+func respondQuotaError(c *gin.Context, err error) {
+	var exceeded *quotaExceededError
+	if errors.As(err, &exceeded) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "quota_exceeded",
+			"message": exceeded.Error(),
+			"plan":    exceeded.Plan,
+			"quota":   exceeded.Quota,
+			"limit":   exceeded.Limit,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// orgQuotaExceeded returns the error for a quota of an organization's plan
+// that adding more of what it counts would exceed, or nil when it would not
+// or the plan does not limit it.
+func orgQuotaExceeded(quotas orgQuotaRecord, usage orgUsageRecord, quota string, adding int) *quotaExceededError {
+	var limit *int
+	var used int
+	switch quota {
+	case quotaUsers:
+		limit, used = quotas.MaxUsers, usage.Users
+	case quotaCourses:
+		limit, used = quotas.MaxCourses, usage.Courses
+	case quotaMonthlyClasses:
+		limit, used = quotas.MaxMonthlyClasses, usage.MonthlyClasses
+	}
+
+	if limit == nil || used+adding <= *limit {
+		return nil
+	}
+	return &quotaExceededError{Plan: quotas.Plan, Quota: quota, Limit: *limit}
+}
+
+// quotaDescription describes what a quota counts, for error messages.
+func quotaDescription(quota string) string {
+	switch quota {
+	case quotaUsers:
+		return "active users"
+	case quotaMonthlyClasses:
+		return "classes a month"
+	default:
+		return quota
+	}
+}
+
+// calendarMonth returns the start of the UTC calendar month of t and of the
+// month after.
+func calendarMonth(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
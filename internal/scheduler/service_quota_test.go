@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrgQuotaExceeded(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	quotas := orgQuotaRecord{Plan: "starter", MaxUsers: limit(10), MaxMonthlyClasses: limit(0)}
+	usage := orgUsageRecord{Users: 9, Courses: 500, MonthlyClasses: 0}
+
+	tests := []struct {
+		quota  string
+		adding int
+		want   bool
+	}{
+		{quotaUsers, 1, false},
+		{quotaUsers, 2, true},
+		{quotaCourses, 1, false},
+		{quotaMonthlyClasses, 0, false},
+		{quotaMonthlyClasses, 1, true},
+	}
+
+	for _, tt := range tests {
+		got := orgQuotaExceeded(quotas, usage, tt.quota, tt.adding)
+		if (got != nil) != tt.want {
+			t.Errorf("orgQuotaExceeded(%s, %d) = %v, want exceeded %v", tt.quota, tt.adding, got, tt.want)
+		}
+	}
+
+	// Creates checked after the fact add nothing, and fail once past the
+	// limit rather than at it.
+	usage.Users = 10
+	if got := orgQuotaExceeded(quotas, usage, quotaUsers, 0); got != nil {
+		t.Errorf("at the limit: %v, want nil", got)
+	}
+	usage.Users = 11
+	got := orgQuotaExceeded(quotas, usage, quotaUsers, 0)
+	if got == nil || got.Error() != "the starter plan allows at most 10 active users" {
+		t.Errorf("past the limit: %v", got)
+	}
+}
+
+func TestCalendarMonth(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	from, to := calendarMonth(time.Date(2026, 1, 1, 1, 0, 0, 0, loc))
+
+	if want := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("from = %v, want %v", from, want)
+	}
+	if want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("to = %v, want %v", to, want)
+	}
+}
@@ -0,0 +1,879 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// errResourceReserved is returned when the database rejects a reservation
+// that overlaps another one, which happens when two classes race for the
+// same resource.
+var errResourceReserved = errors.New("resource is already reserved for an overlapping class")
+
+type ResourceService interface {
+	ListResources(*gin.Context, ListResourcesParams)
+	CreateResource(*gin.Context)
+	FindAvailableResources(*gin.Context, FindAvailableResourcesParams)
+	GetResource(*gin.Context, string)
+	UpdateResource(*gin.Context, string)
+	DeleteResource(*gin.Context, string)
+	GetResourceCalendar(*gin.Context, string, GetResourceCalendarParams)
+	UpdateClassResources(*gin.Context, string)
+}
+
+var _ ResourceService = (*Service)(nil)
+
+func (s *Service) ListResources(c *gin.Context, params ListResourcesParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	resources := []Resource{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &resources, queryListResourcesSQL, currentUser.OrgID, params.Type); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := loadOpeningHours(ctx, s.pgxPool, resources); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
+
+func (s *Service) CreateResource(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage resources")
+	if !ok {
+		return
+	}
+
+	request := Resource{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timezone, hours, err := validateResource(request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx        = c.Request.Context()
+		now        = time.Now()
+		resourceID = uuid.New().String()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, createResourceSQL, resourceID, currentUser.OrgID, request.Name, request.Type, request.Capacity, timezone, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := replaceOpeningHours(ctx, tx, resourceID, hours); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithResource(c, resourceID, currentUser.OrgID, http.StatusCreated)
+}
+
+func (s *Service) FindAvailableResources(c *gin.Context, params FindAvailableResourcesParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if params.Duration <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "duration must be greater than zero"})
+		return
+	}
+
+	var (
+		ctx  = c.Request.Context()
+		slot = classSlot{params.StartTime, params.Duration}
+	)
+
+	candidates := []Resource{}
+	err = pgxscan.Select(ctx, s.pgxPool, &candidates, queryListFreeResourcesSQL, currentUser.OrgID, slot.StartTime, slot.endTime(),
+		params.Type, params.MinCapacity)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := loadOpeningHours(ctx, s.pgxPool, candidates); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resources := []Resource{}
+	for _, resource := range candidates {
+		hours, err := openingHoursFromResource(resource)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if isResourceOpen(hours, resourceLocation(resource), slot) {
+			resources = append(resources, resource)
+		}
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
+
+func (s *Service) GetResource(c *gin.Context, resourceID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	s.respondWithResource(c, resourceID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) UpdateResource(c *gin.Context, resourceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage resources")
+	if !ok {
+		return
+	}
+
+	request := Resource{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timezone, hours, err := validateResource(request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, updateResourceSQL, resourceID, currentUser.OrgID, request.Name, request.Type, request.Capacity, timezone, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondResourceNotFound(c)
+		return
+	}
+
+	if err := replaceOpeningHours(ctx, tx, resourceID, hours); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithResource(c, resourceID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) DeleteResource(c *gin.Context, resourceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage resources")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deactivateResourceSQL, resourceID, currentUser.OrgID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		respondResourceNotFound(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) GetResourceCalendar(c *gin.Context, resourceID string, params GetResourceCalendarParams) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if !params.To.After(params.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := getResource(ctx, s.pgxPool, resourceID, currentUser.OrgID); err != nil {
+		respondResourceLookupError(c, err)
+		return
+	}
+
+	reservations := []ResourceReservation{}
+	err = pgxscan.Select(ctx, s.pgxPool, &reservations, queryListResourceReservationsSQL, resourceID, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+func (s *Service) UpdateClassResources(c *gin.Context, classID string) {
+	if _, ok := s.requireAdmin(c, "reserve resources"); !ok {
+		return
+	}
+
+	request := ClassResourceChanges{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var add, remove []string
+	if request.Add != nil {
+		add = uniqueIDs(*request.Add)
+	}
+	if request.Remove != nil {
+		remove = uniqueIDs(*request.Remove)
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "no_changes",
+			"message": "No resources provided to add or remove",
+		})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Cancelled classes must be restored before their resources can change",
+		})
+		return
+	}
+
+	for _, resourceID := range remove {
+		if _, err := tx.Exec(ctx, releaseClassResourceSQL, classID, resourceID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	slot := classSlot{class.StartTime, class.Duration}
+	if len(add) > 0 {
+		participants, err := getClassParticipants(ctx, tx, []string{classID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		conflicts, err := checkResourceReservations(ctx, tx, class.OrgID, add, &classID, slot, len(participants))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(conflicts) > 0 {
+			respondResourceConflicts(c, conflicts)
+			return
+		}
+
+		if err := reserveClassResources(ctx, tx, classID, class.OrgID, add, slot, now); err != nil {
+			respondReservationError(c, err)
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+// respondWithResource writes a resource with its opening hours as the
+// response body.
+func (s *Service) respondWithResource(c *gin.Context, resourceID, orgID string, status int) {
+	ctx := c.Request.Context()
+
+	resource, err := getResource(ctx, s.pgxPool, resourceID, orgID)
+	if err != nil {
+		respondResourceLookupError(c, err)
+		return
+	}
+
+	resources := []Resource{resource}
+	if err := loadOpeningHours(ctx, s.pgxPool, resources); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, resources[0])
+}
+
+func respondResourceNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error":   "resource_not_found",
+		"message": "Resource not found",
+	})
+}
+
+func respondResourceLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		respondResourceNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func respondResourceConflicts(c *gin.Context, conflicts []resourceConflict) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error":     "resource_conflict",
+		"message":   "Resources cannot be reserved for the class",
+		"conflicts": conflicts,
+	})
+}
+
+// respondReservationError writes the error of a failed reservation write,
+// turning a lost race for a resource into a conflict.
+func respondReservationError(c *gin.Context, err error) {
+	if errors.Is(err, errResourceReserved) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "resource_conflict",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+//go:embed queries/resource/create_resource.sql
+var createResourceSQL string
+
+//go:embed queries/resource/update_resource.sql
+var updateResourceSQL string
+
+//go:embed queries/resource/deactivate_resource.sql
+var deactivateResourceSQL string
+
+//go:embed queries/resource/get_resource.sql
+var queryGetResourceSQL string
+
+//go:embed queries/resource/list_resources.sql
+var queryListResourcesSQL string
+
+//go:embed queries/resource/lock_resources.sql
+var queryLockResourcesSQL string
+
+//go:embed queries/resource/list_free_resources.sql
+var queryListFreeResourcesSQL string
+
+//go:embed queries/resource/list_opening_hours.sql
+var queryListOpeningHoursSQL string
+
+//go:embed queries/resource/delete_opening_hours.sql
+var deleteOpeningHoursSQL string
+
+//go:embed queries/resource/add_opening_hours.sql
+var addOpeningHoursSQL string
+
+//go:embed queries/resource/list_resource_reservations.sql
+var queryListResourceReservationsSQL string
+
+//go:embed queries/resource/list_overlapping_reservations.sql
+var queryListOverlappingReservationsSQL string
+
+//go:embed queries/resource/reserve_class_resource.sql
+var reserveClassResourceSQL string
+
+//go:embed queries/resource/release_class_resource.sql
+var releaseClassResourceSQL string
+
+//go:embed queries/resource/list_class_resources.sql
+var queryListClassResourcesSQL string
+
+//go:embed queries/resource/update_class_reservations.sql
+var updateClassReservationsSQL string
+
+// classResourceRecord is a resource reserved by a class.
+type classResourceRecord struct {
+	ClassID    string
+	ResourceID string
+}
+
+func getResource(ctx context.Context, db dbExecutor, resourceID, orgID string) (Resource, error) {
+	resource := Resource{}
+	return resource, pgxscan.Get(ctx, db, &resource, queryGetResourceSQL, resourceID, orgID)
+}
+
+// validateResource checks a resource from a request and returns its
+// timezone and opening hours as stored.
+func validateResource(resource Resource) (string, []resourceOpeningHoursRecord, error) {
+	if strings.TrimSpace(resource.Name) == "" || strings.TrimSpace(resource.Type) == "" {
+		return "", nil, errors.New("name and type are required")
+	}
+
+	if resource.Capacity != nil && *resource.Capacity < 1 {
+		return "", nil, errors.New("capacity must be at least 1")
+	}
+
+	timezone := "UTC"
+	if resource.Timezone != nil {
+		timezone = *resource.Timezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return "", nil, fmt.Errorf("unknown timezone %s", timezone)
+	}
+
+	hours, err := openingHoursFromResource(resource)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return timezone, hours, nil
+}
+
+// openingHoursFromResource converts the opening hours of a resource into
+// minutes after midnight.
+func openingHoursFromResource(resource Resource) ([]resourceOpeningHoursRecord, error) {
+	if resource.OpeningHours == nil {
+		return nil, nil
+	}
+
+	hours := make([]resourceOpeningHoursRecord, 0, len(*resource.OpeningHours))
+	for _, window := range *resource.OpeningHours {
+		if window.Weekday < 0 || window.Weekday > 6 {
+			return nil, fmt.Errorf("weekday must be between 0 and 6, got %d", window.Weekday)
+		}
+
+		open, err := parseClockTime(window.OpenTime)
+		if err != nil {
+			return nil, err
+		}
+		closes, err := parseClockTime(window.CloseTime)
+		if err != nil {
+			return nil, err
+		}
+		if closes <= open {
+			return nil, fmt.Errorf("close_time %s must be after open_time %s", window.CloseTime, window.OpenTime)
+		}
+
+		resourceID := ""
+		if resource.ResourceId != nil {
+			resourceID = *resource.ResourceId
+		}
+		hours = append(hours, resourceOpeningHoursRecord{resourceID, window.Weekday, open, closes})
+	}
+
+	return hours, nil
+}
+
+func replaceOpeningHours(ctx context.Context, db dbExecutor, resourceID string, hours []resourceOpeningHoursRecord) error {
+	if _, err := db.Exec(ctx, deleteOpeningHoursSQL, resourceID); err != nil {
+		return err
+	}
+
+	for _, window := range hours {
+		if _, err := db.Exec(ctx, addOpeningHoursSQL, resourceID, window.Weekday, window.OpenMinute, window.CloseMinute); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func listOpeningHours(ctx context.Context, db dbExecutor, resourceIDs []string) ([]resourceOpeningHoursRecord, error) {
+	hours := []resourceOpeningHoursRecord{}
+	return hours, pgxscan.Select(ctx, db, &hours, queryListOpeningHoursSQL, resourceIDs)
+}
+
+// loadOpeningHours fills in the opening hours of every resource with a
+// single query.
+func loadOpeningHours(ctx context.Context, db dbExecutor, resources []Resource) error {
+	resourceIDs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		resourceIDs = append(resourceIDs, *resource.ResourceId)
+	}
+
+	hours, err := listOpeningHours(ctx, db, resourceIDs)
+	if err != nil {
+		return err
+	}
+
+	byResource := map[string][]ResourceOpeningHours{}
+	for _, window := range hours {
+		byResource[window.ResourceID] = append(byResource[window.ResourceID], ResourceOpeningHours{
+			Weekday:   window.Weekday,
+			OpenTime:  formatClockTime(window.OpenMinute),
+			CloseTime: formatClockTime(window.CloseMinute),
+		})
+	}
+
+	for i := range resources {
+		windows := byResource[*resources[i].ResourceId]
+		if windows == nil {
+			windows = []ResourceOpeningHours{}
+		}
+		resources[i].OpeningHours = &windows
+	}
+
+	return nil
+}
+
+// loadClassResources fills in the reserved resources of every class with a
+// single query.
+func loadClassResources(ctx context.Context, db dbExecutor, classes []Class) error {
+	classIDs := make([]string, 0, len(classes))
+	for _, class := range classes {
+		if class.ClassId != nil {
+			classIDs = append(classIDs, *class.ClassId)
+		}
+	}
+
+	records := []classResourceRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListClassResourcesSQL, classIDs); err != nil {
+		return err
+	}
+
+	byClass := map[string][]string{}
+	for _, record := range records {
+		byClass[record.ClassID] = append(byClass[record.ClassID], record.ResourceID)
+	}
+
+	for i := range classes {
+		if classes[i].ClassId == nil {
+			continue
+		}
+
+		resourceIDs := byClass[*classes[i].ClassId]
+		if resourceIDs == nil {
+			resourceIDs = []string{}
+		}
+		classes[i].Resources = &resourceIDs
+	}
+
+	return nil
+}
+
+// checkResourceReservations returns the reasons the resources cannot be
+// reserved for a class at slot with the given number of participants. The
+// resources are locked until the transaction ends so concurrent bookings
+// queue up behind each other; the exclusion constraint on class_resources
+// catches anything that slips through. classID, if set, is the class being
+// booked, whose own reservations never conflict.
+func checkResourceReservations(ctx context.Context, db dbExecutor, orgID string, resourceIDs []string, classID *string, slot classSlot, participants int) ([]resourceConflict, error) {
+	if len(resourceIDs) == 0 {
+		return nil, nil
+	}
+
+	resources := []Resource{}
+	if err := pgxscan.Select(ctx, db, &resources, queryLockResourcesSQL, resourceIDs, orgID); err != nil {
+		return nil, err
+	}
+
+	hours, err := listOpeningHours(ctx, db, resourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := []ResourceReservation{}
+	err = pgxscan.Select(ctx, db, &reservations, queryListOverlappingReservationsSQL, resourceIDs, slot.StartTime, slot.endTime(), classID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservationConflicts(resourceIDs, resources, hours, reservations, slot, participants), nil
+}
+
+func listClassResourceIDs(ctx context.Context, db dbExecutor, classID string) ([]string, error) {
+	records := []classResourceRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListClassResourcesSQL, []string{classID}); err != nil {
+		return nil, err
+	}
+
+	resourceIDs := make([]string, len(records))
+	for i, record := range records {
+		resourceIDs[i] = record.ResourceID
+	}
+
+	return resourceIDs, nil
+}
+
+// checkClassResources re-checks every resource a class has reserved as if
+// the class took place at slot, for reschedules and restores.
+func checkClassResources(ctx context.Context, db dbExecutor, classID, orgID string, slot classSlot) ([]resourceConflict, error) {
+	resourceIDs, err := listClassResourceIDs(ctx, db, classID)
+	if err != nil || len(resourceIDs) == 0 {
+		return nil, err
+	}
+
+	participants, err := getClassParticipants(ctx, db, []string{classID})
+	if err != nil {
+		return nil, err
+	}
+
+	return checkResourceReservations(ctx, db, orgID, resourceIDs, &classID, slot, len(participants))
+}
+
+// checkClassResourceCapacity returns the resources of a class that are too
+// small for its current participants.
+func checkClassResourceCapacity(ctx context.Context, db dbExecutor, classID, orgID string) ([]resourceConflict, error) {
+	resourceIDs, err := listClassResourceIDs(ctx, db, classID)
+	if err != nil || len(resourceIDs) == 0 {
+		return nil, err
+	}
+
+	participants, err := getClassParticipants(ctx, db, []string{classID})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []Resource{}
+	if err := pgxscan.Select(ctx, db, &resources, queryLockResourcesSQL, resourceIDs, orgID); err != nil {
+		return nil, err
+	}
+
+	var conflicts []resourceConflict
+	for _, resource := range resources {
+		if message, ok := resourceCapacityConflict(resource, len(participants)); ok {
+			conflicts = append(conflicts, resourceConflict{ResourceID: *resource.ResourceId, Message: message})
+		}
+	}
+
+	return conflicts, nil
+}
+
+// reserveClassResources reserves the resources for the class at slot.
+func reserveClassResources(ctx context.Context, db dbExecutor, classID, orgID string, resourceIDs []string, slot classSlot, now time.Time) error {
+	for _, resourceID := range resourceIDs {
+		_, err := db.Exec(ctx, reserveClassResourceSQL, classID, resourceID, orgID, slot.StartTime, slot.endTime(), now)
+		if err != nil {
+			return reservationError(err)
+		}
+	}
+
+	return nil
+}
+
+// updateClassReservations moves every reservation of a class to slot and
+// activates or releases them, following the class through reschedules,
+// cancellations and restores.
+func updateClassReservations(ctx context.Context, db dbExecutor, classID string, slot classSlot, active bool) error {
+	_, err := db.Exec(ctx, updateClassReservationsSQL, classID, slot.StartTime, slot.endTime(), active)
+	return reservationError(err)
+}
+
+// reservationError maps a violation of the reservation exclusion constraint
+// to errResourceReserved.
+func reservationError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23P01" {
+		return errResourceReserved
+	}
+
+	return err
+}
+
+// resourceOpeningHoursRecord is a weekly window in which a resource can be
+// booked, in minutes after midnight in the resource timezone.
+type resourceOpeningHoursRecord struct {
+	ResourceID  string
+	Weekday     int
+	OpenMinute  int
+	CloseMinute int
+}
+
+// isResourceOpen reports whether a resource with the given opening hours in
+// loc is open for the whole slot. A slot may run across windows that meet,
+// such as one closing at 24:00 and the next opening at 00:00. A resource
+// without opening hours is always open.
+func isResourceOpen(hours []resourceOpeningHoursRecord, loc *time.Location, slot classSlot) bool {
+	if len(hours) == 0 {
+		return true
+	}
+
+	at, end := slot.StartTime, slot.endTime()
+	for at.Before(end) {
+		local := at.In(loc)
+		minute := local.Hour()*60 + local.Minute()
+
+		var closes time.Time
+		for _, window := range hours {
+			if time.Weekday(window.Weekday) != local.Weekday() || minute < window.OpenMinute || minute >= window.CloseMinute {
+				continue
+			}
+			if c := time.Date(local.Year(), local.Month(), local.Day(), 0, window.CloseMinute, 0, 0, loc); c.After(closes) {
+				closes = c
+			}
+		}
+
+		if !closes.After(at) {
+			return false
+		}
+		at = closes
+	}
+
+	return true
+}
+
+// resourceConflict is a reason a resource cannot be reserved for a class.
+type resourceConflict struct {
+	ResourceID string `json:"resource_id"`
+	Message    string `json:"message"`
+}
+
+// reservationConflicts describes why each requested resource cannot be
+// reserved for a class at slot with the given number of participants.
+// resources, hours and reservations hold what was found for the requested
+// ids; reservations must already leave out the class itself.
+func reservationConflicts(resourceIDs []string, resources []Resource, hours []resourceOpeningHoursRecord, reservations []ResourceReservation, slot classSlot, participants int) []resourceConflict {
+	var conflicts []resourceConflict
+	add := func(resourceID, format string, args ...any) {
+		conflicts = append(conflicts, resourceConflict{ResourceID: resourceID, Message: fmt.Sprintf(format, args...)})
+	}
+
+	byID := map[string]Resource{}
+	for _, resource := range resources {
+		byID[*resource.ResourceId] = resource
+	}
+
+	for _, resourceID := range resourceIDs {
+		resource, ok := byID[resourceID]
+		switch {
+		case !ok:
+			add(resourceID, "resource not found")
+			continue
+		case resource.Active != nil && !*resource.Active:
+			add(resourceID, "%s is retired", resource.Name)
+			continue
+		}
+
+		if message, ok := resourceCapacityConflict(resource, participants); ok {
+			add(resourceID, "%s", message)
+		}
+
+		var resourceHours []resourceOpeningHoursRecord
+		for _, window := range hours {
+			if window.ResourceID == resourceID {
+				resourceHours = append(resourceHours, window)
+			}
+		}
+		if !isResourceOpen(resourceHours, resourceLocation(resource), slot) {
+			add(resourceID, "%s is closed during the class", resource.Name)
+		}
+
+		for _, reservation := range reservations {
+			if reservation.ResourceId == resourceID {
+				add(resourceID, "%s is already reserved by class %s from %s to %s", resource.Name, reservation.ClassId,
+					reservation.StartTime.Format(time.RFC3339), reservation.EndTime.Format(time.RFC3339))
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// resourceCapacityConflict describes why a resource is too small for the
+// given number of participants.
+func resourceCapacityConflict(resource Resource, participants int) (string, bool) {
+	if resource.Capacity == nil || *resource.Capacity >= participants {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s fits %d people but the class has %d participants", resource.Name, *resource.Capacity, participants), true
+}
+
+// resourceLocation returns the timezone of a resource's opening hours.
+func resourceLocation(resource Resource) *time.Location {
+	if resource.Timezone != nil {
+		if loc, err := time.LoadLocation(*resource.Timezone); err == nil {
+			return loc
+		}
+	}
+
+	return time.UTC
+}
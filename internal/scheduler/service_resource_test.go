@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsResourceOpen(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	// Open Monday 08:00-12:00 and 12:00-24:00, and Tuesday from midnight.
+	hours := []resourceOpeningHoursRecord{
+		{Weekday: 1, OpenMinute: 8 * 60, CloseMinute: 12 * 60},
+		{Weekday: 1, OpenMinute: 12 * 60, CloseMinute: 24 * 60},
+		{Weekday: 2, OpenMinute: 0, CloseMinute: 6 * 60},
+	}
+
+	tests := []struct {
+		name string
+		slot classSlot
+		want bool
+	}{
+		{"inside a window", classSlot{at(4, 9, 0), 60}, true},
+		{"across windows that meet", classSlot{at(4, 11, 30), 60}, true},
+		{"across midnight", classSlot{at(4, 23, 0), 120}, true},
+		{"before opening", classSlot{at(4, 7, 30), 60}, false},
+		{"after closing", classSlot{at(5, 5, 30), 60}, false},
+		{"closed day", classSlot{at(6, 9, 0), 60}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isResourceOpen(hours, time.UTC, tt.slot); got != tt.want {
+				t.Errorf("isResourceOpen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !isResourceOpen(nil, time.UTC, classSlot{at(6, 3, 0), 60}) {
+		t.Error("expected a resource without opening hours to be always open")
+	}
+}
+
+func TestIsResourceOpenUsesResourceTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Open Monday 09:00-17:00 in New York, which is 13:00-21:00 UTC in
+	// daylight saving time.
+	hours := []resourceOpeningHoursRecord{{Weekday: 1, OpenMinute: 9 * 60, CloseMinute: 17 * 60}}
+
+	if !isResourceOpen(hours, loc, classSlot{time.Date(2024, 6, 3, 13, 0, 0, 0, time.UTC), 60}) {
+		t.Error("expected 09:00 New York time to be open")
+	}
+	if isResourceOpen(hours, loc, classSlot{time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC), 60}) {
+		t.Error("expected 05:00 New York time to be closed")
+	}
+}
+
+func TestReservationConflicts(t *testing.T) {
+	id := func(s string) *string { return &s }
+	capacity, inactive := 4, false
+	slot := classSlot{time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), 60}
+
+	resources := []Resource{
+		{ResourceId: id("small"), Name: "Small room", Capacity: &capacity},
+		{ResourceId: id("retired"), Name: "Old room", Active: &inactive},
+		{ResourceId: id("booked"), Name: "Piano"},
+		{ResourceId: id("free"), Name: "Hall"},
+	}
+	reservations := []ResourceReservation{
+		{ResourceId: "booked", ClassId: "other", StartTime: slot.StartTime, EndTime: slot.endTime()},
+	}
+
+	conflicts := reservationConflicts([]string{"small", "retired", "booked", "free", "missing"}, resources, nil, reservations, slot, 5)
+
+	got := map[string]int{}
+	for _, conflict := range conflicts {
+		got[conflict.ResourceID]++
+	}
+	want := map[string]int{"small": 1, "retired": 1, "booked": 1, "missing": 1}
+	if len(got) != len(want) {
+		t.Fatalf("expected conflicts for %v, got %v", want, conflicts)
+	}
+	for resourceID, n := range want {
+		if got[resourceID] != n {
+			t.Errorf("expected %d conflict for %s, got %d", n, resourceID, got[resourceID])
+		}
+	}
+}
@@ -0,0 +1,1037 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"scheduler-api/internal/netguard"
+	"scheduler-api/internal/oneroster"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// errRosterFetch is returned when the roster of a source cannot be read.
+var errRosterFetch = errors.New("failed to read roster")
+
+const (
+	// rosterBundleTimeout bounds the download of a CSV bundle.
+	rosterBundleTimeout = 2 * time.Minute
+	// rosterRequestTimeout bounds each request to a REST API.
+	rosterRequestTimeout = 30 * time.Second
+)
+
+// dropReasonRoster is recorded when an enrollment is removed from the
+// roster.
+const dropReasonRoster = "removed from roster"
+
+type RosterService interface {
+	ListRosterSources(*gin.Context)
+	CreateRosterSource(*gin.Context)
+	DeleteRosterSource(*gin.Context, string)
+	SyncRosterSource(*gin.Context, string, SyncRosterSourceParams)
+	ListRosterSyncRuns(*gin.Context, string, ListRosterSyncRunsParams)
+}
+
+var _ RosterService = (*Service)(nil)
+
+func (s *Service) ListRosterSources(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage roster sources")
+	if !ok {
+		return
+	}
+
+	sources := []RosterSource{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &sources, listRosterSourcesSQL, currentUser.OrgID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sources)
+}
+
+func (s *Service) CreateRosterSource(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage roster sources")
+	if !ok {
+		return
+	}
+
+	request := RosterSourceCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateRosterSource(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx      = c.Request.Context()
+		sourceID = uuid.New().String()
+	)
+
+	_, err := s.pgxPool.Exec(ctx, createRosterSourceSQL, sourceID, currentUser.OrgID, request.Name, request.Format, request.Url,
+		request.TokenUrl, request.ClientId, request.ClientSecret, currentUser.UserID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	source := RosterSource{}
+	if err := pgxscan.Get(ctx, s.pgxPool, &source, listRosterSourcesSQL, currentUser.OrgID, sourceID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, source)
+}
+
+func (s *Service) DeleteRosterSource(c *gin.Context, sourceID string) {
+	currentUser, ok := s.requireAdmin(c, "manage roster sources")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deleteRosterSourceSQL, sourceID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "roster_source_not_found",
+			"message": "Roster source not found",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) SyncRosterSource(c *gin.Context, sourceID string, params SyncRosterSourceParams) {
+	currentUser, ok := s.requireAdmin(c, "sync rosters")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	source, err := getRosterSource(ctx, s.pgxPool, sourceID, &currentUser.OrgID)
+	if err != nil {
+		respondWebhookLookupError(c, err, "roster_source_not_found", "Roster source not found")
+		return
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+	run, err := runRosterSync(ctx, s.pgxPool, source, currentUser.UserID, dryRun, s.waitlistOfferTTL, time.Now())
+	switch {
+	case errors.Is(err, errRosterFetch):
+		c.JSON(http.StatusBadGateway, run)
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, run)
+	}
+}
+
+func (s *Service) ListRosterSyncRuns(c *gin.Context, sourceID string, params ListRosterSyncRunsParams) {
+	currentUser, ok := s.requireAdmin(c, "sync rosters")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getRosterSource(ctx, s.pgxPool, sourceID, &currentUser.OrgID); err != nil {
+		respondWebhookLookupError(c, err, "roster_source_not_found", "Roster source not found")
+		return
+	}
+
+	limit := 20
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = min(*params.Limit, 100)
+	}
+
+	runs := []RosterSyncRun{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &runs, listRosterSyncRunsSQL, sourceID, limit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// validateRosterSource checks the URLs of a roster source being created.
+func validateRosterSource(request RosterSourceCreate) error {
+	if request.Format != Csv && request.Format != Rest {
+		return fmt.Errorf("format must be csv or rest")
+	}
+
+	urls := map[string]*string{"url": &request.Url, "token_url": request.TokenUrl}
+	for name, value := range urls {
+		if value == nil {
+			continue
+		}
+		u, err := url.Parse(*value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an absolute http or https URL", name)
+		}
+	}
+
+	if request.TokenUrl != nil && (request.ClientId == nil || *request.ClientId == "") {
+		return fmt.Errorf("token_url needs a client_id")
+	}
+
+	return nil
+}
+
+// rosterSource is a roster source with its credentials.
+type rosterSource struct {
+	SourceID     string
+	OrgID        string
+	Name         string
+	Format       RosterFormat
+	URL          string
+	TokenURL     *string
+	ClientID     *string
+	ClientSecret *string
+	CreatedBy    *string
+}
+
+// getRosterSource loads a source, of organization orgID unless it is nil.
+func getRosterSource(ctx context.Context, db dbExecutor, sourceID string, orgID *string) (rosterSource, error) {
+	source := rosterSource{}
+	return source, pgxscan.Get(ctx, db, &source, queryGetRosterSourceSQL, sourceID, orgID)
+}
+
+// rosterClient returns the client rosters are read with. Sources are
+// configured by admins, so it refuses non-public addresses unless
+// ROSTER_ALLOW_PRIVATE_NETWORKS is set for local development.
+func rosterClient(timeout time.Duration) *http.Client {
+	allowPrivate, _ := strconv.ParseBool(os.Getenv("ROSTER_ALLOW_PRIVATE_NETWORKS"))
+	return netguard.NewClient(timeout, allowPrivate)
+}
+
+// fetchRoster reads the roster of a source.
+func fetchRoster(ctx context.Context, source rosterSource) (oneroster.Roster, error) {
+	if source.Format == Rest {
+		var tokenURL, clientID, clientSecret string
+		if source.TokenURL != nil {
+			tokenURL = *source.TokenURL
+		}
+		if source.ClientID != nil {
+			clientID = *source.ClientID
+		}
+		if source.ClientSecret != nil {
+			clientSecret = *source.ClientSecret
+		}
+		return oneroster.NewClient(rosterClient(rosterRequestTimeout), source.URL, tokenURL, clientID, clientSecret).Fetch(ctx)
+	}
+
+	return oneroster.FetchBundle(ctx, rosterClient(rosterBundleTimeout), source.URL)
+}
+
+// runRosterSync reads the roster of a source and syncs it in one
+// transaction, acting as actorID, and records the run. A dry run is rolled
+// back. When the roster cannot be read the error wraps errRosterFetch.
+func runRosterSync(ctx context.Context, pool *pgxpool.Pool, source rosterSource, actorID string, dryRun bool, ttl time.Duration, now time.Time) (RosterSyncRun, error) {
+	run := RosterSyncRun{
+		RunId:     uuid.New().String(),
+		SourceId:  source.SourceID,
+		DryRun:    dryRun,
+		StartedAt: now,
+	}
+
+	report, err := func() (RosterSyncReport, error) {
+		roster, err := fetchRoster(ctx, source)
+		if err != nil {
+			return RosterSyncReport{}, fmt.Errorf("%w: %w", errRosterFetch, err)
+		}
+
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return RosterSyncReport{}, err
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		report, err := syncRoster(ctx, tx, source, roster, actorID, ttl, now)
+		if err != nil || dryRun {
+			return report, err
+		}
+
+		if _, err := tx.Exec(ctx, setRosterSourceSyncedSQL, source.SourceID, now); err != nil {
+			return report, err
+		}
+
+		return report, tx.Commit(ctx)
+	}()
+
+	run.FinishedAt = time.Now()
+	run.Status = RosterSyncRunStatusSucceeded
+	run.Report = &report
+	if err != nil {
+		message := err.Error()
+		run.Status = RosterSyncRunStatusFailed
+		run.Report = nil
+		run.Error = &message
+	}
+
+	var data []byte
+	if run.Report != nil {
+		var marshalErr error
+		if data, marshalErr = json.Marshal(run.Report); marshalErr != nil {
+			return run, errors.Join(err, marshalErr)
+		}
+	}
+
+	_, recordErr := pool.Exec(ctx, createRosterSyncRunSQL, run.RunId, run.SourceId, source.OrgID, run.DryRun, run.Status, data, run.Error,
+		run.StartedAt, run.FinishedAt)
+	if recordErr != nil {
+		return run, errors.Join(err, recordErr)
+	}
+
+	return run, err
+}
+
+// rosterUser is the user a roster user is synced to.
+type rosterUser struct {
+	UserID string
+	Role   UserRole
+}
+
+// rosterSync threads the state of one roster sync through its steps: orgs,
+// users, classes and then enrollments, which need the users and courses
+// synced before them.
+type rosterSync struct {
+	tx      pgx.Tx
+	source  rosterSource
+	roster  oneroster.Roster
+	actorID string
+	ttl     time.Duration
+	now     time.Time
+	report  RosterSyncReport
+
+	// users and courses map the sourced IDs of the users and classes that
+	// are part of the organization after the sync to their user and
+	// course.
+	users   map[string]rosterUser
+	courses map[string]string
+}
+
+// syncRoster maps the orgs of a roster to the organization of its source,
+// users to users, classes to courses and enrollments to course enrollments.
+//
+// Records are matched by sourced ID, so syncing again updates them rather
+// than creating duplicates. Users synced for the first time are matched to
+// users of the organization by email. A record is only written when its
+// roster data changed since the last sync, so edits made here last until
+// the roster changes the record again; roles are never changed. Users
+// removed from the roster are made inactive and enrollments dropped.
+// Removed classes keep their course, but their enrollments are dropped.
+func syncRoster(ctx context.Context, tx pgx.Tx, source rosterSource, roster oneroster.Roster, actorID string, ttl time.Duration, now time.Time) (RosterSyncReport, error) {
+	if _, err := tx.Exec(ctx, lockRosterSourceSQL, source.SourceID); err != nil {
+		return RosterSyncReport{}, err
+	}
+
+	sync := &rosterSync{
+		tx:      tx,
+		source:  source,
+		roster:  roster,
+		actorID: actorID,
+		ttl:     ttl,
+		now:     now,
+		report:  RosterSyncReport{Issues: []RosterSyncIssue{}},
+		users:   map[string]rosterUser{},
+		courses: map[string]string{},
+	}
+
+	steps := []func(context.Context) error{sync.syncOrgs, sync.syncUsers, sync.syncClasses, sync.syncEnrollments}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return sync.report, err
+		}
+	}
+
+	return sync.report, nil
+}
+
+func (s *rosterSync) syncOrgs(ctx context.Context) error {
+	links, err := s.links(ctx, RosterRecordTypeOrg)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	present, listed := map[string]string{}, map[string]bool{}
+	for _, org := range s.roster.Orgs {
+		if org.SourcedID == "" {
+			continue
+		}
+		ids = append(ids, org.SourcedID)
+		listed[org.SourcedID] = true
+		if oneroster.Active(org.Status) {
+			present[org.SourcedID] = rosterChecksum(org.Name, org.Type, org.ParentSourcedID)
+		}
+	}
+
+	changes := rosterChanges(links, present, listed, s.roster.Complete[oneroster.CollectionOrgs])
+	for _, id := range rosterOrder(ids, changes) {
+		change := changes[id]
+		switch change {
+		case rosterCreate, rosterUpdate:
+			err = s.link(ctx, RosterRecordTypeOrg, id, s.source.OrgID, nil, present[id])
+		case rosterDeactivate:
+			err = s.remove(ctx, RosterRecordTypeOrg, id)
+		}
+		if err != nil {
+			return err
+		}
+		countRosterChange(&s.report.Orgs, change)
+	}
+
+	return nil
+}
+
+func (s *rosterSync) syncUsers(ctx context.Context) error {
+	links, err := s.links(ctx, RosterRecordTypeUser)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	present, listed := map[string]string{}, map[string]bool{}
+	records := map[string]oneroster.User{}
+	emails := []string{}
+	for _, user := range s.roster.Users {
+		if user.SourcedID == "" {
+			continue
+		}
+		ids = append(ids, user.SourcedID)
+		listed[user.SourcedID] = true
+
+		role, ok := rosterUserRole(user.Role)
+		if !user.Active() || !ok {
+			continue
+		}
+
+		problem := ""
+		switch {
+		case user.GivenName == "" || user.FamilyName == "":
+			problem = "has no givenName or familyName"
+		case user.Email != "" && !isValidEmail(user.Email):
+			problem = fmt.Sprintf("email %q is not a valid email address", user.Email)
+		}
+		if problem != "" {
+			s.issue(RosterRecordTypeUser, user.SourcedID, problem)
+			// A user synced before is left as it is.
+			if link, ok := links[user.SourcedID]; ok && link.RemovedAt == nil {
+				present[user.SourcedID] = link.Checksum
+			}
+			continue
+		}
+
+		records[user.SourcedID] = user
+		present[user.SourcedID] = rosterChecksum(string(role), user.GivenName, user.FamilyName, user.Email, user.Phone)
+		if user.Email != "" {
+			emails = append(emails, strings.ToLower(user.Email))
+		}
+	}
+
+	linked := map[string]bool{}
+	localIDs := []string{}
+	for _, link := range links {
+		localIDs = append(localIDs, link.LocalID)
+		if link.RemovedAt == nil {
+			linked[link.LocalID] = true
+		}
+	}
+
+	existing := []rosterUser{}
+	if err := pgxscan.Select(ctx, s.tx, &existing, queryListRosterUsersSQL, s.source.OrgID, localIDs); err != nil {
+		return err
+	}
+	roles := map[string]UserRole{}
+	for _, user := range existing {
+		roles[user.UserID] = user.Role
+	}
+
+	matches := []importExistingUser{}
+	if err := pgxscan.Select(ctx, s.tx, &matches, queryListImportUsersSQL, uniqueIDs(emails), s.source.OrgID); err != nil {
+		return err
+	}
+	byEmail := map[string]importExistingUser{}
+	for _, match := range matches {
+		byEmail[match.Email] = match
+	}
+
+	changes := rosterChanges(links, present, listed, s.roster.Complete[oneroster.CollectionUsers])
+	for _, id := range rosterOrder(ids, changes) {
+		change := changes[id]
+		link, isLinked := links[id]
+		user, hasRecord := records[id]
+
+		switch {
+		case change == rosterDeactivate:
+			if _, err := s.tx.Exec(ctx, deactivateRosterUserSQL, link.LocalID, s.source.OrgID, s.now); err != nil {
+				return err
+			}
+			if err := s.remove(ctx, RosterRecordTypeUser, id); err != nil {
+				return err
+			}
+
+		case change == rosterCreate || change == rosterUpdate || (hasRecord && isLinked && roles[link.LocalID] == ""):
+			// Users synced before that have since been deleted here are
+			// created again.
+			userID := ""
+			if isLinked && roles[link.LocalID] != "" {
+				userID = link.LocalID
+			}
+
+			saved, err := s.saveUser(ctx, id, user, userID, byEmail, linked, present[id])
+			if err != nil {
+				return err
+			}
+			if !saved {
+				change = rosterSkip
+			} else if userID == "" && !isLinked {
+				change = rosterCreate
+			} else {
+				change = rosterUpdate
+			}
+
+		case change == rosterUnchanged && isLinked && link.RemovedAt == nil && roles[link.LocalID] != "":
+			s.users[id] = rosterUser{UserID: link.LocalID, Role: roles[link.LocalID]}
+		}
+
+		countRosterChange(&s.report.Users, change)
+	}
+
+	// Users can be removed as others are created, so the quota is checked
+	// once the step is done.
+	return checkOrgQuota(ctx, s.tx, s.source.OrgID, quotaUsers, 0, s.now)
+}
+
+// saveUser updates the user userID of a roster user or, without one,
+// creates it or links the user of the organization with its email. It
+// returns false when the user was skipped with an issue.
+func (s *rosterSync) saveUser(ctx context.Context, sourcedID string, user oneroster.User, userID string, byEmail map[string]importExistingUser, linked map[string]bool, checksum string) (bool, error) {
+	role, _ := rosterUserRole(user.Role)
+
+	var email, phone *string
+	if user.Email != "" {
+		email = &user.Email
+	}
+	if user.Phone != "" {
+		phone = &user.Phone
+	}
+
+	if match, ok := byEmail[strings.ToLower(user.Email)]; userID == "" && ok {
+		switch {
+		case match.OrgID != s.source.OrgID:
+			s.issue(RosterRecordTypeUser, sourcedID, "email belongs to a user of another organization")
+			return false, nil
+		case match.Role != role:
+			s.issue(RosterRecordTypeUser, sourcedID, fmt.Sprintf("email belongs to a %s, not a %s", match.Role, role))
+			return false, nil
+		case linked[match.UserID]:
+			s.issue(RosterRecordTypeUser, sourcedID, "email belongs to a user synced from another record")
+			return false, nil
+		}
+		userID = match.UserID
+	}
+
+	if userID == "" {
+		userID = uuid.New().String()
+		_, err := s.tx.Exec(ctx, createImportUserSQL, userID, s.source.OrgID, role, user.GivenName, user.FamilyName, phone, email, s.now)
+		if err != nil {
+			return false, err
+		}
+
+		event := gin.H{
+			"user_id":    userID,
+			"org_id":     s.source.OrgID,
+			"role":       role,
+			"first_name": user.GivenName,
+			"last_name":  user.FamilyName,
+			"email":      email,
+			"created_at": s.now,
+		}
+		if err := webhooks.Enqueue(ctx, s.tx, s.source.OrgID, webhooks.EventUserCreated, event, s.now); err != nil {
+			return false, err
+		}
+
+		if email != nil {
+			byEmail[strings.ToLower(*email)] = importExistingUser{UserID: userID, OrgID: s.source.OrgID, Email: *email, Role: role}
+		}
+	} else {
+		var current UserRole
+		err := s.tx.QueryRow(ctx, updateRosterUserSQL, userID, s.source.OrgID, user.GivenName, user.FamilyName, email, phone, s.now).Scan(&current)
+		if err != nil {
+			return false, err
+		}
+		if current != role {
+			s.issue(RosterRecordTypeUser, sourcedID, fmt.Sprintf("is a %s in the roster but a %s here; roles are not synced", role, current))
+			role = current
+		}
+	}
+
+	linked[userID] = true
+	s.users[sourcedID] = rosterUser{UserID: userID, Role: role}
+	return true, s.link(ctx, RosterRecordTypeUser, sourcedID, userID, nil, checksum)
+}
+
+func (s *rosterSync) syncClasses(ctx context.Context) error {
+	links, err := s.links(ctx, RosterRecordTypeClass)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	present, listed := map[string]string{}, map[string]bool{}
+	titles := map[string]string{}
+	for _, class := range s.roster.Classes {
+		if class.SourcedID == "" {
+			continue
+		}
+		ids = append(ids, class.SourcedID)
+		listed[class.SourcedID] = true
+		if !oneroster.Active(class.Status) {
+			continue
+		}
+
+		if class.Title == "" {
+			s.issue(RosterRecordTypeClass, class.SourcedID, "has no title")
+			if link, ok := links[class.SourcedID]; ok && link.RemovedAt == nil {
+				present[class.SourcedID] = link.Checksum
+			}
+			continue
+		}
+
+		titles[class.SourcedID] = class.Title
+		present[class.SourcedID] = rosterChecksum(class.Title)
+	}
+
+	localIDs := []string{}
+	for _, link := range links {
+		localIDs = append(localIDs, link.LocalID)
+	}
+	courses := []importCourseRecord{}
+	if err := pgxscan.Select(ctx, s.tx, &courses, queryListImportCoursesSQL, localIDs); err != nil {
+		return err
+	}
+	exists := map[string]bool{}
+	for _, course := range courses {
+		exists[course.CourseID] = course.OrgID == s.source.OrgID
+	}
+
+	changes := rosterChanges(links, present, listed, s.roster.Complete[oneroster.CollectionClasses])
+	for _, id := range rosterOrder(ids, changes) {
+		change := changes[id]
+		link, isLinked := links[id]
+		title, hasRecord := titles[id]
+
+		switch {
+		case change == rosterDeactivate:
+			err = s.remove(ctx, RosterRecordTypeClass, id)
+
+		case change == rosterCreate || change == rosterUpdate || (hasRecord && isLinked && !exists[link.LocalID]):
+			// Courses synced before that have since been deleted here are
+			// created again.
+			courseID := link.LocalID
+			if isLinked && exists[courseID] {
+				_, err = s.tx.Exec(ctx, updateRosterCourseSQL, courseID, s.source.OrgID, title, s.now)
+				change = rosterUpdate
+			} else {
+				courseID = uuid.New().String()
+				_, err = s.tx.Exec(ctx, createCourseSql, courseID, s.source.OrgID, title, nil, nil, nil, nil, nil, nil, s.now)
+				change = rosterCreate
+			}
+			if err == nil {
+				s.courses[id] = courseID
+				err = s.link(ctx, RosterRecordTypeClass, id, courseID, nil, present[id])
+			}
+
+		case change == rosterUnchanged && isLinked && link.RemovedAt == nil && exists[link.LocalID]:
+			s.courses[id] = link.LocalID
+		}
+		if err != nil {
+			return err
+		}
+
+		countRosterChange(&s.report.Classes, change)
+	}
+
+	// Like users, courses are counted once they are all created.
+	return checkOrgQuota(ctx, s.tx, s.source.OrgID, quotaCourses, 0, s.now)
+}
+
+func (s *rosterSync) syncEnrollments(ctx context.Context) error {
+	links, err := s.links(ctx, RosterRecordTypeEnrollment)
+	if err != nil {
+		return err
+	}
+
+	type enrollee struct {
+		sourcedID string
+		userID    string
+	}
+	type group struct {
+		courseID string
+		role     EnrollmentRole
+	}
+
+	var ids []string
+	present, listed := map[string]string{}, map[string]bool{}
+	targets := map[string]group{}
+	enrollees := map[string]enrollee{}
+	for _, enrollment := range s.roster.Enrollments {
+		if enrollment.SourcedID == "" {
+			continue
+		}
+		ids = append(ids, enrollment.SourcedID)
+		listed[enrollment.SourcedID] = true
+
+		role, userRole, ok := rosterEnrollmentRole(enrollment.Role)
+		if !oneroster.Active(enrollment.Status) || !ok {
+			continue
+		}
+
+		// Enrollments of users and classes that are not part of the
+		// organization are dropped along with them.
+		user, hasUser := s.users[enrollment.UserSourcedID]
+		courseID, hasCourse := s.courses[enrollment.ClassSourcedID]
+		if !hasUser || !hasCourse {
+			continue
+		}
+
+		if user.Role != userRole {
+			s.issue(RosterRecordTypeEnrollment, enrollment.SourcedID, fmt.Sprintf("enrolls a %s as a %s", user.Role, role))
+			continue
+		}
+
+		targets[enrollment.SourcedID] = group{courseID: courseID, role: role}
+		enrollees[enrollment.SourcedID] = enrollee{sourcedID: enrollment.SourcedID, userID: user.UserID}
+		present[enrollment.SourcedID] = rosterChecksum(string(role), user.UserID, courseID)
+	}
+
+	var groups []group
+	members := map[group][]enrollee{}
+
+	changes := rosterChanges(links, present, listed, s.roster.Complete[oneroster.CollectionEnrollments])
+	for _, id := range rosterOrder(ids, changes) {
+		change := changes[id]
+		switch change {
+		case rosterCreate, rosterUpdate:
+			target := targets[id]
+
+			// The user or class of the enrollment changed, or was synced
+			// to a new user or course.
+			link, ok := links[id]
+			if ok && link.RemovedAt == nil && (link.LocalID != enrollees[id].userID || *link.CourseID != target.courseID) {
+				if err := dropRosterEnrollment(ctx, s.tx, s.source.OrgID, *link.CourseID, link.LocalID, s.actorID, s.ttl, s.now); err != nil {
+					return err
+				}
+			}
+
+			if _, ok := members[target]; !ok {
+				groups = append(groups, target)
+			}
+			members[target] = append(members[target], enrollees[id])
+
+		case rosterDeactivate:
+			link := links[id]
+			if err := dropRosterEnrollment(ctx, s.tx, s.source.OrgID, *link.CourseID, link.LocalID, s.actorID, s.ttl, s.now); err != nil {
+				return err
+			}
+			if err := s.remove(ctx, RosterRecordTypeEnrollment, id); err != nil {
+				return err
+			}
+		}
+		countRosterChange(&s.report.Enrollments, change)
+	}
+
+	// Students past a course's capacity join its waitlist in roster order.
+	for _, target := range groups {
+		userIDs := []string{}
+		for _, member := range members[target] {
+			userIDs = append(userIDs, member.userID)
+		}
+
+		role := target.role
+		result, err := enrollUsers(ctx, s.tx, target.courseID, userIDs, &role, s.actorID, s.now)
+		if err != nil {
+			return err
+		}
+		s.report.Waitlisted += len(result.Waitlisted)
+
+		for _, member := range members[target] {
+			courseID := target.courseID
+			err := s.link(ctx, RosterRecordTypeEnrollment, member.sourcedID, member.userID, &courseID, present[member.sourcedID])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dropRosterEnrollment drops a user's active enrollment in a course, if
+// both still exist, and offers the seat a student frees to the waitlist.
+func dropRosterEnrollment(ctx context.Context, tx pgx.Tx, orgID, courseID, userID, actorID string, ttl time.Duration, now time.Time) error {
+	if _, err := courseWaitlist(courseID).capacity(ctx, tx, now); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	enrollment, err := getEnrollmentForUpdate(ctx, tx, courseID, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if enrollment.Status != EnrollmentStatusActive {
+		return nil
+	}
+
+	dropped, err := dropEnrollment(ctx, tx, orgID, enrollment, dropReasonRoster, actorID, ttl, now)
+	if err != nil || dropped.Role != EnrollmentRoleStudent {
+		return err
+	}
+	return offerOpenSeats(ctx, tx, courseWaitlist(courseID), ttl, now)
+}
+
+func (s *rosterSync) links(ctx context.Context, recordType RosterRecordType) (map[string]rosterLink, error) {
+	records := []rosterLink{}
+	if err := pgxscan.Select(ctx, s.tx, &records, queryListRosterRecordsSQL, s.source.SourceID, recordType); err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]rosterLink, len(records))
+	for _, link := range records {
+		links[link.SourcedID] = link
+	}
+	return links, nil
+}
+
+func (s *rosterSync) link(ctx context.Context, recordType RosterRecordType, sourcedID, localID string, courseID *string, checksum string) error {
+	_, err := s.tx.Exec(ctx, upsertRosterRecordSQL, s.source.SourceID, recordType, sourcedID, localID, courseID, checksum, s.now)
+	return err
+}
+
+func (s *rosterSync) remove(ctx context.Context, recordType RosterRecordType, sourcedID string) error {
+	_, err := s.tx.Exec(ctx, removeRosterRecordSQL, s.source.SourceID, recordType, sourcedID, s.now)
+	return err
+}
+
+func (s *rosterSync) issue(recordType RosterRecordType, sourcedID, message string) {
+	s.report.Issues = append(s.report.Issues, RosterSyncIssue{RecordType: recordType, SourcedId: sourcedID, Message: message})
+}
+
+//go:embed queries/roster/create_roster_source.sql
+var createRosterSourceSQL string
+
+//go:embed queries/roster/list_roster_sources.sql
+var listRosterSourcesSQL string
+
+//go:embed queries/roster/delete_roster_source.sql
+var deleteRosterSourceSQL string
+
+//go:embed queries/roster/get_roster_source.sql
+var queryGetRosterSourceSQL string
+
+//go:embed queries/roster/lock_roster_source.sql
+var lockRosterSourceSQL string
+
+//go:embed queries/roster/list_due_roster_sources.sql
+var queryListDueRosterSourcesSQL string
+
+//go:embed queries/roster/set_roster_source_synced.sql
+var setRosterSourceSyncedSQL string
+
+//go:embed queries/roster/list_roster_records.sql
+var queryListRosterRecordsSQL string
+
+//go:embed queries/roster/upsert_roster_record.sql
+var upsertRosterRecordSQL string
+
+//go:embed queries/roster/remove_roster_record.sql
+var removeRosterRecordSQL string
+
+//go:embed queries/roster/list_roster_users.sql
+var queryListRosterUsersSQL string
+
+//go:embed queries/roster/update_roster_user.sql
+var updateRosterUserSQL string
+
+//go:embed queries/roster/deactivate_roster_user.sql
+var deactivateRosterUserSQL string
+
+//go:embed queries/roster/update_roster_course.sql
+var updateRosterCourseSQL string
+
+//go:embed queries/roster/create_roster_sync_run.sql
+var createRosterSyncRunSQL string
+
+//go:embed queries/roster/list_roster_sync_runs.sql
+var listRosterSyncRunsSQL string
+
+// rosterLink is the sourced ID of a synced roster record with the record it
+// maps to: an organization, user or course, or the user and course of an
+// enrollment.
+type rosterLink struct {
+	SourcedID string
+	LocalID   string
+	CourseID  *string
+	Checksum  string
+	RemovedAt *time.Time
+}
+
+// rosterChange is what a sync does with a roster record.
+type rosterChange int
+
+const (
+	rosterUnchanged rosterChange = iota
+	rosterCreate
+	rosterUpdate
+	rosterDeactivate
+	rosterSkip
+)
+
+// rosterChanges decides what a sync does with the records of one type.
+// present maps each record that is part of the roster to the checksum of
+// its data; listed holds every sourced ID the roster names, including
+// removed records. Records that were synced before and are missing from a
+// complete collection are deactivated; missing from one that is not
+// complete, such as a delta file, they are left alone.
+func rosterChanges(links map[string]rosterLink, present map[string]string, listed map[string]bool, complete bool) map[string]rosterChange {
+	changes := map[string]rosterChange{}
+
+	for sourcedID, checksum := range present {
+		link, ok := links[sourcedID]
+		switch {
+		case !ok:
+			changes[sourcedID] = rosterCreate
+		case link.RemovedAt != nil || link.Checksum != checksum:
+			changes[sourcedID] = rosterUpdate
+		default:
+			changes[sourcedID] = rosterUnchanged
+		}
+	}
+
+	for sourcedID := range listed {
+		if _, ok := present[sourcedID]; ok {
+			continue
+		}
+		link, ok := links[sourcedID]
+		switch {
+		case !ok:
+			changes[sourcedID] = rosterSkip
+		case link.RemovedAt == nil:
+			changes[sourcedID] = rosterDeactivate
+		default:
+			changes[sourcedID] = rosterUnchanged
+		}
+	}
+
+	for sourcedID, link := range links {
+		if _, ok := changes[sourcedID]; ok || link.RemovedAt != nil {
+			continue
+		}
+		if complete {
+			changes[sourcedID] = rosterDeactivate
+		} else {
+			changes[sourcedID] = rosterUnchanged
+		}
+	}
+
+	return changes
+}
+
+// countRosterChange adds a change to the counts of a sync report.
+func countRosterChange(counts *RosterSyncCounts, change rosterChange) {
+	switch change {
+	case rosterCreate:
+		counts.Created++
+	case rosterUpdate:
+		counts.Updated++
+	case rosterDeactivate:
+		counts.Deactivated++
+	case rosterSkip:
+		counts.Skipped++
+	default:
+		counts.Unchanged++
+	}
+}
+
+// rosterChecksum fingerprints the roster data of a record, so a sync can
+// tell whether it changed since the last one.
+func rosterChecksum(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\x1f")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// rosterUserRole maps a OneRoster user role to a user role. Only students
+// and teachers are synced.
+func rosterUserRole(role string) (UserRole, bool) {
+	switch role {
+	case "student":
+		return UserRoleStudent, true
+	case "teacher":
+		return UserRoleTutor, true
+	default:
+		return "", false
+	}
+}
+
+// rosterEnrollmentRole maps a OneRoster enrollment role to an enrollment
+// role, along with the user role it needs.
+func rosterEnrollmentRole(role string) (EnrollmentRole, UserRole, bool) {
+	switch role {
+	case "student":
+		return EnrollmentRoleStudent, UserRoleStudent, true
+	case "teacher":
+		return EnrollmentRoleTeacher, UserRoleTutor, true
+	default:
+		return "", "", false
+	}
+}
+
+// rosterOrder orders the records of a sync: those of the roster in roster
+// order, then records synced before that it no longer lists.
+func rosterOrder(ids []string, changes map[string]rosterChange) []string {
+	order := uniqueIDs(ids)
+	listed := map[string]bool{}
+	for _, id := range order {
+		listed[id] = true
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(changes)) {
+		if !listed[id] {
+			order = append(order, id)
+		}
+	}
+	return order
+}
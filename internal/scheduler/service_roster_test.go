@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRosterChanges(t *testing.T) {
+	removed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	links := map[string]rosterLink{
+		"same":     {SourcedID: "same", Checksum: "a"},
+		"changed":  {SourcedID: "changed", Checksum: "a"},
+		"returned": {SourcedID: "returned", Checksum: "a", RemovedAt: &removed},
+		"deleted":  {SourcedID: "deleted", Checksum: "a"},
+		"missing":  {SourcedID: "missing", Checksum: "a"},
+		"gone":     {SourcedID: "gone", Checksum: "a", RemovedAt: &removed},
+	}
+	present := map[string]string{"new": "a", "same": "a", "changed": "b", "returned": "a"}
+	listed := map[string]bool{"new": true, "same": true, "changed": true, "returned": true, "deleted": true, "guardian": true}
+
+	changes := rosterChanges(links, present, listed, true)
+	expected := map[string]rosterChange{
+		"new":      rosterCreate,
+		"same":     rosterUnchanged,
+		"changed":  rosterUpdate,
+		"returned": rosterUpdate,
+		"deleted":  rosterDeactivate,
+		"missing":  rosterDeactivate,
+		"guardian": rosterSkip,
+	}
+	if !maps.Equal(changes, expected) {
+		t.Errorf("complete collection: expected %v, got %v", expected, changes)
+	}
+
+	// Records missing from a delta are left alone, but removed ones are
+	// still deactivated.
+	changes = rosterChanges(links, present, listed, false)
+	expected["missing"] = rosterUnchanged
+	if !maps.Equal(changes, expected) {
+		t.Errorf("delta: expected %v, got %v", expected, changes)
+	}
+}
+
+func TestRosterOrder(t *testing.T) {
+	changes := map[string]rosterChange{"b": rosterCreate, "a": rosterCreate, "z": rosterDeactivate, "y": rosterDeactivate}
+	if got := rosterOrder([]string{"b", "a", "b"}, changes); !slices.Equal(got, []string{"b", "a", "y", "z"}) {
+		t.Errorf("expected roster order then removed records, got %v", got)
+	}
+}
+
+func TestRosterRoles(t *testing.T) {
+	if role, ok := rosterUserRole("teacher"); !ok || role != UserRoleTutor {
+		t.Errorf("expected teachers to be tutors, got %q", role)
+	}
+	for _, role := range []string{"administrator", "guardian", "aide"} {
+		if _, ok := rosterUserRole(role); ok {
+			t.Errorf("expected %s not to be synced", role)
+		}
+	}
+
+	if role, userRole, ok := rosterEnrollmentRole("teacher"); !ok || role != EnrollmentRoleTeacher || userRole != UserRoleTutor {
+		t.Errorf("unexpected teacher enrollment %q of a %q", role, userRole)
+	}
+
+	if rosterChecksum("a", "bc") == rosterChecksum("ab", "c") {
+		t.Error("expected checksums to tell fields apart")
+	}
+}
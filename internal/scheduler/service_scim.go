@@ -0,0 +1,1065 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/scim"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// scimMaxResults bounds the page of a SCIM list request.
+const scimMaxResults = 200
+
+// scimOrgIDKey is the context key of the organization a SCIM token belongs
+// to.
+const scimOrgIDKey = "scimOrgID"
+
+// AccountSyncer keeps the sign-in accounts of users provisioned over SCIM
+// in step with their user records.
+type AccountSyncer interface {
+	AccountProvisioner
+	UpdateAccount(ctx context.Context, uid, email, displayName string, disabled bool) error
+}
+
+var _ AccountSyncer = (*auth.FirebaseService)(nil)
+
+type ScimService interface {
+	ListScimTokens(*gin.Context)
+	CreateScimToken(*gin.Context)
+	DeleteScimToken(*gin.Context, string)
+}
+
+var _ ScimService = (*Service)(nil)
+
+func (s *Service) ListScimTokens(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage SCIM tokens")
+	if !ok {
+		return
+	}
+
+	tokens := []ScimToken{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &tokens, listSCIMTokensSQL, currentUser.OrgID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (s *Service) CreateScimToken(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage SCIM tokens")
+	if !ok {
+		return
+	}
+
+	request := ScimTokenCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	secret, err := newSCIMToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx     = c.Request.Context()
+		tokenID = uuid.New().String()
+	)
+
+	_, err = s.pgxPool.Exec(ctx, createSCIMTokenSQL, tokenID, currentUser.OrgID, request.Description, hashSCIMToken(secret),
+		currentUser.UserID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	token := ScimToken{}
+	if err := pgxscan.Get(ctx, s.pgxPool, &token, listSCIMTokensSQL, currentUser.OrgID, tokenID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only a hash is stored, so the token is only ever shown here.
+	token.Token = &secret
+	c.JSON(http.StatusCreated, token)
+}
+
+func (s *Service) DeleteScimToken(c *gin.Context, tokenID string) {
+	currentUser, ok := s.requireAdmin(c, "manage SCIM tokens")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deleteSCIMTokenSQL, tokenID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "scim_token_not_found",
+			"message": "SCIM token not found",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterSCIMHandlers registers the SCIM 2.0 API at /scim/v2. Identity
+// providers authenticate with an organization's SCIM token rather than a
+// Firebase ID token; only the discovery endpoints are public.
+func RegisterSCIMHandlers(router gin.IRouter, s *Service) {
+	group := router.Group("/scim/v2")
+	group.GET("/ServiceProviderConfig", s.scimServiceProviderConfig)
+	group.GET("/ResourceTypes", s.scimResourceTypes)
+
+	provisioning := group.Group("", s.requireSCIMToken)
+	provisioning.GET("/Users", s.scimListUsers)
+	provisioning.POST("/Users", s.scimCreateUser)
+	provisioning.GET("/Users/:id", s.scimGetUser)
+	provisioning.PUT("/Users/:id", s.scimReplaceUser)
+	provisioning.PATCH("/Users/:id", s.scimPatchUser)
+	provisioning.DELETE("/Users/:id", s.scimDeleteUser)
+	provisioning.GET("/Groups", s.scimListGroups)
+	provisioning.POST("/Groups", s.scimCreateGroup)
+	provisioning.GET("/Groups/:id", s.scimGetGroup)
+	provisioning.PUT("/Groups/:id", s.scimReplaceGroup)
+	provisioning.PATCH("/Groups/:id", s.scimPatchGroup)
+	provisioning.DELETE("/Groups/:id", s.scimDeleteGroup)
+}
+
+// requireSCIMToken authenticates a SCIM request by its bearer token,
+// setting the organization the token belongs to.
+func (s *Service) requireSCIMToken(c *gin.Context) {
+	scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+
+	var orgID string
+	err := pgx.ErrNoRows
+	if strings.EqualFold(scheme, "Bearer") && token != "" {
+		err = s.pgxPool.QueryRow(c.Request.Context(), authenticateSCIMTokenSQL, hashSCIMToken(token), time.Now()).Scan(&orgID)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+		err = scim.Errorf(http.StatusUnauthorized, "", "a valid SCIM token is required")
+	}
+	if err != nil {
+		s.respondSCIMError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Set(scimOrgIDKey, orgID)
+	c.Next()
+}
+
+func (s *Service) scimServiceProviderConfig(c *gin.Context) {
+	respondSCIM(c, http.StatusOK, gin.H{
+		"schemas":          []string{scim.SchemaServiceProviderConfig},
+		"documentationUri": "https://datatracker.ietf.org/doc/html/rfc7644",
+		"patch":            gin.H{"supported": true},
+		"bulk":             gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           gin.H{"supported": true, "maxResults": scimMaxResults},
+		"changePassword":   gin.H{"supported": false},
+		"sort":             gin.H{"supported": false},
+		"etag":             gin.H{"supported": false},
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "A SCIM token created by an admin of the organization",
+			"primary":     true,
+		}},
+		"meta": scim.Meta{ResourceType: "ServiceProviderConfig", Location: scimLocation(c, "ServiceProviderConfig")},
+	})
+}
+
+func (s *Service) scimResourceTypes(c *gin.Context) {
+	resourceTypes := []any{
+		gin.H{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scim.SchemaUser,
+			"meta":     scim.Meta{ResourceType: "ResourceType", Location: scimLocation(c, "ResourceTypes/User")},
+		},
+		gin.H{
+			"schemas":     []string{scim.SchemaResourceType},
+			"id":          "Group",
+			"name":        "Group",
+			"endpoint":    "/Groups",
+			"description": "The roles of the organization; a user's group is its role",
+			"schema":      scim.SchemaGroup,
+			"meta":        scim.Meta{ResourceType: "ResourceType", Location: scimLocation(c, "ResourceTypes/Group")},
+		},
+	}
+
+	respondSCIM(c, http.StatusOK, scim.ListResponse{
+		Schemas:      []string{scim.SchemaListResponse},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+// scimUser is a SCIM user resource. userName is the user's email.
+type scimUser struct {
+	Schemas      []string         `json:"schemas"`
+	ID           string           `json:"id"`
+	ExternalID   string           `json:"externalId,omitempty"`
+	UserName     string           `json:"userName"`
+	Name         scimName         `json:"name"`
+	DisplayName  string           `json:"displayName,omitempty"`
+	Emails       []scimMultiValue `json:"emails,omitempty"`
+	PhoneNumbers []scimMultiValue `json:"phoneNumbers,omitempty"`
+	Active       *scim.Bool       `json:"active,omitempty"`
+	Groups       []scimMember     `json:"groups,omitempty"`
+	Meta         *scim.Meta       `json:"meta,omitempty"`
+}
+
+type scimName struct {
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+	Formatted  string `json:"formatted,omitempty"`
+}
+
+type scimMultiValue struct {
+	Value   string    `json:"value"`
+	Type    string    `json:"type,omitempty"`
+	Primary scim.Bool `json:"primary,omitempty"`
+}
+
+// scimGroup is a SCIM group resource, one for each role.
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members,omitempty"`
+	Meta        *scim.Meta   `json:"meta,omitempty"`
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type scimUserRecord struct {
+	UserID      string
+	Role        UserRole
+	FirstName   string
+	LastName    string
+	Email       string
+	PhoneNumber string
+	Status      string
+	ExternalID  string
+	FirebaseUID *string
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+func (s *Service) scimListUsers(c *gin.Context) {
+	query, err := scim.ParseQuery(c.Request.URL.Query(), scimMaxResults)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	users, err := listSCIMUsers(c.Request.Context(), s.pgxPool, c.GetString(scimOrgIDKey), nil)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	resources := make([]map[string]any, 0, len(users))
+	for _, user := range users {
+		resource, err := scim.ToMap(scimUserResource(c, user))
+		if err != nil {
+			s.respondSCIMError(c, err)
+			return
+		}
+		resources = append(resources, resource)
+	}
+
+	respondSCIM(c, http.StatusOK, scim.List(resources, query))
+}
+
+func (s *Service) scimGetUser(c *gin.Context) {
+	user, err := getSCIMUser(c.Request.Context(), s.pgxPool, c.GetString(scimOrgIDKey), c.Param("id"))
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	respondSCIM(c, http.StatusOK, scimUserResource(c, user))
+}
+
+func (s *Service) scimCreateUser(c *gin.Context) {
+	var resource scimUser
+	if err := decodeSCIM(c, &resource); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	var (
+		ctx    = c.Request.Context()
+		orgID  = c.GetString(scimOrgIDKey)
+		userID string
+	)
+
+	err := s.inSCIMTx(ctx, func(tx pgx.Tx, created *[]string) error {
+		var err error
+		userID, err = writeSCIMUser(ctx, tx, s.firebaseService, orgID, nil, resource, created, time.Now())
+		return err
+	})
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	user, err := getSCIMUser(ctx, s.pgxPool, orgID, userID)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	c.Header("Location", scimLocation(c, "Users/"+userID))
+	respondSCIM(c, http.StatusCreated, scimUserResource(c, user))
+}
+
+func (s *Service) scimReplaceUser(c *gin.Context) {
+	var resource scimUser
+	if err := decodeSCIM(c, &resource); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimUpdateUser(c, func(scimUser) (scimUser, error) {
+		return resource, nil
+	})
+}
+
+func (s *Service) scimPatchUser(c *gin.Context) {
+	var request scim.PatchRequest
+	if err := decodeSCIM(c, &request); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimUpdateUser(c, func(current scimUser) (scimUser, error) {
+		resource, err := scim.ToMap(current)
+		if err != nil {
+			return scimUser{}, err
+		}
+		if err := request.Apply(resource); err != nil {
+			return scimUser{}, err
+		}
+
+		var updated scimUser
+		return updated, scim.FromMap(resource, &updated)
+	})
+}
+
+// scimUpdateUser writes the user change makes of the user's current
+// resource.
+func (s *Service) scimUpdateUser(c *gin.Context, change func(scimUser) (scimUser, error)) {
+	var (
+		ctx    = c.Request.Context()
+		orgID  = c.GetString(scimOrgIDKey)
+		userID = c.Param("id")
+	)
+
+	err := s.inSCIMTx(ctx, func(tx pgx.Tx, created *[]string) error {
+		user, err := lockSCIMUser(ctx, tx, orgID, userID)
+		if err != nil {
+			return err
+		}
+
+		resource, err := change(scimUserResource(c, user))
+		if err != nil {
+			return err
+		}
+
+		_, err = writeSCIMUser(ctx, tx, s.firebaseService, orgID, &user, resource, created, time.Now())
+		return err
+	})
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimGetUser(c)
+}
+
+// scimDeleteUser deprovisions a user: it is made inactive and its sign-in
+// account disabled, but kept with its classes and attendance. Provisioning
+// a user with its email again restores it.
+func (s *Service) scimDeleteUser(c *gin.Context) {
+	var (
+		ctx    = c.Request.Context()
+		orgID  = c.GetString(scimOrgIDKey)
+		userID = c.Param("id")
+	)
+
+	err := s.inSCIMTx(ctx, func(tx pgx.Tx, created *[]string) error {
+		user, err := lockSCIMUser(ctx, tx, orgID, userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if _, err := tx.Exec(ctx, deprovisionSCIMUserSQL, userID, orgID, now); err != nil {
+			return err
+		}
+
+		user.Status = "inactive"
+		return syncSCIMAccount(ctx, tx, s.firebaseService, orgID, user, created, now)
+	})
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) scimListGroups(c *gin.Context) {
+	query, err := scim.ParseQuery(c.Request.URL.Query(), scimMaxResults)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	users, err := listSCIMUsers(c.Request.Context(), s.pgxPool, c.GetString(scimOrgIDKey), nil)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	resources := make([]map[string]any, 0, len(scimGroups))
+	for _, group := range scimGroups {
+		resource, err := scim.ToMap(scimGroupResource(c, group.role, users))
+		if err != nil {
+			s.respondSCIMError(c, err)
+			return
+		}
+		resources = append(resources, resource)
+	}
+
+	respondSCIM(c, http.StatusOK, scim.List(resources, query))
+}
+
+func (s *Service) scimGetGroup(c *gin.Context) {
+	role, ok := scimGroupRole(c.Param("id"))
+	if !ok {
+		s.respondSCIMError(c, scim.NotFound("Group", c.Param("id")))
+		return
+	}
+
+	users, err := listSCIMUsers(c.Request.Context(), s.pgxPool, c.GetString(scimOrgIDKey), nil)
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	respondSCIM(c, http.StatusOK, scimGroupResource(c, role, users))
+}
+
+// scimCreateGroup rejects new groups: groups are the organization's roles,
+// which identity providers link their own groups to.
+func (s *Service) scimCreateGroup(c *gin.Context) {
+	var resource scimGroup
+	if err := decodeSCIM(c, &resource); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	if _, ok := scimGroupRole(resource.DisplayName); ok {
+		s.respondSCIMError(c, scim.Errorf(http.StatusConflict, scim.TypeUniqueness, "group %s already exists", resource.DisplayName))
+		return
+	}
+	s.respondSCIMError(c, scim.Errorf(http.StatusBadRequest, scim.TypeInvalidValue,
+		"groups are the roles Admins, Tutors and Students; other groups cannot be created"))
+}
+
+func (s *Service) scimReplaceGroup(c *gin.Context) {
+	var resource scimGroup
+	if err := decodeSCIM(c, &resource); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimUpdateGroup(c, func(scimGroup) (scimGroup, error) {
+		return resource, nil
+	})
+}
+
+func (s *Service) scimPatchGroup(c *gin.Context) {
+	var request scim.PatchRequest
+	if err := decodeSCIM(c, &request); err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimUpdateGroup(c, func(current scimGroup) (scimGroup, error) {
+		resource, err := scim.ToMap(current)
+		if err != nil {
+			return scimGroup{}, err
+		}
+		if err := request.Apply(resource); err != nil {
+			return scimGroup{}, err
+		}
+
+		var updated scimGroup
+		return updated, scim.FromMap(resource, &updated)
+	})
+}
+
+// scimUpdateGroup gives the role of a group to the users change adds to
+// its members. Users it removes become students; students stay students,
+// as every user has a role.
+func (s *Service) scimUpdateGroup(c *gin.Context, change func(scimGroup) (scimGroup, error)) {
+	role, ok := scimGroupRole(c.Param("id"))
+	if !ok {
+		s.respondSCIMError(c, scim.NotFound("Group", c.Param("id")))
+		return
+	}
+
+	var (
+		ctx   = c.Request.Context()
+		orgID = c.GetString(scimOrgIDKey)
+	)
+
+	err := pgx.BeginFunc(ctx, s.pgxPool, func(tx pgx.Tx) error {
+		users, err := listSCIMUsers(ctx, tx, orgID, nil)
+		if err != nil {
+			return err
+		}
+
+		current := scimGroupResource(c, role, users)
+		updated, err := change(current)
+		if err != nil {
+			return err
+		}
+
+		added, removed := scimMemberChanges(scimMemberIDs(current.Members), scimMemberIDs(updated.Members))
+		for _, id := range added {
+			if uuid.Validate(id) != nil {
+				return scim.Errorf(http.StatusBadRequest, scim.TypeInvalidValue, "member %s is not a user", id)
+			}
+		}
+
+		now := time.Now()
+		if err := setSCIMUserRole(ctx, tx, s.firebaseService, orgID, added, role, now); err != nil {
+			return err
+		}
+		if role != UserRoleStudent {
+			return setSCIMUserRole(ctx, tx, s.firebaseService, orgID, removed, UserRoleStudent, now)
+		}
+		return nil
+	})
+	if err != nil {
+		s.respondSCIMError(c, err)
+		return
+	}
+
+	s.scimGetGroup(c)
+}
+
+func (s *Service) scimDeleteGroup(c *gin.Context) {
+	if _, ok := scimGroupRole(c.Param("id")); !ok {
+		s.respondSCIMError(c, scim.NotFound("Group", c.Param("id")))
+		return
+	}
+	s.respondSCIMError(c, scim.Errorf(http.StatusBadRequest, scim.TypeMutability, "groups are roles and cannot be deleted"))
+}
+
+// inSCIMTx runs write in a transaction, deleting the sign-in accounts it
+// created if the transaction does not commit.
+func (s *Service) inSCIMTx(ctx context.Context, write func(tx pgx.Tx, created *[]string) error) error {
+	var created []string
+	err := pgx.BeginFunc(ctx, s.pgxPool, func(tx pgx.Tx) error {
+		return write(tx, &created)
+	})
+	if err != nil {
+		for _, uid := range created {
+			if deleteErr := s.firebaseService.DeleteUser(context.WithoutCancel(ctx), uid); deleteErr != nil {
+				s.log(ctx).Error("Failed to delete account of rolled back SCIM user", zap.String("firebase_uid", uid), zap.Error(deleteErr))
+			}
+		}
+	}
+	return err
+}
+
+// writeSCIMUser creates or, given the existing user, updates a user from
+// its SCIM resource and syncs its sign-in account. Creating a user with the
+// email of a deprovisioned one restores that user.
+func writeSCIMUser(ctx context.Context, tx pgx.Tx, accounts AccountSyncer, orgID string, existing *scimUserRecord, resource scimUser, created *[]string, now time.Time) (string, error) {
+	values, err := scimUserValues(resource)
+	if err != nil {
+		return "", err
+	}
+
+	var match struct {
+		UserID        string
+		Deprovisioned bool
+	}
+	err = pgxscan.Get(ctx, tx, &match, findSCIMUserSQL, orgID, values.email)
+	found := err == nil
+	if err != nil && !pgxscan.NotFound(err) {
+		return "", err
+	}
+	if found && !match.Deprovisioned && (existing == nil || match.UserID != existing.UserID) {
+		return "", scim.Errorf(http.StatusConflict, scim.TypeUniqueness, "userName %s is already taken", values.email)
+	}
+
+	userID, status := uuid.New().String(), scimUserStatus("", values.active)
+	switch {
+	case existing != nil:
+		userID, status = existing.UserID, scimUserStatus(existing.Status, values.active)
+	case found:
+		userID = match.UserID
+	}
+
+	if existing == nil && !found {
+		_, err := tx.Exec(ctx, createSCIMUserSQL, userID, orgID, values.firstName, values.lastName, values.phoneNumber,
+			values.email, status, values.externalID, now)
+		if err != nil {
+			return "", err
+		}
+
+		event := gin.H{
+			"user_id":    userID,
+			"org_id":     orgID,
+			"role":       UserRoleStudent,
+			"first_name": values.firstName,
+			"last_name":  values.lastName,
+			"email":      values.email,
+			"created_at": now,
+		}
+		if err := webhooks.Enqueue(ctx, tx, orgID, webhooks.EventUserCreated, event, now); err != nil {
+			return "", err
+		}
+	} else {
+		_, err := tx.Exec(ctx, updateSCIMUserSQL, userID, orgID, values.firstName, values.lastName, values.phoneNumber,
+			values.email, status, values.externalID, now)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Users created or made active again count against the quota.
+	if status == "active" && (existing == nil || existing.Status != "active") {
+		var exceeded *quotaExceededError
+		err := checkOrgQuota(ctx, tx, orgID, quotaUsers, 0, now)
+		if errors.As(err, &exceeded) {
+			return "", scim.Errorf(http.StatusForbidden, "", "%s", exceeded)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	user, err := getSCIMUser(ctx, tx, orgID, userID)
+	if err != nil {
+		return "", err
+	}
+	return userID, syncSCIMAccount(ctx, tx, accounts, orgID, user, created, now)
+}
+
+// syncSCIMAccount brings the sign-in account of a user in line with its
+// record: its email and name are updated and it is disabled unless the
+// user is active. Active users without an account get one, added to
+// created.
+func syncSCIMAccount(ctx context.Context, tx pgx.Tx, accounts AccountSyncer, orgID string, user scimUserRecord, created *[]string, now time.Time) error {
+	var (
+		email       = user.Email
+		displayName = user.FirstName + " " + user.LastName
+		disabled    = user.Status != "active"
+	)
+
+	if user.FirebaseUID != nil {
+		if err := accounts.UpdateAccount(ctx, *user.FirebaseUID, email, displayName, disabled); err != nil {
+			return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+		}
+		return nil
+	}
+	if disabled {
+		return nil
+	}
+
+	uid, isNew, err := accounts.CreateInvitedUser(ctx, email, displayName)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+	}
+	if isNew {
+		*created = append(*created, uid)
+	}
+
+	if _, err := tx.Exec(ctx, setUserFirebaseUIDSQL, user.UserID, uid, now); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return scim.Errorf(http.StatusConflict, scim.TypeUniqueness, "the sign-in account of %s belongs to another user", email)
+		}
+		return err
+	}
+
+	// An account that already existed for the email was not made for this
+	// organization, so it is linked but its claims are left alone.
+	if !isNew {
+		return nil
+	}
+
+	claims := map[string]interface{}{
+		"role":   user.Role,
+		"org_id": orgID,
+	}
+	if err := accounts.SetCustomClaims(ctx, uid, claims); err != nil {
+		return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+	}
+	return nil
+}
+
+// setSCIMUserRole gives users a role and updates the role claim of their
+// sign-in accounts. Users that are not users of the organization are
+// invalid members.
+func setSCIMUserRole(ctx context.Context, tx pgx.Tx, accounts AccountSyncer, orgID string, userIDs []string, role UserRole, now time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	var updated []struct {
+		UserID      string
+		FirebaseUID *string
+	}
+	if err := pgxscan.Select(ctx, tx, &updated, setSCIMUserRoleSQL, userIDs, orgID, role, now); err != nil {
+		return err
+	}
+	if len(updated) != len(userIDs) {
+		return scim.Errorf(http.StatusBadRequest, scim.TypeInvalidValue, "members must be users of the organization")
+	}
+
+	for _, user := range updated {
+		if user.FirebaseUID == nil {
+			continue
+		}
+		claims := map[string]interface{}{
+			"role":   role,
+			"org_id": orgID,
+		}
+		if err := accounts.SetCustomClaims(ctx, *user.FirebaseUID, claims); err != nil {
+			return fmt.Errorf("%w: %w", errAccountProvisioning, err)
+		}
+	}
+	return nil
+}
+
+func listSCIMUsers(ctx context.Context, db dbExecutor, orgID string, userID *string) ([]scimUserRecord, error) {
+	users := []scimUserRecord{}
+	return users, pgxscan.Select(ctx, db, &users, listSCIMUsersSQL, orgID, userID)
+}
+
+func getSCIMUser(ctx context.Context, db dbExecutor, orgID, userID string) (scimUserRecord, error) {
+	if uuid.Validate(userID) != nil {
+		return scimUserRecord{}, scim.NotFound("User", userID)
+	}
+
+	users, err := listSCIMUsers(ctx, db, orgID, &userID)
+	if err != nil {
+		return scimUserRecord{}, err
+	}
+	if len(users) == 0 {
+		return scimUserRecord{}, scim.NotFound("User", userID)
+	}
+	return users[0], nil
+}
+
+func lockSCIMUser(ctx context.Context, tx pgx.Tx, orgID, userID string) (scimUserRecord, error) {
+	if uuid.Validate(userID) != nil {
+		return scimUserRecord{}, scim.NotFound("User", userID)
+	}
+
+	var id string
+	if err := tx.QueryRow(ctx, lockSCIMUserSQL, userID, orgID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return scimUserRecord{}, scim.NotFound("User", userID)
+		}
+		return scimUserRecord{}, err
+	}
+	return getSCIMUser(ctx, tx, orgID, userID)
+}
+
+func scimUserResource(c *gin.Context, user scimUserRecord) scimUser {
+	var (
+		name   = user.FirstName + " " + user.LastName
+		email  = user.Email
+		active = scim.Bool(user.Status == "active")
+	)
+
+	resource := scimUser{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          user.UserID,
+		ExternalID:  user.ExternalID,
+		UserName:    email,
+		Name:        scimName{GivenName: user.FirstName, FamilyName: user.LastName, Formatted: name},
+		DisplayName: name,
+		Active:      &active,
+		Groups: []scimMember{{
+			Value:   string(user.Role),
+			Ref:     scimLocation(c, "Groups/"+string(user.Role)),
+			Display: scimGroupDisplayName(user.Role),
+		}},
+		Meta: &scim.Meta{ResourceType: "User", Location: scimLocation(c, "Users/"+user.UserID)},
+	}
+	if email != "" {
+		resource.Emails = []scimMultiValue{{Value: email, Type: "work", Primary: true}}
+	}
+	if user.PhoneNumber != "" {
+		resource.PhoneNumbers = []scimMultiValue{{Value: user.PhoneNumber, Type: "work"}}
+	}
+	if user.CreatedAt != nil {
+		resource.Meta.Created = user.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if user.UpdatedAt != nil {
+		resource.Meta.LastModified = user.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return resource
+}
+
+func scimGroupResource(c *gin.Context, role UserRole, users []scimUserRecord) scimGroup {
+	group := scimGroup{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          string(role),
+		DisplayName: scimGroupDisplayName(role),
+		Meta:        &scim.Meta{ResourceType: "Group", Location: scimLocation(c, "Groups/"+string(role))},
+	}
+	for _, user := range users {
+		if user.Role == role {
+			group.Members = append(group.Members, scimMember{
+				Value:   user.UserID,
+				Ref:     scimLocation(c, "Users/"+user.UserID),
+				Display: user.FirstName + " " + user.LastName,
+			})
+		}
+	}
+	return group
+}
+
+// scimLocation is the URL of a SCIM endpoint or resource.
+func scimLocation(c *gin.Context, path string) string {
+	scheme := "https"
+	if c.Request.TLS == nil && c.GetHeader("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return scheme + "://" + c.Request.Host + "/scim/v2/" + path
+}
+
+func decodeSCIM(c *gin.Context, v any) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(v); err != nil {
+		return scim.Errorf(http.StatusBadRequest, scim.TypeInvalidSyntax, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func respondSCIM(c *gin.Context, status int, body any) {
+	// gin keeps a content type that is already set.
+	c.Header("Content-Type", scim.MediaType)
+	c.JSON(status, body)
+}
+
+func (s *Service) respondSCIMError(c *gin.Context, err error) {
+	var scimErr *scim.Error
+	switch {
+	case errors.As(err, &scimErr):
+	case errors.Is(err, errAccountProvisioning):
+		s.log(c.Request.Context()).Error("SCIM request rolled back", zap.String("path", c.FullPath()), zap.Error(err))
+		scimErr = scim.Errorf(http.StatusBadGateway, "", "failed to update the user's sign-in account")
+	default:
+		s.log(c.Request.Context()).Error("SCIM request failed", zap.String("path", c.FullPath()), zap.Error(err))
+		scimErr = scim.Errorf(http.StatusInternalServerError, "", "internal error")
+	}
+	respondSCIM(c, scimErr.Status, scimErr)
+}
+
+// newSCIMToken returns a random bearer token for an identity provider.
+func newSCIMToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "scim_" + hex.EncodeToString(b), nil
+}
+
+//go:embed queries/scim/create_scim_token.sql
+var createSCIMTokenSQL string
+
+//go:embed queries/scim/list_scim_tokens.sql
+var listSCIMTokensSQL string
+
+//go:embed queries/scim/delete_scim_token.sql
+var deleteSCIMTokenSQL string
+
+//go:embed queries/scim/authenticate_scim_token.sql
+var authenticateSCIMTokenSQL string
+
+//go:embed queries/scim/list_scim_users.sql
+var listSCIMUsersSQL string
+
+//go:embed queries/scim/lock_scim_user.sql
+var lockSCIMUserSQL string
+
+//go:embed queries/scim/find_scim_user.sql
+var findSCIMUserSQL string
+
+//go:embed queries/scim/create_scim_user.sql
+var createSCIMUserSQL string
+
+//go:embed queries/scim/update_scim_user.sql
+var updateSCIMUserSQL string
+
+//go:embed queries/scim/deprovision_scim_user.sql
+var deprovisionSCIMUserSQL string
+
+//go:embed queries/scim/set_scim_user_role.sql
+var setSCIMUserRoleSQL string
+
+// scimGroups are the SCIM groups, one for each role, in the order they are
+// listed. A group's ID is its role.
+var scimGroups = []struct {
+	role        UserRole
+	displayName string
+}{
+	{UserRoleAdmin, "Admins"},
+	{UserRoleTutor, "Tutors"},
+	{UserRoleStudent, "Students"},
+}
+
+// scimGroupRole finds the role of a SCIM group by its ID or display name.
+func scimGroupRole(name string) (UserRole, bool) {
+	for _, group := range scimGroups {
+		if strings.EqualFold(name, string(group.role)) || strings.EqualFold(name, group.displayName) {
+			return group.role, true
+		}
+	}
+	return "", false
+}
+
+// scimUserStatus is the status of a user the identity provider made active
+// or not. Suspended users stay suspended until it makes them active again.
+func scimUserStatus(current string, active bool) string {
+	switch {
+	case active:
+		return "active"
+	case current == "suspended":
+		return "suspended"
+	default:
+		return "inactive"
+	}
+}
+
+// scimMemberChanges compares the members of a group before and after a
+// change.
+func scimMemberChanges(before, after []string) (added, removed []string) {
+	for _, id := range uniqueIDs(after) {
+		if !slices.Contains(before, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range uniqueIDs(before) {
+		if !slices.Contains(after, id) {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// hashSCIMToken is what a SCIM token is stored and looked up as.
+func hashSCIMToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// scimGroupDisplayName is the display name of the SCIM group of a role.
+func scimGroupDisplayName(role UserRole) string {
+	for _, group := range scimGroups {
+		if group.role == role {
+			return group.displayName
+		}
+	}
+	return string(role)
+}
+
+// scimMemberIDs are the user IDs of the members of a SCIM group.
+func scimMemberIDs(members []scimMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+// scimUserColumns are the columns of a user that a SCIM user resource sets.
+type scimUserColumns struct {
+	firstName   string
+	lastName    string
+	email       string
+	phoneNumber *string
+	externalID  *string
+	active      bool
+}
+
+// scimUserValues validates a SCIM user resource and reads its columns.
+// userName must be the user's email; users are active unless the resource
+// says otherwise.
+func scimUserValues(resource scimUser) (scimUserColumns, error) {
+	values := scimUserColumns{
+		firstName: strings.TrimSpace(resource.Name.GivenName),
+		lastName:  strings.TrimSpace(resource.Name.FamilyName),
+		email:     strings.TrimSpace(resource.UserName),
+		active:    resource.Active == nil || bool(*resource.Active),
+	}
+
+	if !isValidEmail(values.email) {
+		return scimUserColumns{}, scim.Errorf(http.StatusBadRequest, scim.TypeInvalidValue, "userName must be the user's email address")
+	}
+	if values.firstName == "" || values.lastName == "" {
+		return scimUserColumns{}, scim.Errorf(http.StatusBadRequest, scim.TypeInvalidValue, "name.givenName and name.familyName are required")
+	}
+
+	// The primary phone number, or else the first.
+	for _, phone := range resource.PhoneNumbers {
+		if number := strings.TrimSpace(phone.Value); number != "" && (values.phoneNumber == nil || bool(phone.Primary)) {
+			values.phoneNumber = &number
+		}
+	}
+	if externalID := strings.TrimSpace(resource.ExternalID); externalID != "" {
+		values.externalID = &externalID
+	}
+
+	return values, nil
+}
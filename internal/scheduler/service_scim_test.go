@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"errors"
+	"scheduler-api/internal/scim"
+	"slices"
+	"testing"
+)
+
+func TestSCIMGroups(t *testing.T) {
+	for name, want := range map[string]UserRole{"tutor": UserRoleTutor, "Tutors": UserRoleTutor, "ADMINS": UserRoleAdmin, "student": UserRoleStudent} {
+		if role, ok := scimGroupRole(name); !ok || role != want {
+			t.Errorf("scimGroupRole(%s) = %q, want %q", name, role, want)
+		}
+	}
+	if _, ok := scimGroupRole("Engineering"); ok {
+		t.Error("expected only roles to be groups")
+	}
+	if name := scimGroupDisplayName(UserRoleStudent); name != "Students" {
+		t.Errorf("unexpected display name %s", name)
+	}
+
+	added, removed := scimMemberChanges([]string{"a", "b", "c"}, []string{"c", "d", "d", "a"})
+	if !slices.Equal(added, []string{"d"}) || !slices.Equal(removed, []string{"b"}) {
+		t.Errorf("scimMemberChanges() = %v, %v", added, removed)
+	}
+}
+
+func TestSCIMUserStatus(t *testing.T) {
+	tests := []struct {
+		current string
+		active  bool
+		want    string
+	}{
+		{"inactive", true, "active"},
+		{"suspended", true, "active"},
+		{"active", false, "inactive"},
+		{"suspended", false, "suspended"},
+		{"", false, "inactive"},
+	}
+
+	for _, tt := range tests {
+		if got := scimUserStatus(tt.current, tt.active); got != tt.want {
+			t.Errorf("scimUserStatus(%q, %v) = %q, want %q", tt.current, tt.active, got, tt.want)
+		}
+	}
+}
+
+func TestSCIMUserValues(t *testing.T) {
+	inactive := scim.Bool(false)
+	values, err := scimUserValues(scimUser{
+		UserName:     " ada@example.com ",
+		Name:         scimName{GivenName: "Ada", FamilyName: "Lovelace"},
+		PhoneNumbers: []scimMultiValue{{Value: "555-0100"}, {Value: "555-0199", Primary: true}},
+		ExternalID:   "00u1",
+		Active:       &inactive,
+	})
+	if err != nil {
+		t.Fatalf("scimUserValues() error = %v", err)
+	}
+	if values.email != "ada@example.com" || *values.phoneNumber != "555-0199" || *values.externalID != "00u1" || values.active {
+		t.Errorf("unexpected values %+v", values)
+	}
+
+	values, err = scimUserValues(scimUser{UserName: "alan@example.com", Name: scimName{GivenName: "Alan", FamilyName: "Turing"}})
+	if err != nil || !values.active || values.phoneNumber != nil || values.externalID != nil {
+		t.Errorf("expected an active user without a phone number, got %+v (%v)", values, err)
+	}
+
+	for _, resource := range []scimUser{
+		{UserName: "ada", Name: scimName{GivenName: "Ada", FamilyName: "Lovelace"}},
+		{UserName: "ada@example.com", Name: scimName{GivenName: "Ada"}},
+	} {
+		var scimErr *scim.Error
+		if _, err := scimUserValues(resource); !errors.As(err, &scimErr) || scimErr.ScimType != scim.TypeInvalidValue {
+			t.Errorf("expected an invalidValue error for %+v, got %v", resource, err)
+		}
+	}
+
+	if hashSCIMToken("scim_a") == hashSCIMToken("scim_b") || len(hashSCIMToken("scim_a")) != 64 {
+		t.Error("expected tokens to hash to distinct SHA-256 digests")
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+import "testing"
+
+func TestETagMatches(t *testing.T) {
+	etag := versionETag(7)
+	if etag != `"7"` {
+		t.Fatalf("expected %q, got %q", `"7"`, etag)
+	}
+
+	tests := []struct {
+		header string
+		weak   bool
+		want   bool
+	}{
+		{header: `"7"`, want: true},
+		{header: `"6"`, want: false},
+		{header: `"6", "7"`, want: true},
+		{header: `"6","7"`, want: true},
+		{header: `*`, want: true},
+		{header: `7`, want: false},
+		{header: `W/"7"`, weak: false, want: false},
+		{header: `W/"7"`, weak: true, want: true},
+		{header: `W/"6", "7"`, weak: false, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag, tt.weak); got != tt.want {
+			t.Errorf("etagMatches(%q, weak %v) = %v, want %v", tt.header, tt.weak, got, tt.want)
+		}
+	}
+}
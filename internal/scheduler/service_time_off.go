@@ -0,0 +1,695 @@
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type TimeOffService interface {
+	ListUserTimeOff(*gin.Context, string)
+	RequestTimeOff(*gin.Context, string)
+	ListTimeOffRequests(*gin.Context, ListTimeOffRequestsParams)
+	GetTimeOffRequest(*gin.Context, string)
+	CancelTimeOffRequest(*gin.Context, string)
+	ApproveTimeOffRequest(*gin.Context, string)
+	RejectTimeOffRequest(*gin.Context, string)
+	SuggestSubstitutes(*gin.Context, string)
+	AssignSubstitute(*gin.Context, string)
+}
+
+var _ TimeOffService = (*Service)(nil)
+
+func (s *Service) ListUserTimeOff(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own time off",
+		})
+		return
+	}
+
+	s.respondWithTimeOffList(c, currentUser.OrgID, &userID, nil)
+}
+
+func (s *Service) RequestTimeOff(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only request your own time off",
+		})
+		return
+	}
+
+	request := TimeOffRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !request.EndTime.After(request.StartTime) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tutor, err := getUser(ctx, s.pgxPool, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tutor.Role != "tutor" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_a_tutor",
+			"message": "Time off can only be requested for tutors",
+		})
+		return
+	}
+
+	requestID := uuid.New().String()
+	_, err = s.pgxPool.Exec(ctx, createTimeOffSQL, requestID, tutor.OrgId, userID, request.StartTime, request.EndTime, request.Reason, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithTimeOff(c, requestID, http.StatusCreated)
+}
+
+func (s *Service) ListTimeOffRequests(c *gin.Context, params ListTimeOffRequestsParams) {
+	currentUser, ok := s.requireAdmin(c, "view every time-off request")
+	if !ok {
+		return
+	}
+
+	s.respondWithTimeOffList(c, currentUser.OrgID, nil, params.Status)
+}
+
+func (s *Service) GetTimeOffRequest(c *gin.Context, requestID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	request, err := getTimeOff(c.Request.Context(), s.pgxPool, queryGetTimeOffSQL, requestID)
+	if err != nil || request.OrgID != currentUser.OrgID {
+		respondTimeOffLookupError(c, err)
+		return
+	}
+
+	if *request.TutorId != currentUser.UserID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own time off",
+		})
+		return
+	}
+
+	s.respondWithTimeOff(c, requestID, http.StatusOK)
+}
+
+func (s *Service) CancelTimeOffRequest(c *gin.Context, requestID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	request, err := getTimeOff(ctx, tx, queryGetTimeOffForUpdateSQL, requestID)
+	if err != nil || request.OrgID != currentUser.OrgID {
+		respondTimeOffLookupError(c, err)
+		return
+	}
+
+	if *request.TutorId != currentUser.UserID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only cancel your own time off",
+		})
+		return
+	}
+
+	if *request.Status != TimeOffStatusPending {
+		respondTimeOffNotPending(c, request)
+		return
+	}
+
+	if _, err := tx.Exec(ctx, cancelTimeOffSQL, requestID, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) ApproveTimeOffRequest(c *gin.Context, requestID string) {
+	s.reviewTimeOff(c, requestID, TimeOffStatusApproved)
+}
+
+func (s *Service) RejectTimeOffRequest(c *gin.Context, requestID string) {
+	s.reviewTimeOff(c, requestID, TimeOffStatusRejected)
+}
+
+// reviewTimeOff approves or rejects a pending time-off request. Approval
+// flags the classes the tutor teaches during the time off and clears their
+// open availability and unbooked slots so nothing new lands in it.
+func (s *Service) reviewTimeOff(c *gin.Context, requestID string, status TimeOffStatus) {
+	currentUser, ok := s.requireAdmin(c, "review time off")
+	if !ok {
+		return
+	}
+
+	// The request body is optional and only carries a note.
+	review := TimeOffReview{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&review); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	request, err := getTimeOff(ctx, tx, queryGetTimeOffForUpdateSQL, requestID)
+	if err != nil || request.OrgID != currentUser.OrgID {
+		respondTimeOffLookupError(c, err)
+		return
+	}
+
+	if *request.Status != TimeOffStatusPending {
+		respondTimeOffNotPending(c, request)
+		return
+	}
+
+	if _, err := tx.Exec(ctx, reviewTimeOffSQL, requestID, status, currentUser.UserID, now, review.Note); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if status == TimeOffStatusApproved {
+		tutorID := *request.TutorId
+		if _, err := tx.Exec(ctx, flagAffectedClassesSQL, requestID, tutorID, request.StartTime, request.EndTime, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if _, err := tx.Exec(ctx, clearTimeOffScheduleSQL, tutorID, request.StartTime, request.EndTime, now); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithTimeOff(c, requestID, http.StatusOK)
+}
+
+func (s *Service) SuggestSubstitutes(c *gin.Context, classID string) {
+	currentUser, ok := s.requireAdmin(c, "look for substitutes")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	orgID, err := getClassOrgID(ctx, s.pgxPool, classID)
+	if err != nil || orgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	class, err := getClass(ctx, s.pgxPool, classID)
+	if err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	suggestions, err := findSubstitutes(ctx, s.pgxPool, classID, nil, classSlot{class.StartTime, class.Duration})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+func (s *Service) AssignSubstitute(c *gin.Context, classID string) {
+	currentUser, ok := s.requireAdmin(c, "assign substitutes")
+	if !ok {
+		return
+	}
+
+	request := SubstituteAssignment{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil || class.OrgID != currentUser.OrgID {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	if !checkIfMatch(c, class.Version, "The class") {
+		return
+	}
+
+	if class.Status == string(ClassStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "class_cancelled",
+			"message": "Cancelled classes must be restored before they can be reassigned",
+		})
+		return
+	}
+
+	replacedID, err := absentTeacher(ctx, tx, classID, request.TutorId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	participants, err := getClassParticipants(ctx, tx, []string{classID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if role, ok := classParticipantRole(classParticipants(participants), replacedID); replacedID == "" || !ok || role != ClassParticipantRoleTeacher {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "no_teacher_to_replace",
+			"message": "Name a teacher of the class to replace with tutor_id",
+		})
+		return
+	}
+
+	substitute, err := getUser(ctx, tx, request.SubstituteId)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "not_a_tutor",
+				"message": "Substitute not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if substitute.Role != "tutor" || substitute.OrgId != class.OrgID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_a_tutor",
+			"message": "Substitutes must be tutors of the class's organization",
+		})
+		return
+	}
+
+	// Workload violations are reported in detail first; findSubstitutes then
+	// applies every other requirement a suggested substitute meets.
+	slot := classSlot{class.StartTime, class.Duration}
+	violations, err := checkTutorWorkload(ctx, tx, []string{request.SubstituteId}, &classID, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(violations) > 0 {
+		respondWorkloadViolations(c, violations)
+		return
+	}
+
+	candidates, err := findSubstitutes(ctx, tx, classID, &request.SubstituteId, slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(candidates) == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "substitute_unavailable",
+			"message": "Substitute is already in the class, does not teach its course, is away or teaching at that time, or has no open availability for all of it",
+		})
+		return
+	}
+
+	if !checkPayrollLock(c, tx, classID, []string{request.SubstituteId}, class.StartTime) {
+		return
+	}
+
+	update := ClassParticipantUpdate{
+		Teachers: &CourseParticipantChanges{
+			Add:    &[]string{request.SubstituteId},
+			Remove: &[]string{replacedID},
+		},
+	}
+	if err := updateClassParticipants(ctx, tx, classID, update, class.StartTime, slot.endTime(), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, resolveAffectedClassSQL, classID, replacedID, request.SubstituteId, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	note := fmt.Sprintf("substitute %s replaces teacher %s", request.SubstituteId, replacedID)
+	if request.Note != nil && *request.Note != "" {
+		note += ": " + *request.Note
+	}
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionParticipantsUpdated,
+		StartTime: class.StartTime,
+		Duration:  class.Duration,
+		Note:      &note,
+		ActorId:   &currentUser.UserID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	substituteName := map[string]any{"substitute_name": substitute.FirstName + " " + substitute.LastName}
+	if err := notifications.EnqueueClassEvent(ctx, tx, classID, notifications.EventClassSubstituteAssigned, substituteName, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassParticipantsUpdated, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := live.PublishClassEvent(ctx, tx, classID, live.EventClassParticipantsUpdated, []string{replacedID}, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithClass(c, classID, http.StatusOK)
+}
+
+// respondWithTimeOff writes a time-off request with its affected classes as
+// the response body.
+func (s *Service) respondWithTimeOff(c *gin.Context, requestID string, status int) {
+	ctx := c.Request.Context()
+
+	request, err := getTimeOff(ctx, s.pgxPool, queryGetTimeOffSQL, requestID)
+	if err != nil {
+		respondTimeOffLookupError(c, err)
+		return
+	}
+
+	requests := []TimeOffRequest{request.TimeOffRequest}
+	if err := loadAffectedClasses(ctx, s.pgxPool, requests); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, requests[0])
+}
+
+func (s *Service) respondWithTimeOffList(c *gin.Context, orgID string, tutorID *string, status *TimeOffStatus) {
+	ctx := c.Request.Context()
+
+	requests := []TimeOffRequest{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &requests, queryListTimeOffSQL, orgID, tutorID, status); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := loadAffectedClasses(ctx, s.pgxPool, requests); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// respondTimeOffLookupError treats requests of other organizations as
+// missing.
+func respondTimeOffLookupError(c *gin.Context, err error) {
+	if err == nil || pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "time_off_not_found",
+			"message": "Time-off request not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func respondTimeOffNotPending(c *gin.Context, request timeOffRecord) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   "time_off_not_pending",
+		"message": "Time-off request is already " + string(*request.Status),
+	})
+}
+
+//go:embed queries/timeoff/create_time_off.sql
+var createTimeOffSQL string
+
+//go:embed queries/timeoff/get_time_off.sql
+var queryGetTimeOffSQL string
+
+//go:embed queries/timeoff/get_time_off_for_update.sql
+var queryGetTimeOffForUpdateSQL string
+
+//go:embed queries/timeoff/list_time_off.sql
+var queryListTimeOffSQL string
+
+//go:embed queries/timeoff/review_time_off.sql
+var reviewTimeOffSQL string
+
+//go:embed queries/timeoff/cancel_time_off.sql
+var cancelTimeOffSQL string
+
+//go:embed queries/timeoff/flag_affected_classes.sql
+var flagAffectedClassesSQL string
+
+//go:embed queries/timeoff/list_affected_classes.sql
+var queryListAffectedClassesSQL string
+
+//go:embed queries/timeoff/clear_time_off_schedule.sql
+var clearTimeOffScheduleSQL string
+
+//go:embed queries/timeoff/find_absent_teacher.sql
+var queryFindAbsentTeacherSQL string
+
+//go:embed queries/timeoff/resolve_affected_class.sql
+var resolveAffectedClassSQL string
+
+//go:embed queries/timeoff/list_substitute_candidates.sql
+var queryListSubstituteCandidatesSQL string
+
+// timeOffRecord is a time-off request with the organization it belongs to.
+type timeOffRecord struct {
+	OrgID string
+	TimeOffRequest
+}
+
+// affectedClassRecord is an affected class tagged with its time-off request,
+// so the classes of many requests can be loaded at once.
+type affectedClassRecord struct {
+	RequestID string
+	TimeOffAffectedClass
+}
+
+func getTimeOff(ctx context.Context, db dbExecutor, query, requestID string) (timeOffRecord, error) {
+	request := timeOffRecord{}
+	return request, pgxscan.Get(ctx, db, &request, query, requestID)
+}
+
+// loadAffectedClasses fills in the affected classes of every request with a
+// single query.
+func loadAffectedClasses(ctx context.Context, db dbExecutor, requests []TimeOffRequest) error {
+	requestIDs := make([]string, 0, len(requests))
+	for _, request := range requests {
+		requestIDs = append(requestIDs, *request.RequestId)
+	}
+
+	records := []affectedClassRecord{}
+	if err := pgxscan.Select(ctx, db, &records, queryListAffectedClassesSQL, requestIDs); err != nil {
+		return err
+	}
+
+	byRequest := map[string][]TimeOffAffectedClass{}
+	for _, record := range records {
+		byRequest[record.RequestID] = append(byRequest[record.RequestID], record.TimeOffAffectedClass)
+	}
+
+	for i := range requests {
+		classes := byRequest[*requests[i].RequestId]
+		if classes == nil {
+			classes = []TimeOffAffectedClass{}
+		}
+		requests[i].AffectedClasses = &classes
+	}
+
+	return nil
+}
+
+// absentTeacher returns the teacher to replace in a class: tutorID if set,
+// otherwise a teacher whose approved time off flagged the class. It returns
+// an empty id when there is none.
+func absentTeacher(ctx context.Context, tx pgx.Tx, classID string, tutorID *string) (string, error) {
+	if tutorID != nil {
+		return *tutorID, nil
+	}
+
+	var teacherID string
+	err := tx.QueryRow(ctx, queryFindAbsentTeacherSQL, classID).Scan(&teacherID)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return teacherID, err
+}
+
+func listSubstituteCandidates(ctx context.Context, db dbExecutor, classID string, tutorID *string) ([]SubstituteSuggestion, error) {
+	candidates := []SubstituteSuggestion{}
+	return candidates, pgxscan.Select(ctx, db, &candidates, queryListSubstituteCandidatesSQL, classID, tutorID)
+}
+
+// findSubstitutes returns the tutors who could take over a class at slot:
+// qualified and free according to listSubstituteCandidates, with open
+// availability for the whole class and room under their workload limits,
+// ranked by rankSubstitutes.
+func findSubstitutes(ctx context.Context, db dbExecutor, classID string, tutorID *string, slot classSlot) ([]SubstituteSuggestion, error) {
+	candidates, err := listSubstituteCandidates(ctx, db, classID, tutorID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []SubstituteSuggestion{}
+	for _, candidate := range candidates {
+		availability, err := listOpenAvailability(ctx, db, candidate.UserId, slot.StartTime, slot.endTime())
+		if err != nil {
+			return nil, err
+		}
+		if !intervalsCover(availability, slot.StartTime, slot.endTime()) {
+			continue
+		}
+
+		violations, err := checkTutorWorkload(ctx, db, []string{candidate.UserId}, &classID, slot)
+		if err != nil {
+			return nil, err
+		}
+		if len(violations) == 0 {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	rankSubstitutes(suggestions)
+	return suggestions, nil
+}
+
+// rankSubstitutes puts the tutors who teach the fewest minutes in the week
+// around the class first, spreading cover evenly, and breaks ties by name.
+func rankSubstitutes(suggestions []SubstituteSuggestion) {
+	slices.SortStableFunc(suggestions, func(a, b SubstituteSuggestion) int {
+		return cmp.Or(
+			cmp.Compare(a.ScheduledMinutes, b.ScheduledMinutes),
+			strings.Compare(a.LastName, b.LastName),
+			strings.Compare(a.FirstName, b.FirstName),
+			strings.Compare(a.UserId, b.UserId),
+		)
+	})
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRankSubstitutes(t *testing.T) {
+	suggestion := func(userID, first, last string, minutes int) SubstituteSuggestion {
+		return SubstituteSuggestion{UserId: userID, FirstName: first, LastName: last, ScheduledMinutes: minutes}
+	}
+
+	tests := []struct {
+		name        string
+		suggestions []SubstituteSuggestion
+		expected    []string
+	}{
+		{
+			name:        "none",
+			suggestions: []SubstituteSuggestion{},
+			expected:    []string{},
+		},
+		{
+			name: "fewest scheduled minutes first",
+			suggestions: []SubstituteSuggestion{
+				suggestion("busy", "Ada", "Lovelace", 300),
+				suggestion("free", "Grace", "Hopper", 0),
+				suggestion("some", "Alan", "Turing", 90),
+			},
+			expected: []string{"free", "some", "busy"},
+		},
+		{
+			name: "ties broken by last then first name",
+			suggestions: []SubstituteSuggestion{
+				suggestion("turing", "Alan", "Turing", 60),
+				suggestion("hopper-grace", "Grace", "Hopper", 60),
+				suggestion("hopper-anne", "Anne", "Hopper", 60),
+			},
+			expected: []string{"hopper-anne", "hopper-grace", "turing"},
+		},
+		{
+			name: "namesakes ordered by user id",
+			suggestions: []SubstituteSuggestion{
+				suggestion("u-2", "Sam", "Lee", 30),
+				suggestion("u-1", "Sam", "Lee", 30),
+			},
+			expected: []string{"u-1", "u-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankSubstitutes(tt.suggestions)
+
+			ranked := []string{}
+			for _, s := range tt.suggestions {
+				ranked = append(ranked, s.UserId)
+			}
+			if !slices.Equal(ranked, tt.expected) {
+				t.Errorf("rankSubstitutes() = %v, want %v", ranked, tt.expected)
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"scheduler-api/internal/auth"
+	"scheduler-api/internal/webhooks"
 	"strings"
+	"time"
 
 	"context"
 	_ "embed"
@@ -65,9 +68,9 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 		}
 
 		// Start database transaction
-		tx, err := s.sqlDB.Begin()
+		tx, err := s.sqlDB.BeginTx(c.Request.Context(), nil)
 		if err != nil {
-			s.logger.Error("Failed to begin transaction", zap.Error(err))
+			s.log(c.Request.Context()).Error("Failed to begin transaction", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "database_error",
 				"message": "Failed to start transaction",
@@ -77,22 +80,15 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 		defer func() {
 			if err != nil {
 				if rollbackErr := tx.Rollback(); rollbackErr != nil {
-					s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+					s.log(c.Request.Context()).Error("Failed to rollback transaction", zap.Error(rollbackErr))
 				}
 			}
 		}()
 
-		// Set custom claims for role-based access
-		claims := map[string]interface{}{
-			"role":   req.Role,
-			"org_id": req.OrgID,
-		}
-		if err := s.firebaseService.SetCustomClaims(userID, claims); err != nil {
-			s.logger.Error("Failed to set custom claims", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "firebase_error", 
-				"message": "Failed to set user permissions",
-			})
+		// Check the organization's quota before the account gets claims
+		// for it. The quota row stays locked until the user is committed.
+		if err = checkOrgQuotaSQL(c.Request.Context(), tx, req.OrgID, quotaUsers, 1, time.Now()); err != nil {
+			respondQuotaError(c, err)
 			return
 		}
 
@@ -105,10 +101,10 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 		
 		var dbUserID string
 		var createdAt, updatedAt string
-		err = tx.QueryRow(query, req.OrgID, userID, req.Role, req.FirstName, req.LastName, req.Email).Scan(
+		err = tx.QueryRowContext(c.Request.Context(), query, req.OrgID, userID, req.Role, req.FirstName, req.LastName, req.Email).Scan(
 			&dbUserID, &createdAt, &updatedAt)
 		if err != nil {
-			s.logger.Error("Failed to create user in database", zap.Error(err))
+			s.log(c.Request.Context()).Error("Failed to create user in database", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "database_error",
 				"message": "Failed to create user record",
@@ -118,7 +114,7 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 
 		// Commit transaction
 		if err := tx.Commit(); err != nil {
-			s.logger.Error("Failed to commit transaction", zap.Error(err))
+			s.log(c.Request.Context()).Error("Failed to commit transaction", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "database_error",
 				"message": "Failed to save user",
@@ -126,6 +122,24 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 			return
 		}
 
+		// Set custom claims for role-based access once the quota lock is
+		// released, removing the user again if the account cannot get them
+		claims := map[string]interface{}{
+			"role":   req.Role,
+			"org_id": req.OrgID,
+		}
+		if err := s.firebaseService.SetCustomClaims(c.Request.Context(), userID, claims); err != nil {
+			s.log(c.Request.Context()).Error("Failed to set custom claims", zap.Error(err))
+			if _, deleteErr := s.sqlDB.ExecContext(context.WithoutCancel(c.Request.Context()), `DELETE FROM users WHERE user_id = $1`, dbUserID); deleteErr != nil {
+				s.log(c.Request.Context()).Error("Failed to delete user without claims", zap.String("user_id", dbUserID), zap.Error(deleteErr))
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "firebase_error",
+				"message": "Failed to set user permissions",
+			})
+			return
+		}
+
 		// Return created user
 		user := gin.H{
 			"user_id":        dbUserID,
@@ -141,12 +155,27 @@ func (s *Service) CreateUser(c *gin.Context, userID string) {
 			"updated_at":     updatedAt,
 		}
 
-		s.logger.Info("User created successfully", 
+		s.log(c.Request.Context()).Info("User created successfully", 
 			zap.String("user_id", dbUserID),
 			zap.String("firebase_uid", userID),
 			zap.String("email", req.Email),
 			zap.String("role", req.Role))
 
+		// Users are created through database/sql, so the webhook cannot share
+		// the transaction and is queued once the user is committed.
+		event := gin.H{
+			"user_id":    dbUserID,
+			"org_id":     req.OrgID,
+			"role":       req.Role,
+			"first_name": req.FirstName,
+			"last_name":  req.LastName,
+			"email":      req.Email,
+			"created_at": createdAt,
+		}
+		if err := webhooks.Enqueue(c.Request.Context(), s.pgxPool, req.OrgID, webhooks.EventUserCreated, event, time.Now()); err != nil {
+			s.log(c.Request.Context()).Error("Failed to queue user.created webhook", zap.String("user_id", dbUserID), zap.Error(err))
+		}
+
 		c.JSON(http.StatusCreated, user)
 		return
 	}
@@ -183,7 +212,7 @@ func (s *Service) GetUser(c *gin.Context, userID string) {
 		SELECT user_id, org_id, role, first_name, last_name, email, 
 		       COALESCE(email_verified, false) as email_verified,
 		       COALESCE(status, 'active') as status,
-		       created_at, updated_at, last_login_at
+		       created_at, updated_at, last_login_at, version
 		FROM users 
 		WHERE firebase_uid = $1 AND status = 'active'
 	`
@@ -200,9 +229,10 @@ func (s *Service) GetUser(c *gin.Context, userID string) {
 		CreatedAt     string     `json:"created_at"`
 		UpdatedAt     string     `json:"updated_at"`
 		LastLoginAt   *string    `json:"last_login_at,omitempty"`
+		Version       int64      `json:"-"`
 	}
 
-	err = s.sqlDB.QueryRow(query, userID).Scan(
+	err = s.sqlDB.QueryRowContext(c.Request.Context(), query, userID).Scan(
 		&user.UserID,
 		&user.OrgID,
 		&user.Role,
@@ -214,6 +244,7 @@ func (s *Service) GetUser(c *gin.Context, userID string) {
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.LastLoginAt,
+		&user.Version,
 	)
 
 	if err != nil {
@@ -223,7 +254,7 @@ func (s *Service) GetUser(c *gin.Context, userID string) {
 				"message": "User not found",
 			})
 		} else {
-			s.logger.Error("Failed to query user", zap.Error(err))
+			s.log(c.Request.Context()).Error("Failed to query user", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "database_error",
 				"message": "Failed to retrieve user",
@@ -232,6 +263,11 @@ func (s *Service) GetUser(c *gin.Context, userID string) {
 		return
 	}
 
+	// The ETag leaves out last_login_at, which every request updates.
+	if notModified(c, user.Version) {
+		return
+	}
+
 	c.JSON(http.StatusOK, user)
 }
 
@@ -311,9 +347,9 @@ func (s *Service) UpdateUser(c *gin.Context, userID string) {
 	}
 
 	// Start transaction
-	tx, err := s.sqlDB.Begin()
+	tx, err := s.sqlDB.BeginTx(c.Request.Context(), nil)
 	if err != nil {
-		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		s.log(c.Request.Context()).Error("Failed to begin transaction", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "database_error",
 			"message": "Failed to start transaction",
@@ -323,7 +359,7 @@ func (s *Service) UpdateUser(c *gin.Context, userID string) {
 	defer func() {
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				s.log(c.Request.Context()).Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			}
 		}
 	}()
@@ -362,14 +398,39 @@ func (s *Service) UpdateUser(c *gin.Context, userID string) {
 		return
 	}
 
+	// Lock the user so it cannot change between the If-Match check and the
+	// update
+	var version int64
+	err = tx.QueryRowContext(c.Request.Context(), "SELECT version FROM users WHERE firebase_uid = $1 FOR UPDATE", userID).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		s.log(c.Request.Context()).Error("Failed to lock user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "database_error",
+			"message": "Failed to update user",
+		})
+		return
+	}
+
+	if !checkIfMatch(c, version, "The user") {
+		_ = tx.Rollback()
+		return
+	}
+
 	// Update database using Firebase UID
 	query := fmt.Sprintf("UPDATE users SET %s WHERE firebase_uid = $%d", 
 		strings.Join(setParts, ", "), argIndex)
 	args = append(args, userID)
 
-	_, err = tx.Exec(query, args...)
+	_, err = tx.ExecContext(c.Request.Context(), query, args...)
 	if err != nil {
-		s.logger.Error("Failed to update user", zap.Error(err))
+		s.log(c.Request.Context()).Error("Failed to update user", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "database_error",
 			"message": "Failed to update user",
@@ -387,8 +448,8 @@ func (s *Service) UpdateUser(c *gin.Context, userID string) {
 			"role":   req.Role,
 			"org_id": currentUser.OrgID,
 		}
-		if err := s.firebaseService.SetCustomClaims(firebaseUID, claims); err != nil {
-			s.logger.Error("Failed to update custom claims", zap.Error(err))
+		if err := s.firebaseService.SetCustomClaims(c.Request.Context(), firebaseUID, claims); err != nil {
+			s.log(c.Request.Context()).Error("Failed to update custom claims", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   "firebase_error",
 				"message": "Failed to update user permissions",
@@ -399,7 +460,7 @@ func (s *Service) UpdateUser(c *gin.Context, userID string) {
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		s.log(c.Request.Context()).Error("Failed to commit transaction", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "database_error",
 			"message": "Failed to save changes",
@@ -431,6 +492,9 @@ func (s *Service) DeleteUser(c *gin.Context, userID string) {
 //go:embed queries/user/list_users.sql
 var queryListUsersSQL string
 
+//go:embed queries/user/get_user.sql
+var queryGetUserSQL string
+
 //go:embed queries/user/get_user_courses.sql
 var queryGetUserCoursesSQL string
 
@@ -439,6 +503,11 @@ func listUsers(ctx context.Context, pgxPool *pgxpool.Pool, organizationID string
 	return users, pgxscan.Select(ctx, pgxPool, &users, queryListUsersSQL, organizationID)
 }
 
+func getUser(ctx context.Context, db dbExecutor, userID string) (User, error) {
+	user := User{}
+	return user, pgxscan.Get(ctx, db, &user, queryGetUserSQL, userID)
+}
+
 func getUserCourses(ctx context.Context, pgxPool *pgxpool.Pool, userID string) ([]string, error) {
 	courses := []string{}
 	return courses, pgxscan.Select(ctx, pgxPool, &courses, queryGetUserCoursesSQL, userID)
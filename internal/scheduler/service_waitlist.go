@@ -0,0 +1,710 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/webhooks"
+	"slices"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultWaitlistOfferTTL is how long an offered seat is held for the
+// waitlisted student before it goes to the next entry.
+const defaultWaitlistOfferTTL = 48 * time.Hour
+
+type WaitlistService interface {
+	ListCourseWaitlist(*gin.Context, string)
+	ReorderCourseWaitlist(*gin.Context, string)
+	ListClassWaitlist(*gin.Context, string)
+	ReorderClassWaitlist(*gin.Context, string)
+	ListUserWaitlist(*gin.Context, string)
+	RemoveWaitlistEntry(*gin.Context, string)
+	AcceptWaitlistOffer(*gin.Context, string)
+	DeclineWaitlistOffer(*gin.Context, string)
+}
+
+var _ WaitlistService = (*Service)(nil)
+
+func (s *Service) ListCourseWaitlist(c *gin.Context, courseID string) {
+	if _, ok := s.requireAdmin(c, "view waitlists"); !ok {
+		return
+	}
+
+	if _, err := getCourse(c.Request.Context(), s.pgxPool, courseID); err != nil {
+		respondCourseLookupError(c, err)
+		return
+	}
+
+	s.respondWithWaitlist(c, courseWaitlist(courseID))
+}
+
+func (s *Service) ReorderCourseWaitlist(c *gin.Context, courseID string) {
+	s.reorderWaitlist(c, courseWaitlist(courseID))
+}
+
+func (s *Service) ListClassWaitlist(c *gin.Context, classID string) {
+	if _, ok := s.requireAdmin(c, "view waitlists"); !ok {
+		return
+	}
+
+	if _, err := getClass(c.Request.Context(), s.pgxPool, classID); err != nil {
+		s.respondClassLookupError(c, err)
+		return
+	}
+
+	s.respondWithWaitlist(c, classWaitlist(classID))
+}
+
+func (s *Service) ReorderClassWaitlist(c *gin.Context, classID string) {
+	s.reorderWaitlist(c, classWaitlist(classID))
+}
+
+func (s *Service) ListUserWaitlist(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own waitlist entries",
+		})
+		return
+	}
+
+	entries := []WaitlistEntry{}
+	if err := pgxscan.Select(c.Request.Context(), s.pgxPool, &entries, queryListUserWaitlistSQL, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (s *Service) RemoveWaitlistEntry(c *gin.Context, waitlistID string) {
+	if _, ok := s.requireAdmin(c, "remove waitlist entries"); !ok {
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	entry, err := getWaitlistEntry(ctx, s.pgxPool, waitlistID)
+	if err != nil {
+		respondWaitlistLookupError(c, err)
+		return
+	}
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	target := waitlistTarget{CourseID: entry.CourseId, ClassID: entry.ClassId}
+	if _, err := target.capacity(ctx, tx, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := tx.Exec(ctx, removeWaitlistEntrySQL, waitlistID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "waitlist_entry_closed",
+			"message": "Entry is no longer waiting or offered a seat",
+		})
+		return
+	}
+
+	if err := offerOpenSeats(ctx, tx, target, s.waitlistOfferTTL, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) AcceptWaitlistOffer(c *gin.Context, waitlistID string) {
+	currentUser, entry, ok := s.getOwnWaitlistEntry(c, waitlistID, "accept")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx    = c.Request.Context()
+		now    = time.Now()
+		target = waitlistTarget{CourseID: entry.CourseId, ClassID: entry.ClassId}
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	capacity, err := target.capacity(ctx, tx, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !capacity.Open {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "waitlist_closed",
+			"message": "The class has been cancelled or has already started",
+		})
+		return
+	}
+
+	tag, err := tx.Exec(ctx, respondToWaitlistOfferSQL, waitlistID, WaitlistStatusAccepted, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "no_offer",
+			"message": "No seat is offered or the offer has expired",
+		})
+		return
+	}
+
+	if entry.CourseId != nil {
+		err = enrollWaitlistedCourseStudent(ctx, tx, capacity.OrgID, *entry.CourseId, entry.UserId, currentUser.UserID, now)
+	} else {
+		err = enrollWaitlistedClassStudent(ctx, tx, *entry.ClassId, entry.UserId, currentUser.UserID, now)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithWaitlistEntry(c, s.pgxPool, waitlistID)
+}
+
+func (s *Service) DeclineWaitlistOffer(c *gin.Context, waitlistID string) {
+	_, entry, ok := s.getOwnWaitlistEntry(c, waitlistID, "decline")
+	if !ok {
+		return
+	}
+
+	var (
+		ctx    = c.Request.Context()
+		now    = time.Now()
+		target = waitlistTarget{CourseID: entry.CourseId, ClassID: entry.ClassId}
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := target.capacity(ctx, tx, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := tx.Exec(ctx, respondToWaitlistOfferSQL, waitlistID, WaitlistStatusDeclined, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "no_offer",
+			"message": "No seat is offered",
+		})
+		return
+	}
+
+	if err := offerOpenSeats(ctx, tx, target, s.waitlistOfferTTL, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondWithWaitlistEntry(c, s.pgxPool, waitlistID)
+}
+
+// getOwnWaitlistEntry loads a waitlist entry the current user may respond
+// to, writing an error response and returning false otherwise.
+func (s *Service) getOwnWaitlistEntry(c *gin.Context, waitlistID, action string) (*auth.User, WaitlistEntry, bool) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return nil, WaitlistEntry{}, false
+	}
+
+	entry, err := getWaitlistEntry(c.Request.Context(), s.pgxPool, waitlistID)
+	if err != nil {
+		respondWaitlistLookupError(c, err)
+		return nil, WaitlistEntry{}, false
+	}
+
+	if currentUser.UserID != entry.UserId && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only " + action + " your own offers",
+		})
+		return nil, WaitlistEntry{}, false
+	}
+
+	return currentUser, entry, true
+}
+
+func (s *Service) reorderWaitlist(c *gin.Context, target waitlistTarget) {
+	if _, ok := s.requireAdmin(c, "reorder waitlists"); !ok {
+		return
+	}
+
+	request := WaitlistOrder{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx = c.Request.Context()
+		now = time.Now()
+	)
+
+	tx, err := s.pgxPool.Begin(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := target.capacity(ctx, tx, now); err != nil {
+		if target.CourseID != nil {
+			respondCourseLookupError(c, err)
+		} else {
+			s.respondClassLookupError(c, err)
+		}
+		return
+	}
+
+	waiting := []string{}
+	if err := pgxscan.Select(ctx, tx, &waiting, queryListWaitingEntryIDsSQL, target.CourseID, target.ClassID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isPermutation(request.WaitlistIds, waiting) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_order",
+			"message": "waitlist_ids must list every waiting entry exactly once",
+		})
+		return
+	}
+
+	if _, err := tx.Exec(ctx, reorderWaitlistSQL, request.WaitlistIds, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithWaitlist(c, target)
+}
+
+func (s *Service) respondWithWaitlist(c *gin.Context, target waitlistTarget) {
+	entries := []WaitlistEntry{}
+	if err := pgxscan.Select(c.Request.Context(), s.pgxPool, &entries, queryListWaitlistSQL, target.CourseID, target.ClassID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func respondWithWaitlistEntry(c *gin.Context, db dbExecutor, waitlistID string) {
+	entry, err := getWaitlistEntry(c.Request.Context(), db, waitlistID)
+	if err != nil {
+		respondWaitlistLookupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func respondWaitlistLookupError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "waitlist_entry_not_found",
+			"message": "Waitlist entry not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func respondCourseLookupError(c *gin.Context, err error) {
+	if err == nil || pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "course_not_found",
+			"message": "Course not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+//go:embed queries/waitlist/get_course_capacity.sql
+var queryGetCourseCapacitySQL string
+
+//go:embed queries/waitlist/get_class_capacity.sql
+var queryGetClassCapacitySQL string
+
+//go:embed queries/waitlist/add_waitlist_entry.sql
+var addWaitlistEntrySQL string
+
+//go:embed queries/waitlist/offer_waitlist_seats.sql
+var offerWaitlistSeatsSQL string
+
+//go:embed queries/waitlist/list_waitlist.sql
+var queryListWaitlistSQL string
+
+//go:embed queries/waitlist/list_user_waitlist.sql
+var queryListUserWaitlistSQL string
+
+//go:embed queries/waitlist/get_waitlist_entry.sql
+var queryGetWaitlistEntrySQL string
+
+//go:embed queries/waitlist/list_waiting_entry_ids.sql
+var queryListWaitingEntryIDsSQL string
+
+//go:embed queries/waitlist/reorder_waitlist.sql
+var reorderWaitlistSQL string
+
+//go:embed queries/waitlist/respond_to_waitlist_offer.sql
+var respondToWaitlistOfferSQL string
+
+//go:embed queries/waitlist/remove_waitlist_entry.sql
+var removeWaitlistEntrySQL string
+
+//go:embed queries/waitlist/resolve_waitlist_entries.sql
+var resolveWaitlistEntriesSQL string
+
+//go:embed queries/waitlist/expire_waitlist_offers.sql
+var expireWaitlistOffersSQL string
+
+//go:embed queries/waitlist/list_open_waitlists.sql
+var queryListOpenWaitlistsSQL string
+
+// waitlistTarget is the course or class a waitlist belongs to. Exactly one
+// of its fields is set.
+type waitlistTarget struct {
+	CourseID *string
+	ClassID  *string
+}
+
+func courseWaitlist(courseID string) waitlistTarget {
+	return waitlistTarget{CourseID: &courseID}
+}
+
+func classWaitlist(classID string) waitlistTarget {
+	return waitlistTarget{ClassID: &classID}
+}
+
+// seatCapacity is how full a course or class is. Taken counts enrolled
+// students and seats held by outstanding offers.
+type seatCapacity struct {
+	OrgID       string
+	MaxStudents *int
+	Taken       int
+	// Open is false once a class is cancelled or has started, when no more
+	// seats are offered.
+	Open bool
+}
+
+// freeSeats returns how many more students fit, or nil when there is no
+// limit.
+func (c seatCapacity) freeSeats() *int {
+	if c.MaxStudents == nil {
+		return nil
+	}
+
+	free := max(*c.MaxStudents-c.Taken, 0)
+	return &free
+}
+
+// capacity locks the course or class row and returns its capacity. Every
+// change to a waitlist happens under this lock, so concurrent enrollments
+// cannot oversell seats.
+func (t waitlistTarget) capacity(ctx context.Context, db dbExecutor, now time.Time) (seatCapacity, error) {
+	capacity := seatCapacity{}
+	if t.CourseID != nil {
+		return capacity, pgxscan.Get(ctx, db, &capacity, queryGetCourseCapacitySQL, *t.CourseID)
+	}
+	return capacity, pgxscan.Get(ctx, db, &capacity, queryGetClassCapacitySQL, *t.ClassID, now)
+}
+
+func getWaitlistEntry(ctx context.Context, db dbExecutor, waitlistID string) (WaitlistEntry, error) {
+	entry := WaitlistEntry{}
+	return entry, pgxscan.Get(ctx, db, &entry, queryGetWaitlistEntrySQL, waitlistID)
+}
+
+// addToWaitlist appends users to the end of a waitlist. Users already
+// waiting keep their place. Positions are taken under the capacity lock, so
+// users added by concurrent requests get positions of their own.
+func addToWaitlist(ctx context.Context, db dbExecutor, orgID string, target waitlistTarget, userIDs []string, now time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if _, err := target.capacity(ctx, db, now); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if _, err := db.Exec(ctx, addWaitlistEntrySQL, orgID, target.CourseID, target.ClassID, userID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveWaitlistEntries closes the waitlist entries of users who were
+// enrolled directly, releasing any seat they were offered.
+func resolveWaitlistEntries(ctx context.Context, db dbExecutor, target waitlistTarget, userIDs []string, now time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec(ctx, resolveWaitlistEntriesSQL, target.CourseID, target.ClassID, userIDs, now)
+	return err
+}
+
+// waitlistOffer is a seat offered to a waitlisted user. It is also the
+// payload of the waitlist.offered webhook.
+type waitlistOffer struct {
+	WaitlistID     string    `json:"waitlist_id"`
+	CourseID       *string   `json:"course_id,omitempty"`
+	ClassID        *string   `json:"class_id,omitempty"`
+	UserID         string    `json:"user_id"`
+	OfferExpiresAt time.Time `json:"offer_expires_at"`
+}
+
+// offerOpenSeats offers every free seat of a course or class to the next
+// waiting entries, holding each for ttl, and notifies the users offered a
+// seat. It must run in a transaction.
+func offerOpenSeats(ctx context.Context, tx pgx.Tx, target waitlistTarget, ttl time.Duration, now time.Time) error {
+	capacity, err := target.capacity(ctx, tx, now)
+	if err != nil {
+		return err
+	}
+
+	free := capacity.freeSeats()
+	if !capacity.Open || (free != nil && *free == 0) {
+		return nil
+	}
+
+	offers := []waitlistOffer{}
+	if err := pgxscan.Select(ctx, tx, &offers, offerWaitlistSeatsSQL, target.CourseID, target.ClassID, free, now, now.Add(ttl)); err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if err := notifications.EnqueueWaitlistOffer(ctx, tx, offer.WaitlistID, now); err != nil {
+			return err
+		}
+
+		if err := webhooks.Enqueue(ctx, tx, capacity.OrgID, webhooks.EventWaitlistOffered, offer, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enrollWaitlistedCourseStudent enrolls a student who accepted an offered
+// seat in a course, re-activating an earlier enrollment.
+func enrollWaitlistedCourseStudent(ctx context.Context, tx pgx.Tx, orgID, courseID, userID, actorID string, now time.Time) error {
+	var previous *EnrollmentStatus
+	existing, err := getEnrollmentForUpdate(ctx, tx, courseID, userID)
+	switch {
+	case err == nil && existing.Status == EnrollmentStatusActive:
+		return nil
+	case err == nil:
+		previous = &existing.Status
+	case !pgxscan.NotFound(err):
+		return err
+	}
+
+	_, err = activateEnrollment(ctx, tx, orgID, userID, courseID, EnrollmentRoleStudent, previous, actorID, now)
+	return err
+}
+
+// enrollWaitlistedClassStudent adds a student who accepted an offered seat
+// to a class and records the change in the class history.
+func enrollWaitlistedClassStudent(ctx context.Context, tx pgx.Tx, classID, userID, actorID string, now time.Time) error {
+	class, err := getClassForUpdate(ctx, tx, classID)
+	if err != nil {
+		return err
+	}
+
+	update := ClassParticipantUpdate{
+		Students: &CourseParticipantChanges{Add: &[]string{userID}},
+	}
+	end := classEndTime(class.StartTime, class.Duration)
+	if err := updateClassParticipants(ctx, tx, classID, update, class.StartTime, end, now); err != nil {
+		return err
+	}
+
+	note := describeParticipantChanges(update) + " from the waitlist"
+	history := ClassHistoryEntry{
+		HistoryId: uuid.New().String(),
+		ClassId:   classID,
+		Action:    ClassHistoryEntryActionParticipantsUpdated,
+		StartTime: class.StartTime,
+		Duration:  class.Duration,
+		Note:      &note,
+		ActorId:   &actorID,
+		CreatedAt: now,
+	}
+	if err := createClassHistory(ctx, tx, history, class.OrgID); err != nil {
+		return err
+	}
+
+	if err := webhooks.EnqueueClassEvent(ctx, tx, classID, webhooks.EventClassParticipantsUpdated, now); err != nil {
+		return err
+	}
+
+	return live.PublishClassEvent(ctx, tx, classID, live.EventClassParticipantsUpdated, nil, now)
+}
+
+// admitClassStudents limits the students an update adds to a class to its
+// free seats, counting the seats of students the update removes. It returns
+// the update to apply and the students to waitlist instead.
+func admitClassStudents(ctx context.Context, tx pgx.Tx, classID string, update ClassParticipantUpdate, now time.Time) (ClassParticipantUpdate, []string, error) {
+	if update.Students == nil || update.Students.Add == nil {
+		return update, nil, nil
+	}
+
+	capacity, err := classWaitlist(classID).capacity(ctx, tx, now)
+	if err != nil {
+		return update, nil, err
+	}
+
+	free := capacity.freeSeats()
+	if free == nil {
+		return update, nil, nil
+	}
+
+	records, err := getClassParticipants(ctx, tx, []string{classID})
+	if err != nil {
+		return update, nil, err
+	}
+
+	participants := classParticipants(records)
+	isStudent := func(userID string) bool {
+		role, ok := classParticipantRole(participants, userID)
+		return ok && role == ClassParticipantRoleStudent
+	}
+
+	seats := *free
+	if update.Students.Remove != nil {
+		for _, userID := range *update.Students.Remove {
+			if isStudent(userID) {
+				seats++
+			}
+		}
+	}
+
+	var present, added []string
+	for _, userID := range *update.Students.Add {
+		if isStudent(userID) {
+			present = append(present, userID)
+		} else {
+			added = append(added, userID)
+		}
+	}
+
+	admitted, waitlisted := splitBySeats(&seats, added)
+	add := append(present, admitted...)
+	students := *update.Students
+	students.Add = &add
+	update.Students = &students
+
+	return update, waitlisted, nil
+}
+
+// splitBySeats admits users in order while seats are free and returns the
+// rest, who go on the waitlist. A nil free count admits everyone. Users
+// listed more than once are only counted once.
+func splitBySeats(free *int, userIDs []string) ([]string, []string) {
+	userIDs = uniqueIDs(userIDs)
+	if free == nil || *free >= len(userIDs) {
+		return userIDs, nil
+	}
+
+	return userIDs[:*free], userIDs[*free:]
+}
+
+// isPermutation reports whether ids lists every entry of expected exactly
+// once, in any order.
+func isPermutation(ids, expected []string) bool {
+	if len(ids) != len(expected) {
+		return false
+	}
+
+	a, b := slices.Clone(ids), slices.Clone(expected)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
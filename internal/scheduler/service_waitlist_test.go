@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitBySeats(t *testing.T) {
+	users := []string{"a", "b", "c"}
+	seats := func(n int) *int { return &n }
+
+	tests := []struct {
+		name               string
+		free               *int
+		expectedAdmitted   []string
+		expectedWaitlisted []string
+	}{
+		{name: "no limit", free: nil, expectedAdmitted: users},
+		{name: "enough seats", free: seats(5), expectedAdmitted: users},
+		{name: "some seats", free: seats(2), expectedAdmitted: []string{"a", "b"}, expectedWaitlisted: []string{"c"}},
+		{name: "full", free: seats(0), expectedAdmitted: []string{}, expectedWaitlisted: users},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admitted, waitlisted := splitBySeats(tt.free, users)
+			if strings.Join(admitted, ",") != strings.Join(tt.expectedAdmitted, ",") {
+				t.Errorf("expected admitted %v, got %v", tt.expectedAdmitted, admitted)
+			}
+			if strings.Join(waitlisted, ",") != strings.Join(tt.expectedWaitlisted, ",") {
+				t.Errorf("expected waitlisted %v, got %v", tt.expectedWaitlisted, waitlisted)
+			}
+		})
+	}
+
+	t.Run("duplicate at capacity", func(t *testing.T) {
+		admitted, waitlisted := splitBySeats(seats(2), []string{"a", "b", "b", "c"})
+		if strings.Join(admitted, ",") != "a,b" {
+			t.Errorf("expected admitted [a b], got %v", admitted)
+		}
+		if strings.Join(waitlisted, ",") != "c" {
+			t.Errorf("expected waitlisted [c], got %v", waitlisted)
+		}
+	})
+}
+
+func TestSeatCapacityFreeSeats(t *testing.T) {
+	limit := 3
+
+	if free := (seatCapacity{Taken: 10}).freeSeats(); free != nil {
+		t.Errorf("expected no limit, got %d", *free)
+	}
+	if free := (seatCapacity{MaxStudents: &limit, Taken: 1}).freeSeats(); free == nil || *free != 2 {
+		t.Errorf("expected 2 free seats, got %v", free)
+	}
+	// Lowering the limit below the enrollment leaves no seats rather than a
+	// negative count.
+	if free := (seatCapacity{MaxStudents: &limit, Taken: 5}).freeSeats(); free == nil || *free != 0 {
+		t.Errorf("expected 0 free seats, got %v", free)
+	}
+}
+
+func TestIsPermutation(t *testing.T) {
+	expected := []string{"a", "b", "c"}
+
+	if !isPermutation([]string{"c", "a", "b"}, expected) {
+		t.Error("expected reordered ids to match")
+	}
+	if isPermutation([]string{"a", "b"}, expected) {
+		t.Error("expected missing id to fail")
+	}
+	if isPermutation([]string{"a", "a", "b"}, expected) {
+		t.Error("expected duplicate id to fail")
+	}
+}
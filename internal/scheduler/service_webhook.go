@@ -0,0 +1,354 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"net/url"
+	"scheduler-api/internal/webhooks"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	ListWebhookSubscriptions(*gin.Context)
+	CreateWebhookSubscription(*gin.Context)
+	GetWebhookSubscription(*gin.Context, string)
+	UpdateWebhookSubscription(*gin.Context, string)
+	DeleteWebhookSubscription(*gin.Context, string)
+	PingWebhookSubscription(*gin.Context, string)
+	ListWebhookDeliveries(*gin.Context, string, ListWebhookDeliveriesParams)
+	GetWebhookDelivery(*gin.Context, string)
+	ReplayWebhookDelivery(*gin.Context, string)
+}
+
+var _ WebhookService = (*Service)(nil)
+
+func (s *Service) ListWebhookSubscriptions(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	subscriptions := []WebhookSubscription{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &subscriptions, listWebhookSubscriptionsSQL, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+func (s *Service) CreateWebhookSubscription(c *gin.Context) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	request := WebhookSubscriptionCreate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateWebhookSubscription(&request.Url, &request.Events); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := s.webhookDispatcher.CheckURL(request.Url); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		ctx            = c.Request.Context()
+		subscriptionID = uuid.New().String()
+		now            = time.Now()
+	)
+
+	_, err = s.pgxPool.Exec(ctx, createWebhookSubscriptionSQL, subscriptionID, currentUser.OrgID, request.Url,
+		secret, request.Events, request.Description, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := getWebhookSubscription(ctx, s.pgxPool, subscriptionID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The secret is only ever shown here, so the receiver can be configured
+	// with it.
+	subscription.Secret = &secret
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (s *Service) GetWebhookSubscription(c *gin.Context, subscriptionID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	subscription, err := getWebhookSubscription(c.Request.Context(), s.pgxPool, subscriptionID, currentUser.OrgID)
+	if err != nil {
+		respondWebhookLookupError(c, err, "webhook_subscription_not_found", "Webhook subscription not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (s *Service) UpdateWebhookSubscription(c *gin.Context, subscriptionID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	request := WebhookSubscriptionUpdate{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateWebhookSubscription(request.Url, request.Events); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Url != nil {
+		if err := s.webhookDispatcher.CheckURL(*request.Url); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	tag, err := s.pgxPool.Exec(ctx, updateWebhookSubscriptionSQL, subscriptionID, currentUser.OrgID, request.Url,
+		request.Events, request.Description, request.Active, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "webhook_subscription_not_found",
+			"message": "Webhook subscription not found",
+		})
+		return
+	}
+
+	subscription, err := getWebhookSubscription(ctx, s.pgxPool, subscriptionID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (s *Service) DeleteWebhookSubscription(c *gin.Context, subscriptionID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	tag, err := s.pgxPool.Exec(c.Request.Context(), deleteWebhookSubscriptionSQL, subscriptionID, currentUser.OrgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if tag.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "webhook_subscription_not_found",
+			"message": "Webhook subscription not found",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) PingWebhookSubscription(c *gin.Context, subscriptionID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getWebhookSubscription(ctx, s.pgxPool, subscriptionID, currentUser.OrgID); err != nil {
+		respondWebhookLookupError(c, err, "webhook_subscription_not_found", "Webhook subscription not found")
+		return
+	}
+
+	deliveryID, err := s.webhookDispatcher.Ping(ctx, subscriptionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondWithWebhookDelivery(c, deliveryID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) ListWebhookDeliveries(c *gin.Context, subscriptionID string, params ListWebhookDeliveriesParams) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getWebhookSubscription(ctx, s.pgxPool, subscriptionID, currentUser.OrgID); err != nil {
+		respondWebhookLookupError(c, err, "webhook_subscription_not_found", "Webhook subscription not found")
+		return
+	}
+
+	deliveries := []WebhookDelivery{}
+	err := pgxscan.Select(ctx, s.pgxPool, &deliveries, listWebhookDeliveriesSQL, subscriptionID, currentUser.OrgID, params.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func (s *Service) GetWebhookDelivery(c *gin.Context, deliveryID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	s.respondWithWebhookDelivery(c, deliveryID, currentUser.OrgID, http.StatusOK)
+}
+
+func (s *Service) ReplayWebhookDelivery(c *gin.Context, deliveryID string) {
+	currentUser, ok := s.requireAdmin(c, "manage webhooks")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := getWebhookDelivery(ctx, s.pgxPool, deliveryID, currentUser.OrgID); err != nil {
+		respondWebhookLookupError(c, err, "webhook_delivery_not_found", "Webhook delivery not found")
+		return
+	}
+
+	replayed, err := webhooks.Replay(ctx, s.pgxPool, deliveryID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !replayed {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "webhook_delivery_pending",
+			"message": "Only deliveries that succeeded or failed can be replayed",
+		})
+		return
+	}
+
+	s.respondWithWebhookDelivery(c, deliveryID, currentUser.OrgID, http.StatusAccepted)
+}
+
+// respondWithWebhookDelivery writes a delivery, including its attempt log,
+// as the response body.
+func (s *Service) respondWithWebhookDelivery(c *gin.Context, deliveryID, orgID string, status int) {
+	ctx := c.Request.Context()
+
+	delivery, err := getWebhookDelivery(ctx, s.pgxPool, deliveryID, orgID)
+	if err != nil {
+		respondWebhookLookupError(c, err, "webhook_delivery_not_found", "Webhook delivery not found")
+		return
+	}
+
+	attempts := []WebhookDeliveryAttempt{}
+	if err := pgxscan.Select(ctx, s.pgxPool, &attempts, listWebhookDeliveryAttemptsSQL, deliveryID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	delivery.AttemptLog = &attempts
+
+	c.JSON(status, delivery)
+}
+
+func respondWebhookLookupError(c *gin.Context, err error, code, message string) {
+	if pgxscan.NotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   code,
+			"message": message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// validateWebhookSubscription checks the URL and events of a subscription
+// being created or updated. Nil fields are not being changed.
+func validateWebhookSubscription(endpoint *string, events *[]string) error {
+	if endpoint != nil {
+		u, err := url.Parse(*endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url must be an absolute http or https URL")
+		}
+	}
+
+	if events != nil {
+		if len(*events) == 0 {
+			return fmt.Errorf("events must not be empty")
+		}
+		for _, event := range *events {
+			if !webhooks.IsSubscribable(event) {
+				return fmt.Errorf("unknown event %q", event)
+			}
+		}
+	}
+
+	return nil
+}
+
+//go:embed queries/webhook/create_webhook_subscription.sql
+var createWebhookSubscriptionSQL string
+
+//go:embed queries/webhook/list_webhook_subscriptions.sql
+var listWebhookSubscriptionsSQL string
+
+//go:embed queries/webhook/get_webhook_subscription.sql
+var getWebhookSubscriptionSQL string
+
+//go:embed queries/webhook/update_webhook_subscription.sql
+var updateWebhookSubscriptionSQL string
+
+//go:embed queries/webhook/delete_webhook_subscription.sql
+var deleteWebhookSubscriptionSQL string
+
+//go:embed queries/webhook/list_webhook_deliveries.sql
+var listWebhookDeliveriesSQL string
+
+//go:embed queries/webhook/get_webhook_delivery.sql
+var getWebhookDeliverySQL string
+
+//go:embed queries/webhook/list_webhook_delivery_attempts.sql
+var listWebhookDeliveryAttemptsSQL string
+
+func getWebhookSubscription(ctx context.Context, db dbExecutor, subscriptionID, orgID string) (WebhookSubscription, error) {
+	subscription := WebhookSubscription{}
+	return subscription, pgxscan.Get(ctx, db, &subscription, getWebhookSubscriptionSQL, subscriptionID, orgID)
+}
+
+func getWebhookDelivery(ctx context.Context, db dbExecutor, deliveryID, orgID string) (WebhookDelivery, error) {
+	delivery := WebhookDelivery{}
+	return delivery, pgxscan.Get(ctx, db, &delivery, getWebhookDeliverySQL, deliveryID, orgID)
+}
@@ -0,0 +1,338 @@
+package scheduler
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"scheduler-api/internal/auth"
+	"slices"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+type WorkloadService interface {
+	GetTutorWorkloadLimits(*gin.Context, string)
+	SetTutorWorkloadLimits(*gin.Context, string)
+	GetTutorUtilization(*gin.Context, GetTutorUtilizationParams)
+}
+
+var _ WorkloadService = (*Service)(nil)
+
+func (s *Service) GetTutorWorkloadLimits(c *gin.Context, userID string) {
+	currentUser, err := auth.GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	if currentUser.UserID != userID && currentUser.Role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Can only view your own workload limits",
+		})
+		return
+	}
+
+	limits, err := getWorkloadLimits(c.Request.Context(), s.pgxPool, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusOK, TutorWorkloadLimits{UserId: &userID})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+func (s *Service) SetTutorWorkloadLimits(c *gin.Context, userID string) {
+	if _, ok := s.requireAdmin(c, "set workload limits"); !ok {
+		return
+	}
+
+	request := TutorWorkloadLimits{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, limit := range []*int{request.MaxMinutesPerDay, request.MaxMinutesPerWeek, request.MaxConsecutiveClasses} {
+		if limit != nil && *limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limits must be at least 1"})
+			return
+		}
+	}
+
+	breakMinutes, timezone := 15, "UTC"
+	if request.BreakMinutes != nil {
+		breakMinutes = *request.BreakMinutes
+	}
+	if request.Timezone != nil {
+		timezone = *request.Timezone
+	}
+
+	if breakMinutes < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "break_minutes cannot be negative"})
+		return
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown timezone " + timezone})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	user, err := getUser(ctx, s.pgxPool, userID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if user.Role != "tutor" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "not_a_tutor",
+			"message": "Workload limits can only be set for tutors",
+		})
+		return
+	}
+
+	_, err = s.pgxPool.Exec(ctx, upsertWorkloadLimitsSQL, userID, user.OrgId, request.MaxMinutesPerDay, request.MaxMinutesPerWeek,
+		request.MaxConsecutiveClasses, breakMinutes, timezone, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	limits, err := getWorkloadLimits(ctx, s.pgxPool, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+func (s *Service) GetTutorUtilization(c *gin.Context, params GetTutorUtilizationParams) {
+	currentUser, ok := s.requireAdmin(c, "view reports")
+	if !ok {
+		return
+	}
+
+	if !params.To.After(params.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	report := []TutorUtilization{}
+	err := pgxscan.Select(c.Request.Context(), s.pgxPool, &report, queryTutorUtilizationSQL, currentUser.OrgID, params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range report {
+		report[i].Utilization = ratio(report[i].ScheduledMinutes, report[i].AvailableMinutes)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+//go:embed queries/workload/get_workload_limits.sql
+var queryGetWorkloadLimitsSQL string
+
+//go:embed queries/workload/lock_workload_limits.sql
+var queryLockWorkloadLimitsSQL string
+
+//go:embed queries/workload/upsert_workload_limits.sql
+var upsertWorkloadLimitsSQL string
+
+//go:embed queries/workload/list_teacher_classes.sql
+var queryListTeacherClassesSQL string
+
+//go:embed queries/workload/tutor_utilization.sql
+var queryTutorUtilizationSQL string
+
+func getWorkloadLimits(ctx context.Context, db dbExecutor, userID string) (TutorWorkloadLimits, error) {
+	limits := TutorWorkloadLimits{}
+	return limits, pgxscan.Get(ctx, db, &limits, queryGetWorkloadLimitsSQL, userID)
+}
+
+type teacherClassRecord struct {
+	UserID    string
+	StartTime time.Time
+	Duration  int
+}
+
+// workloadViolation is a workload limit a teacher would break.
+type workloadViolation struct {
+	UserID  string `json:"user_id"`
+	Message string `json:"message"`
+}
+
+// checkTutorWorkload returns the workload limits each teacher would break by
+// teaching class. The teachers' limits are locked until the transaction ends
+// so concurrent bookings cannot both squeeze under a limit. excludeClassID,
+// if set, is left out of the teachers' other classes so a class being
+// rescheduled is not counted twice.
+func checkTutorWorkload(ctx context.Context, db dbExecutor, teacherIDs []string, excludeClassID *string, class classSlot) ([]workloadViolation, error) {
+	if len(teacherIDs) == 0 {
+		return nil, nil
+	}
+
+	limits := []TutorWorkloadLimits{}
+	if err := pgxscan.Select(ctx, db, &limits, queryLockWorkloadLimitsSQL, teacherIDs); err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	limitedIDs := make([]string, len(limits))
+	for i, limit := range limits {
+		limitedIDs[i] = *limit.UserId
+	}
+
+	// A week around the class covers its day, its week and any run of
+	// consecutive classes it is part of.
+	records := []teacherClassRecord{}
+	from, to := class.StartTime.AddDate(0, 0, -8), class.StartTime.AddDate(0, 0, 8)
+	if err := pgxscan.Select(ctx, db, &records, queryListTeacherClassesSQL, limitedIDs, from, to, excludeClassID); err != nil {
+		return nil, err
+	}
+
+	scheduled := map[string][]classSlot{}
+	for _, record := range records {
+		scheduled[record.UserID] = append(scheduled[record.UserID], classSlot{record.StartTime, record.Duration})
+	}
+
+	var violations []workloadViolation
+	for _, limit := range limits {
+		loc := time.UTC
+		if limit.Timezone != nil {
+			if l, err := time.LoadLocation(*limit.Timezone); err == nil {
+				loc = l
+			}
+		}
+
+		for _, message := range workloadViolations(limit, loc, scheduled[*limit.UserId], class) {
+			violations = append(violations, workloadViolation{UserID: *limit.UserId, Message: message})
+		}
+	}
+
+	return violations, nil
+}
+
+func respondWorkloadViolations(c *gin.Context, violations []workloadViolation) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error":      "workload_limit_exceeded",
+		"message":    "Class would exceed the workload limits of its teachers",
+		"violations": violations,
+	})
+}
+
+// checkClassTeachersWorkload checks the workload of a class's current
+// teachers as if the class took place at slot.
+func checkClassTeachersWorkload(ctx context.Context, db dbExecutor, classID string, slot classSlot) ([]workloadViolation, error) {
+	participants, err := getClassParticipants(ctx, db, []string{classID})
+	if err != nil {
+		return nil, err
+	}
+
+	var teacherIDs []string
+	for _, participant := range participants {
+		if participant.Role == ClassParticipantRoleTeacher {
+			teacherIDs = append(teacherIDs, participant.UserId)
+		}
+	}
+
+	return checkTutorWorkload(ctx, db, teacherIDs, &classID, slot)
+}
+
+// workloadViolations describes each workload limit a tutor would break by
+// teaching class on top of their other scheduled classes. Days and weeks,
+// which start on Monday, are counted in loc by class start time.
+func workloadViolations(limits TutorWorkloadLimits, loc *time.Location, scheduled []classSlot, class classSlot) []string {
+	var violations []string
+
+	slots := append(slices.Clone(scheduled), class)
+	slices.SortFunc(slots, func(a, b classSlot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	day := startOfDay(class.StartTime.In(loc))
+	week := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+
+	if limits.MaxMinutesPerDay != nil {
+		total := 0
+		for _, slot := range slots {
+			if startOfDay(slot.StartTime.In(loc)).Equal(day) {
+				total += slot.Duration
+			}
+		}
+		if total > *limits.MaxMinutesPerDay {
+			violations = append(violations, fmt.Sprintf("%d minutes on %s exceeds the limit of %d minutes per day",
+				total, day.Format("2006-01-02"), *limits.MaxMinutesPerDay))
+		}
+	}
+
+	if limits.MaxMinutesPerWeek != nil {
+		total, weekEnd := 0, week.AddDate(0, 0, 7)
+		for _, slot := range slots {
+			if !slot.StartTime.Before(week) && slot.StartTime.Before(weekEnd) {
+				total += slot.Duration
+			}
+		}
+		if total > *limits.MaxMinutesPerWeek {
+			violations = append(violations, fmt.Sprintf("%d minutes in the week of %s exceeds the limit of %d minutes per week",
+				total, week.Format("2006-01-02"), *limits.MaxMinutesPerWeek))
+		}
+	}
+
+	if limits.MaxConsecutiveClasses != nil {
+		breakMinutes := 15
+		if limits.BreakMinutes != nil {
+			breakMinutes = *limits.BreakMinutes
+		}
+		breakLength := time.Duration(breakMinutes) * time.Minute
+
+		// Find the run of classes without a break that contains class.
+		run, containsClass := 0, false
+		for i, slot := range slots {
+			if i > 0 && slot.StartTime.Sub(slots[i-1].endTime()) >= breakLength {
+				if containsClass {
+					break
+				}
+				run = 0
+			}
+			run++
+			if slot == class {
+				containsClass = true
+			}
+		}
+		if run > *limits.MaxConsecutiveClasses {
+			violations = append(violations, fmt.Sprintf("%d classes in a row without a %d minute break exceeds the limit of %d",
+				run, breakMinutes, *limits.MaxConsecutiveClasses))
+		}
+	}
+
+	return violations
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
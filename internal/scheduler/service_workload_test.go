@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkloadViolations(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	// Monday 2024-03-04 has two classes back to back; the rest of the week
+	// has one more hour.
+	scheduled := []classSlot{
+		{at(4, 9, 0), 60},
+		{at(4, 10, 0), 60},
+		{at(6, 9, 0), 60},
+	}
+
+	tests := []struct {
+		name     string
+		limits   TutorWorkloadLimits
+		class    classSlot
+		expected int
+	}{
+		{
+			name:     "no limits",
+			limits:   TutorWorkloadLimits{},
+			class:    classSlot{at(4, 11, 0), 60},
+			expected: 0,
+		},
+		{
+			name:     "over daily limit",
+			limits:   TutorWorkloadLimits{MaxMinutesPerDay: limit(150)},
+			class:    classSlot{at(4, 14, 0), 60},
+			expected: 1,
+		},
+		{
+			name:     "daily limit on another day",
+			limits:   TutorWorkloadLimits{MaxMinutesPerDay: limit(150)},
+			class:    classSlot{at(5, 14, 0), 60},
+			expected: 0,
+		},
+		{
+			name:     "over weekly limit",
+			limits:   TutorWorkloadLimits{MaxMinutesPerWeek: limit(200)},
+			class:    classSlot{at(10, 9, 0), 60},
+			expected: 1,
+		},
+		{
+			name:     "weekly limit in the next week",
+			limits:   TutorWorkloadLimits{MaxMinutesPerWeek: limit(200)},
+			class:    classSlot{at(11, 9, 0), 60},
+			expected: 0,
+		},
+		{
+			name:     "too many classes in a row",
+			limits:   TutorWorkloadLimits{MaxConsecutiveClasses: limit(2)},
+			class:    classSlot{at(4, 11, 5), 60},
+			expected: 1,
+		},
+		{
+			name:     "break before the class",
+			limits:   TutorWorkloadLimits{MaxConsecutiveClasses: limit(2)},
+			class:    classSlot{at(4, 11, 15), 60},
+			expected: 0,
+		},
+		{
+			name:     "longer required break",
+			limits:   TutorWorkloadLimits{MaxConsecutiveClasses: limit(2), BreakMinutes: limit(30)},
+			class:    classSlot{at(4, 11, 15), 60},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := workloadViolations(tt.limits, time.UTC, scheduled, tt.class)
+			if len(violations) != tt.expected {
+				t.Errorf("expected %d violations, got %v", tt.expected, violations)
+			}
+		})
+	}
+}
+
+func TestWorkloadViolationsUseTutorTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	maxPerDay := 60
+	limits := TutorWorkloadLimits{MaxMinutesPerDay: &maxPerDay}
+
+	// 2024-03-05 06:00 UTC is still March 4 in Los Angeles.
+	scheduled := []classSlot{{time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC), 60}}
+	class := classSlot{time.Date(2024, 3, 5, 6, 0, 0, 0, time.UTC), 30}
+
+	if violations := workloadViolations(limits, time.UTC, scheduled, class); len(violations) != 0 {
+		t.Errorf("expected no violations in UTC, got %v", violations)
+	}
+	if violations := workloadViolations(limits, loc, scheduled, class); len(violations) != 1 {
+		t.Errorf("expected 1 violation in Los Angeles, got %v", violations)
+	}
+}
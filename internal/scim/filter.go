@@ -0,0 +1,391 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed filter expression of RFC 7644 section 3.4.2.2.
+type Filter interface {
+	// Match reports whether the resource, or the element of a multi-valued
+	// attribute being filtered, matches.
+	Match(resource map[string]any) bool
+}
+
+type logical struct {
+	and         bool
+	left, right Filter
+}
+
+func (f logical) Match(resource map[string]any) bool {
+	if f.and {
+		return f.left.Match(resource) && f.right.Match(resource)
+	}
+	return f.left.Match(resource) || f.right.Match(resource)
+}
+
+type not struct {
+	filter Filter
+}
+
+func (f not) Match(resource map[string]any) bool {
+	return !f.filter.Match(resource)
+}
+
+// valuePath matches resources with an element of a multi-valued attribute
+// that matches the filter, such as emails[type eq "work"].
+type valuePath struct {
+	attribute string
+	filter    Filter
+}
+
+func (f valuePath) Match(resource map[string]any) bool {
+	for _, value := range attributeValues(resource, f.attribute) {
+		if element, ok := value.(map[string]any); ok && f.filter.Match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// comparison compares an attribute with a value, or checks that it is
+// present when op is pr.
+type comparison struct {
+	attribute string
+	op        string
+	value     any
+}
+
+func (f comparison) Match(resource map[string]any) bool {
+	values := attributeValues(resource, f.attribute)
+
+	switch f.op {
+	case "pr":
+		for _, value := range values {
+			if present(value) {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		// Resources without the attribute are not equal to any value.
+		return !comparison{f.attribute, "eq", f.value}.Match(resource)
+	}
+
+	if f.value == nil {
+		return f.op == "eq" && len(values) == 0
+	}
+	for _, value := range values {
+		if compare(value, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare applies op to a value of a resource and the value of the filter.
+// Strings compare case insensitively, and booleans only support eq.
+func compare(actual any, op string, expected any) bool {
+	switch expected := expected.(type) {
+	case string:
+		actual, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		a, e := strings.ToLower(actual), strings.ToLower(expected)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case float64:
+		actual, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return actual == expected
+		case "gt":
+			return actual > expected
+		case "ge":
+			return actual >= expected
+		case "lt":
+			return actual < expected
+		case "le":
+			return actual <= expected
+		}
+	case bool:
+		actual, ok := actual.(bool)
+		return ok && op == "eq" && actual == expected
+	}
+	return false
+}
+
+func present(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case string:
+		return value != ""
+	case []any:
+		return len(value) > 0
+	case map[string]any:
+		return len(value) > 0
+	default:
+		return true
+	}
+}
+
+// attributeValues gets every value of an attribute path such as
+// name.familyName or emails.value, flattening multi-valued attributes.
+func attributeValues(resource map[string]any, path string) []any {
+	values := []any{resource}
+	for _, name := range strings.Split(attributeName(path), ".") {
+		var next []any
+		for _, value := range values {
+			m, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			_, value, ok := lookup(m, name)
+			if !ok {
+				continue
+			}
+			if list, ok := value.([]any); ok {
+				next = append(next, list...)
+			} else {
+				next = append(next, value)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "lt": true, "ge": true, "le": true,
+}
+
+// ParseFilter parses a filter such as
+// userName eq "ada@example.com" and not (emails[type eq "home"]).
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilter("unexpected %s", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+func invalidFilter(format string, args ...any) *Error {
+	return Errorf(http.StatusBadRequest, TypeInvalidFilter, "invalid filter: "+format, args...)
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits a filter into brackets, quoted strings and words.
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("()[]", c) >= 0:
+			tokens = append(tokens, token{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, invalidFilter("unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, invalidFilter("bad string %s", filter[i:end+1])
+			}
+			tokens = append(tokens, token{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && strings.IndexByte(" \t()[]\"", filter[end]) < 0 {
+				end++
+			}
+			tokens = append(tokens, token{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+// keyword reports whether the next token is the unquoted word, and
+// consumes it if it is.
+func (p *filterParser) keyword(word string) bool {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) next() (token, error) {
+	if p.pos >= len(p.tokens) {
+		return token{}, invalidFilter("unexpected end")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) expect(text string) error {
+	if !p.keyword(text) {
+		return invalidFilter("expected %s", text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.keyword("not") {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return not{f}, nil
+	}
+	if p.keyword("(") {
+		return p.parseGroup()
+	}
+	return p.parseAttribute()
+}
+
+// parseGroup parses the rest of a parenthesized filter.
+func (p *filterParser) parseGroup() (Filter, error) {
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	return f, p.expect(")")
+}
+
+func (p *filterParser) parseAttribute() (Filter, error) {
+	attribute, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if attribute.quoted || strings.IndexAny(attribute.text, "()[]") >= 0 {
+		return nil, invalidFilter("expected an attribute, got %s", attribute.text)
+	}
+
+	if p.keyword("[") {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return valuePath{attribute: attribute.text, filter: f}, nil
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	operator := strings.ToLower(op.text)
+	if operator == "pr" && !op.quoted {
+		return comparison{attribute: attribute.text, op: operator}, nil
+	}
+	if op.quoted || !compareOps[operator] {
+		return nil, invalidFilter("unknown operator %s", op.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if value.quoted {
+		return comparison{attribute: attribute.text, op: operator, value: value.text}, nil
+	}
+
+	switch strings.ToLower(value.text) {
+	case "true":
+		return comparison{attribute: attribute.text, op: operator, value: true}, nil
+	case "false":
+		return comparison{attribute: attribute.text, op: operator, value: false}, nil
+	case "null":
+		return comparison{attribute: attribute.text, op: operator, value: nil}, nil
+	}
+	number, err := strconv.ParseFloat(value.text, 64)
+	if err != nil {
+		return nil, invalidFilter("bad value %s", value.text)
+	}
+	return comparison{attribute: attribute.text, op: operator, value: number}, nil
+}
@@ -0,0 +1,298 @@
+package scim
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// PatchRequest is the body of a PATCH request, RFC 7644 section 3.5.2.
+type PatchRequest struct {
+	Schemas    []string    `json:"schemas"`
+	Operations []Operation `json:"Operations"`
+}
+
+// Operation is one add, replace or remove operation. Operations without a
+// path take an object whose attributes are each added or replaced.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Apply applies the operations to resource in order. Attribute names are
+// matched case insensitively, and operation names may be capitalized, as
+// some identity providers send them.
+func (r PatchRequest) Apply(resource map[string]any) error {
+	if len(r.Operations) == 0 {
+		return Errorf(http.StatusBadRequest, TypeInvalidValue, "the request has no operations")
+	}
+	for _, op := range r.Operations {
+		if err := applyOperation(resource, strings.ToLower(op.Op), op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchPath is the target of an operation: an attribute, an optional
+// filter on its elements and an optional sub-attribute, as in
+// emails[type eq "work"].value.
+type patchPath struct {
+	attribute string
+	filter    Filter
+	sub       string
+}
+
+func parsePath(path string) (patchPath, error) {
+	var p patchPath
+	if open := strings.IndexByte(path, '['); open >= 0 {
+		end := strings.LastIndexByte(path, ']')
+		if end < open {
+			return patchPath{}, Errorf(http.StatusBadRequest, TypeInvalidPath, "invalid path %s", path)
+		}
+
+		filter, err := ParseFilter(path[open+1 : end])
+		if err != nil {
+			return patchPath{}, err
+		}
+		p.attribute, p.filter = attributeName(path[:open]), filter
+
+		if rest := path[end+1:]; rest != "" {
+			sub, ok := strings.CutPrefix(rest, ".")
+			if !ok {
+				return patchPath{}, Errorf(http.StatusBadRequest, TypeInvalidPath, "invalid path %s", path)
+			}
+			p.sub = sub
+		}
+	} else {
+		p.attribute, p.sub, _ = strings.Cut(attributeName(path), ".")
+	}
+
+	if p.attribute == "" || strings.ContainsAny(p.attribute, " \"") {
+		return patchPath{}, Errorf(http.StatusBadRequest, TypeInvalidPath, "invalid path %s", path)
+	}
+	return p, nil
+}
+
+func applyOperation(resource map[string]any, op, path string, value any) error {
+	if op != "add" && op != "replace" && op != "remove" {
+		return Errorf(http.StatusBadRequest, TypeInvalidSyntax, "unknown operation %q", op)
+	}
+
+	if path == "" {
+		if op == "remove" {
+			return Errorf(http.StatusBadRequest, TypeNoTarget, "remove operations need a path")
+		}
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			return Errorf(http.StatusBadRequest, TypeInvalidValue, "operations without a path need an object value")
+		}
+		for name, value := range attributes {
+			if err := applyOperation(resource, op, name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if p.filter != nil {
+		return applyFiltered(resource, op, p, value)
+	}
+	return applyAttribute(resource, op, p, value)
+}
+
+func applyAttribute(resource map[string]any, op string, p patchPath, value any) error {
+	key, current, exists := lookup(resource, p.attribute)
+
+	if p.sub != "" {
+		switch current := current.(type) {
+		case map[string]any:
+			setAttribute(current, op, p.sub, value)
+		case []any:
+			if op != "remove" {
+				return Errorf(http.StatusBadRequest, TypeInvalidPath, "%s is multi-valued and needs a value filter", p.attribute)
+			}
+			for _, element := range current {
+				if element, ok := element.(map[string]any); ok {
+					setAttribute(element, op, p.sub, nil)
+				}
+			}
+		default:
+			if op != "remove" {
+				resource[key] = map[string]any{p.sub: value}
+			}
+		}
+		return nil
+	}
+
+	list, multiValued := current.([]any)
+	switch {
+	case op == "remove" && multiValued && value != nil:
+		// Some identity providers remove members by listing them as the
+		// value rather than with a filter.
+		setList(resource, key, removeElements(list, asList(value)))
+	case op == "remove":
+		delete(resource, key)
+	case op == "add" && multiValued:
+		for _, element := range asList(value) {
+			if !containsElement(list, element) {
+				list = append(list, element)
+			}
+		}
+		resource[key] = list
+	case exists:
+		setAttribute(resource, op, key, value)
+	default:
+		resource[key] = value
+	}
+	return nil
+}
+
+func applyFiltered(resource map[string]any, op string, p patchPath, value any) error {
+	key, current, _ := lookup(resource, p.attribute)
+	list, _ := current.([]any)
+
+	var updated []any
+	matched := false
+	for _, element := range list {
+		m, ok := element.(map[string]any)
+		if !ok || !p.filter.Match(m) {
+			updated = append(updated, element)
+			continue
+		}
+		matched = true
+
+		switch {
+		case p.sub != "":
+			setAttribute(m, op, p.sub, value)
+		case op == "remove":
+			continue
+		case op == "add":
+			if value, ok := value.(map[string]any); ok {
+				merge(m, value)
+				break
+			}
+			element = value
+		default:
+			element = value
+		}
+		updated = append(updated, element)
+	}
+
+	if !matched {
+		if op == "remove" {
+			return nil
+		}
+		// Setting emails[type eq "work"].value on a user without a work
+		// email adds one.
+		element := equalityElement(p.filter)
+		if element == nil || p.sub == "" {
+			return Errorf(http.StatusBadRequest, TypeNoTarget, "no %s match the filter", p.attribute)
+		}
+		element[p.sub] = value
+		updated = append(updated, element)
+	}
+
+	setList(resource, key, updated)
+	return nil
+}
+
+// setList sets a multi-valued attribute, which is unassigned once no
+// values remain.
+func setList(resource map[string]any, key string, list []any) {
+	if len(list) == 0 {
+		delete(resource, key)
+		return
+	}
+	resource[key] = list
+}
+
+// setAttribute adds, replaces or removes the attribute name of m. Complex
+// values are merged into complex attributes rather than replacing them.
+func setAttribute(m map[string]any, op, name string, value any) {
+	key, current, _ := lookup(m, name)
+	if op == "remove" {
+		delete(m, key)
+		return
+	}
+
+	currentMap, ok := current.(map[string]any)
+	valueMap, isMap := value.(map[string]any)
+	if ok && isMap {
+		merge(currentMap, valueMap)
+		return
+	}
+	m[key] = value
+}
+
+func merge(m, values map[string]any) {
+	for name, value := range values {
+		key, _, _ := lookup(m, name)
+		m[key] = value
+	}
+}
+
+// equalityElement returns the element a filter of eq comparisons, such as
+// type eq "work", matches, or nil for any other filter.
+func equalityElement(f Filter) map[string]any {
+	switch f := f.(type) {
+	case comparison:
+		if f.op != "eq" || f.value == nil || strings.Contains(f.attribute, ".") {
+			return nil
+		}
+		return map[string]any{f.attribute: f.value}
+	case logical:
+		left, right := equalityElement(f.left), equalityElement(f.right)
+		if !f.and || left == nil || right == nil {
+			return nil
+		}
+		merge(left, right)
+		return left
+	}
+	return nil
+}
+
+func asList(value any) []any {
+	if list, ok := value.([]any); ok {
+		return list
+	}
+	return []any{value}
+}
+
+func removeElements(list, removed []any) []any {
+	var kept []any
+	for _, element := range list {
+		if !containsElement(removed, element) {
+			kept = append(kept, element)
+		}
+	}
+	return kept
+}
+
+// containsElement reports whether list holds element. Complex elements
+// with a value, such as group members, are the same if their values are.
+func containsElement(list []any, element any) bool {
+	for _, e := range list {
+		a, aOK := e.(map[string]any)
+		b, bOK := element.(map[string]any)
+		if aOK && bOK {
+			_, aValue, aHas := lookup(a, "value")
+			_, bValue, bHas := lookup(b, "value")
+			if aHas && bHas {
+				if reflect.DeepEqual(aValue, bValue) {
+					return true
+				}
+				continue
+			}
+		}
+		if reflect.DeepEqual(e, element) {
+			return true
+		}
+	}
+	return false
+}
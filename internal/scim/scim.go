@@ -0,0 +1,219 @@
+// Package scim implements the protocol side of SCIM 2.0 (RFC 7643 and RFC
+// 7644): errors, list responses and pagination, filters and PATCH
+// operations.
+//
+// Resources are handled as the maps their JSON decodes to, so filters and
+// PATCH operations work on any resource type. The scheduler maps the
+// resulting resources onto its own tables.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MediaType is the content type of SCIM requests and responses.
+const MediaType = "application/scim+json"
+
+// Schema URNs.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Error detail types of RFC 7644 section 3.12.
+const (
+	TypeInvalidFilter = "invalidFilter"
+	TypeUniqueness    = "uniqueness"
+	TypeMutability    = "mutability"
+	TypeInvalidSyntax = "invalidSyntax"
+	TypeInvalidPath   = "invalidPath"
+	TypeNoTarget      = "noTarget"
+	TypeInvalidValue  = "invalidValue"
+)
+
+// Error is a SCIM error response.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+// Errorf returns an error with status and detail type.
+func Errorf(status int, scimType, format string, args ...any) *Error {
+	return &Error{Status: status, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{[]string{SchemaError}, strconv.Itoa(e.Status), e.ScimType, e.Detail})
+}
+
+// NotFound returns the error for a resource that does not exist.
+func NotFound(resourceType, id string) *Error {
+	return Errorf(http.StatusNotFound, "", "%s %s not found", resourceType, id)
+}
+
+// ListResponse is a page of resources.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// Meta is the meta attribute of a resource.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Bool is a boolean that some identity providers send as the string "True"
+// or "False".
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" {
+		*b = false
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(strings.ToLower(value))
+	if err != nil {
+		return fmt.Errorf("%s is not a boolean", data)
+	}
+	*b = Bool(parsed)
+	return nil
+}
+
+// Query is the filter and page asked for when listing resources.
+type Query struct {
+	Filter     Filter
+	StartIndex int
+	Count      int
+}
+
+// ParseQuery reads the filter, startIndex and count parameters of a list
+// request. Without a count, pages hold maxCount resources, which also
+// bounds a larger count.
+func ParseQuery(values url.Values, maxCount int) (Query, error) {
+	query := Query{StartIndex: 1, Count: maxCount}
+
+	if value := values.Get("filter"); value != "" {
+		filter, err := ParseFilter(value)
+		if err != nil {
+			return Query{}, err
+		}
+		query.Filter = filter
+	}
+
+	if value := values.Get("startIndex"); value != "" {
+		startIndex, err := strconv.Atoi(value)
+		if err != nil {
+			return Query{}, Errorf(http.StatusBadRequest, TypeInvalidValue, "startIndex must be an integer")
+		}
+		// Values less than 1 are interpreted as 1.
+		query.StartIndex = max(startIndex, 1)
+	}
+
+	if value := values.Get("count"); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return Query{}, Errorf(http.StatusBadRequest, TypeInvalidValue, "count must be an integer")
+		}
+		// Negative values are interpreted as 0.
+		query.Count = min(max(count, 0), maxCount)
+	}
+
+	return query, nil
+}
+
+// List filters resources and returns the page the query asks for.
+func List(resources []map[string]any, query Query) ListResponse {
+	matched := []any{}
+	for _, resource := range resources {
+		if query.Filter == nil || query.Filter.Match(resource) {
+			matched = append(matched, resource)
+		}
+	}
+
+	start := min(query.StartIndex-1, len(matched))
+	end := min(start+query.Count, len(matched))
+
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(matched),
+		StartIndex:   query.StartIndex,
+		ItemsPerPage: end - start,
+		Resources:    matched[start:end],
+	}
+}
+
+// ToMap converts a resource to the map its JSON decodes to.
+func ToMap(resource any) (map[string]any, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]any{}
+	return m, json.Unmarshal(data, &m)
+}
+
+// FromMap converts a map back into a resource, reporting values of the
+// wrong type as invalid.
+func FromMap(m map[string]any, resource any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, resource); err != nil {
+		return Errorf(http.StatusBadRequest, TypeInvalidValue, "invalid resource: %v", err)
+	}
+	return nil
+}
+
+// attributeName strips the schema URN that a fully qualified attribute path,
+// such as urn:ietf:params:scim:schemas:core:2.0:User:name.givenName,
+// starts with.
+func attributeName(path string) string {
+	if !strings.HasPrefix(strings.ToLower(path), "urn:") {
+		return path
+	}
+	return path[strings.LastIndex(path, ":")+1:]
+}
+
+// lookup gets the attribute of m by its case insensitive name, returning
+// the key it is stored under.
+func lookup(m map[string]any, name string) (string, any, bool) {
+	if value, ok := m[name]; ok {
+		return name, value, true
+	}
+	for key, value := range m {
+		if strings.EqualFold(key, name) {
+			return key, value, true
+		}
+	}
+	return name, nil, false
+}
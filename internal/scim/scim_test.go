@@ -0,0 +1,229 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func resource(t *testing.T, data string) map[string]any {
+	t.Helper()
+
+	m := map[string]any{}
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+const ada = `{
+	"id": "u-1",
+	"userName": "ada@example.com",
+	"name": {"givenName": "Ada", "familyName": "Lovelace"},
+	"emails": [{"value": "ada@example.com", "type": "work", "primary": true}, {"value": "ada@home.example", "type": "home"}],
+	"active": true,
+	"meta": {"lastModified": "2026-03-01T10:00:00Z"}
+}`
+
+func TestParseFilter(t *testing.T) {
+	user := resource(t, ada)
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "ADA@example.com"`, true},
+		{`userName eq "alan@example.com"`, false},
+		{`UserName Eq "ada@example.com"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:name.familyName sw "love"`, true},
+		{`name.givenName co "d"`, true},
+		{`emails.value ew "@home.example"`, true},
+		{`emails[type eq "work" and value co "ada"]`, true},
+		{`emails[type eq "other"]`, false},
+		{`active eq true and not (name.familyName eq "Turing")`, true},
+		{`active eq false or userName eq "ada@example.com"`, true},
+		{`active eq false or userName eq "x" and id eq "u-1"`, false},
+		{`(active eq false or userName eq "x") and id eq "u-1"`, false},
+		{`externalId pr`, false},
+		{`externalId eq null`, true},
+		{`externalId ne "x"`, true},
+		{`meta.lastModified gt "2026-01-01T00:00:00Z"`, true},
+		{`title pr or name.familyName pr`, true},
+	}
+
+	for _, tt := range tests {
+		filter, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%s) error = %v", tt.filter, err)
+			continue
+		}
+		if got := filter.Match(user); got != tt.want {
+			t.Errorf("%s matched = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	for _, filter := range []string{``, `userName`, `userName eq`, `userName is "x"`, `userName eq "x`, `(userName pr`, `emails[type eq "work"`, `userName eq "x" extra`} {
+		var scimErr *Error
+		if _, err := ParseFilter(filter); !errors.As(err, &scimErr) || scimErr.ScimType != TypeInvalidFilter {
+			t.Errorf("ParseFilter(%s) error = %v, want an invalidFilter error", filter, err)
+		}
+	}
+}
+
+func TestPatchRequestApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       string
+	}{
+		{
+			name:       "replace without a path",
+			operations: `[{"op": "Replace", "value": {"active": "False", "name.givenName": "Augusta"}}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Augusta", "familyName": "Lovelace"}, "active": "False", "emails": [{"value": "ada@example.com", "type": "work"}]}`,
+		},
+		{
+			name:       "replace a sub-attribute",
+			operations: `[{"op": "replace", "path": "name.familyName", "value": "King"}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "King"}, "active": true, "emails": [{"value": "ada@example.com", "type": "work"}]}`,
+		},
+		{
+			name:       "replace through a value filter",
+			operations: `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "ada@new.example"}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "Lovelace"}, "active": true, "emails": [{"value": "ada@new.example", "type": "work"}]}`,
+		},
+		{
+			name:       "add through a value filter without a match",
+			operations: `[{"op": "add", "path": "emails[type eq \"home\"].value", "value": "ada@home.example"}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "Lovelace"}, "active": true, "emails": [{"value": "ada@example.com", "type": "work"}, {"value": "ada@home.example", "type": "home"}]}`,
+		},
+		{
+			name:       "remove an attribute",
+			operations: `[{"op": "remove", "path": "urn:ietf:params:scim:schemas:core:2.0:User:active"}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "Lovelace"}, "emails": [{"value": "ada@example.com", "type": "work"}]}`,
+		},
+		{
+			name:       "remove through a value filter",
+			operations: `[{"op": "remove", "path": "emails[type eq \"work\"]"}]`,
+			want:       `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "Lovelace"}, "active": true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := resource(t, `{"userName": "ada@example.com", "name": {"givenName": "Ada", "familyName": "Lovelace"}, "active": true, "emails": [{"value": "ada@example.com", "type": "work"}]}`)
+
+			var request PatchRequest
+			if err := json.Unmarshal([]byte(`{"schemas": ["`+SchemaPatchOp+`"], "Operations": `+tt.operations+`}`), &request); err != nil {
+				t.Fatal(err)
+			}
+			if err := request.Apply(user); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			if want := resource(t, tt.want); !reflect.DeepEqual(user, want) {
+				t.Errorf("Apply() = %v, want %v", user, want)
+			}
+		})
+	}
+}
+
+func TestPatchRequestApplyMembers(t *testing.T) {
+	group := resource(t, `{"displayName": "Tutors", "members": [{"value": "u-1"}, {"value": "u-2"}]}`)
+
+	request := PatchRequest{Operations: []Operation{
+		{Op: "add", Path: "members", Value: []any{map[string]any{"value": "u-2"}, map[string]any{"value": "u-3"}}},
+		{Op: "remove", Path: `members[value eq "u-1"]`},
+		{Op: "Remove", Path: "members", Value: []any{map[string]any{"value": "u-2", "display": "Alan"}}},
+	}}
+	if err := request.Apply(group); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := []any{map[string]any{"value": "u-3"}}
+	if !reflect.DeepEqual(group["members"], want) {
+		t.Errorf("members = %v, want %v", group["members"], want)
+	}
+
+	for _, request := range []PatchRequest{
+		{},
+		{Operations: []Operation{{Op: "move", Path: "members"}}},
+		{Operations: []Operation{{Op: "remove"}}},
+		{Operations: []Operation{{Op: "replace", Path: `members[value eq "u-9"]`, Value: "x"}}},
+		{Operations: []Operation{{Op: "replace", Path: `members[value eq "u-3"`}}},
+	} {
+		if err := request.Apply(group); err == nil {
+			t.Errorf("expected an error for %+v", request.Operations)
+		}
+	}
+}
+
+func TestParseQueryAndList(t *testing.T) {
+	var resources []map[string]any
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		resources = append(resources, map[string]any{"id": name, "active": name != "c"})
+	}
+
+	tests := []struct {
+		query string
+		total int
+		ids   []string
+	}{
+		{"", 5, []string{"a", "b", "c"}},
+		{"startIndex=4", 5, []string{"d", "e"}},
+		{"startIndex=0&count=2", 5, []string{"a", "b"}},
+		{"count=-1", 5, nil},
+		{"count=50", 5, []string{"a", "b", "c"}},
+		{"filter=active+eq+true&startIndex=3", 4, []string{"d", "e"}},
+		{"startIndex=9", 5, nil},
+	}
+
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		query, err := ParseQuery(values, 3)
+		if err != nil {
+			t.Errorf("ParseQuery(%s) error = %v", tt.query, err)
+			continue
+		}
+
+		list := List(resources, query)
+		var ids []string
+		for _, resource := range list.Resources {
+			ids = append(ids, resource.(map[string]any)["id"].(string))
+		}
+		if list.TotalResults != tt.total || !reflect.DeepEqual(ids, tt.ids) || list.ItemsPerPage != len(tt.ids) {
+			t.Errorf("%s listed %v of %d, want %v of %d", tt.query, ids, list.TotalResults, tt.ids, tt.total)
+		}
+	}
+
+	for _, query := range []string{"count=many", "startIndex=first", "filter=active+eq"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := ParseQuery(values, 3); err == nil {
+			t.Errorf("expected an error for %s", query)
+		}
+	}
+}
+
+func TestErrorAndBool(t *testing.T) {
+	data, err := json.Marshal(Errorf(409, TypeUniqueness, "userName %s is taken", "ada@example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"schemas":["` + SchemaError + `"],"status":"409","scimType":"uniqueness","detail":"userName ada@example.com is taken"}`
+	if string(data) != want {
+		t.Errorf("error = %s, want %s", data, want)
+	}
+
+	var user struct {
+		Active Bool `json:"active"`
+	}
+	for data, want := range map[string]bool{`true`: true, `"True"`: true, `"False"`: false, `null`: false} {
+		if err := json.Unmarshal([]byte(`{"active": `+data+`}`), &user); err != nil || bool(user.Active) != want {
+			t.Errorf("active %s = %v (%v), want %v", data, user.Active, err, want)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"active": "maybe"}`), &user); err == nil {
+		t.Error("expected an error for a value that is not a boolean")
+	}
+}
@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	queriesMu sync.RWMutex
+	// queryFiles holds the SQL of every registered file by its name.
+	queryFiles = map[string]string{}
+	// queryIndex maps SQL to the names of the files holding it, so
+	// statements can be named after their file.
+	queryIndex = map[string][]string{}
+)
+
+// RegisterQueries names the SQL files under the queries directory of fsys
+// after pkg and their paths, such as "scheduler/booking/create_hold.sql",
+// so the spans of statements that run one carry its name. Packages embed
+// their queries directory and register it when they are initialized.
+func RegisterQueries(pkg string, fsys fs.FS) {
+	err := fs.WalkDir(fsys, "queries", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(name) != ".sql" {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		registerQuery(pkg+"/"+strings.TrimPrefix(name, "queries/"), string(content))
+		return nil
+	})
+	if err != nil {
+		panic("tracing: registering queries of " + pkg + ": " + err.Error())
+	}
+}
+
+// registerQuery registers the SQL of the file name, replacing what the
+// file held before.
+func registerQuery(name, sql string) {
+	queriesMu.Lock()
+	defer queriesMu.Unlock()
+
+	if previous, ok := queryFiles[name]; ok {
+		queryIndex[previous] = slices.DeleteFunc(queryIndex[previous], func(n string) bool { return n == name })
+		if len(queryIndex[previous]) == 0 {
+			delete(queryIndex, previous)
+		}
+	}
+	queryFiles[name] = sql
+	queryIndex[sql] = append(queryIndex[sql], name)
+	slices.Sort(queryIndex[sql])
+}
+
+// queryName returns the name of the SQL file sql was read from, or the
+// statement's first keyword, such as "SELECT", for SQL written inline.
+// Statements held by several files are named after their keyword too,
+// since the file they came from cannot be told.
+func queryName(sql string) string {
+	queriesMu.RLock()
+	names := queryIndex[sql]
+	queriesMu.RUnlock()
+	if len(names) == 1 {
+		return names[0]
+	}
+
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		return strings.ToUpper(strings.TrimRight(strings.Fields(line)[0], "(;"))
+	}
+	return "query"
+}
+
+// queryAttributes are the attributes of a span of a Postgres statement.
+func queryAttributes(sql string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.DBSystemPostgreSQL,
+		semconv.DBQueryTextKey.String(sql),
+	}
+}
+
+// startQuerySpan starts a client span of a statement, as a child of the
+// span in ctx. Statements run outside a trace, such as the polling of
+// background workers, are not traced, so they do not each start a trace.
+func startQuerySpan(ctx context.Context, name string, attributes ...attribute.KeyValue) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	ctx, _ = otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+	return ctx
+}
+
+// endQuerySpan ends a span of a statement, recording err.
+func endQuerySpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// PgxTracer traces pgx queries, batches and copies run inside a trace as
+// client spans named after the SQL files of their statements. Set it as the
+// Tracer of a pool's connection config.
+type PgxTracer struct{}
+
+var (
+	_ pgx.QueryTracer    = PgxTracer{}
+	_ pgx.BatchTracer    = PgxTracer{}
+	_ pgx.CopyFromTracer = PgxTracer{}
+)
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return startQuerySpan(ctx, queryName(data.SQL), queryAttributes(data.SQL)...)
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	endQuerySpan(span, data.Err)
+}
+
+// TraceBatchStart starts one span for the batch, named after its
+// statement when every query of the batch runs the same one. Each query
+// is an event of the span.
+func (PgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	name := "batch"
+	if queries := data.Batch.QueuedQueries; len(queries) > 0 {
+		first := queryName(queries[0].SQL)
+		same := true
+		for _, query := range queries[1:] {
+			if queryName(query.SQL) != first {
+				same = false
+				break
+			}
+		}
+		if same {
+			name = "batch " + first
+		}
+	}
+
+	return startQuerySpan(ctx, name, semconv.DBSystemPostgreSQL, attribute.Int("db.batch.size", data.Batch.Len()))
+}
+
+func (PgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attributes := []attribute.KeyValue{attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected())}
+	if data.Err != nil {
+		attributes = append(attributes, semconv.ExceptionMessageKey.String(data.Err.Error()))
+	}
+	trace.SpanFromContext(ctx).AddEvent(queryName(data.SQL), trace.WithAttributes(attributes...))
+}
+
+func (PgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endQuerySpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (PgxTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	table := data.TableName.Sanitize()
+	return startQuerySpan(ctx, "copy "+table, semconv.DBSystemPostgreSQL, semconv.DBCollectionNameKey.String(table))
+}
+
+func (PgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	endQuerySpan(span, data.Err)
+}
@@ -0,0 +1,218 @@
+// Package tracing records OpenTelemetry spans for requests, Postgres
+// queries and Firebase calls, and exports them to stdout or to an OTLP
+// collector. Code creates spans through the OpenTelemetry API, with
+// otel.Tracer, and Provider records and exports them with the OpenTelemetry
+// SDK once it is the global tracer provider.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// Exporters spans can be sent to.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+const instrumentationName = "scheduler-api/internal/tracing"
+
+// Config holds tracing configuration
+type Config struct {
+	// Exporter is where spans are sent, one of ExporterNone, ExporterStdout
+	// and ExporterOTLP.
+	Exporter string
+	// ServiceName names the service spans come from.
+	ServiceName string
+	// SampleRatio is the share of new traces recorded, from 0 to 1. Traces
+	// started by a caller follow the caller's sampling decision.
+	SampleRatio float64
+
+	// OTLPEndpoint is the URL OTLP spans are posted to, and OTLPHeaders
+	// are sent with them, for authentication.
+	OTLPEndpoint string
+	OTLPHeaders  map[string]string
+
+	// Spans are exported in batches of up to BatchSize, at least every
+	// FlushInterval, and dropped when QueueSize are waiting.
+	BatchSize     int
+	QueueSize     int
+	FlushInterval time.Duration
+}
+
+// DefaultConfig returns tracing configuration with spans not exported.
+func DefaultConfig() Config {
+	return Config{
+		Exporter:      ExporterNone,
+		ServiceName:   "scheduler-api",
+		SampleRatio:   1,
+		OTLPEndpoint:  "http://localhost:4318/v1/traces",
+		BatchSize:     512,
+		QueueSize:     2048,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// LoadConfigFromEnv loads tracing configuration from the standard
+// OpenTelemetry environment variables.
+func LoadConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("OTEL_TRACES_EXPORTER"); value != "" {
+		switch value {
+		case ExporterNone, ExporterStdout, ExporterOTLP:
+			config.Exporter = value
+		default:
+			return Config{}, fmt.Errorf("OTEL_TRACES_EXPORTER must be %s, %s or %s, got %q", ExporterNone, ExporterStdout, ExporterOTLP, value)
+		}
+	}
+
+	if value := os.Getenv("OTEL_SERVICE_NAME"); value != "" {
+		config.ServiceName = value
+	}
+
+	if value := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return Config{}, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be a ratio from 0 to 1, got %q", value)
+		}
+		config.SampleRatio = ratio
+	}
+
+	if value := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); value != "" {
+		config.OTLPEndpoint = value
+	} else if value := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); value != "" {
+		config.OTLPEndpoint = otlpTracesEndpoint(value)
+	}
+
+	if value := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"); value != "" {
+		headers, err := parseHeaders(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("OTEL_EXPORTER_OTLP_HEADERS: %w", err)
+		}
+		config.OTLPHeaders = headers
+	}
+
+	return config, nil
+}
+
+// Provider records sampled spans and exports them in batches, through an
+// SDK tracer provider. Without an exporter, spans are not recorded at all.
+type Provider struct {
+	logger *zap.Logger
+	sdk    *sdktrace.TracerProvider
+}
+
+// NewProvider creates a provider that exports spans as the configuration
+// says.
+func NewProvider(logger *zap.Logger, config Config) (*Provider, error) {
+	var exporter sdktrace.SpanExporter
+	switch config.Exporter {
+	case ExporterNone:
+		return &Provider{logger: logger}, nil
+	case ExporterStdout:
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout)); err != nil {
+			return nil, err
+		}
+	case ExporterOTLP:
+		var err error
+		exporter, err = otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(config.OTLPEndpoint),
+			otlptracehttp.WithHeaders(config.OTLPHeaders))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.Exporter)
+	}
+
+	processor := sdktrace.NewBatchSpanProcessor(exporter,
+		sdktrace.WithMaxExportBatchSize(config.BatchSize),
+		sdktrace.WithMaxQueueSize(config.QueueSize),
+		sdktrace.WithBatchTimeout(config.FlushInterval))
+	return &Provider{logger: logger, sdk: newSDKProvider(config, processor)}, nil
+}
+
+// newSDKProvider creates an SDK tracer provider sampling new traces at the
+// configured ratio and passing ended spans to processor. Traces continued
+// from a caller follow the caller's sampling decision.
+func newSDKProvider(config Config, processor sdktrace.SpanProcessor) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(config.ServiceName))),
+		sdktrace.WithSpanProcessor(processor),
+	)
+}
+
+// Install makes p the global tracer provider, and W3C trace context the
+// format trace context is propagated in, as traceparent and tracestate
+// headers. Spans that fail to export are logged.
+func (p *Provider) Install() {
+	if p.sdk != nil {
+		otel.SetTracerProvider(p.sdk)
+	} else {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	}
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		p.logger.Warn("Failed to export spans", zap.Error(err))
+	}))
+}
+
+// Run waits until ctx is done, then exports the spans still waiting and
+// shuts the provider down.
+func (p *Provider) Run(ctx context.Context) {
+	if p.sdk == nil {
+		return
+	}
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.sdk.Shutdown(shutdownCtx); err != nil {
+		p.logger.Warn("Failed to export spans", zap.Error(err))
+	}
+}
+
+// otlpTracesEndpoint returns the traces URL under an OTLP base endpoint,
+// as OTEL_EXPORTER_OTLP_ENDPOINT is specified to be used.
+func otlpTracesEndpoint(base string) string {
+	return strings.TrimSuffix(base, "/") + "/v1/traces"
+}
+
+// parseHeaders parses OTEL_EXPORTER_OTLP_HEADERS, a comma-separated list of
+// name=value pairs with URL-encoded values.
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		name, encoded, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header %q is not name=value", strings.TrimSpace(pair))
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		headers[name] = decoded
+	}
+	return headers, nil
+}
@@ -0,0 +1,240 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// install makes a provider sampling at ratio the global tracer provider for
+// the test, and returns a function that takes the spans ended since it was
+// last called.
+func install(t *testing.T, ratio float64) func() []sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.SampleRatio = ratio
+	recorder := tracetest.NewSpanRecorder()
+	p := &Provider{logger: zap.NewNop(), sdk: newSDKProvider(config, recorder)}
+
+	saved := otel.GetTracerProvider()
+	p.Install()
+	t.Cleanup(func() { otel.SetTracerProvider(saved) })
+
+	taken := 0
+	return func() []sdktrace.ReadOnlySpan {
+		spans := recorder.Ended()[taken:]
+		taken += len(spans)
+		return spans
+	}
+}
+
+func TestSpanParentage(t *testing.T) {
+	ended := install(t, 1)
+
+	tracer := otel.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	spans := ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	if spans[0].Name() != "child" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Errorf("child span %q has parent %s, want %s", spans[0].Name(), spans[0].Parent().SpanID(), spans[1].SpanContext().SpanID())
+	}
+	if spans[0].SpanContext().TraceID() != spans[1].SpanContext().TraceID() {
+		t.Error("child span is in another trace")
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "failed" {
+		t.Errorf("child status = %v %q, want Error \"failed\"", status.Code, status.Description)
+	}
+}
+
+func TestSampleRatio(t *testing.T) {
+	ended := install(t, 0)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "unsampled")
+	span.End()
+
+	if spans := ended(); len(spans) != 0 {
+		t.Errorf("%d spans recorded with a sample ratio of 0", len(spans))
+	}
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("unsampled span carries no trace context")
+	}
+}
+
+func TestMiddlewareContinuesTrace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ended := install(t, 0)
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/v1/class/:class_id/", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	// The caller sampled the trace, so it is recorded whatever the ratio.
+	req := httptest.NewRequest(http.MethodGet, "/v1/class/c-1/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans ended, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name() != "GET /v1/class/:class_id/" || s.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span %q of kind %v, want the server span of the route", s.Name(), s.SpanKind())
+	}
+	if s.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || s.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("span is in trace %s under %s, want the caller's", s.SpanContext().TraceID(), s.Parent().SpanID())
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error for a 500", s.Status().Code)
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	RegisterQueries("test", fstest.MapFS{
+		"queries/booking/create_hold.sql": {Data: []byte("insert into slot_holds values ($1);\n")},
+		"queries/booking/hold_slot.sql":   {Data: []byte("delete from slot_holds;\n")},
+		"queries/booking/release.sql":     {Data: []byte("delete from slot_holds;\n")},
+		"queries/README.md":               {Data: []byte("not a query")},
+	})
+
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"insert into slot_holds values ($1);\n", "test/booking/create_hold.sql"},
+		{"delete from slot_holds;\n", "DELETE"},
+		{"  select 1", "SELECT"},
+		{"-- comment\nupdate users set x = 1", "UPDATE"},
+		{"", "query"},
+	}
+
+	for _, tt := range tests {
+		if got := queryName(tt.sql); got != tt.want {
+			t.Errorf("queryName(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestPgxTracer(t *testing.T) {
+	ended := install(t, 1)
+	tracer := PgxTracer{}
+
+	// Statements outside a trace are not traced.
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	if spans := ended(); len(spans) != 0 {
+		t.Fatalf("%d spans for a statement outside a trace, want 0", len(spans))
+	}
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "delete from users"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("permission denied")})
+	parent.End()
+
+	spans := ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	if s := spans[0]; s.Name() != "DELETE" || s.Status().Code != codes.Error || len(s.Events()) != 1 {
+		t.Errorf("query span %q with status %v and %d events, want DELETE with the error recorded", s.Name(), s.Status().Code, len(s.Events()))
+	}
+}
+
+func TestWrapConnector(t *testing.T) {
+	ended := install(t, 1)
+
+	db := sql.OpenDB(WrapConnector(fakeConnector{}))
+	defer db.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	if _, err := db.ExecContext(ctx, "UPDATE users SET last_login_at = NOW()"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	parent.End()
+
+	spans := ended()
+	if len(spans) != 2 || spans[0].Name() != "UPDATE" || spans[0].SpanKind() != trace.SpanKindClient {
+		t.Fatalf("spans = %d, want an UPDATE client span and the parent", len(spans))
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	for _, exporter := range []string{ExporterNone, ExporterStdout, ExporterOTLP} {
+		config := DefaultConfig()
+		config.Exporter = exporter
+		p, err := NewProvider(zap.NewNop(), config)
+		if err != nil {
+			t.Fatalf("NewProvider(%s) error = %v", exporter, err)
+		}
+		if (p.sdk == nil) != (exporter == ExporterNone) {
+			t.Errorf("NewProvider(%s) records spans = %v", exporter, p.sdk != nil)
+		}
+	}
+
+	config := DefaultConfig()
+	config.Exporter = "jaeger"
+	if _, err := NewProvider(zap.NewNop(), config); err == nil {
+		t.Error("NewProvider() accepted an unknown exporter")
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector.example.com/")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "api-key=secret%3D, x-team = scheduling")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+
+	config, err := LoadConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadConfigFromEnv() error = %v", err)
+	}
+	if config.Exporter != ExporterOTLP || config.OTLPEndpoint != "https://collector.example.com/v1/traces" || config.SampleRatio != 0.25 {
+		t.Errorf("config = %+v", config)
+	}
+	if config.OTLPHeaders["api-key"] != "secret=" || config.OTLPHeaders["x-team"] != "scheduling" {
+		t.Errorf("headers = %v", config.OTLPHeaders)
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "jaeger")
+	if _, err := LoadConfigFromEnv(); err == nil {
+		t.Error("LoadConfigFromEnv() accepted an unknown exporter")
+	}
+}
+
+// fakeConnector connects to a driver whose statements do nothing.
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
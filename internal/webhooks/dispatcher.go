@@ -0,0 +1,277 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/netguard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed queries/enqueue_ping.sql
+	enqueuePingSQL string
+
+	//go:embed queries/claim_deliveries.sql
+	claimDeliveriesSQL string
+
+	//go:embed queries/claim_delivery.sql
+	claimDeliverySQL string
+
+	//go:embed queries/record_attempt.sql
+	recordAttemptSQL string
+
+	//go:embed queries/mark_delivery_succeeded.sql
+	markDeliverySucceededSQL string
+
+	//go:embed queries/mark_delivery_failed.sql
+	markDeliveryFailedSQL string
+
+	//go:embed queries/replay_delivery.sql
+	replayDeliverySQL string
+)
+
+// Config controls how the dispatcher polls, sends and retries deliveries.
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease is how long a claimed delivery is hidden from other dispatchers
+	// while it is being sent.
+	Lease time.Duration
+	// Timeout bounds each request to a receiver.
+	Timeout time.Duration
+	// AllowPrivateNetworks lets receivers be on loopback and private
+	// addresses, for local development.
+	AllowPrivateNetworks bool
+}
+
+// DefaultConfig returns the dispatcher defaults. With these, a delivery is
+// retried for roughly a day before it is marked failed.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 10 * time.Second,
+		BatchSize:    50,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   6 * time.Hour,
+		Lease:        2 * time.Minute,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// LoadConfigFromEnv overrides the defaults with WEBHOOK_* environment
+// variables. Durations use time.ParseDuration syntax, e.g. "30s".
+func LoadConfigFromEnv() Config {
+	config := DefaultConfig()
+	config.PollInterval = background.DurationFromEnv("WEBHOOK_POLL_INTERVAL", config.PollInterval)
+	config.BatchSize = background.IntFromEnv("WEBHOOK_BATCH_SIZE", config.BatchSize)
+	config.MaxAttempts = background.IntFromEnv("WEBHOOK_MAX_ATTEMPTS", config.MaxAttempts)
+	config.BaseBackoff = background.DurationFromEnv("WEBHOOK_BASE_BACKOFF", config.BaseBackoff)
+	config.MaxBackoff = background.DurationFromEnv("WEBHOOK_MAX_BACKOFF", config.MaxBackoff)
+	config.Timeout = background.DurationFromEnv("WEBHOOK_TIMEOUT", config.Timeout)
+	config.AllowPrivateNetworks, _ = strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"))
+	return config
+}
+
+// Dispatcher posts pending deliveries to their subscription's URL.
+type Dispatcher struct {
+	logger  *zap.Logger
+	pgxPool *pgxpool.Pool
+	client  *http.Client
+	config  Config
+	now     func() time.Time
+}
+
+// NewDispatcher creates a dispatcher.
+func NewDispatcher(logger *zap.Logger, pgxPool *pgxpool.Pool, config Config) *Dispatcher {
+	return &Dispatcher{
+		logger:  logger,
+		pgxPool: pgxPool,
+		client:  netguard.NewClient(config.Timeout, config.AllowPrivateNetworks),
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// CheckURL rejects receiver URLs whose host is localhost or a non-public IP
+// address, unless private networks are allowed. Host names are checked again
+// when the dispatcher connects, after they are resolved.
+func (d *Dispatcher) CheckURL(rawURL string) error {
+	if d.config.AllowPrivateNetworks {
+		return nil
+	}
+	return netguard.CheckURL(rawURL)
+}
+
+// Run polls for pending deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error("Webhook dispatcher run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends one batch of pending deliveries.
+func (d *Dispatcher) RunOnce(ctx context.Context) error {
+	now := d.now()
+
+	rows, err := d.pgxPool.Query(ctx, claimDeliveriesSQL, now, d.config.BatchSize, now.Add(d.config.Lease))
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByPos[claimedDelivery])
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		d.attempt(ctx, delivery)
+	}
+
+	return nil
+}
+
+// Ping queues a ping event for a subscription and sends it right away,
+// returning the delivery ID so the outcome can be looked up. It is sent with
+// the same client, and so to the same addresses, as any other delivery, and a
+// failed ping is retried like one.
+func (d *Dispatcher) Ping(ctx context.Context, subscriptionID string) (string, error) {
+	now := d.now()
+
+	var deliveryID string
+	if err := d.pgxPool.QueryRow(ctx, enqueuePingSQL, subscriptionID, uuid.New().String(), now).Scan(&deliveryID); err != nil {
+		return "", err
+	}
+
+	rows, err := d.pgxPool.Query(ctx, claimDeliverySQL, deliveryID, now, now.Add(d.config.Lease))
+	if err != nil {
+		return "", err
+	}
+
+	delivery, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[claimedDelivery])
+	if err != nil {
+		return "", err
+	}
+
+	d.attempt(ctx, delivery)
+	return deliveryID, nil
+}
+
+// Replay puts a delivery that succeeded or failed back in the queue to be
+// sent again from its first attempt. Pending deliveries, including ones a
+// dispatcher is sending, are left alone so they are not sent twice; Replay
+// reports false for them and for unknown deliveries.
+func Replay(ctx context.Context, db background.Executor, deliveryID string, now time.Time) (bool, error) {
+	tag, err := db.Exec(ctx, replayDeliverySQL, deliveryID, now)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+type claimedDelivery struct {
+	DeliveryID string
+	Event      string
+	EventID    string
+	Payload    []byte
+	Attempts   int
+	URL        string
+	Secret     string
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery claimedDelivery) {
+	start := d.now()
+	statusCode, err := d.send(ctx, delivery, start)
+	now := d.now()
+
+	var errMessage *string
+	if err != nil {
+		message := err.Error()
+		errMessage = &message
+	}
+
+	durationMs := now.Sub(start).Milliseconds()
+	if _, logErr := d.pgxPool.Exec(ctx, recordAttemptSQL, delivery.DeliveryID, statusCode, errMessage, durationMs, start); logErr != nil {
+		d.logger.Error("Failed to record webhook attempt", zap.String("delivery_id", delivery.DeliveryID), zap.Error(logErr))
+	}
+
+	if err == nil {
+		if _, err := d.pgxPool.Exec(ctx, markDeliverySucceededSQL, delivery.DeliveryID, statusCode, now); err != nil {
+			d.logger.Error("Failed to mark webhook delivered", zap.String("delivery_id", delivery.DeliveryID), zap.Error(err))
+		}
+		return
+	}
+
+	status, nextAttempt := StatusPending, now.Add(background.Backoff(delivery.Attempts, d.config.BaseBackoff, d.config.MaxBackoff))
+	if delivery.Attempts >= d.config.MaxAttempts {
+		status, nextAttempt = StatusFailed, now
+	}
+
+	d.logger.Warn("Webhook delivery failed",
+		zap.String("delivery_id", delivery.DeliveryID),
+		zap.String("event", delivery.Event),
+		zap.Int("attempts", delivery.Attempts),
+		zap.String("status", status),
+		zap.Error(err))
+
+	if _, err := d.pgxPool.Exec(ctx, markDeliveryFailedSQL, delivery.DeliveryID, status, nextAttempt, statusCode, *errMessage, now); err != nil {
+		d.logger.Error("Failed to record webhook failure", zap.String("delivery_id", delivery.DeliveryID), zap.Error(err))
+	}
+}
+
+// send posts the payload and returns the response status code, or nil if no
+// response was received.
+func (d *Dispatcher) send(ctx context.Context, delivery claimedDelivery, now time.Time) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bookSmart-Webhooks/1.0")
+	req.Header.Set("X-BookSmart-Event", delivery.Event)
+	req.Header.Set("X-BookSmart-Event-Id", delivery.EventID)
+	req.Header.Set("X-BookSmart-Delivery", delivery.DeliveryID)
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	// Drain a little of the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return &statusCode, fmt.Errorf("receiver responded with status %d", statusCode)
+	}
+	return &statusCode, nil
+}
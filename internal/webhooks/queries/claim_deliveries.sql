@@ -0,0 +1,30 @@
+with claimed as (
+	select d.delivery_id
+	from webhook_deliveries as d
+	inner join webhook_subscriptions as s on d.subscription_id = s.subscription_id
+	where
+		d.status = 'pending'
+		and d.next_attempt_at <= $1
+		and s.active
+	order by d.next_attempt_at
+	limit $2
+	for update of d skip locked
+)
+
+update webhook_deliveries as d
+set
+	attempts = d.attempts + 1,
+	next_attempt_at = $3,
+	updated_at = $1
+from claimed, webhook_subscriptions as s
+where
+	d.delivery_id = claimed.delivery_id
+	and d.subscription_id = s.subscription_id
+returning
+	d.delivery_id,
+	d.event,
+	d.event_id,
+	d.payload,
+	d.attempts,
+	s.url,
+	s.secret;
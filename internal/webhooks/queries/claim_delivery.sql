@@ -0,0 +1,17 @@
+update webhook_deliveries as d
+set
+	attempts = d.attempts + 1,
+	next_attempt_at = $3,
+	updated_at = $2
+from webhook_subscriptions as s
+where
+	d.delivery_id = $1
+	and d.subscription_id = s.subscription_id
+returning
+	d.delivery_id,
+	d.event,
+	d.event_id,
+	d.payload,
+	d.attempts,
+	s.url,
+	s.secret;
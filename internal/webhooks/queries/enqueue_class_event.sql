@@ -0,0 +1,47 @@
+with class as (
+	select
+		c.org_id,
+		jsonb_build_object(
+			'class_id', c.class_id,
+			'course_id', c.course_id,
+			'start_time', c.start_time,
+			'duration', c.duration,
+			'status', c.status,
+			'cancel_reason', c.cancel_reason,
+			'participants', coalesce(
+				(
+					select jsonb_agg(jsonb_build_object('user_id', cp.user_id, 'role', cp.role) order by cp.role, cp.user_id)
+					from class_participants as cp
+					where cp.class_id = c.class_id
+				),
+				'[]'::jsonb
+			)
+		) as data
+	from classes as c
+	where c.class_id = $1
+)
+
+insert into webhook_deliveries (
+	subscription_id, org_id, event, event_id, payload, status, next_attempt_at, created_at, updated_at
+)
+select
+	s.subscription_id,
+	s.org_id,
+	$2::text,
+	$3::uuid,
+	jsonb_build_object(
+		'id', $3::uuid,
+		'type', $2::text,
+		'org_id', s.org_id,
+		'created_at', $4::timestamptz,
+		'data', class.data
+	),
+	'pending',
+	$4::timestamptz,
+	$4::timestamptz,
+	$4::timestamptz
+from class
+inner join webhook_subscriptions as s on class.org_id = s.org_id
+where
+	s.active
+	and ($2::text = any(s.events) or '*' = any(s.events));
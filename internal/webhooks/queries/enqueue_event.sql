@@ -0,0 +1,24 @@
+insert into webhook_deliveries (
+	subscription_id, org_id, event, event_id, payload, status, next_attempt_at, created_at, updated_at
+)
+select
+	s.subscription_id,
+	s.org_id,
+	$2::text,
+	$3::uuid,
+	jsonb_build_object(
+		'id', $3::uuid,
+		'type', $2::text,
+		'org_id', s.org_id,
+		'created_at', $5::timestamptz,
+		'data', $4::jsonb
+	),
+	'pending',
+	$5::timestamptz,
+	$5::timestamptz,
+	$5::timestamptz
+from webhook_subscriptions as s
+where
+	s.org_id = $1
+	and s.active
+	and ($2::text = any(s.events) or '*' = any(s.events));
@@ -0,0 +1,22 @@
+insert into webhook_deliveries (
+	subscription_id, org_id, event, event_id, payload, status, next_attempt_at, created_at, updated_at
+)
+select
+	s.subscription_id,
+	s.org_id,
+	'ping',
+	$2::uuid,
+	jsonb_build_object(
+		'id', $2::uuid,
+		'type', 'ping',
+		'org_id', s.org_id,
+		'created_at', $3::timestamptz,
+		'data', jsonb_build_object('subscription_id', s.subscription_id)
+	),
+	'pending',
+	$3::timestamptz,
+	$3::timestamptz,
+	$3::timestamptz
+from webhook_subscriptions as s
+where s.subscription_id = $1
+returning delivery_id;
@@ -0,0 +1,8 @@
+update webhook_deliveries
+set
+	status = $2,
+	next_attempt_at = $3,
+	last_status_code = $4,
+	last_error = $5,
+	updated_at = $6
+where delivery_id = $1;
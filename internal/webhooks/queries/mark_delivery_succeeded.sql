@@ -0,0 +1,8 @@
+update webhook_deliveries
+set
+	status = 'succeeded',
+	last_status_code = $2,
+	last_error = null,
+	delivered_at = $3,
+	updated_at = $3
+where delivery_id = $1;
@@ -0,0 +1,2 @@
+insert into webhook_delivery_attempts (delivery_id, status_code, error, duration_ms, attempted_at)
+values ($1, $2, $3, $4, $5);
@@ -0,0 +1,8 @@
+update webhook_deliveries
+set
+	status = 'pending',
+	attempts = 0,
+	next_attempt_at = $2,
+	updated_at = $2
+where delivery_id = $1
+	and status in ('succeeded', 'failed');
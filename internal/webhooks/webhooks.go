@@ -0,0 +1,122 @@
+// Package webhooks posts signed event payloads to endpoints organizations
+// subscribe to.
+//
+// Like notifications, events are written as deliveries into an outbox table
+// inside the same transaction as the change. A Dispatcher later posts them to
+// each subscribed endpoint, retrying with backoff and logging every attempt.
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"scheduler-api/internal/background"
+	"scheduler-api/internal/tracing"
+
+	"github.com/google/uuid"
+)
+
+// Events that can be subscribed to.
+const (
+	EventClassCreated             = "class.created"
+	EventClassRescheduled         = "class.rescheduled"
+	EventClassCancelled           = "class.cancelled"
+	EventClassRestored            = "class.restored"
+	EventClassParticipantsUpdated = "class.participants_updated"
+	EventUserCreated              = "user.created"
+	EventCourseUpdated            = "course.updated"
+	EventAttendanceRecorded       = "attendance.recorded"
+	EventTrackerStatusChanged     = "tracker.status_changed"
+	EventWaitlistOffered          = "waitlist.offered"
+	EventEnrollmentChanged        = "enrollment.changed"
+	EventInvoiceStatusChanged     = "invoice.status_changed"
+
+	// EventPing is only sent by test pings and cannot be subscribed to.
+	EventPing = "ping"
+
+	// AllEvents subscribes to every event.
+	AllEvents = "*"
+)
+
+// Events lists every event that can be subscribed to.
+var Events = []string{
+	EventClassCreated,
+	EventClassRescheduled,
+	EventClassCancelled,
+	EventClassRestored,
+	EventClassParticipantsUpdated,
+	EventUserCreated,
+	EventCourseUpdated,
+	EventAttendanceRecorded,
+	EventTrackerStatusChanged,
+	EventWaitlistOffered,
+	EventEnrollmentChanged,
+	EventInvoiceStatusChanged,
+}
+
+// Delivery statuses.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// IsSubscribable reports whether a subscription may list the event.
+func IsSubscribable(event string) bool {
+	if event == AllEvents {
+		return true
+	}
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret returns a random signing secret for a new subscription.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+//go:embed queries
+var queryFiles embed.FS
+
+func init() {
+	tracing.RegisterQueries("webhooks", queryFiles)
+}
+
+var (
+	//go:embed queries/enqueue_event.sql
+	enqueueEventSQL string
+
+	//go:embed queries/enqueue_class_event.sql
+	enqueueClassEventSQL string
+)
+
+// Enqueue queues an event for every active subscription of the organization
+// that listens to it. data becomes the "data" field of the payload.
+func Enqueue(ctx context.Context, db background.Executor, orgID, event string, data any, now time.Time) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, enqueueEventSQL, orgID, event, uuid.New().String(), payload, now)
+	return err
+}
+
+// EnqueueClassEvent queues a class event, using the class and its
+// participants as they are at enqueue time as the payload data.
+func EnqueueClassEvent(ctx context.Context, db background.Executor, classID, event string, now time.Time) error {
+	_, err := db.Exec(ctx, enqueueClassEventSQL, classID, event, uuid.New().String(), now)
+	return err
+}
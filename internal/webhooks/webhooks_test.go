@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"scheduler-api/internal/netguard"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	var (
+		secret    = "whsec_test"
+		body      = []byte(`{"id":"evt","type":"class.created"}`)
+		sentAt    = time.Unix(1700000000, 0)
+		tolerance = 5 * time.Minute
+	)
+
+	header := Sign(secret, sentAt, body)
+	want := "t=1700000000,v1="
+	if len(header) <= len(want) || header[:len(want)] != want {
+		t.Fatalf("Sign() = %q, want prefix %q", header, want)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", secret, header, body, sentAt.Add(time.Minute), nil},
+		{"wrong secret", "whsec_other", header, body, sentAt, ErrSignatureMismatch},
+		{"tampered body", secret, header, []byte(`{"id":"evt","type":"class.cancelled"}`), sentAt, ErrSignatureMismatch},
+		{"too old", secret, header, body, sentAt.Add(10 * time.Minute), ErrSignatureExpired},
+		{"missing signature", secret, "t=1700000000", body, sentAt, ErrInvalidSignatureHeader},
+		{"malformed", secret, "garbage", body, sentAt, ErrInvalidSignatureHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tolerance, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsSubscribable(t *testing.T) {
+	for _, event := range append([]string{AllEvents}, Events...) {
+		if !IsSubscribable(event) {
+			t.Errorf("IsSubscribable(%q) = false, want true", event)
+		}
+	}
+
+	for _, event := range []string{EventPing, "", "class.deleted"} {
+		if IsSubscribable(event) {
+			t.Errorf("IsSubscribable(%q) = true, want false", event)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	if err := NewDispatcher(nil, nil, DefaultConfig()).CheckURL("http://localhost:8080/hook"); !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("CheckURL() error = %v, want ErrPrivateAddress", err)
+	}
+
+	config := DefaultConfig()
+	config.AllowPrivateNetworks = true
+	if err := NewDispatcher(nil, nil, config).CheckURL("http://localhost:8080/hook"); err != nil {
+		t.Errorf("CheckURL() error = %v with private networks allowed", err)
+	}
+}
@@ -6,9 +6,19 @@ import (
 	"log"
 	"os"
 	"strings"
+	_ "time/tzdata"
 
 	"scheduler-api/internal/auth"
+	"scheduler-api/internal/idempotency"
+	"scheduler-api/internal/jobs"
+	"scheduler-api/internal/live"
+	"scheduler-api/internal/logging"
+	"scheduler-api/internal/metrics"
+	"scheduler-api/internal/notifications"
+	"scheduler-api/internal/ratelimit"
 	"scheduler-api/internal/scheduler"
+	"scheduler-api/internal/tracing"
+	"scheduler-api/internal/webhooks"
 	"scheduler-api/database"
 
 	"github.com/gin-gonic/gin"
@@ -25,6 +35,7 @@ func initPostgres(log *zap.Logger) (*pgxpool.Pool, error) {
 	if err != nil {
 		log.Fatal("Failed to parse database URL:", zap.Error(err))
 	}
+	pgxConfig.ConnConfig.Tracer = tracing.PgxTracer{}
 
 	pgxPool, err := pgxpool.NewWithConfig(context.Background(), pgxConfig)
 	if err != nil {
@@ -64,7 +75,41 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	r := gin.Default()
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal("Failed to initialize logger:", err)
+	}
+
+	// Trace requests, queries and Firebase calls, exporting spans as
+	// OTEL_TRACES_EXPORTER says
+	tracingConfig, err := tracing.LoadConfigFromEnv()
+	if err != nil {
+		logger.Fatal("Failed to load tracing configuration:", zap.Error(err))
+	}
+	tracerProvider, err := tracing.NewProvider(logger, tracingConfig)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing:", zap.Error(err))
+	}
+	tracerProvider.Install()
+
+	// Log requests as structured lines carrying a request ID, which the
+	// handlers' own log lines carry too, trace them and record per-route
+	// latency. Panics are recovered inside all three, so they see the 500.
+	r := gin.New()
+	r.Use(logging.Middleware(logger), tracing.Middleware(), metrics.Middleware(), logging.Recovery(logger))
+
+	// Only trust the client address in X-Forwarded-For when the request
+	// comes from a known proxy. Otherwise clients could pick the address
+	// they are logged and rate limited by.
+	var trustedProxies []string
+	if value := os.Getenv("TRUSTED_PROXIES"); value != "" {
+		for _, proxy := range strings.Split(value, ",") {
+			trustedProxies = append(trustedProxies, strings.TrimSpace(proxy))
+		}
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		logger.Fatal("Invalid TRUSTED_PROXIES:", zap.Error(err))
+	}
 
 	// Add CORS middleware for frontend integration - MUST be before route registration
 	allowedOrigins := strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",")
@@ -80,7 +125,8 @@ func main() {
 		}
 		
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, If-Match, If-None-Match, Idempotency-Key, X-Request-ID, traceparent, tracestate")
+		c.Header("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, X-Request-ID")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		
 		if c.Request.Method == "OPTIONS" {
@@ -91,10 +137,12 @@ func main() {
 		c.Next()
 	})
 
-	logger, err := zap.NewProduction()
-	if err != nil {
-		log.Fatal("Failed to initialize logger:", err)
-	}
+	// Limit requests by client address before anything else runs for them,
+	// and by user and organization once they are authenticated
+	rateLimitConfig := ratelimit.LoadConfigFromEnv()
+	rateLimitConfig.BatchRoutes = scheduler.BatchRoutes
+	rateLimiter := ratelimit.New(rateLimitConfig)
+	r.Use(rateLimiter.ByIP())
 
 	// Initialize PGX pool connection
 	pgxPool, err := initPostgres(logger)
@@ -114,6 +162,40 @@ func main() {
 		}
 	}()
 
+	// Expose both pools' connection stats with the other metrics
+	database.RegisterPoolMetrics(pgxPool, sqlDB)
+
+	// Start the notification worker that delivers queued notifications
+	notificationWorker, err := notifications.NewWorker(logger, pgxPool, notifications.ChannelsFromEnv(logger), notifications.LoadConfigFromEnv())
+	if err != nil {
+		logger.Fatal("Failed to initialize notification worker:", zap.Error(err))
+	}
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+	go notificationWorker.Run(workerCtx)
+
+	// Start the webhook dispatcher that posts queued events to subscribers
+	webhookDispatcher := webhooks.NewDispatcher(logger, pgxPool, webhooks.LoadConfigFromEnv())
+	go webhookDispatcher.Run(workerCtx)
+
+	// Start the broker that streams live events to connected clients
+	liveBroker := live.NewBroker(logger, pgxPool)
+	go liveBroker.Run(workerCtx)
+
+	// Start the job runner for maintenance and other background jobs
+	jobRunner := jobs.NewRunner(logger, pgxPool, jobs.LoadConfigFromEnv())
+	maintenanceConfig := scheduler.LoadMaintenanceConfigFromEnv()
+	if err := scheduler.RegisterMaintenanceJobs(jobRunner, logger, pgxPool, maintenanceConfig); err != nil {
+		logger.Fatal("Failed to register maintenance jobs:", zap.Error(err))
+	}
+	go jobRunner.Run(workerCtx)
+
+	// Start removing the rate limiter's idle buckets
+	go rateLimiter.Run(workerCtx)
+
+	// Export the spans still waiting when the server shuts down
+	go tracerProvider.Run(workerCtx)
+
 	// Initialize Firebase service
 	firebaseService, err := auth.NewFirebaseService()
 	if err != nil {
@@ -129,20 +211,32 @@ func main() {
 	// requireTutor := authMiddleware.RequireRole("tutor")
 	// requireStudent := authMiddleware.RequireRole("student")
 
-	// Define which routes need authentication
-	authMiddlewares := []scheduler.MiddlewareFunc{
-		// Convert Gin middleware to MiddlewareFunc
-		func(c *gin.Context) {
-			requireAuth(c)
-		},
-	}
+	// Register handlers behind authentication. Rate limits and idempotency
+	// keys are scoped to the authenticated user, and the idempotency
+	// middleware stores the response the handler writes, so they run as group
+	// middleware rather than as generated handler middleware, which has no
+	// way to see the response.
+	service := scheduler.NewService(logger, pgxPool, sqlDB, firebaseService, webhookDispatcher, liveBroker, maintenanceConfig.WaitlistOfferTTL)
+	api := r.Group("", requireAuth, rateLimiter.ByUser(), idempotency.Middleware(logger, pgxPool, idempotency.LoadConfigFromEnv()))
+	scheduler.RegisterHandlers(api, service)
 
-	// Register handlers with authentication middleware
-	scheduler.RegisterHandlersWithOptions(r, scheduler.NewService(logger, pgxPool, sqlDB, firebaseService), scheduler.GinServerOptions{
-		Middlewares: authMiddlewares,
-	})
+	// Register the SCIM API, which identity providers authenticate to with
+	// SCIM tokens rather than Firebase
+	scheduler.RegisterSCIMHandlers(r, service)
+
+	// Register the LTI 1.3 launch endpoints, which platforms reach through
+	// the browser before the user has a session
+	scheduler.RegisterLTIHandlers(r, service)
 
 
+	// Serve metrics for scraping, behind a bearer token. Without one,
+	// /metrics answers 404.
+	metricsToken := os.Getenv("METRICS_TOKEN")
+	if metricsToken == "" {
+		logger.Warn("METRICS_TOKEN is not set, so /metrics is disabled")
+	}
+	r.GET("/metrics", metrics.Handler(metricsToken))
+
 	// Register Swagger documentation endpoints
 	scheduler.RegisterSwaggerHandlers(r)
 
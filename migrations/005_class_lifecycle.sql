@@ -0,0 +1,38 @@
+-- Migration: 005_class_lifecycle.sql
+-- Description: Track class status and keep a history of reschedules and cancellations
+-- Compatible with: PostgreSQL/Neon
+
+-- Cancelled classes are kept so they stay visible in listings
+alter table classes add column status text not null default 'scheduled' check (status in ('scheduled', 'cancelled'));
+alter table classes add column cancel_reason text check (
+	cancel_reason in (
+		'tutor_unavailable', 'student_unavailable', 'scheduling_conflict', 'weather', 'holiday', 'other'
+	)
+);
+alter table classes add column cancelled_at timestamptz;
+
+create index idx_classes_status on classes (status);
+
+-- ClassHistory Table: one row per change made to a class
+create table class_history (
+	history_id UUID primary key default uuid_generate_v4(),
+	class_id UUID not null,
+	org_id UUID not null,
+	action TEXT not null check (action in ('created', 'rescheduled', 'cancelled', 'restored')),
+	previous_start_time TIMESTAMPTZ,
+	previous_duration INTEGER,
+	start_time TIMESTAMPTZ not null,
+	duration INTEGER not null,
+	reason_code TEXT,
+	note TEXT,
+	actor_id UUID,
+	created_at TIMESTAMPTZ default now(),
+	foreign key (class_id) references classes (class_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (actor_id) references users (user_id) on delete set null
+);
+
+create index idx_class_history_class_id on class_history (class_id, created_at);
+
+comment on column classes.status is 'Class status: scheduled or cancelled';
+comment on column classes.cancel_reason is 'Reason code recorded when the class was cancelled';
@@ -0,0 +1,8 @@
+-- Migration: 006_class_participant_history.sql
+-- Description: Record participant changes in the class history
+-- Compatible with: PostgreSQL/Neon
+
+alter table class_history drop constraint class_history_action_check;
+alter table class_history add constraint class_history_action_check check (
+	action in ('created', 'rescheduled', 'cancelled', 'restored', 'participants_updated')
+);
@@ -0,0 +1,41 @@
+-- Migration: 007_notifications.sql
+-- Description: Notification outbox and per-user channel preferences
+-- Compatible with: PostgreSQL/Neon
+
+-- NotificationPreference Table: channels a user opted in or out of.
+-- Users without a row get email notifications only.
+create table notification_preferences (
+	user_id UUID not null,
+	channel TEXT not null check (channel in ('email', 'sms')),
+	enabled BOOLEAN not null,
+	updated_at TIMESTAMPTZ default now(),
+	primary key (user_id, channel),
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+-- Notification Table: transactional outbox, written in the same transaction
+-- as the scheduling change and delivered by the notification worker
+create table notifications (
+	notification_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	user_id UUID not null,
+	channel TEXT not null check (channel in ('email', 'sms')),
+	event TEXT not null,
+	payload JSONB not null default '{}',
+	dedupe_key TEXT unique,
+	status TEXT not null default 'pending' check (status in ('pending', 'sent', 'failed')),
+	attempts INTEGER not null default 0,
+	next_attempt_at TIMESTAMPTZ not null default now(),
+	last_error TEXT,
+	sent_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+create index idx_notifications_pending on notifications (next_attempt_at) where status = 'pending';
+create index idx_notifications_user_id on notifications (user_id, created_at);
+
+comment on column notifications.dedupe_key is 'Optional key that makes enqueueing idempotent, e.g. for class reminders';
+comment on column notifications.next_attempt_at is 'When the worker may next try to deliver; also used as a lease while delivering';
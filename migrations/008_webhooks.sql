@@ -0,0 +1,60 @@
+-- Migration: 008_webhooks.sql
+-- Description: Org-scoped webhook subscriptions and their delivery log
+-- Compatible with: PostgreSQL/Neon
+
+-- WebhookSubscription Table: endpoints an organization wants events posted to
+create table webhook_subscriptions (
+	subscription_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	url TEXT not null,
+	secret TEXT not null,
+	events TEXT[] not null,
+	description TEXT,
+	active BOOLEAN not null default true,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_webhook_subscriptions_org_id on webhook_subscriptions (org_id) where active;
+
+-- WebhookDelivery Table: one event to send to one subscription, written in the
+-- same transaction as the change and delivered by the webhook dispatcher
+create table webhook_deliveries (
+	delivery_id UUID primary key default uuid_generate_v4(),
+	subscription_id UUID not null,
+	org_id UUID not null,
+	event TEXT not null,
+	event_id UUID not null,
+	payload JSONB not null,
+	status TEXT not null default 'pending' check (status in ('pending', 'succeeded', 'failed')),
+	attempts INTEGER not null default 0,
+	next_attempt_at TIMESTAMPTZ not null default now(),
+	last_status_code INTEGER,
+	last_error TEXT,
+	delivered_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (subscription_id) references webhook_subscriptions (subscription_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_webhook_deliveries_pending on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index idx_webhook_deliveries_subscription_id on webhook_deliveries (subscription_id, created_at);
+
+-- WebhookDeliveryAttempt Table: every request made for a delivery
+create table webhook_delivery_attempts (
+	attempt_id UUID primary key default uuid_generate_v4(),
+	delivery_id UUID not null,
+	status_code INTEGER,
+	error TEXT,
+	duration_ms INTEGER not null,
+	attempted_at TIMESTAMPTZ default now(),
+	foreign key (delivery_id) references webhook_deliveries (delivery_id) on delete cascade
+);
+
+create index idx_webhook_delivery_attempts_delivery_id on webhook_delivery_attempts (delivery_id, attempted_at);
+
+comment on column webhook_subscriptions.secret is 'Shared secret used to sign payloads with HMAC-SHA256';
+comment on column webhook_subscriptions.events is 'Event types to deliver; * subscribes to every event';
+comment on column webhook_deliveries.event_id is 'Identifies the event; the same for every subscription it was delivered to';
@@ -0,0 +1,147 @@
+-- Migration: 009_jobs.sql
+-- Description: Background job queue, cron schedules and the tables the
+-- scheduling maintenance jobs work on
+-- Compatible with: PostgreSQL/Neon
+
+-- Job Table: queue shared by every API replica. Workers claim jobs with
+-- select ... for update skip locked, so each job runs on one replica at a time.
+create table jobs (
+	job_id UUID primary key default uuid_generate_v4(),
+	org_id UUID,
+	kind TEXT not null,
+	payload JSONB not null default '{}',
+	status TEXT not null default 'queued' check (status in ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+	attempts INTEGER not null default 0,
+	max_attempts INTEGER not null default 5,
+	run_at TIMESTAMPTZ not null default now(),
+	locked_until TIMESTAMPTZ,
+	locked_by TEXT,
+	last_error TEXT,
+	dedupe_key TEXT unique,
+	started_at TIMESTAMPTZ,
+	finished_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_jobs_runnable on jobs (run_at) where status = 'queued';
+create index idx_jobs_running on jobs (locked_until) where status = 'running';
+create index idx_jobs_kind on jobs (kind, created_at);
+create index idx_jobs_org on jobs (org_id, created_at);
+
+-- JobSchedule Table: cron schedules that enqueue jobs; next_run_at is
+-- advanced by whichever replica enqueues the run
+create table job_schedules (
+	name TEXT primary key,
+	kind TEXT not null,
+	cron TEXT not null,
+	next_run_at TIMESTAMPTZ not null,
+	last_run_at TIMESTAMPTZ,
+	updated_at TIMESTAMPTZ default now()
+);
+
+-- RecurringAvailability Table: weekly availability expanded into
+-- availability chunks ahead of time by a maintenance job
+create table recurring_availability (
+	recurring_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	user_id UUID not null,
+	weekday SMALLINT not null check (weekday between 0 and 6),
+	start_minute INTEGER not null check (start_minute between 0 and 1439 and start_minute % 15 = 0),
+	end_minute INTEGER not null check (end_minute between 15 and 1440 and end_minute % 15 = 0),
+	timezone TEXT not null default 'UTC',
+	created_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (user_id) references users (user_id) on delete cascade,
+	check (end_minute > start_minute)
+);
+
+create index idx_recurring_availability_user_id on recurring_availability (user_id);
+
+-- Availability upserts conflict on the chunk, which needs a unique index.
+-- Chunks entered more than once keep one row, a matched one if there is one.
+delete from availability a
+using availability b
+where b.user_id = a.user_id
+	and b.start_time = a.start_time
+	and b.end_time = a.end_time
+	and (b.matched, b.availability_id) > (a.matched, a.availability_id);
+
+create unique index idx_availability_user_chunk on availability (user_id, start_time, end_time);
+alter table availability add column source TEXT not null default 'manual' check (source in ('manual', 'recurring'));
+
+-- Tracker rollover creates at most one tracker per course period, which
+-- needs a unique index. Trackers created more than once for a period are
+-- folded into the oldest one, which takes over their classes and counts.
+with ranked as (
+	select
+		tracking_id,
+		first_value(tracking_id) over (
+			partition by course_id, period_start
+			order by created_at, tracking_id
+		) as keep_id
+	from trackers
+),
+merged as (
+	select r.keep_id, tc.class_id, bool_or(tc.status = 'completed') as completed
+	from ranked r
+	join tracker_classes tc on tc.tracking_id = r.tracking_id
+	where r.keep_id in (select keep_id from ranked where tracking_id <> keep_id)
+	group by r.keep_id, tc.class_id
+),
+counts as (
+	select keep_id, count(*) as scheduled, count(*) filter (where completed) as completed
+	from merged
+	group by keep_id
+)
+update trackers t
+set
+	scheduled_count = least(t.required_classes, counts.scheduled),
+	completed_count = least(t.required_classes, counts.completed),
+	updated_at = now()
+from counts
+where t.tracking_id = counts.keep_id;
+
+with ranked as (
+	select
+		tracking_id,
+		first_value(tracking_id) over (
+			partition by course_id, period_start
+			order by created_at, tracking_id
+		) as keep_id
+	from trackers
+)
+insert into tracker_classes (tracking_id, class_id, status, created_at)
+select distinct on (r.keep_id, tc.class_id) r.keep_id, tc.class_id, tc.status, tc.created_at
+from ranked r
+join tracker_classes tc on tc.tracking_id = r.tracking_id
+where r.tracking_id <> r.keep_id
+order by r.keep_id, tc.class_id, tc.status = 'completed' desc
+on conflict (tracking_id, class_id) do update
+set status = 'completed'
+where excluded.status = 'completed';
+
+with ranked as (
+	select
+		tracking_id,
+		first_value(tracking_id) over (
+			partition by course_id, period_start
+			order by created_at, tracking_id
+		) as keep_id
+	from trackers
+)
+delete from trackers t
+using ranked r
+where r.tracking_id = t.tracking_id
+	and r.tracking_id <> r.keep_id;
+
+create unique index idx_trackers_course_period on trackers (course_id, period_start);
+alter table trackers add column closed_at TIMESTAMPTZ;
+
+comment on column jobs.org_id is 'Organization the job was queued for, whose admins manage it; null for jobs run for every organization';
+comment on column jobs.locked_until is 'Lease of the worker running the job; expired leases are claimed again';
+comment on column jobs.dedupe_key is 'Optional key that makes enqueueing idempotent, e.g. one job per scheduled run';
+comment on column recurring_availability.weekday is 'Day of the week in the rule timezone, 0 = Sunday';
+comment on column availability.source is 'manual chunks come from users, recurring ones from recurring_availability';
+comment on column trackers.closed_at is 'When the period ended and its final status was settled';
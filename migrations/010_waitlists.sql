@@ -0,0 +1,58 @@
+-- Migration: 010_waitlists.sql
+-- Description: Course and class capacity limits with ordered waitlists
+-- Compatible with: PostgreSQL/Neon
+
+-- Enrollments record whether the user takes or teaches the course, so only
+-- students count towards the course capacity
+alter table user_courses
+	add column role TEXT not null default 'student' check (role in ('student', 'teacher')),
+	add column created_at TIMESTAMPTZ default now();
+
+update user_courses as uc
+set role = 'teacher'
+from users as u
+where uc.user_id = u.user_id and u.role = 'tutor';
+
+-- Capacity limits, NULL means unlimited
+alter table courses add column max_students INTEGER check (max_students > 0);
+alter table classes add column max_students INTEGER check (max_students > 0);
+
+-- WaitlistEntry Table: students waiting for a seat in a full course or class,
+-- in position order. An open seat is offered to the first waiting entry and
+-- held for it until the offer is accepted, declined or expires.
+create table waitlist_entries (
+	waitlist_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	course_id UUID,
+	class_id UUID,
+	user_id UUID not null,
+	position INTEGER not null,
+	status TEXT not null default 'waiting' check (
+		status in ('waiting', 'offered', 'accepted', 'declined', 'expired', 'removed')
+	),
+	offered_at TIMESTAMPTZ,
+	offer_expires_at TIMESTAMPTZ,
+	responded_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete cascade,
+	foreign key (user_id) references users (user_id) on delete cascade,
+	check ((course_id is NULL) <> (class_id is NULL)),
+	check (status <> 'offered' or offer_expires_at is not NULL)
+);
+
+-- A user waits at most once per course or class
+create unique index idx_waitlist_entries_course_user on waitlist_entries (course_id, user_id)
+where course_id is not NULL and status in ('waiting', 'offered');
+create unique index idx_waitlist_entries_class_user on waitlist_entries (class_id, user_id)
+where class_id is not NULL and status in ('waiting', 'offered');
+
+create index idx_waitlist_entries_user_id on waitlist_entries (user_id);
+create index idx_waitlist_entries_offer_expires_at on waitlist_entries (offer_expires_at) where status = 'offered';
+
+comment on column courses.max_students is 'Maximum number of active students, NULL for no limit';
+comment on column classes.max_students is 'Maximum number of students, NULL for no limit';
+comment on column waitlist_entries.position is 'Order within the course or class waitlist, lowest first';
+comment on column waitlist_entries.offer_expires_at is 'Deadline to accept an offered seat before it goes to the next entry';
@@ -0,0 +1,25 @@
+-- Migration: 011_tutor_workload.sql
+-- Description: Per-tutor workload limits enforced when classes are scheduled
+-- Compatible with: PostgreSQL/Neon
+
+-- TutorWorkloadLimits Table: how much a tutor may teach. NULL limits are not
+-- enforced. Days and weeks (starting Monday) are counted in the tutor's
+-- timezone.
+create table tutor_workload_limits (
+	user_id UUID primary key,
+	org_id UUID not null,
+	max_minutes_per_day INTEGER check (max_minutes_per_day > 0),
+	max_minutes_per_week INTEGER check (max_minutes_per_week > 0),
+	max_consecutive_classes INTEGER check (max_consecutive_classes > 0),
+	break_minutes INTEGER not null default 15 check (break_minutes >= 0),
+	timezone TEXT not null default 'UTC',
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (user_id) references users (user_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_tutor_workload_limits_org_id on tutor_workload_limits (org_id);
+
+comment on column tutor_workload_limits.max_consecutive_classes is 'Classes in a row, each starting less than break_minutes after the previous one ends';
+comment on column tutor_workload_limits.break_minutes is 'Gap between two classes that counts as a break';
@@ -0,0 +1,58 @@
+-- Migration: 012_resources.sql
+-- Description: Bookable rooms and resources reserved by classes
+-- Compatible with: PostgreSQL/Neon
+
+-- btree_gist lets the reservation exclusion constraint combine equality on
+-- the resource with overlap of the reserved time range.
+create extension if not exists btree_gist;
+
+-- Resources Table: rooms and equipment of an organization. A NULL capacity
+-- is unlimited.
+create table resources (
+	resource_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	type TEXT not null,
+	capacity INTEGER check (capacity > 0),
+	timezone TEXT not null default 'UTC',
+	active BOOLEAN not null default true,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_resources_org_id on resources (org_id, type) where active;
+
+-- ResourceOpeningHours Table: weekly windows in which a resource can be
+-- booked, in minutes after midnight in the resource timezone. A resource
+-- without rows can be booked at any time.
+create table resource_opening_hours (
+	resource_id UUID not null,
+	weekday INTEGER not null check (weekday between 0 and 6),
+	open_minute INTEGER not null check (open_minute >= 0),
+	close_minute INTEGER not null check (close_minute <= 1440),
+	check (close_minute > open_minute),
+	foreign key (resource_id) references resources (resource_id) on delete cascade
+);
+
+create index idx_resource_opening_hours_resource_id on resource_opening_hours (resource_id, weekday);
+
+-- ClassResources Table: resources reserved by classes. Reservations of
+-- cancelled classes stay inactive so restoring the class can take them back.
+create table class_resources (
+	class_id UUID not null,
+	resource_id UUID not null,
+	org_id UUID not null,
+	during TSTZRANGE not null,
+	active BOOLEAN not null default true,
+	created_at TIMESTAMPTZ default now(),
+	primary key (class_id, resource_id),
+	foreign key (class_id) references classes (class_id) on delete cascade,
+	foreign key (resource_id) references resources (resource_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	constraint class_resources_no_overlap exclude using gist (resource_id with =, during with &&) where (active)
+);
+
+create index idx_class_resources_resource_id on class_resources (resource_id);
+
+comment on column class_resources.during is 'Time range of the class, half-open so back to back classes do not overlap';
@@ -0,0 +1,77 @@
+-- Migration: 013_self_booking.sql
+-- Description: Bookable tutor slots, student holds and self-booking policies
+-- Compatible with: PostgreSQL/Neon
+
+-- BookableSlot Table: office-hour slots a tutor publishes out of their
+-- availability. A slot is held by at most one student at a time and becomes
+-- booked when the hold is confirmed into a class.
+create table bookable_slots (
+	slot_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	tutor_id UUID not null,
+	course_id UUID,
+	start_time TIMESTAMPTZ not null,
+	end_time TIMESTAMPTZ not null,
+	status TEXT not null default 'open' check (status in ('open', 'held', 'booked', 'withdrawn')),
+	class_id UUID,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete set NULL,
+	check (end_time > start_time),
+	check (status <> 'booked' or class_id is not NULL),
+	-- btree_gist is enabled by 012_resources.sql
+	constraint bookable_slots_no_overlap exclude using gist (
+		tutor_id with =,
+		tstzrange(start_time, end_time) with &&
+	) where (status <> 'withdrawn')
+);
+
+create index idx_bookable_slots_org_start on bookable_slots (org_id, start_time) where status = 'open';
+
+-- SlotHold Table: a student's short-lived claim on a slot. Only one hold per
+-- slot can be active; it is confirmed into a class, released, or expires.
+create table slot_holds (
+	hold_id UUID primary key default uuid_generate_v4(),
+	slot_id UUID not null,
+	org_id UUID not null,
+	student_id UUID not null,
+	status TEXT not null default 'active' check (status in ('active', 'confirmed', 'released', 'expired')),
+	expires_at TIMESTAMPTZ not null,
+	class_id UUID,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (slot_id) references bookable_slots (slot_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (student_id) references users (user_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete set NULL
+);
+
+create unique index idx_slot_holds_active_slot on slot_holds (slot_id) where status = 'active';
+create index idx_slot_holds_student_id on slot_holds (student_id);
+create index idx_slot_holds_expires_at on slot_holds (expires_at) where status = 'active';
+
+-- SelfBookingPolicy Table: who may self-book slots, per organization and
+-- optionally narrowed to a tutor and/or course. The most specific matching
+-- policy applies; without any policy self-booking is disabled.
+create table self_booking_policies (
+	policy_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	tutor_id UUID,
+	course_id UUID,
+	audience TEXT not null check (audience in ('all_students', 'enrolled_students', 'nobody')),
+	created_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade
+);
+
+create unique index idx_self_booking_policies_scope on self_booking_policies (
+	org_id,
+	coalesce(tutor_id, '00000000-0000-0000-0000-000000000000'),
+	coalesce(course_id, '00000000-0000-0000-0000-000000000000')
+);
+
+comment on column self_booking_policies.audience is 'enrolled_students are students of the slot course, or of a course the tutor teaches for slots without one';
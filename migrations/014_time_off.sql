@@ -0,0 +1,47 @@
+-- Migration: 014_time_off.sql
+-- Description: Tutor time-off requests and the classes they affect
+-- Compatible with: PostgreSQL/Neon
+
+-- TimeOffRequest Table: a tutor asking to be away for a period. Admins
+-- approve or reject pending requests; tutors can cancel them.
+create table time_off_requests (
+	request_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	tutor_id UUID not null,
+	start_time TIMESTAMPTZ not null,
+	end_time TIMESTAMPTZ not null,
+	reason TEXT,
+	status TEXT not null default 'pending' check (status in ('pending', 'approved', 'rejected', 'cancelled')),
+	reviewed_by UUID,
+	reviewed_at TIMESTAMPTZ,
+	review_note TEXT,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade,
+	foreign key (reviewed_by) references users (user_id) on delete set NULL,
+	check (end_time > start_time)
+);
+
+create index idx_time_off_requests_tutor_id on time_off_requests (tutor_id, start_time);
+create index idx_time_off_requests_org_status on time_off_requests (org_id, status);
+
+-- TimeOffAffectedClass Table: classes the tutor was teaching during approved
+-- time off, flagged until a substitute takes over.
+create table time_off_affected_classes (
+	request_id UUID not null,
+	class_id UUID not null,
+	org_id UUID not null,
+	status TEXT not null default 'needs_substitute' check (status in ('needs_substitute', 'reassigned')),
+	substitute_id UUID,
+	reassigned_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	primary key (request_id, class_id),
+	foreign key (request_id) references time_off_requests (request_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (substitute_id) references users (user_id) on delete set NULL
+);
+
+create index idx_time_off_affected_classes_class_id on time_off_affected_classes (class_id);
+create index idx_time_off_affected_classes_open on time_off_affected_classes (org_id) where status = 'needs_substitute';
@@ -0,0 +1,31 @@
+-- Migration: 015_blackouts.sql
+-- Description: Organization holiday and blackout calendar
+-- Compatible with: PostgreSQL/Neon
+
+-- What happens when a class or availability falls inside a blackout: warn
+-- lets it through with a warning, block rejects it.
+alter table organizations add column blackout_policy TEXT not null default 'warn' check (blackout_policy in ('warn', 'block'));
+
+-- BlackoutPeriod Table: times an organization is closed, entered by hand or
+-- imported from an iCalendar file. Imported periods keep the event UID so a
+-- re-import updates them instead of adding duplicates.
+create table blackout_periods (
+	blackout_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	start_time TIMESTAMPTZ not null,
+	end_time TIMESTAMPTZ not null,
+	source TEXT not null default 'manual' check (source in ('manual', 'ics')),
+	ics_uid TEXT,
+	created_by UUID,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (created_by) references users (user_id) on delete set NULL,
+	check (end_time > start_time)
+);
+
+create index idx_blackout_periods_org_time on blackout_periods (org_id, start_time, end_time);
+create unique index idx_blackout_periods_ics_uid on blackout_periods (org_id, ics_uid) where ics_uid is not NULL;
+
+comment on column organizations.blackout_policy is 'warn or block scheduling inside blackout periods';
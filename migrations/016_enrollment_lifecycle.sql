@@ -0,0 +1,30 @@
+-- Migration: 016_enrollment_lifecycle.sql
+-- Description: Enrollment status changes with their actor and history
+-- Compatible with: PostgreSQL/Neon
+
+-- Who last changed an enrollment's status and when, and why it was dropped
+alter table user_courses
+	add column status_changed_at TIMESTAMPTZ,
+	add column status_changed_by UUID,
+	add column drop_reason TEXT,
+	add constraint user_courses_status_changed_by_fkey foreign key (status_changed_by) references users (user_id) on delete set NULL;
+
+-- EnrollmentHistory Table: every enrollment, drop, completion and
+-- re-activation, with the user who made it.
+create table enrollment_history (
+	history_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	user_id UUID not null,
+	course_id UUID not null,
+	action TEXT not null check (action in ('enrolled', 'reactivated', 'dropped', 'completed')),
+	previous_status TEXT,
+	status TEXT not null,
+	reason TEXT,
+	actor_id UUID,
+	created_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (user_id, course_id) references user_courses (user_id, course_id) on delete cascade,
+	foreign key (actor_id) references users (user_id) on delete set NULL
+);
+
+create index idx_enrollment_history_enrollment on enrollment_history (course_id, user_id, created_at);
@@ -0,0 +1,106 @@
+-- Migration: 017_invoicing.sql
+-- Description: Families, rate cards, invoices and credit notes billed from attendance
+-- Compatible with: PostgreSQL/Neon
+
+-- Family Table: students billed together on one invoice
+create table families (
+	family_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	billing_email TEXT,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+alter table users
+	add column family_id UUID,
+	add constraint users_family_id_fkey foreign key (family_id) references families (family_id) on delete set NULL;
+
+create index idx_users_family_id on users (family_id) where family_id is not NULL;
+
+-- RateCard Table: price of one attended class. Criteria left NULL match any
+-- class; the card matching the most criteria wins.
+create table rate_cards (
+	rate_card_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	course_id UUID,
+	tutor_id UUID,
+	duration INTEGER check (duration > 0),
+	class_type TEXT check (class_type in ('group', 'individual')),
+	amount_cents INTEGER not null check (amount_cents >= 0),
+	currency TEXT not null default 'USD' check (currency ~ '^[A-Z]{3}$'),
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade
+);
+
+create index idx_rate_cards_org_id on rate_cards (org_id);
+
+-- Invoice Table: invoices and credit notes. Numbers are given out per
+-- organization and kind when a document is issued.
+create table invoices (
+	invoice_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	kind TEXT not null default 'invoice' check (kind in ('invoice', 'credit_note')),
+	number TEXT,
+	status TEXT not null default 'draft' check (status in ('draft', 'issued', 'paid', 'void')),
+	student_id UUID,
+	family_id UUID,
+	bill_to TEXT not null,
+	period_start TIMESTAMPTZ not null,
+	period_end TIMESTAMPTZ not null,
+	currency TEXT not null,
+	total_cents INTEGER not null default 0,
+	credited_invoice_id UUID,
+	reason TEXT,
+	issued_at TIMESTAMPTZ,
+	paid_at TIMESTAMPTZ,
+	voided_at TIMESTAMPTZ,
+	created_by UUID,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (student_id) references users (user_id) on delete set NULL,
+	foreign key (family_id) references families (family_id) on delete set NULL,
+	foreign key (credited_invoice_id) references invoices (invoice_id) on delete cascade,
+	foreign key (created_by) references users (user_id) on delete set NULL,
+	check (period_end > period_start),
+	check ((kind = 'credit_note') = (credited_invoice_id is not NULL))
+);
+
+create index idx_invoices_org_status on invoices (org_id, status, created_at);
+create unique index idx_invoices_number on invoices (org_id, kind, number) where number is not NULL;
+
+-- InvoiceLine Table: one attended class of one student. Credit note lines
+-- point at the line they credit and carry a negative amount. Lines of void
+-- invoices are flagged so the class can be billed again.
+create table invoice_lines (
+	line_id UUID primary key default uuid_generate_v4(),
+	invoice_id UUID not null,
+	class_id UUID,
+	student_id UUID,
+	description TEXT not null,
+	start_time TIMESTAMPTZ not null,
+	duration INTEGER not null,
+	rate_card_id UUID,
+	amount_cents INTEGER not null,
+	credited_line_id UUID,
+	void BOOLEAN not null default FALSE,
+	created_at TIMESTAMPTZ default now(),
+	foreign key (invoice_id) references invoices (invoice_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete set NULL,
+	foreign key (student_id) references users (user_id) on delete set NULL,
+	foreign key (rate_card_id) references rate_cards (rate_card_id) on delete set NULL,
+	foreign key (credited_line_id) references invoice_lines (line_id) on delete cascade
+);
+
+create index idx_invoice_lines_invoice_id on invoice_lines (invoice_id, start_time);
+-- A class is billed to a student at most once, and credited at most once
+create unique index idx_invoice_lines_billed on invoice_lines (class_id, student_id)
+where class_id is not NULL and credited_line_id is NULL and not void;
+create unique index idx_invoice_lines_credited on invoice_lines (credited_line_id)
+where credited_line_id is not NULL and not void;
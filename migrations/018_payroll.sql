@@ -0,0 +1,88 @@
+-- Migration: 018_payroll.sql
+-- Description: Tutor pay rates, late cancellation pay and timesheets
+-- Compatible with: PostgreSQL/Neon
+
+-- Classes cancelled less than late_cancellation_hours before they start are
+-- still paid, at late_cancellation_pay_percent of the tutor's rate.
+alter table organizations
+	add column late_cancellation_hours INTEGER not null default 24 check (late_cancellation_hours >= 0),
+	add column late_cancellation_pay_percent INTEGER not null default 100 check (late_cancellation_pay_percent between 0 and 100);
+
+-- PayRate Table: hourly pay of tutors, optionally narrowed to a tutor and/or
+-- course. The most specific matching rate applies.
+create table pay_rates (
+	pay_rate_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	tutor_id UUID,
+	course_id UUID,
+	hourly_cents INTEGER not null check (hourly_cents >= 0),
+	currency TEXT not null default 'USD' check (currency ~ '^[A-Z]{3}$'),
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade
+);
+
+create unique index idx_pay_rates_scope on pay_rates (
+	org_id,
+	coalesce(tutor_id, '00000000-0000-0000-0000-000000000000'),
+	coalesce(course_id, '00000000-0000-0000-0000-000000000000')
+);
+
+-- Timesheet Table: what a tutor is paid for one pay period. Approved
+-- timesheets are locked, and so are the classes they cover.
+create table timesheets (
+	timesheet_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	tutor_id UUID not null,
+	period_start TIMESTAMPTZ not null,
+	period_end TIMESTAMPTZ not null,
+	status TEXT not null default 'draft' check (status in ('draft', 'approved')),
+	currency TEXT not null default 'USD',
+	taught_minutes INTEGER not null default 0,
+	late_cancellation_minutes INTEGER not null default 0,
+	paid_minutes INTEGER not null default 0,
+	total_cents INTEGER not null default 0,
+	approved_by UUID,
+	approved_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (tutor_id) references users (user_id) on delete cascade,
+	foreign key (approved_by) references users (user_id) on delete set NULL,
+	check (period_end > period_start),
+	-- btree_gist is enabled by 012_resources.sql
+	constraint timesheets_no_overlap exclude using gist (
+		tutor_id with =,
+		tstzrange(period_start, period_end) with &&
+	)
+);
+
+create index idx_timesheets_org_period on timesheets (org_id, period_start);
+
+-- TimesheetEntry Table: a taught class, a paid late cancellation or a manual
+-- adjustment. Amounts are copied from the pay rate when the entry is made.
+create table timesheet_entries (
+	entry_id UUID primary key default uuid_generate_v4(),
+	timesheet_id UUID not null,
+	kind TEXT not null check (kind in ('taught', 'late_cancellation', 'adjustment')),
+	class_id UUID,
+	course_name TEXT,
+	start_time TIMESTAMPTZ,
+	duration INTEGER,
+	paid_minutes INTEGER not null default 0,
+	pay_rate_id UUID,
+	hourly_cents INTEGER,
+	amount_cents INTEGER not null,
+	note TEXT,
+	created_by UUID,
+	created_at TIMESTAMPTZ default now(),
+	foreign key (timesheet_id) references timesheets (timesheet_id) on delete cascade,
+	foreign key (class_id) references classes (class_id) on delete set NULL,
+	foreign key (pay_rate_id) references pay_rates (pay_rate_id) on delete set NULL,
+	foreign key (created_by) references users (user_id) on delete set NULL,
+	check ((kind = 'adjustment') = (class_id is NULL and start_time is NULL))
+);
+
+create index idx_timesheet_entries_timesheet_id on timesheet_entries (timesheet_id, start_time);
@@ -0,0 +1,54 @@
+-- Migration: 019_analytics.sql
+-- Description: Rollups behind the attendance, utilization and availability reports
+-- Compatible with: PostgreSQL/Neon
+
+-- AnalyticsClassStats Table: one row per class with its teachers and student
+-- attendance counted, kept up to date by the analytics refresh job
+create table analytics_class_stats (
+	class_id UUID primary key,
+	org_id UUID not null,
+	course_id UUID,
+	start_time TIMESTAMPTZ not null,
+	duration INTEGER not null,
+	status TEXT not null,
+	teacher_ids UUID [] not null default '{}',
+	students INTEGER not null default 0,
+	attended INTEGER not null default 0,
+	absent INTEGER not null default 0,
+	refreshed_at TIMESTAMPTZ not null,
+	foreign key (class_id) references classes (class_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_analytics_class_stats_org_start on analytics_class_stats (org_id, start_time);
+
+-- AnalyticsRefreshState Table: how far each rollup has been refreshed. Only
+-- classes changed since then are counted again; NULL counts every class.
+create table analytics_refresh_state (
+	name TEXT primary key,
+	refreshed_at TIMESTAMPTZ
+);
+
+insert into analytics_refresh_state (name, refreshed_at) values ('class_stats', NULL);
+
+-- Minutes of availability each user offered per hour, refreshed concurrently
+-- by the analytics refresh job
+create materialized view analytics_availability_hours as
+select
+	a.org_id,
+	a.user_id,
+	a.role,
+	h.hour,
+	(sum(extract(epoch from (least(a.end_time, h.hour + interval '1 hour') - greatest(a.start_time, h.hour)))) / 60)::INTEGER as minutes
+from availability as a
+cross join lateral generate_series(
+	date_trunc('hour', a.start_time), a.end_time - interval '1 second', interval '1 hour'
+) as h (hour)
+group by a.org_id, a.user_id, a.role, h.hour;
+
+-- Concurrent refreshes need a unique index
+create unique index idx_analytics_availability_hours on analytics_availability_hours (org_id, user_id, role, hour);
+create index idx_analytics_availability_hours_org_hour on analytics_availability_hours (org_id, hour);
+
+comment on column analytics_class_stats.students is 'Students taking part in the class';
+comment on column analytics_class_stats.attended is 'Students recorded present; students without a record count as unrecorded';
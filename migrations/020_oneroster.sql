@@ -0,0 +1,63 @@
+-- Migration: 020_oneroster.sql
+-- Description: OneRoster sources, the sourced IDs of synced records and sync runs
+-- Compatible with: PostgreSQL/Neon
+
+-- RosterSources Table: where an organization's OneRoster roster is read
+-- from. Scheduled syncs act as the admin who added the source.
+create table roster_sources (
+	source_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	format TEXT not null check (format in ('csv', 'rest')),
+	url TEXT not null,
+	token_url TEXT,
+	client_id TEXT,
+	client_secret TEXT,
+	active BOOLEAN not null default true,
+	created_by UUID,
+	last_synced_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (created_by) references users (user_id) on delete set NULL
+);
+
+create index idx_roster_sources_org on roster_sources (org_id);
+
+-- RosterRecords Table: the sourced ID of every roster record synced, with
+-- the record it maps to and a checksum of its roster data, so syncing again
+-- updates records rather than duplicating them
+create table roster_records (
+	source_id UUID not null,
+	record_type TEXT not null check (record_type in ('org', 'user', 'class', 'enrollment')),
+	sourced_id TEXT not null,
+	local_id UUID not null,
+	course_id UUID,
+	checksum TEXT not null,
+	removed_at TIMESTAMPTZ,
+	synced_at TIMESTAMPTZ not null,
+	primary key (source_id, record_type, sourced_id),
+	foreign key (source_id) references roster_sources (source_id) on delete cascade,
+	check ((record_type = 'enrollment') = (course_id is not NULL))
+);
+
+-- RosterSyncRuns Table: every sync of a source with its report
+create table roster_sync_runs (
+	run_id UUID primary key default uuid_generate_v4(),
+	source_id UUID not null,
+	org_id UUID not null,
+	dry_run BOOLEAN not null,
+	status TEXT not null check (status in ('succeeded', 'failed')),
+	report JSONB,
+	error TEXT,
+	started_at TIMESTAMPTZ not null,
+	finished_at TIMESTAMPTZ not null,
+	foreign key (source_id) references roster_sources (source_id) on delete cascade,
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_roster_sync_runs_source on roster_sync_runs (source_id, started_at desc);
+
+comment on column roster_records.local_id is 'The organization, user or course of the record; the user of an enrollment';
+comment on column roster_records.course_id is 'The course of an enrollment';
+comment on column roster_records.removed_at is 'When the record was removed from the roster and its user made inactive or enrollment dropped';
@@ -0,0 +1,30 @@
+-- Migration: 021_scim.sql
+-- Description: SCIM provisioning tokens and the SCIM attributes of users
+-- Compatible with: PostgreSQL/Neon
+
+-- ScimTokens Table: bearer tokens an organization's identity provider
+-- provisions users with. Only a hash of each token is kept.
+create table scim_tokens (
+	token_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	description TEXT,
+	token_hash TEXT not null unique,
+	created_by UUID,
+	last_used_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (created_by) references users (user_id) on delete set NULL
+);
+
+create index idx_scim_tokens_org on scim_tokens (org_id);
+
+-- The identity provider's ID of a user, and when it deleted the user. Users
+-- are kept, inactive, so their classes and attendance stay intact.
+alter table users
+	add column external_id TEXT,
+	add column deprovisioned_at TIMESTAMPTZ;
+
+create index idx_users_org_email on users (org_id, lower(email));
+
+comment on column users.external_id is 'ID of the user at the identity provider that provisions it over SCIM';
+comment on column users.deprovisioned_at is 'When the user was deleted over SCIM and made inactive';
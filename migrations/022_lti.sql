@@ -0,0 +1,60 @@
+-- Migration: 022_lti.sql
+-- Description: LTI 1.3 platform registrations and the courses and users launches map to
+-- Compatible with: PostgreSQL/Neon
+
+-- LtiPlatforms Table: learning management systems, such as Canvas or
+-- Moodle, registered to launch bookSmart for an organization
+create table lti_platforms (
+	platform_id UUID primary key default uuid_generate_v4(),
+	org_id UUID not null,
+	name TEXT not null,
+	issuer TEXT not null,
+	client_id TEXT not null,
+	deployment_ids TEXT[] not null,
+	auth_login_url TEXT not null,
+	jwks_url TEXT not null,
+	created_by UUID,
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	unique (issuer, client_id),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (created_by) references users (user_id) on delete set NULL
+);
+
+create index idx_lti_platforms_org on lti_platforms (org_id);
+
+-- LtiLaunchStates Table: the state and nonce of each login, until the
+-- platform posts its launch back. Each state can be used once.
+create table lti_launch_states (
+	state TEXT primary key,
+	nonce TEXT not null,
+	platform_id UUID not null,
+	expires_at TIMESTAMPTZ not null,
+	foreign key (platform_id) references lti_platforms (platform_id) on delete cascade
+);
+
+create index idx_lti_launch_states_expires on lti_launch_states (expires_at);
+
+-- LtiContexts Table: the course each platform context launches into
+create table lti_contexts (
+	platform_id UUID not null,
+	context_id TEXT not null,
+	course_id UUID not null,
+	created_at TIMESTAMPTZ default now(),
+	primary key (platform_id, context_id),
+	foreign key (platform_id) references lti_platforms (platform_id) on delete cascade,
+	foreign key (course_id) references courses (course_id) on delete cascade
+);
+
+-- LtiUsers Table: the user each platform user launches as
+create table lti_users (
+	platform_id UUID not null,
+	subject TEXT not null,
+	user_id UUID not null,
+	created_at TIMESTAMPTZ default now(),
+	primary key (platform_id, subject),
+	foreign key (platform_id) references lti_platforms (platform_id) on delete cascade,
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+create index idx_lti_users_user on lti_users (user_id);
@@ -0,0 +1,33 @@
+-- Migration: 023_live_events.sql
+-- Description: Live events streamed to connected clients over server-sent events
+-- Compatible with: PostgreSQL/Neon
+
+-- LiveEvents Table: schedule changes streamed to the organization's users.
+-- Event IDs only increase, so clients resume from the last one they saw.
+create table live_events (
+	event_id BIGSERIAL primary key,
+	org_id UUID not null,
+	event TEXT not null,
+	user_ids UUID[],
+	data JSONB not null,
+	created_at TIMESTAMPTZ not null,
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+create index idx_live_events_org on live_events (org_id, event_id);
+create index idx_live_events_created_at on live_events (created_at);
+
+comment on column live_events.user_ids is 'Users the event concerns besides admins; NULL streams it to the whole organization';
+
+-- Every API instance listens on the live_events channel. The notification
+-- is sent when the transaction that published the event commits.
+create function notify_live_event() returns trigger as $$
+begin
+	perform pg_notify('live_events', NEW.org_id::text || ' ' || NEW.event_id::text);
+	return NEW;
+end;
+$$ language plpgsql;
+
+create trigger live_events_notify
+	after insert on live_events
+	for each row execute function notify_live_event();
@@ -0,0 +1,113 @@
+-- Migration: 024_row_versions.sql
+-- Description: Row versions behind the ETags of users, courses, classes and availability
+-- Compatible with: PostgreSQL/Neon
+
+alter table users add column version BIGINT not null default 1;
+alter table courses add column version BIGINT not null default 1;
+alter table classes add column version BIGINT not null default 1;
+
+comment on column users.version is 'Incremented on every change; the ETag of the user';
+comment on column courses.version is 'Incremented on every change, including enrollments; the ETag of the course';
+comment on column classes.version is 'Incremented on every change, including participants, resources and course renames; the ETag of the class';
+
+-- Updates bump the version unless they set it themselves or only change the
+-- columns named in the trigger arguments, such as timestamps.
+create function bump_row_version() returns trigger as $$
+begin
+	if NEW.version <> OLD.version or to_jsonb(NEW) - TG_ARGV = to_jsonb(OLD) - TG_ARGV then
+		return NEW;
+	end if;
+
+	NEW.version := OLD.version + 1;
+	return NEW;
+end;
+$$ language plpgsql;
+
+create trigger users_bump_version
+	before update on users
+	for each row execute function bump_row_version('updated_at', 'last_login_at');
+
+create trigger courses_bump_version
+	before update on courses
+	for each row execute function bump_row_version('updated_at');
+
+create trigger classes_bump_version
+	before update on classes
+	for each row execute function bump_row_version('updated_at');
+
+-- Courses list their enrolled users.
+create function bump_course_version() returns trigger as $$
+begin
+	update courses set version = version + 1
+	where course_id in (NEW.course_id, OLD.course_id);
+	return NULL;
+end;
+$$ language plpgsql;
+
+create trigger user_courses_bump_course_version
+	after insert or update or delete on user_courses
+	for each row execute function bump_course_version();
+
+-- Classes list their participants, resources and course name.
+create function bump_class_version() returns trigger as $$
+begin
+	update classes set version = version + 1
+	where class_id in (NEW.class_id, OLD.class_id);
+	return NULL;
+end;
+$$ language plpgsql;
+
+create trigger class_participants_bump_class_version
+	after insert or update or delete on class_participants
+	for each row execute function bump_class_version();
+
+create trigger class_resources_bump_class_version
+	after insert or update or delete on class_resources
+	for each row execute function bump_class_version();
+
+create function bump_course_class_versions() returns trigger as $$
+begin
+	update classes set version = version + 1
+	where course_id = NEW.course_id;
+	return NULL;
+end;
+$$ language plpgsql;
+
+create trigger courses_bump_class_versions
+	after update of course_name on courses
+	for each row
+	when (NEW.course_name is distinct from OLD.course_name)
+	execute function bump_course_class_versions();
+
+-- AvailabilityVersions Table: the version of each user's availability,
+-- which is many rows.
+create table availability_versions (
+	user_id UUID primary key,
+	version BIGINT not null,
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+insert into availability_versions (user_id, version)
+select distinct user_id, 1
+from availability;
+
+-- Deletes only bump existing versions, since they also run while the user
+-- itself is being deleted.
+create function bump_availability_version() returns trigger as $$
+begin
+	if TG_OP = 'DELETE' then
+		update availability_versions set version = version + 1
+		where user_id = OLD.user_id;
+		return NULL;
+	end if;
+
+	insert into availability_versions (user_id, version)
+	values (NEW.user_id, 1)
+	on conflict (user_id) do update set version = availability_versions.version + 1;
+	return NULL;
+end;
+$$ language plpgsql;
+
+create trigger availability_bump_version
+	after insert or update or delete on availability
+	for each row execute function bump_availability_version();
@@ -0,0 +1,23 @@
+-- Migration: 025_idempotency_keys.sql
+-- Description: Idempotency keys of POST requests with the responses they are replayed with
+-- Compatible with: PostgreSQL/Neon
+
+-- IdempotencyKeys Table: the first request each user sent with a key, and
+-- its response once it has one. Keys are kept for a retention window.
+create table idempotency_keys (
+	user_id UUID not null,
+	idempotency_key TEXT not null,
+	fingerprint TEXT not null,
+	response_status INTEGER,
+	response_headers JSONB,
+	response_body BYTEA,
+	created_at TIMESTAMPTZ not null,
+	completed_at TIMESTAMPTZ,
+	primary key (user_id, idempotency_key),
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+create index idx_idempotency_keys_created_at on idempotency_keys (created_at);
+
+comment on column idempotency_keys.fingerprint is 'SHA-256 of the method, path, query and body of the request';
+comment on column idempotency_keys.completed_at is 'When the response was stored; NULL while the request is being handled';
@@ -0,0 +1,21 @@
+-- Migration: 026_org_quotas.sql
+-- Description: Plan quotas of organizations, enforced when users, courses and classes are created
+-- Compatible with: PostgreSQL/Neon
+
+-- OrgQuotas Table: the plan an organization is on and the quotas it comes
+-- with. NULL quotas are not enforced, and neither are those of
+-- organizations without a row. Quotas are set by operators, not through the
+-- API, since admins could otherwise lift their own.
+create table org_quotas (
+	org_id UUID primary key,
+	plan TEXT not null,
+	max_users INTEGER check (max_users >= 0),
+	max_courses INTEGER check (max_courses >= 0),
+	max_monthly_classes INTEGER check (max_monthly_classes >= 0),
+	created_at TIMESTAMPTZ default now(),
+	updated_at TIMESTAMPTZ default now(),
+	foreign key (org_id) references organizations (organization_id) on delete cascade
+);
+
+comment on column org_quotas.max_users is 'Active users of the organization';
+comment on column org_quotas.max_monthly_classes is 'Classes not cancelled that start in a calendar month (UTC)';
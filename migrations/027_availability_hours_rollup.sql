@@ -0,0 +1,65 @@
+-- Migration: 027_availability_hours_rollup.sql
+-- Description: Refresh the availability hours rollup incrementally instead of as a whole
+-- Compatible with: PostgreSQL/Neon
+
+drop materialized view analytics_availability_hours;
+
+-- AnalyticsAvailabilityHours Table: minutes of availability each user
+-- offered per hour. The analytics refresh job counts again only the hours
+-- of users whose availability changed since it last ran.
+create table analytics_availability_hours (
+	org_id UUID not null,
+	user_id UUID not null,
+	role TEXT not null,
+	hour TIMESTAMPTZ not null,
+	minutes INTEGER not null,
+	primary key (user_id, hour, role),
+	foreign key (org_id) references organizations (organization_id) on delete cascade,
+	foreign key (user_id) references users (user_id) on delete cascade
+);
+
+create index idx_analytics_availability_hours_org_hour on analytics_availability_hours (org_id, hour);
+
+insert into analytics_availability_hours (org_id, user_id, role, hour, minutes)
+select
+	a.org_id,
+	a.user_id,
+	a.role,
+	h.hour,
+	(sum(extract(epoch from (least(a.end_time, h.hour + interval '1 hour') - greatest(a.start_time, h.hour)))) / 60)::INTEGER as minutes
+from availability as a
+cross join lateral generate_series(
+	date_trunc('hour', a.start_time), a.end_time - interval '1 second', interval '1 hour'
+) as h (hour)
+group by a.org_id, a.user_id, a.role, h.hour;
+
+-- AnalyticsAvailabilityChanges Table: time ranges of users whose
+-- availability was added, moved or removed, taken by the next refresh
+create table analytics_availability_changes (
+	change_id BIGSERIAL primary key,
+	user_id UUID not null,
+	start_time TIMESTAMPTZ not null,
+	end_time TIMESTAMPTZ not null
+);
+
+create function log_availability_change() returns trigger as $$
+begin
+	if TG_OP in ('UPDATE', 'DELETE') then
+		insert into analytics_availability_changes (user_id, start_time, end_time)
+		values (OLD.user_id, OLD.start_time, OLD.end_time);
+	end if;
+	if TG_OP in ('INSERT', 'UPDATE') then
+		insert into analytics_availability_changes (user_id, start_time, end_time)
+		values (NEW.user_id, NEW.start_time, NEW.end_time);
+	end if;
+	return NULL;
+end;
+$$ language plpgsql;
+
+-- Matching availability does not change the minutes offered
+create trigger availability_log_change
+	after insert or delete or update of org_id, user_id, role, start_time, end_time on availability
+	for each row execute function log_availability_change();
+
+-- Refreshes lock their state row, so they do not count the same hours at once
+insert into analytics_refresh_state (name, refreshed_at) values ('availability_hours', now());